@@ -1,7 +1,11 @@
 package api
 
 import (
+	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -10,79 +14,130 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+var errAccountTypeMismatch = errors.New("Account type is different of Category type")
+
 type createAccountRequest struct {
-	UserID      int32     `json:"user_id" binding:"required"`
-	CategoryID  int32     `json:"category_id" binding:"required"`
-	Title       string    `json:"title" binding:"required"`
-	Type        string    `json:"type" binding:"required"`
-	Description string    `json:"description" binding:"required"`
-	Value       int32     `json:"value" binding:"required"`
-	Date        time.Time `json:"date" binding:"required"`
+	HouseholdID int32      `json:"household_id"`
+	WalletID    int32      `json:"wallet_id" binding:"required"`
+	CategoryID  int32      `json:"category_id" binding:"required"`
+	Title       string     `json:"title" binding:"required"`
+	Type        string     `json:"type" binding:"required,oneof=credit debit"`
+	Description string     `json:"description" binding:"required"`
+	Value       util.Money `json:"value" binding:"required"`
+	Date        time.Time  `json:"date" binding:"required"`
 }
 
 // createAccount para criar uma conta
 func (server *Server) createAccount(ctx *gin.Context) {
-	errOnValiteToken := util.GetTokenInHeaderAndVerify(ctx)
-	if errOnValiteToken != nil {
-		return
-	}
-
 	var request createAccountRequest
 	err := ctx.ShouldBindJSON(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
-	//Validação
-	var categoryId = request.CategoryID
-	var accountType = request.Type
-
-	category, err := server.store.GetCategory(ctx, categoryId)
-	if err != nil {
-		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	claims := authClaims(ctx)
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleEditor) {
+		return
 	}
-	var categoryTypeIsDifferentOfAccountType = category.Type != accountType
-	if categoryTypeIsDifferentOfAccountType {
-		ctx.JSON(http.StatusBadRequest, "Account type is different of Category type")
-	} else {
-		arg := db.CreateAccountParams{
-			UserID:      request.UserID,
-			CategoryID:  categoryId,
+	// a validação e a criação rodam na mesma transação, para a categoria e a carteira
+	// conferidas serem as usadas na conta
+	var account db.Account
+	err = server.store.ExecTx(ctx, func(q db.Querier) error {
+		err := checkAccountTemplate(ctx, q, claims.UserID, request.HouseholdID, request.CategoryID, request.WalletID, request.Type)
+		if err != nil {
+			return err
+		}
+
+		account, err = q.CreateAccount(ctx, db.CreateAccountParams{
+			UserID:      claims.UserID,
+			HouseholdID: householdID(request.HouseholdID),
+			WalletID:    request.WalletID,
+			CategoryID:  request.CategoryID,
 			Title:       request.Title,
-			Type:        accountType,
+			Type:        request.Type,
 			Description: request.Description,
 			Value:       request.Value,
 			Date:        request.Date,
+		})
+		if err == sql.ErrNoRows {
+			return errHouseholdForbidden
 		}
+		return err
+	})
+	if err != nil {
+		accountTemplateError(ctx, err)
+		return
+	}
 
-		account, err := server.store.CreateAccount(ctx, arg)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		}
+	ctx.JSON(http.StatusOK, account)
+}
+
+// checkAccountTemplate confere se a categoria e a carteira de uma transação são visíveis,
+// do mesmo escopo da transação e se o tipo bate com o da categoria
+func checkAccountTemplate(ctx context.Context, q db.Querier, userID int32, household int32, categoryID int32, walletID int32, accountType string) error {
+	category, err := q.GetCategory(ctx, db.GetCategoryParams{
+		ID:     categoryID,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	if category.HouseholdID != householdID(household) {
+		return errCategoryOtherHousehold
+	}
+	if category.Type != accountType {
+		return errAccountTypeMismatch
+	}
 
-		ctx.JSON(http.StatusOK, account)
+	// a transação fica na moeda da carteira
+	wallet, err := q.GetWallet(ctx, db.GetWalletParams{
+		ID:     walletID,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	if wallet.HouseholdID != householdID(household) {
+		return errWalletOtherHousehold
 	}
-	//fim
+	return nil
+}
 
+// accountTemplateError responde os erros de checkAccountTemplate e da criação no escopo
+func accountTemplateError(ctx *gin.Context, err error) {
+	switch err {
+	case sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errHouseholdForbidden:
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+	case errAccountTypeMismatch:
+		ctx.JSON(http.StatusBadRequest, err.Error())
+	case errCategoryOtherHousehold, errWalletOtherHousehold:
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
 }
 
 type getAccountRequest struct {
-	ID int32 `json:"id" binding:"required"`
+	ID int32 `uri:"id" binding:"required"`
 }
 
 // getAccount valida a URL e a conta
 func (server *Server) getAccount(ctx *gin.Context) {
-	errOnValiteToken := util.GetTokenInHeaderAndVerify(ctx)
-	if errOnValiteToken != nil {
-		return
-	}
-
 	var request getAccountRequest
-	err := ctx.ShouldBindJSON(&request)
+	err := ctx.ShouldBindUri(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.GetAccountParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
 	}
 
-	account, err := server.store.GetAccount(ctx, request.ID)
+	account, err := server.store.GetAccount(ctx, arg)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -96,65 +151,44 @@ func (server *Server) getAccount(ctx *gin.Context) {
 }
 
 type getAccountReportsRequest struct {
-	UserID int32  `uri:"user_id" binding:"required"`
-	Type   string `uri:"type" binding:"required"`
+	Type string `uri:"type" binding:"required,oneof=credit debit"`
 }
 
 func (server *Server) getAccountReports(ctx *gin.Context) {
-	errOnValiteToken := util.GetTokenInHeaderAndVerify(ctx)
-	if errOnValiteToken != nil {
-		return
-	}
-
 	var request getAccountReportsRequest
 	err := ctx.ShouldBindUri(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
-	arg := db.GetAccountsReportsParams{
-		UserID: request.UserID,
-		Type:   request.Type,
-	}
-
-	sumReports, err := server.store.GetAccountsReports(ctx, arg)
+	var scope householdScopeRequest
+	err = ctx.ShouldBindQuery(&scope)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-
-	ctx.JSON(http.StatusOK, sumReports)
-}
-
-type getAccountGraphRequest struct {
-	UserID int32  `uri:"user_id" binding:"required"`
-	Type   string `uri:"type" binding:"required"`
-}
-
-func (server *Server) getAccountGraph(ctx *gin.Context) {
-	errOnValiteToken := util.GetTokenInHeaderAndVerify(ctx)
-	if errOnValiteToken != nil {
+	if scope.HouseholdID > 0 && !server.authorizeHousehold(ctx, scope.HouseholdID, householdRoleViewer) {
 		return
 	}
 
-	var request getAccountGraphRequest
-	err := ctx.ShouldBindUri(&request)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-	}
-
-	arg := db.GetAccountsGraphParams{
-		UserID: request.UserID,
-		Type:   request.Type,
+	arg := db.GetAccountsReportsParams{
+		HouseholdID: householdID(scope.HouseholdID),
+		UserID:      authClaims(ctx).UserID,
+		Type:        request.Type,
 	}
 
-	countGraph, err := server.store.GetAccountsGraph(ctx, arg)
+	sumReports, err := server.store.GetAccountsReports(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	if sumReports.MissingRates > 0 {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errMissingExchangeRate))
+		return
+	}
 
-	ctx.JSON(http.StatusOK, countGraph)
+	ctx.JSON(http.StatusOK, sumReports.SumValue)
 }
 
 type deleteAccountRequest struct {
@@ -163,50 +197,58 @@ type deleteAccountRequest struct {
 
 // deleteAccount deleta a conta
 func (server *Server) deleteAccount(ctx *gin.Context) {
-	errOnValiteToken := util.GetTokenInHeaderAndVerify(ctx)
-	if errOnValiteToken != nil {
-		return
-	}
-
 	var request deleteAccountRequest
 	err := ctx.ShouldBindUri(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
-	err = server.store.DeleteAccount(ctx, request.ID)
+	arg := db.DeleteAccountParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	}
+
+	rowsDeleted, err := server.store.DeleteAccount(ctx, arg)
 	if err != nil {
 
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 
 	}
+	if rowsDeleted == 0 {
+		account, err := server.store.GetAccount(ctx, db.GetAccountParams(arg))
+		if err == nil && account.TransferID.Valid {
+			// apagar um lado apaga a transferência inteira
+			server.removeTransfer(ctx, account.TransferID.Int32)
+			return
+		}
+		notWritable(ctx, err)
+		return
+	}
 
 	ctx.JSON(http.StatusOK, true)
 }
 
 type updateAccountRequest struct {
-	ID          int32  `json:"id" binding:"required"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Value       int32  `json:"value"`
+	ID          int32      `json:"id" binding:"required"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Value       util.Money `json:"value"`
 }
 
 // updateAccount para atualizar uma conta
 func (server *Server) updateAccount(ctx *gin.Context) {
-	errOnValiteToken := util.GetTokenInHeaderAndVerify(ctx)
-	if errOnValiteToken != nil {
-		return
-	}
-
 	var request updateAccountRequest
 	err := ctx.ShouldBindJSON(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
 	arg := db.UpdateAccountParams{
 		ID:          request.ID,
+		UserID:      authClaims(ctx).UserID,
 		Title:       request.Title,
 		Description: request.Description,
 		Value:       request.Value,
@@ -214,54 +256,218 @@ func (server *Server) updateAccount(ctx *gin.Context) {
 
 	account, err := server.store.UpdateAccount(ctx, arg)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			account, err := server.store.GetAccount(ctx, db.GetAccountParams{ID: arg.ID, UserID: arg.UserID})
+			if err == nil && account.TransferID.Valid {
+				server.updateTransferSide(ctx, account, request)
+				return
+			}
+			notWritable(ctx, err)
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
 	ctx.JSON(http.StatusOK, account)
 }
 
+// defaultAccountsPageSize é o tamanho da página sem page_size; o máximo é 200
+const defaultAccountsPageSize = 50
+
+var errInvalidCursor = errors.New("invalid cursor")
+
 type getAccountsRequest struct {
-	UserID      int32     `form:"user_id" json:"user_id" binding:"required"`
-	Type        string    `form:"type" json:"type" binding:"required"`
-	CategoryID  int32     `form:"category_id" json:"category_id"`
-	Title       string    `form:"title" json:"title"`
-	Description string    `form:"description" json:"description"`
-	Date        time.Time `form:"date" json:"date"`
+	HouseholdID      int32       `form:"household_id" json:"household_id"`
+	Type             string      `form:"type" json:"type" binding:"required,oneof=credit debit"`
+	CategoryID       int32       `form:"category_id" json:"category_id"`
+	WalletID         int32       `form:"wallet_id" json:"wallet_id"`
+	Title            string      `form:"title" json:"title"`
+	Description      string      `form:"description" json:"description"`
+	Date             time.Time   `form:"date" json:"date"`
+	DateFrom         time.Time   `form:"date_from" time_format:"2006-01-02" time_utc:"1"`
+	DateTo           time.Time   `form:"date_to" time_format:"2006-01-02" time_utc:"1"`
+	MinValue         *util.Money `form:"min_value"`
+	MaxValue         *util.Money `form:"max_value"`
+	IncludeTransfers bool        `form:"include_transfers"`
+	SortBy           string      `form:"sort_by" binding:"omitempty,oneof=date value title created_at"`
+	Order            string      `form:"order" binding:"omitempty,oneof=asc desc"`
+	PageSize         int32       `form:"page_size" binding:"omitempty,min=1,max=200"`
+	Cursor           string      `form:"cursor"`
 }
 
-// getAccount valida a URL e as contas
-func (server *Server) getAccounts(ctx *gin.Context) {
-	errOnValiteToken := util.GetTokenInHeaderAndVerify(ctx)
-	if errOnValiteToken != nil {
-		return
+type getAccountsResponse struct {
+	Accounts []db.GetAccountsRow `json:"accounts"`
+	// NextCursor busca a próxima página; vazio na última
+	NextCursor string `json:"next_cursor"`
+	TotalCount int64  `json:"total_count"`
+	// TotalValue é a soma na moeda base do usuário; nula se faltar alguma cotação
+	TotalValue *util.Money `json:"total_value"`
+}
+
+// accountsCursor é a posição da última conta da página, com a ordenação que a gerou
+type accountsCursor struct {
+	SortBy     string    `json:"s"`
+	Descending bool      `json:"d"`
+	ID         int32     `json:"i"`
+	Value      int64     `json:"v,omitempty"`
+	Title      string    `json:"t,omitempty"`
+	Time       time.Time `json:"a,omitempty"`
+}
+
+func newAccountsCursor(sortBy string, descending bool, account db.GetAccountsRow) string {
+	cursor := accountsCursor{SortBy: sortBy, Descending: descending, ID: account.ID}
+	switch sortBy {
+	case "value":
+		cursor.Value = int64(account.Value)
+	case "title":
+		cursor.Title = account.Title
+	case "created_at":
+		cursor.Time = account.CreatedAt
+	default:
+		cursor.Time = account.Date
+	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// applyAccountsCursor continua a listagem depois da posição do cursor, que só vale para a
+// mesma ordenação
+func applyAccountsCursor(arg *db.GetAccountsParams, value string) error {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return errInvalidCursor
+	}
+	var cursor accountsCursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil || cursor.ID <= 0 || cursor.SortBy != arg.SortBy || cursor.Descending != arg.Descending {
+		return errInvalidCursor
+	}
+
+	arg.AfterID = sql.NullInt32{Int32: cursor.ID, Valid: true}
+	switch cursor.SortBy {
+	case "value":
+		arg.AfterValue = sql.NullInt64{Int64: cursor.Value, Valid: true}
+	case "title":
+		arg.AfterTitle = sql.NullString{String: cursor.Title, Valid: true}
+	case "created_at":
+		arg.AfterCreatedAt = sql.NullTime{Time: cursor.Time, Valid: true}
+	default:
+		arg.AfterDate = sql.NullTime{Time: cursor.Time, Valid: true}
 	}
+	return nil
+}
 
+// getAccounts lista as contas filtradas, uma página por vez, com o total do filtro inteiro;
+// as transferências só entram na lista e no total com include_transfers
+func (server *Server) getAccounts(ctx *gin.Context) {
 	var request getAccountsRequest
 	err := ctx.ShouldBindQuery(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	if request.SortBy == "" {
+		request.SortBy = "date"
+	}
+	if request.Order == "" {
+		request.Order = "desc"
+	}
+	if request.PageSize == 0 {
+		request.PageSize = defaultAccountsPageSize
+	}
+	if !request.DateFrom.IsZero() && !request.DateTo.IsZero() && request.DateFrom.After(request.DateTo) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidPeriod))
+		return
+	}
 
-	arg := db.GetAccountsParams{
-		UserID: request.UserID,
-		Type:   request.Type,
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleViewer) {
+		return
+	}
+
+	summaryArg := db.GetAccountsSummaryParams{
+		HouseholdID: householdID(request.HouseholdID),
+		UserID:      authClaims(ctx).UserID,
+		Type:        request.Type,
 		CategoryID: sql.NullInt32{
 			Int32: request.CategoryID,
 			Valid: request.CategoryID > 0,
 		},
+		WalletID: sql.NullInt32{
+			Int32: request.WalletID,
+			Valid: request.WalletID > 0,
+		},
 		Title:       request.Title,
 		Description: request.Description,
 		Date: sql.NullTime{
 			Time:  request.Date,
 			Valid: !request.Date.IsZero(),
 		},
+		DateFrom: sql.NullTime{
+			Time:  request.DateFrom,
+			Valid: !request.DateFrom.IsZero(),
+		},
+		DateTo: sql.NullTime{
+			Time:  request.DateTo,
+			Valid: !request.DateTo.IsZero(),
+		},
+		MinValue:         nullMoney(request.MinValue),
+		MaxValue:         nullMoney(request.MaxValue),
+		IncludeTransfers: request.IncludeTransfers,
+	}
+	arg := db.GetAccountsParams{
+		HouseholdID:      summaryArg.HouseholdID,
+		UserID:           summaryArg.UserID,
+		Type:             summaryArg.Type,
+		Title:            summaryArg.Title,
+		Description:      summaryArg.Description,
+		CategoryID:       summaryArg.CategoryID,
+		WalletID:         summaryArg.WalletID,
+		Date:             summaryArg.Date,
+		DateFrom:         summaryArg.DateFrom,
+		DateTo:           summaryArg.DateTo,
+		MinValue:         summaryArg.MinValue,
+		MaxValue:         summaryArg.MaxValue,
+		IncludeTransfers: summaryArg.IncludeTransfers,
+		SortBy:           request.SortBy,
+		Descending:       request.Order == "desc",
+		// uma linha a mais diz se existe próxima página
+		PageSize: request.PageSize + 1,
+	}
+	if request.Cursor != "" {
+		err = applyAccountsCursor(&arg, request.Cursor)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 	}
 
 	accounts, err := server.store.GetAccounts(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	summary, err := server.store.GetAccountsSummary(ctx, summaryArg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := getAccountsResponse{Accounts: accounts, TotalCount: summary.TotalCount}
+	if len(accounts) > int(request.PageSize) {
+		response.Accounts = accounts[:request.PageSize]
+		response.NextCursor = newAccountsCursor(arg.SortBy, arg.Descending, response.Accounts[request.PageSize-1])
 	}
+	if summary.MissingRates == 0 {
+		response.TotalValue = &summary.TotalValue
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
 
-	ctx.JSON(http.StatusOK, accounts)
+func nullMoney(money *util.Money) sql.NullInt64 {
+	if money == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*money), Valid: true}
 }
@@ -0,0 +1,818 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// randomTransfer monta uma transferência com o débito e o crédito nas carteiras
+func randomTransfer(user db.User, from db.GetWalletRow, to db.GetWalletRow) db.TransferTxResult {
+	transfer := db.Transfer{
+		ID:           randomID(),
+		UserID:       user.ID,
+		FromWalletID: from.ID,
+		ToWalletID:   to.ID,
+		Title:        defaultTransferTitle,
+		Value:        util.NewMoney(100, 0),
+		ToValue:      util.NewMoney(100, 0),
+	}
+	fromAccount := randomAccount(user, db.Category{Type: "debit"}, from)
+	fromAccount.CategoryID = sql.NullInt32{}
+	fromAccount.TransferID = sql.NullInt32{Int32: transfer.ID, Valid: true}
+	fromAccount.Value = transfer.Value
+	toAccount := randomAccount(user, db.Category{Type: "credit"}, to)
+	toAccount.CategoryID = sql.NullInt32{}
+	toAccount.TransferID = sql.NullInt32{Int32: transfer.ID, Valid: true}
+	toAccount.Value = transfer.ToValue
+
+	return db.TransferTxResult{Transfer: transfer, FromAccount: fromAccount, ToAccount: toAccount}
+}
+
+func TestCreateAccount(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomCategory(user, "debit")
+	wallet := randomWallet(user, "BRL")
+	account := randomAccount(user, category, wallet)
+
+	request := createAccountRequest{
+		WalletID:    wallet.ID,
+		CategoryID:  category.ID,
+		Title:       account.Title,
+		Type:        account.Type,
+		Description: account.Description,
+		Value:       account.Value,
+		Date:        account.Date,
+	}
+	creditRequest := request
+	creditRequest.Type = "credit"
+	invalidType := request
+	invalidType.Type = "Debit"
+	householdWallet := wallet
+	householdWallet.HouseholdID = sql.NullInt32{Int32: randomID(), Valid: true}
+
+	expectCategory := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetCategory(gomock.Any(), gomock.Eq(db.GetCategoryParams{ID: category.ID, UserID: user.ID})).
+			Times(1).
+			Return(category, nil)
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				expectCategory(store)
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(db.GetWalletParams{ID: wallet.ID, UserID: user.ID})).Times(1).Return(wallet, nil)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Eq(db.CreateAccountParams{
+					UserID:      user.ID,
+					WalletID:    wallet.ID,
+					CategoryID:  category.ID,
+					Title:       account.Title,
+					Type:        account.Type,
+					Description: account.Description,
+					Value:       account.Value,
+					Date:        account.Date,
+				})).Times(1).Return(account, nil)
+			},
+			status:   http.StatusOK,
+			response: account,
+		},
+		{
+			// o valor chega em reais no JSON e é gravado em centavos
+			name: "DecimalValue",
+			body: fmt.Sprintf(`{"wallet_id": %d, "category_id": %d, "title": "Mercado", "type": "debit", "description": "Feira", "value": 12.34, "date": %q}`,
+				wallet.ID, category.ID, account.Date.Format(time.RFC3339)),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				expectCategory(store)
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(db.GetWalletParams{ID: wallet.ID, UserID: user.ID})).Times(1).Return(wallet, nil)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Eq(db.CreateAccountParams{
+					UserID:      user.ID,
+					WalletID:    wallet.ID,
+					CategoryID:  category.ID,
+					Title:       "Mercado",
+					Type:        "debit",
+					Description: "Feira",
+					Value:       util.NewMoney(12, 34),
+					Date:        account.Date,
+				})).Times(1).DoAndReturn(func(_ context.Context, arg db.CreateAccountParams) (db.Account, error) {
+					created := account
+					created.Value = arg.Value
+					return created, nil
+				})
+			},
+			status: http.StatusOK,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Contains(t, recorder.Body.String(), `"value":12.34`)
+			},
+		},
+		{
+			name:      "InvalidType",
+			body:      invalidType,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "TypeMismatch",
+			body:      creditRequest,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				expectCategory(store)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errAccountTypeMismatch.Error(),
+		},
+		{
+			name:      "CategoryNotFound",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(1).Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "WalletOtherHousehold",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				expectCategory(store)
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(1).Return(householdWallet, nil)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errWalletOtherHousehold),
+		},
+		{
+			name:      "NotWritable",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				expectCategory(store)
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(1).Return(wallet, nil)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "BadRequest",
+			body:      createAccountRequest{Title: account.Title},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			body: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeAccountsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/account", testCases)
+}
+
+func TestGetAccount(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user, randomCategory(user, "debit"), randomWallet(user, "BRL"))
+	params := db.GetAccountParams{ID: account.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(params)).Times(1).Return(account, nil)
+			},
+			status:   http.StatusOK,
+			response: account,
+		},
+		{
+			name:      "InvalidID",
+			url:       "/account/id/0",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeReportsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, fmt.Sprintf("/account/id/%d", account.ID), testCases)
+}
+
+func TestGetAccounts(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := randomWallet(user, "BRL")
+	account := randomAccount(user, randomCategory(user, "debit"), wallet)
+	accounts := []db.GetAccountsRow{{
+		ID:          account.ID,
+		UserID:      account.UserID,
+		WalletID:    account.WalletID,
+		Title:       account.Title,
+		Type:        account.Type,
+		Description: account.Description,
+		Value:       account.Value,
+		Currency:    account.Currency,
+		Date:        account.Date,
+		CreatedAt:   account.CreatedAt,
+	}}
+	next := accounts[0]
+	next.ID++
+	householdID := randomID()
+	walletID := sql.NullInt32{Int32: wallet.ID, Valid: true}
+	summary := db.GetAccountsSummaryRow{TotalCount: 1, TotalValue: account.Value}
+	listArg := func(arg db.GetAccountsParams) db.GetAccountsParams {
+		arg.UserID = user.ID
+		arg.Type = "debit"
+		if arg.SortBy == "" {
+			arg.SortBy = "date"
+			arg.Descending = true
+		}
+		if arg.PageSize == 0 {
+			arg.PageSize = defaultAccountsPageSize + 1
+		}
+		return arg
+	}
+	cursor := newAccountsCursor("value", false, accounts[0])
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(listArg(db.GetAccountsParams{WalletID: walletID}))).Times(1).Return(accounts, nil)
+				store.EXPECT().GetAccountsSummary(gomock.Any(), gomock.Eq(db.GetAccountsSummaryParams{
+					UserID:   user.ID,
+					Type:     "debit",
+					WalletID: walletID,
+				})).Times(1).Return(summary, nil)
+			},
+			status:   http.StatusOK,
+			response: getAccountsResponse{Accounts: accounts, TotalCount: 1, TotalValue: &account.Value},
+		},
+		{
+			name:      "IncludeTransfers",
+			url:       fmt.Sprintf("/account?type=debit&wallet_id=%d&include_transfers=true", wallet.ID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(listArg(db.GetAccountsParams{
+					WalletID:         walletID,
+					IncludeTransfers: true,
+				}))).Times(1).Return(accounts, nil)
+				store.EXPECT().GetAccountsSummary(gomock.Any(), gomock.Eq(db.GetAccountsSummaryParams{
+					UserID:           user.ID,
+					Type:             "debit",
+					WalletID:         walletID,
+					IncludeTransfers: true,
+				})).Times(1).Return(summary, nil)
+			},
+			status:   http.StatusOK,
+			response: getAccountsResponse{Accounts: accounts, TotalCount: 1, TotalValue: &account.Value},
+		},
+		{
+			name:      "NextPage",
+			url:       fmt.Sprintf("/account?type=debit&wallet_id=%d&page_size=1&sort_by=value&order=asc", wallet.ID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(listArg(db.GetAccountsParams{
+					WalletID: walletID,
+					SortBy:   "value",
+					PageSize: 2,
+				}))).Times(1).Return(append(accounts, next), nil)
+				store.EXPECT().GetAccountsSummary(gomock.Any(), gomock.Any()).Times(1).Return(db.GetAccountsSummaryRow{TotalCount: 2}, nil)
+			},
+			status: http.StatusOK,
+			response: getAccountsResponse{
+				Accounts:   accounts,
+				NextCursor: cursor,
+				TotalCount: 2,
+				TotalValue: new(util.Money),
+			},
+		},
+		{
+			name:      "Cursor",
+			url:       fmt.Sprintf("/account?type=debit&sort_by=value&order=asc&cursor=%s", cursor),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(listArg(db.GetAccountsParams{
+					SortBy:     "value",
+					AfterID:    sql.NullInt32{Int32: account.ID, Valid: true},
+					AfterValue: sql.NullInt64{Int64: int64(account.Value), Valid: true},
+				}))).Times(1).Return([]db.GetAccountsRow{next}, nil)
+				store.EXPECT().GetAccountsSummary(gomock.Any(), gomock.Any()).Times(1).Return(summary, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "CursorOtherSort",
+			url:       fmt.Sprintf("/account?type=debit&sort_by=title&order=asc&cursor=%s", cursor),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errInvalidCursor),
+		},
+		{
+			name:      "MalformedCursor",
+			url:       "/account?type=debit&cursor=abc$",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errInvalidCursor),
+		},
+		{
+			name:      "Filters",
+			url:       "/account?type=debit&date_from=2024-01-01&date_to=2024-01-31&min_value=10.5&max_value=100",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				dateFrom := sql.NullTime{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+				dateTo := sql.NullTime{Time: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), Valid: true}
+				minValue := sql.NullInt64{Int64: int64(util.NewMoney(10, 50)), Valid: true}
+				maxValue := sql.NullInt64{Int64: int64(util.NewMoney(100, 0)), Valid: true}
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(listArg(db.GetAccountsParams{
+					DateFrom: dateFrom,
+					DateTo:   dateTo,
+					MinValue: minValue,
+					MaxValue: maxValue,
+				}))).Times(1).Return(accounts, nil)
+				store.EXPECT().GetAccountsSummary(gomock.Any(), gomock.Eq(db.GetAccountsSummaryParams{
+					UserID:   user.ID,
+					Type:     "debit",
+					DateFrom: dateFrom,
+					DateTo:   dateTo,
+					MinValue: minValue,
+					MaxValue: maxValue,
+				})).Times(1).Return(summary, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "MissingRate",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(1).Return(accounts, nil)
+				store.EXPECT().GetAccountsSummary(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.GetAccountsSummaryRow{TotalCount: 1, MissingRates: 1}, nil)
+			},
+			status:   http.StatusOK,
+			response: getAccountsResponse{Accounts: accounts, TotalCount: 1},
+		},
+		{
+			name:      "Household",
+			url:       fmt.Sprintf("/account?type=debit&household_id=%d", householdID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(listArg(db.GetAccountsParams{
+					HouseholdID: sql.NullInt32{Int32: householdID, Valid: true},
+				}))).Times(1).Return(accounts, nil)
+				store.EXPECT().GetAccountsSummary(gomock.Any(), gomock.Any()).Times(1).Return(summary, nil)
+			},
+			status:   http.StatusOK,
+			response: getAccountsResponse{Accounts: accounts, TotalCount: 1, TotalValue: &account.Value},
+		},
+		{
+			name:      "NotHouseholdMember",
+			url:       fmt.Sprintf("/account?type=debit&household_id=%d", householdID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHouseholdMember(gomock.Any(), gomock.Any()).Times(1).Return(db.HouseholdMember{}, sql.ErrNoRows)
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingType",
+			url:       "/account",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "InvalidSort",
+			url:       "/account?type=debit&sort_by=category",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "PageTooLarge",
+			url:       "/account?type=debit&page_size=201",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "InvalidPeriod",
+			url:       "/account?type=debit&date_from=2024-02-01&date_to=2024-01-01",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errInvalidPeriod),
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeAccountsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, fmt.Sprintf("/account?type=debit&wallet_id=%d", wallet.ID), testCases)
+}
+
+func TestGetAccountReports(t *testing.T) {
+	user, _ := randomUser(t)
+	sum := util.NewMoney(1234, 56)
+	params := db.GetAccountsReportsParams{UserID: user.ID, Type: "credit"}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withAPIKey(user, scopeReportsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountsReports(gomock.Any(), gomock.Eq(params)).Times(1).
+					Return(db.GetAccountsReportsRow{SumValue: sum}, nil)
+			},
+			status:   http.StatusOK,
+			response: sum,
+		},
+		{
+			name:      "MissingExchangeRate",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountsReports(gomock.Any(), gomock.Eq(params)).Times(1).
+					Return(db.GetAccountsReportsRow{SumValue: sum, MissingRates: 2}, nil)
+			},
+			status:   http.StatusUnprocessableEntity,
+			response: errorResponse(errMissingExchangeRate),
+		},
+		{
+			name:      "NotHouseholdMember",
+			url:       "/account/reports/credit?household_id=7",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHouseholdMember(gomock.Any(), gomock.Any()).Times(1).Return(db.HouseholdMember{}, sql.ErrNoRows)
+				store.EXPECT().GetAccountsReports(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountsReports(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountsReports(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountsReports(gomock.Any(), gomock.Any()).Times(1).Return(db.GetAccountsReportsRow{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/account/reports/credit", testCases)
+}
+
+func TestUpdateAccount(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user, randomCategory(user, "debit"), randomWallet(user, "BRL"))
+	request := updateAccountRequest{ID: account.ID, Title: "new title", Description: "new description", Value: util.NewMoney(42, 0)}
+	params := db.UpdateAccountParams{ID: account.ID, UserID: user.ID, Title: request.Title, Description: request.Description, Value: request.Value}
+	getParams := db.GetAccountParams{ID: account.ID, UserID: user.ID}
+	updated := account
+	updated.Title, updated.Description, updated.Value = request.Title, request.Description, request.Value
+
+	transfer := randomTransfer(user, randomWallet(user, "BRL"), randomWallet(user, "BRL"))
+	transferRequest := updateAccountRequest{ID: transfer.FromAccount.ID, Title: "rent", Value: util.NewMoney(250, 0)}
+	updatedTransfer := transfer
+	updatedTransfer.FromAccount.Value = transferRequest.Value
+	updatedTransfer.ToAccount.Value = transferRequest.Value
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccount(gomock.Any(), gomock.Eq(params)).Times(1).Return(updated, nil)
+			},
+			status:   http.StatusOK,
+			response: updated,
+		},
+		{
+			name:      "TransferSide",
+			body:      transferRequest,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(db.GetAccountParams{ID: transfer.FromAccount.ID, UserID: user.ID})).Times(1).Return(transfer.FromAccount, nil)
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(db.GetTransferParams{ID: transfer.Transfer.ID, UserID: user.ID})).Times(1).Return(transfer.Transfer, nil)
+				store.EXPECT().GetTransferAccounts(gomock.Any(), gomock.Eq(transfer.Transfer.ID)).Times(1).
+					Return([]db.Account{transfer.FromAccount, transfer.ToAccount}, nil)
+				store.EXPECT().UpdateTransferTx(gomock.Any(), gomock.Eq(db.UpdateTransferParams{
+					ID:      transfer.Transfer.ID,
+					UserID:  user.ID,
+					Title:   transferRequest.Title,
+					Value:   transferRequest.Value,
+					ToValue: transferRequest.Value,
+					Date:    transfer.Transfer.Date,
+				})).Times(1).Return(updatedTransfer, nil)
+			},
+			status:   http.StatusOK,
+			response: updatedTransfer.FromAccount,
+		},
+		{
+			// sem título, a transferência mantém o que já tinha
+			name:      "TransferSideKeepsTitle",
+			body:      updateAccountRequest{ID: transfer.FromAccount.ID, Value: transferRequest.Value},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(1).Return(transfer.FromAccount, nil)
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(1).Return(transfer.Transfer, nil)
+				store.EXPECT().GetTransferAccounts(gomock.Any(), gomock.Eq(transfer.Transfer.ID)).Times(1).
+					Return([]db.Account{transfer.FromAccount, transfer.ToAccount}, nil)
+				store.EXPECT().UpdateTransferTx(gomock.Any(), gomock.Eq(db.UpdateTransferParams{
+					ID:      transfer.Transfer.ID,
+					UserID:  user.ID,
+					Title:   transfer.Transfer.Title,
+					Value:   transferRequest.Value,
+					ToValue: transferRequest.Value,
+					Date:    transfer.Transfer.Date,
+				})).Times(1).Return(updatedTransfer, nil)
+			},
+			status:   http.StatusOK,
+			response: updatedTransfer.FromAccount,
+		},
+		{
+			name:      "BadRequest",
+			body:      updateAccountRequest{Title: request.Title},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccount(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(getParams)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "NotWritable",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccount(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(getParams)).Times(1).Return(account, nil)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			body: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPut, fmt.Sprintf("/account/%d", account.ID), testCases)
+}
+
+func TestDeleteAccount(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user, randomCategory(user, "debit"), randomWallet(user, "BRL"))
+	params := db.DeleteAccountParams{ID: account.ID, UserID: user.ID}
+	transfer := randomTransfer(user, randomWallet(user, "BRL"), randomWallet(user, "USD"))
+	transferSide := transfer.ToAccount
+	transferSide.ID = account.ID
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "TransferSide",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(db.GetAccountParams(params))).Times(1).Return(transferSide, nil)
+				store.EXPECT().DeleteTransfer(gomock.Any(), gomock.Eq(db.DeleteTransferParams{ID: transfer.Transfer.ID, UserID: user.ID})).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "InvalidID",
+			url:       "/account/abc",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(db.GetAccountParams(params))).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "NotWritable",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(db.GetAccountParams(params))).Times(1).Return(account, nil)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodDelete, fmt.Sprintf("/account/%d", account.ID), testCases)
+}
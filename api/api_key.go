@@ -0,0 +1,155 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/gin-gonic/gin"
+)
+
+// Escopos que podem ser dados a uma API key
+const (
+	scopeAccountsRead    = "accounts:read"
+	scopeAccountsWrite   = "accounts:write"
+	scopeCategoriesRead  = "categories:read"
+	scopeCategoriesWrite = "categories:write"
+	scopeReportsRead     = "reports:read"
+	scopeWalletsRead     = "wallets:read"
+	scopeWalletsWrite    = "wallets:write"
+	scopeBudgetsRead     = "budgets:read"
+	scopeBudgetsWrite    = "budgets:write"
+	scopeGoalsRead       = "goals:read"
+	scopeGoalsWrite      = "goals:write"
+)
+
+var errAPIKeyExpiresInPast = errors.New("expires_at must be in the future")
+
+type createAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,oneof=accounts:read accounts:write categories:read categories:write reports:read wallets:read wallets:write budgets:read budgets:write goals:read goals:write"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type apiKeyResponse struct {
+	ID         int32      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type createAPIKeyResponse struct {
+	apiKeyResponse
+	Key string `json:"key"`
+}
+
+func newAPIKeyResponse(apiKey db.ApiKey) apiKeyResponse {
+	response := apiKeyResponse{
+		ID:        apiKey.ID,
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Scopes:    apiKey.Scopes,
+		CreatedAt: apiKey.CreatedAt,
+	}
+	if apiKey.ExpiresAt.Valid {
+		response.ExpiresAt = &apiKey.ExpiresAt.Time
+	}
+	if apiKey.LastUsedAt.Valid {
+		response.LastUsedAt = &apiKey.LastUsedAt.Time
+	}
+	return response
+}
+
+// createAPIKey gera uma API key; a chave só é mostrada nesta resposta
+func (server *Server) createAPIKey(ctx *gin.Context) {
+	var request createAPIKeyRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var expiresAt sql.NullTime
+	if request.ExpiresAt != nil {
+		if !request.ExpiresAt.After(time.Now()) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errAPIKeyExpiresInPast))
+			return
+		}
+		expiresAt = sql.NullTime{Time: request.ExpiresAt.UTC(), Valid: true}
+	}
+
+	key, prefix, keyHash, err := util.NewAPIKey()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	apiKey, err := server.store.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+		UserID:    authClaims(ctx).UserID,
+		Name:      request.Name,
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		Scopes:    request.Scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createAPIKeyResponse{
+		apiKeyResponse: newAPIKeyResponse(apiKey),
+		Key:            key,
+	})
+}
+
+// getAPIKeys lista as API keys ativas do usuário, sem a chave
+func (server *Server) getAPIKeys(ctx *gin.Context) {
+	apiKeys, err := server.store.GetAPIKeys(ctx, authClaims(ctx).UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := make([]apiKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		response[i] = newAPIKeyResponse(apiKey)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+type revokeAPIKeyRequest struct {
+	ID int32 `uri:"id" binding:"required"`
+}
+
+// revokeAPIKey revoga uma API key do usuário
+func (server *Server) revokeAPIKey(ctx *gin.Context) {
+	var request revokeAPIKeyRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.RevokeAPIKey(ctx, db.RevokeAPIKeyParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	user, _ := randomUser(t)
+	scopes := []string{scopeAccountsRead, scopeReportsRead}
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	past := time.Now().Add(-time.Hour)
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      createAPIKeyRequest{Name: "ci", Scopes: scopes, ExpiresAt: &expiresAt},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateAPIKeyParams) (db.ApiKey, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.Equal(t, scopes, arg.Scopes)
+						require.True(t, arg.ExpiresAt.Valid)
+						require.True(t, expiresAt.Equal(arg.ExpiresAt.Time))
+						return db.ApiKey{
+							ID:        randomID(),
+							UserID:    arg.UserID,
+							Name:      arg.Name,
+							Prefix:    arg.Prefix,
+							KeyHash:   arg.KeyHash,
+							Scopes:    arg.Scopes,
+							ExpiresAt: arg.ExpiresAt,
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response createAPIKeyResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.True(t, util.IsAPIKey(response.Key))
+				require.Equal(t, scopes, response.Scopes)
+				require.NotContains(t, recorder.Body.String(), "key_hash")
+			},
+		},
+		{
+			name:      "InvalidScope",
+			body:      createAPIKeyRequest{Name: "ci", Scopes: []string{"admin"}},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NoScopes",
+			body:      createAPIKeyRequest{Name: "ci", Scopes: []string{}},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "ExpiresInPast",
+			body:      createAPIKeyRequest{Name: "ci", Scopes: scopes, ExpiresAt: &past},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errAPIKeyExpiresInPast),
+		},
+		{
+			name:      "APIKeyNotAllowed",
+			body:      createAPIKeyRequest{Name: "ci", Scopes: scopes},
+			setupAuth: withAPIKeyHeader,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name: "NoAuthorization",
+			body: createAPIKeyRequest{Name: "ci", Scopes: scopes},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      createAPIKeyRequest{Name: "ci", Scopes: scopes},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(1).Return(db.ApiKey{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/api-keys", testCases)
+}
+
+func TestGetAPIKeys(t *testing.T) {
+	user, _ := randomUser(t)
+	apiKeys := []db.ApiKey{
+		{ID: randomID(), UserID: user.ID, Name: "ci", Prefix: "gfk_abc", KeyHash: "hash", Scopes: []string{scopeAccountsRead}},
+		{ID: randomID(), UserID: user.ID, Name: "bot", Prefix: "gfk_def", KeyHash: "hash", Scopes: []string{scopeReportsRead},
+			LastUsedAt: sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true}},
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAPIKeys(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(apiKeys, nil)
+			},
+			status:   http.StatusOK,
+			response: []apiKeyResponse{newAPIKeyResponse(apiKeys[0]), newAPIKeyResponse(apiKeys[1])},
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAPIKeys(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAPIKeys(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/api-keys", testCases)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	user, _ := randomUser(t)
+	id := randomID()
+	params := db.RevokeAPIKeyParams{ID: id, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "InvalidID",
+			url:       "/api-keys/0",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodDelete, fmt.Sprintf("/api-keys/%d", id), testCases)
+}
@@ -1,16 +1,12 @@
 package api
 
 import (
-	"bytes"
-	"crypto/sha512"
 	"database/sql"
+	"log"
 	"net/http"
-	"time"
 
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
 	"github.com/gin-gonic/gin"
-	"golang.org/x/crypto/bcrypt"
-
-	"github.com/golang-jwt/jwt/v5"
 )
 
 type loginRequest struct {
@@ -18,64 +14,84 @@ type loginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
-type loginResponse struct {
-	UserID int32  `json:"user_id"`
-	Token  string `json:"token"`
-}
-
-type Claims struct {
-	Username string `json:"username"`
-	jwt.RegisteredClaims
-}
-
 func (server *Server) login(ctx *gin.Context) {
 	var request loginRequest
 	err := ctx.ShouldBindJSON(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.checkLoginLimit(ctx, request.Username) {
+		return
 	}
 
 	user, err := server.store.GetUser(ctx, request.Username)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			server.loginFailed(ctx, request.Username, http.StatusNotFound, err)
 			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	hashedInput := sha512.Sum512([]byte(request.Password))
-	trimmedHash := bytes.Trim(hashedInput[:], "\x00")
-	preparedPassword := string(trimmedHash)
-	plainTextInBytes := []byte(preparedPassword)
-	hashTextInBytes := []byte(user.Password)
-	err = bcrypt.CompareHashAndPassword(hashTextInBytes, plainTextInBytes)
+	needsRehash, err := server.passwordHasher.Verify(request.Password, user.Password)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		server.loginFailed(ctx, request.Username, http.StatusUnauthorized, err)
 		return
 	}
 
-	expirationTime := time.Now().Add(5 * time.Minute)
+	if needsRehash {
+		server.rehashPassword(ctx, user, request.Password)
+	}
 
-	claims := &Claims{
-		Username: request.Username,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-		},
+	twoFactor, err := server.twoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
-	generatedToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	var jwtSignedKey = []byte("secret_key")
-	generatedTokenToString, err := generatedToken.SignedString(jwtSignedKey)
+	if twoFactor {
+		challenge, err := server.twoFactorChallenge(ctx, user)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, challenge)
+		return
+	}
+
+	err = server.loginSucceeded(ctx, request.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response, err := server.createSession(ctx, user)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
-	arg := &loginResponse{
-		UserID: user.ID,
-		Token:  generatedTokenToString,
+	ctx.JSON(http.StatusOK, response)
+}
+
+// rehashPassword troca o hash antigo pelo Argon2id atual; falhas não impedem o login
+func (server *Server) rehashPassword(ctx *gin.Context, user db.User, password string) {
+	passwordHashed, err := server.passwordHasher.Hash(password)
+	if err == nil {
+		err = server.store.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{
+			ID:       user.ID,
+			Password: passwordHashed,
+		})
 	}
+	if err != nil {
+		log.Printf("cannot rehash password of user %d: %v", user.ID, err)
+	}
+}
 
-	ctx.JSON(http.StatusOK, arg)
+// getJWKS publica as chaves públicas usadas para assinar os tokens
+func (server *Server) getJWKS(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, server.tokenMaker.JWKS())
 }
@@ -0,0 +1,428 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// requireSessionResponse confere que a resposta traz um access token válido do usuário
+func requireSessionResponse(t *testing.T, recorder *httptest.ResponseRecorder, user db.User, sessionID int32) {
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var response sessionResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, response.UserID)
+	require.NotEmpty(t, response.RefreshToken)
+
+	claims, err := testTokenMaker.ValidateToken(response.Token)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, claims.UserID)
+	require.Equal(t, sessionID, claims.SessionID)
+}
+
+func expectCreateSession(store *mockdb.MockStore, user db.User, session db.Session) {
+	store.EXPECT().
+		CreateSession(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateSessionParams) (db.Session, error) {
+			session.UserID = arg.UserID
+			session.ExpiresAt = arg.ExpiresAt
+			return session, nil
+		})
+}
+
+func TestLogin(t *testing.T) {
+	user, password := randomUser(t)
+	session := db.Session{ID: randomID()}
+
+	// hash antigo: bcrypt do SHA-512 da senha, trocado por Argon2id no login
+	legacyUser, _ := randomUser(t)
+	hashedInput := sha512.Sum512([]byte(password))
+	legacyHash, err := bcrypt.GenerateFromPassword(bytes.Trim(hashedInput[:], "\x00"), bcrypt.MinCost)
+	require.NoError(t, err)
+	legacyUser.Password = string(legacyHash)
+
+	testCases := []routeTestCase{
+		{
+			name: "OK",
+			body: loginRequest{Username: user.Username, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(db.UserTotp{}, sql.ErrNoRows)
+				expectCreateSession(store, user, session)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				requireSessionResponse(t, recorder, user, session.ID)
+			},
+		},
+		{
+			name: "LegacyPasswordRehashed",
+			body: loginRequest{Username: legacyUser.Username, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(legacyUser.Username)).Times(1).Return(legacyUser, nil)
+				store.EXPECT().UpdateUserPassword(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateUserPasswordParams) error {
+						require.Equal(t, legacyUser.ID, arg.ID)
+						require.True(t, strings.HasPrefix(arg.Password, "$argon2id$"))
+						return nil
+					})
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(legacyUser.ID)).Times(1).Return(db.UserTotp{}, sql.ErrNoRows)
+				expectCreateSession(store, legacyUser, session)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				requireSessionResponse(t, recorder, legacyUser, session.ID)
+			},
+		},
+		{
+			name: "TwoFactorRequired",
+			body: loginRequest{Username: user.Username, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).
+					Return(db.UserTotp{UserID: user.ID, ConfirmedAt: sql.NullTime{Time: time.Now(), Valid: true}}, nil)
+				store.EXPECT().InvalidateUserTokens(gomock.Any(), gomock.Eq(db.InvalidateUserTokensParams{
+					UserID:  user.ID,
+					Purpose: tokenPurposeTwoFactorChallenge,
+				})).Times(1).Return(nil)
+				store.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).Times(1).Return(db.UserToken{}, nil)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var challenge twoFactorChallengeResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &challenge)
+				require.NoError(t, err)
+				require.True(t, challenge.TwoFactorRequired)
+				require.NotEmpty(t, challenge.ChallengeToken)
+			},
+		},
+		{
+			name: "BadRequest",
+			body: loginRequest{Username: user.Username},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "NotFound",
+			body: loginRequest{Username: user.Username, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.User{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "WrongPassword",
+			body: loginRequest{Username: user.Username, Password: "wrong-" + password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "InternalError",
+			body: loginRequest{Username: user.Username, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name: "CreateSessionError",
+			body: loginRequest{Username: user.Username, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(user, nil)
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Any()).Times(1).Return(db.UserTotp{}, sql.ErrNoRows)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(1).Return(db.Session{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/login", testCases)
+}
+
+func TestLoginTwoFactor(t *testing.T) {
+	user, _ := randomUser(t)
+	session := db.Session{ID: randomID()}
+	key, err := util.GenerateTOTPKey(twoFactorIssuer, user.Username)
+	require.NoError(t, err)
+	userTOTP := db.UserTotp{
+		UserID:      user.ID,
+		Secret:      key.Secret,
+		ConfirmedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+
+	challengeToken := util.RandomString(32)
+	challengeParams := db.GetValidUserTokenParams{
+		TokenHash: util.HashOpaqueToken(challengeToken),
+		Purpose:   tokenPurposeTwoFactorChallenge,
+	}
+	challenge := db.UserToken{ID: randomID(), UserID: user.ID, Purpose: tokenPurposeTwoFactorChallenge}
+
+	code, err := totp.GenerateCode(key.Secret, time.Now())
+	require.NoError(t, err)
+	recoveryCode := "abcd-efgh"
+
+	testCases := []routeTestCase{
+		{
+			name: "OK",
+			body: loginTwoFactorRequest{ChallengeToken: challengeToken, Code: code},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetValidUserToken(gomock.Any(), gomock.Eq(challengeParams)).Times(1).Return(challenge, nil)
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(userTOTP, nil)
+				store.EXPECT().UpdateUserTOTPCounter(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				store.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Eq(db.ConsumeUserTokenParams(challengeParams))).Times(1).Return(challenge, nil)
+				expectCreateSession(store, user, session)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				requireSessionResponse(t, recorder, user, session.ID)
+			},
+		},
+		{
+			name: "RecoveryCode",
+			body: loginTwoFactorRequest{ChallengeToken: challengeToken, Code: recoveryCode},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetValidUserToken(gomock.Any(), gomock.Eq(challengeParams)).Times(1).Return(challenge, nil)
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(userTOTP, nil)
+				store.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Eq(db.UseRecoveryCodeParams{
+					UserID:   user.ID,
+					CodeHash: util.HashRecoveryCode(recoveryCode),
+				})).Times(1).Return(int64(1), nil)
+				store.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Any()).Times(1).Return(challenge, nil)
+				expectCreateSession(store, user, session)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				requireSessionResponse(t, recorder, user, session.ID)
+			},
+		},
+		{
+			name: "BadRequest",
+			body: loginTwoFactorRequest{ChallengeToken: challengeToken},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetValidUserToken(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "InvalidChallenge",
+			body: loginTwoFactorRequest{ChallengeToken: challengeToken, Code: code},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetValidUserToken(gomock.Any(), gomock.Eq(challengeParams)).Times(1).Return(db.UserToken{}, sql.ErrNoRows)
+			},
+			status:   http.StatusUnauthorized,
+			response: errorResponse(errInvalidTwoFactorChallenge),
+		},
+		{
+			name: "InvalidCode",
+			body: loginTwoFactorRequest{ChallengeToken: challengeToken, Code: recoveryCode},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetValidUserToken(gomock.Any(), gomock.Eq(challengeParams)).Times(1).Return(challenge, nil)
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(userTOTP, nil)
+				store.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusUnauthorized,
+			response: errorResponse(errInvalidTwoFactorCode),
+		},
+		{
+			name: "ChallengeAlreadyUsed",
+			body: loginTwoFactorRequest{ChallengeToken: challengeToken, Code: code},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetValidUserToken(gomock.Any(), gomock.Eq(challengeParams)).Times(1).Return(challenge, nil)
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(userTOTP, nil)
+				store.EXPECT().UpdateUserTOTPCounter(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				store.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Any()).Times(1).Return(db.UserToken{}, sql.ErrNoRows)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "InternalError",
+			body: loginTwoFactorRequest{ChallengeToken: challengeToken, Code: code},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetValidUserToken(gomock.Any(), gomock.Any()).Times(1).Return(db.UserToken{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/login/2fa", testCases)
+}
+
+func TestRefreshToken(t *testing.T) {
+	user, _ := randomUser(t)
+	refreshToken := util.RandomString(32)
+	refreshTokenHash := util.HashOpaqueToken(refreshToken)
+	session := db.RotateSessionRefreshTokenRow{ID: randomID(), UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+
+	testCases := []routeTestCase{
+		{
+			name: "OK",
+			body: refreshTokenRequest{RefreshToken: refreshToken},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RotateSessionRefreshToken(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, arg db.RotateSessionRefreshTokenParams) (db.RotateSessionRefreshTokenRow, error) {
+						require.Equal(t, refreshTokenHash, arg.OldRefreshTokenHash)
+						require.NotEqual(t, refreshTokenHash, arg.NewRefreshTokenHash)
+						return session, nil
+					})
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				requireSessionResponse(t, recorder, user, session.ID)
+			},
+		},
+		{
+			name: "BadRequest",
+			body: refreshTokenRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RotateSessionRefreshToken(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "InvalidToken",
+			body: refreshTokenRequest{RefreshToken: refreshToken},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RotateSessionRefreshToken(gomock.Any(), gomock.Any()).Times(1).Return(db.RotateSessionRefreshTokenRow{}, sql.ErrNoRows)
+				store.EXPECT().GetRotatedRefreshTokenSession(gomock.Any(), gomock.Eq(refreshTokenHash)).Times(1).Return(int32(0), sql.ErrNoRows)
+				store.EXPECT().RevokeSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusUnauthorized,
+			response: errorResponse(errInvalidRefreshToken),
+		},
+		{
+			name: "ReusedToken",
+			body: refreshTokenRequest{RefreshToken: refreshToken},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RotateSessionRefreshToken(gomock.Any(), gomock.Any()).Times(1).Return(db.RotateSessionRefreshTokenRow{}, sql.ErrNoRows)
+				store.EXPECT().GetRotatedRefreshTokenSession(gomock.Any(), gomock.Eq(refreshTokenHash)).Times(1).Return(session.ID, nil)
+				store.EXPECT().RevokeSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(nil)
+			},
+			status:   http.StatusUnauthorized,
+			response: errorResponse(errRefreshTokenReused),
+		},
+		{
+			name: "InternalError",
+			body: refreshTokenRequest{RefreshToken: refreshToken},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RotateSessionRefreshToken(gomock.Any(), gomock.Any()).Times(1).Return(db.RotateSessionRefreshTokenRow{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/token/refresh", testCases)
+}
+
+func TestGetJWKS(t *testing.T) {
+	testCases := []routeTestCase{
+		{
+			name:     "OK",
+			status:   http.StatusOK,
+			response: testTokenMaker.JWKS(),
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/.well-known/jwks.json", testCases)
+}
+
+func TestLogout(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeSession(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "APIKeyNotAllowed",
+			setupAuth: withAPIKeyHeader,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errAPIKeyNotAllowed),
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeSession(gomock.Any(), gomock.Any()).Times(1).Return(errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/logout", testCases)
+}
+
+func TestLogoutAll(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeUserSessions(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(int64(2), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeUserSessions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeUserSessions(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/logout/all", testCases)
+}
@@ -0,0 +1,295 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/budget"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+var (
+	errBudgetAmount       = errors.New("amount must be positive")
+	errBudgetCategoryType = errors.New("budgets are only for debit categories")
+	errBudgetExists       = errors.New("the category already has a budget for this month")
+)
+
+type createBudgetRequest struct {
+	HouseholdID int32      `json:"household_id"`
+	CategoryID  int32      `json:"category_id" binding:"required"`
+	Year        int        `json:"year" binding:"required,min=1900,max=9999"`
+	Month       int        `json:"month" binding:"required,min=1,max=12"`
+	Amount      util.Money `json:"amount" binding:"required"`
+	Recurring   bool       `json:"recurring"`
+	Rollover    bool       `json:"rollover"`
+}
+
+// createBudget define o limite de gastos de uma categoria de débito a partir do mês
+// (recorrente) ou só no mês (avulso)
+func (server *Server) createBudget(ctx *gin.Context) {
+	var request createBudgetRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.Amount <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errBudgetAmount))
+		return
+	}
+
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleEditor) {
+		return
+	}
+
+	userID := authClaims(ctx).UserID
+	category, err := server.store.GetCategory(ctx, db.GetCategoryParams{
+		ID:     request.CategoryID,
+		UserID: userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if category.HouseholdID != householdID(request.HouseholdID) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errCategoryOtherHousehold))
+		return
+	}
+	if category.Type != "debit" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errBudgetCategoryType))
+		return
+	}
+
+	arg := db.CreateBudgetParams{
+		UserID:      userID,
+		HouseholdID: householdID(request.HouseholdID),
+		CategoryID:  request.CategoryID,
+		Month:       time.Date(request.Year, time.Month(request.Month), 1, 0, 0, 0, 0, time.UTC),
+		Amount:      request.Amount,
+		Recurring:   request.Recurring,
+		Rollover:    request.Rollover,
+	}
+
+	createdBudget, err := server.store.CreateBudget(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errHouseholdForbidden))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(errBudgetExists))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createdBudget)
+}
+
+type budgetRequest struct {
+	ID int32 `uri:"id" binding:"required"`
+}
+
+// getBudget mostra o orçamento
+func (server *Server) getBudget(ctx *gin.Context) {
+	var request budgetRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	found, err := server.store.GetBudget(ctx, db.GetBudgetParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, found)
+}
+
+// getBudgets lista os orçamentos cadastrados no escopo
+func (server *Server) getBudgets(ctx *gin.Context) {
+	var request householdScopeRequest
+	err := ctx.ShouldBindQuery(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleViewer) {
+		return
+	}
+
+	budgets, err := server.store.GetBudgets(ctx, db.GetBudgetsParams{
+		HouseholdID: householdID(request.HouseholdID),
+		UserID:      authClaims(ctx).UserID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, budgets)
+}
+
+type updateBudgetRequest struct {
+	Amount    util.Money `json:"amount" binding:"required"`
+	Recurring bool       `json:"recurring"`
+	Rollover  bool       `json:"rollover"`
+}
+
+// updateBudget altera o limite; a categoria e o mês ficam
+func (server *Server) updateBudget(ctx *gin.Context) {
+	var uri budgetRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var request updateBudgetRequest
+	err = ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.Amount <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errBudgetAmount))
+		return
+	}
+
+	arg := db.UpdateBudgetParams{
+		ID:        uri.ID,
+		UserID:    authClaims(ctx).UserID,
+		Amount:    request.Amount,
+		Recurring: request.Recurring,
+		Rollover:  request.Rollover,
+	}
+
+	updated, err := server.store.UpdateBudget(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			_, err = server.store.GetBudget(ctx, db.GetBudgetParams{ID: arg.ID, UserID: arg.UserID})
+			notWritable(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, updated)
+}
+
+// deleteBudget apaga o orçamento
+func (server *Server) deleteBudget(ctx *gin.Context) {
+	var request budgetRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.DeleteBudgetParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	}
+
+	rowsDeleted, err := server.store.DeleteBudget(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rowsDeleted == 0 {
+		_, err = server.store.GetBudget(ctx, db.GetBudgetParams(arg))
+		notWritable(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
+
+type getBudgetMonthRequest struct {
+	Year  int `uri:"year" binding:"required,min=1900,max=9999"`
+	Month int `uri:"month" binding:"required,min=1,max=12"`
+}
+
+// getBudgetMonth compara o orçamento do mês com os gastos, por categoria e no total, na
+// moeda base do usuário
+func (server *Server) getBudgetMonth(ctx *gin.Context) {
+	var request getBudgetMonthRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var scope householdScopeRequest
+	err = ctx.ShouldBindQuery(&scope)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if scope.HouseholdID > 0 && !server.authorizeHousehold(ctx, scope.HouseholdID, householdRoleViewer) {
+		return
+	}
+
+	userID := authClaims(ctx).UserID
+	month := time.Date(request.Year, time.Month(request.Month), 1, 0, 0, 0, 0, time.UTC)
+	budgets, err := server.store.GetBudgetsUntil(ctx, db.GetBudgetsUntilParams{
+		HouseholdID: householdID(scope.HouseholdID),
+		UserID:      userID,
+		Month:       month,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if len(budgets) == 0 {
+		ctx.JSON(http.StatusOK, budget.Summarize(budgets, nil, month))
+		return
+	}
+
+	// o rollover depende dos meses anteriores, desde o primeiro orçamento
+	from := month
+	for _, row := range budgets {
+		if row.Month.Before(from) {
+			from = budget.Month(row.Month)
+		}
+	}
+
+	spending, err := server.store.GetBudgetSpending(ctx, db.GetBudgetSpendingParams{
+		HouseholdID: householdID(scope.HouseholdID),
+		UserID:      userID,
+		FromMonth:   from,
+		ToMonth:     month.AddDate(0, 1, 0),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	for _, row := range spending {
+		if row.MissingRates > 0 {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errMissingExchangeRate))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, budget.Summarize(budgets, spending, month))
+}
@@ -0,0 +1,491 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/budget"
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/lib/pq"
+	"go.uber.org/mock/gomock"
+)
+
+func randomBudget(user db.User, category db.Category) db.Budget {
+	return db.Budget{
+		ID:         randomID(),
+		UserID:     user.ID,
+		CategoryID: category.ID,
+		Month:      time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Amount:     util.NewMoney(800, 0),
+		Recurring:  true,
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func TestCreateBudget(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomCategory(user, "debit")
+	created := randomBudget(user, category)
+	householdID := randomID()
+	request := createBudgetRequest{
+		CategoryID: category.ID,
+		Year:       2024,
+		Month:      3,
+		Amount:     created.Amount,
+		Recurring:  true,
+	}
+	householdRequest := request
+	householdRequest.HouseholdID = householdID
+
+	expectCategory := func(store *mockdb.MockStore, category db.Category) {
+		store.EXPECT().
+			GetCategory(gomock.Any(), gomock.Eq(db.GetCategoryParams{ID: category.ID, UserID: user.ID})).
+			Times(1).
+			Return(category, nil)
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectCategory(store, category)
+				store.EXPECT().CreateBudget(gomock.Any(), gomock.Eq(db.CreateBudgetParams{
+					UserID:     user.ID,
+					CategoryID: category.ID,
+					Month:      created.Month,
+					Amount:     created.Amount,
+					Recurring:  true,
+				})).Times(1).Return(created, nil)
+			},
+			status:   http.StatusOK,
+			response: created,
+		},
+		{
+			name:      "InvalidMonth",
+			body:      createBudgetRequest{CategoryID: category.ID, Year: 2024, Month: 13, Amount: created.Amount},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NegativeAmount",
+			body:      createBudgetRequest{CategoryID: category.ID, Year: 2024, Month: 3, Amount: util.NewMoney(-5, 0)},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errBudgetAmount),
+		},
+		{
+			name:      "CreditCategory",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				credit := category
+				credit.Type = "credit"
+				expectCategory(store, credit)
+				store.EXPECT().CreateBudget(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errBudgetCategoryType),
+		},
+		{
+			name:      "CategoryNotFound",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(1).Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().CreateBudget(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "CategoryOtherHousehold",
+			body:      householdRequest,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleEditor)
+				expectCategory(store, category)
+				store.EXPECT().CreateBudget(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errCategoryOtherHousehold),
+		},
+		{
+			name:      "HouseholdViewer",
+			body:      householdRequest,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "AlreadyExists",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectCategory(store, category)
+				store.EXPECT().CreateBudget(gomock.Any(), gomock.Any()).Times(1).Return(db.Budget{}, &pq.Error{Code: "23505"})
+			},
+			status:   http.StatusConflict,
+			response: errorResponse(errBudgetExists),
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeBudgetsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			body: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeBudgetsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectCategory(store, category)
+				store.EXPECT().CreateBudget(gomock.Any(), gomock.Any()).Times(1).Return(db.Budget{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/budgets", testCases)
+}
+
+func TestGetBudget(t *testing.T) {
+	user, _ := randomUser(t)
+	found := randomBudget(user, randomCategory(user, "debit"))
+	params := db.GetBudgetParams{ID: found.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withAPIKey(user, scopeBudgetsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudget(gomock.Any(), gomock.Eq(params)).Times(1).Return(found, nil)
+			},
+			status:   http.StatusOK,
+			response: found,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudget(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.Budget{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudget(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudget(gomock.Any(), gomock.Any()).Times(1).Return(db.Budget{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, fmt.Sprintf("/budgets/%d", found.ID), testCases)
+}
+
+func TestGetBudgets(t *testing.T) {
+	user, _ := randomUser(t)
+	budgets := []db.Budget{
+		randomBudget(user, randomCategory(user, "debit")),
+		randomBudget(user, randomCategory(user, "debit")),
+	}
+	householdID := randomID()
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudgets(gomock.Any(), gomock.Eq(db.GetBudgetsParams{UserID: user.ID})).Times(1).Return(budgets, nil)
+			},
+			status:   http.StatusOK,
+			response: budgets,
+		},
+		{
+			name:      "HouseholdNotMember",
+			url:       fmt.Sprintf("/budgets?household_id=%d", householdID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetHouseholdMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.HouseholdMember{}, sql.ErrNoRows)
+				store.EXPECT().GetBudgets(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudgets(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudgets(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/budgets", testCases)
+}
+
+func TestUpdateBudget(t *testing.T) {
+	user, _ := randomUser(t)
+	found := randomBudget(user, randomCategory(user, "debit"))
+	request := updateBudgetRequest{Amount: util.NewMoney(950, 0), Recurring: true, Rollover: true}
+	updated := found
+	updated.Amount = request.Amount
+	updated.Rollover = true
+	arg := db.UpdateBudgetParams{ID: found.ID, UserID: user.ID, Amount: request.Amount, Recurring: true, Rollover: true}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateBudget(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updated, nil)
+			},
+			status:   http.StatusOK,
+			response: updated,
+		},
+		{
+			name:      "ZeroAmount",
+			body:      map[string]interface{}{"amount": "0"},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateBudget(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateBudget(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Budget{}, sql.ErrNoRows)
+				store.EXPECT().GetBudget(gomock.Any(), gomock.Any()).Times(1).Return(db.Budget{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "NotWritable",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateBudget(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Budget{}, sql.ErrNoRows)
+				store.EXPECT().GetBudget(gomock.Any(), gomock.Eq(db.GetBudgetParams{ID: found.ID, UserID: user.ID})).Times(1).Return(found, nil)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeBudgetsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateBudget(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeBudgetsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateBudget(gomock.Any(), gomock.Any()).Times(1).Return(db.Budget{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPut, fmt.Sprintf("/budgets/%d", found.ID), testCases)
+}
+
+func TestDeleteBudget(t *testing.T) {
+	user, _ := randomUser(t)
+	found := randomBudget(user, randomCategory(user, "debit"))
+	params := db.DeleteBudgetParams{ID: found.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteBudget(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "NotWritable",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteBudget(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetBudget(gomock.Any(), gomock.Eq(db.GetBudgetParams(params))).Times(1).Return(found, nil)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeBudgetsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteBudget(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteBudget(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodDelete, fmt.Sprintf("/budgets/%d", found.ID), testCases)
+}
+
+func TestGetBudgetMonth(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomCategory(user, "debit")
+	month := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	budgets := []db.GetBudgetsUntilRow{
+		{
+			ID:            randomID(),
+			UserID:        user.ID,
+			CategoryID:    category.ID,
+			CategoryTitle: category.Title,
+			Month:         time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Amount:        util.NewMoney(500, 0),
+			Recurring:     true,
+			Rollover:      true,
+		},
+	}
+	spending := []db.GetBudgetSpendingRow{
+		{CategoryID: category.ID, Month: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Spent: util.NewMoney(400, 0)},
+		{CategoryID: category.ID, Month: month, Spent: util.NewMoney(300, 0)},
+	}
+	untilParams := db.GetBudgetsUntilParams{UserID: user.ID, Month: month}
+	spendingParams := db.GetBudgetSpendingParams{
+		UserID:    user.ID,
+		FromMonth: budgets[0].Month,
+		ToMonth:   time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withAPIKey(user, scopeBudgetsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudgetsUntil(gomock.Any(), gomock.Eq(untilParams)).Times(1).Return(budgets, nil)
+				store.EXPECT().GetBudgetSpending(gomock.Any(), gomock.Eq(spendingParams)).Times(1).Return(spending, nil)
+			},
+			status:   http.StatusOK,
+			response: budget.Summarize(budgets, spending, month),
+		},
+		{
+			name:      "NoBudgets",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudgetsUntil(gomock.Any(), gomock.Eq(untilParams)).Times(1).Return([]db.GetBudgetsUntilRow{}, nil)
+				store.EXPECT().GetBudgetSpending(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusOK,
+			response: budget.Summary{Year: 2024, Month: 3, Categories: []budget.Progress{}},
+		},
+		{
+			name:      "MissingRate",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudgetsUntil(gomock.Any(), gomock.Any()).Times(1).Return(budgets, nil)
+				store.EXPECT().GetBudgetSpending(gomock.Any(), gomock.Any()).Times(1).Return([]db.GetBudgetSpendingRow{{CategoryID: category.ID, Month: month, MissingRates: 1}}, nil)
+			},
+			status:   http.StatusUnprocessableEntity,
+			response: errorResponse(errMissingExchangeRate),
+		},
+		{
+			name:      "InvalidMonth",
+			url:       "/budget/2024/13",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudgetsUntil(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeReportsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudgetsUntil(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudgetsUntil(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBudgetsUntil(gomock.Any(), gomock.Any()).Times(1).Return(budgets, nil)
+				store.EXPECT().GetBudgetSpending(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/budget/2024/3", testCases)
+}
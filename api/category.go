@@ -5,32 +5,32 @@ import (
 	"net/http"
 
 	db "github.com/SraReaper/gofinance-backend/db/sqlc"
-	"github.com/SraReaper/gofinance-backend/util"
 	"github.com/gin-gonic/gin"
 )
 
 type createCategoryRequest struct {
-	UserID      int32  `json:"user_id" binding:"required"`
+	HouseholdID int32  `json:"household_id"`
 	Title       string `json:"title" binding:"required"`
-	Type        string `json:"type" binding:"required"`
+	Type        string `json:"type" binding:"required,oneof=credit debit"`
 	Description string `json:"description" binding:"required"`
 }
 
 // createCategory para criar um usuário
 func (server *Server) createCategory(ctx *gin.Context) {
-	errOnValiteToken := util.GetTokenInHeaderAndVerify(ctx)
-	if errOnValiteToken != nil {
-		return
-	}
-
 	var request createCategoryRequest
 	err := ctx.ShouldBindJSON(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleEditor) {
+		return
 	}
 
 	arg := db.CreateCategoryParams{
-		UserID:      request.UserID,
+		UserID:      authClaims(ctx).UserID,
+		HouseholdID: householdID(request.HouseholdID),
 		Title:       request.Title,
 		Type:        request.Type,
 		Description: request.Description,
@@ -38,7 +38,12 @@ func (server *Server) createCategory(ctx *gin.Context) {
 
 	user, err := server.store.CreateCategory(ctx, arg)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errHouseholdForbidden))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
 	ctx.JSON(http.StatusOK, user)
@@ -50,18 +55,19 @@ type getCategoryRequest struct {
 
 // getCategory valida a URL e a categoria
 func (server *Server) getCategory(ctx *gin.Context) {
-	errOnValiteToken := util.GetTokenInHeaderAndVerify(ctx)
-	if errOnValiteToken != nil {
-		return
-	}
-
 	var request getCategoryRequest
 	err := ctx.ShouldBindUri(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
-	user, err := server.store.GetCategory(ctx, request.ID)
+	arg := db.GetCategoryParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	}
+
+	user, err := server.store.GetCategory(ctx, arg)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -80,24 +86,30 @@ type deleteCategoryRequest struct {
 
 // deleteCategory deleta a categoria
 func (server *Server) deleteCategory(ctx *gin.Context) {
-	errOnValiteToken := util.GetTokenInHeaderAndVerify(ctx)
-	if errOnValiteToken != nil {
-		return
-	}
-
 	var request deleteCategoryRequest
 	err := ctx.ShouldBindUri(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.DeleteCategoriesParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
 	}
 
-	err = server.store.DeleteCategories(ctx, request.ID)
+	rowsDeleted, err := server.store.DeleteCategories(ctx, arg)
 	if err != nil {
 
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 
 	}
+	if rowsDeleted == 0 {
+		_, err = server.store.GetCategory(ctx, db.GetCategoryParams(arg))
+		notWritable(ctx, err)
+		return
+	}
 
 	ctx.JSON(http.StatusOK, true)
 }
@@ -110,44 +122,43 @@ type updateCategoryRequest struct {
 
 // updateCategory para atualizar um usuário
 func (server *Server) updateCategory(ctx *gin.Context) {
-	errOnValiteToken := util.GetTokenInHeaderAndVerify(ctx)
-	if errOnValiteToken != nil {
-		return
-	}
 	var request updateCategoryRequest
 	err := ctx.ShouldBindJSON(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
 	arg := db.UpdateCategoriesParams{
 		ID:          request.ID,
+		UserID:      authClaims(ctx).UserID,
 		Title:       request.Title,
 		Description: request.Description,
 	}
 
 	category, err := server.store.UpdateCategories(ctx, arg)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			_, err = server.store.GetCategory(ctx, db.GetCategoryParams{ID: arg.ID, UserID: arg.UserID})
+			notWritable(ctx, err)
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
 	ctx.JSON(http.StatusOK, category)
 }
 
 type getCategoriesRequest struct {
-	UserID      int32  `form:"user_id" json:"user_id" binding:"required"`
-	Type        string `form:"type" json:"type" binding:"required"`
+	HouseholdID int32  `form:"household_id" json:"household_id"`
+	Type        string `form:"type" json:"type" binding:"required,oneof=credit debit"`
 	Title       string `form:"title" json:"title"`
 	Description string `form:"description" json:"description"`
 }
 
 // getCategories valida a URL e a categorias
 func (server *Server) getCategories(ctx *gin.Context) {
-	errOnValiteToken := util.GetTokenInHeaderAndVerify(ctx)
-	if errOnValiteToken != nil {
-		return
-	}
-
 	var request getCategoriesRequest
 	err := ctx.ShouldBindQuery(&request)
 	if err != nil {
@@ -155,8 +166,13 @@ func (server *Server) getCategories(ctx *gin.Context) {
 		return
 	}
 
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleViewer) {
+		return
+	}
+
 	arg := db.GetCategoriesParams{
-		UserID:      request.UserID,
+		HouseholdID: householdID(request.HouseholdID),
+		UserID:      authClaims(ctx).UserID,
 		Type:        request.Type,
 		Title:       request.Title,
 		Description: request.Description,
@@ -165,6 +181,7 @@ func (server *Server) getCategories(ctx *gin.Context) {
 	categories, err := server.store.GetCategories(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
 	ctx.JSON(http.StatusOK, categories)
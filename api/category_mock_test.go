@@ -0,0 +1,415 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateCategory(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomCategory(user, "debit")
+	householdID := randomID()
+	request := createCategoryRequest{Title: category.Title, Type: category.Type, Description: category.Description}
+	householdRequest := request
+	householdRequest.HouseholdID = householdID
+	invalidType := request
+	invalidType.Type = "expense"
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCategory(gomock.Any(), gomock.Eq(db.CreateCategoryParams{
+					UserID:      user.ID,
+					Title:       category.Title,
+					Type:        category.Type,
+					Description: category.Description,
+				})).Times(1).Return(category, nil)
+			},
+			status:   http.StatusOK,
+			response: category,
+		},
+		{
+			// o saldo e os relatórios só conhecem crédito e débito
+			name:      "InvalidType",
+			body:      invalidType,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "Household",
+			body:      householdRequest,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleEditor)
+				store.EXPECT().CreateCategory(gomock.Any(), gomock.Eq(db.CreateCategoryParams{
+					UserID:      user.ID,
+					HouseholdID: sql.NullInt32{Int32: householdID, Valid: true},
+					Title:       category.Title,
+					Type:        category.Type,
+					Description: category.Description,
+				})).Times(1).Return(category, nil)
+			},
+			status:   http.StatusOK,
+			response: category,
+		},
+		{
+			name:      "HouseholdViewer",
+			body:      householdRequest,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				store.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "BadRequest",
+			body:      createCategoryRequest{Title: category.Title},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeCategoriesRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			body: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeCategoriesWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Times(1).Return(db.Category{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/category", testCases)
+}
+
+func TestGetCategory(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomCategory(user, "credit")
+	params := db.GetCategoryParams{ID: category.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(params)).Times(1).Return(category, nil)
+			},
+			status:   http.StatusOK,
+			response: category,
+		},
+		{
+			name:      "InvalidID",
+			url:       "/category/id/0",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.Category{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.Category{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, fmt.Sprintf("/category/id/%d", category.ID), testCases)
+}
+
+func TestGetCategories(t *testing.T) {
+	user, _ := randomUser(t)
+	categories := []db.Category{randomCategory(user, "debit"), randomCategory(user, "debit")}
+	householdID := randomID()
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withAPIKey(user, scopeCategoriesRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategories(gomock.Any(), gomock.Eq(db.GetCategoriesParams{
+					UserID: user.ID,
+					Type:   "debit",
+					Title:  "food",
+				})).Times(1).Return(categories, nil)
+			},
+			status:   http.StatusOK,
+			response: categories,
+		},
+		{
+			name:      "Household",
+			url:       fmt.Sprintf("/category?type=debit&household_id=%d", householdID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				store.EXPECT().GetCategories(gomock.Any(), gomock.Eq(db.GetCategoriesParams{
+					HouseholdID: sql.NullInt32{Int32: householdID, Valid: true},
+					UserID:      user.ID,
+					Type:        "debit",
+				})).Times(1).Return(categories, nil)
+			},
+			status:   http.StatusOK,
+			response: categories,
+		},
+		{
+			name:      "NotHouseholdMember",
+			url:       fmt.Sprintf("/category?type=debit&household_id=%d", householdID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHouseholdMember(gomock.Any(), gomock.Any()).Times(1).Return(db.HouseholdMember{}, sql.ErrNoRows)
+				store.EXPECT().GetCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingType",
+			url:       "/category",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "InvalidType",
+			url:       "/category?type=Debit",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategories(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/category?type=debit&title=food", testCases)
+}
+
+func TestUpdateCategory(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomCategory(user, "debit")
+	request := updateCategoryRequest{ID: category.ID, Title: "new title", Description: "new description"}
+	params := db.UpdateCategoriesParams{ID: category.ID, UserID: user.ID, Title: request.Title, Description: request.Description}
+	updated := category
+	updated.Title = request.Title
+	updated.Description = request.Description
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCategories(gomock.Any(), gomock.Eq(params)).Times(1).Return(updated, nil)
+			},
+			status:   http.StatusOK,
+			response: updated,
+		},
+		{
+			name:      "BadRequest",
+			body:      updateCategoryRequest{Title: request.Title},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCategories(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(db.GetCategoryParams{ID: category.ID, UserID: user.ID})).Times(1).Return(db.Category{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "NotWritable",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCategories(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(1).Return(category, nil)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeCategoriesRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			body: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCategories(gomock.Any(), gomock.Any()).Times(1).Return(db.Category{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPut, fmt.Sprintf("/category/%d", category.ID), testCases)
+}
+
+func TestDeleteCategory(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomCategory(user, "debit")
+	params := db.DeleteCategoriesParams{ID: category.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteCategories(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "InvalidID",
+			url:       "/category/abc",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteCategories(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(db.GetCategoryParams(params))).Times(1).Return(db.Category{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "NotWritable",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteCategories(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(db.GetCategoryParams(params))).Times(1).Return(category, nil)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeCategoriesRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteCategories(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodDelete, fmt.Sprintf("/category/%d", category.ID), testCases)
+}
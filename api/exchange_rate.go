@@ -0,0 +1,65 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+var errMissingExchangeRate = errors.New("missing exchange rate to convert to the base currency")
+
+// currency converte o código opcional dos requests; vazio usa a moeda base do usuário
+func currency(code string) sql.NullString {
+	return sql.NullString{String: code, Valid: code != ""}
+}
+
+type getExchangeRateRequest struct {
+	From string    `form:"from" binding:"required,iso4217"`
+	To   string    `form:"to" binding:"required,iso4217"`
+	Date time.Time `form:"date" time_format:"2006-01-02"`
+}
+
+type exchangeRateResponse struct {
+	From string    `json:"from"`
+	To   string    `json:"to"`
+	Date time.Time `json:"date"`
+	Rate string    `json:"rate"`
+}
+
+// getExchangeRate mostra a taxa em vigor na data (hoje, se não informada)
+func (server *Server) getExchangeRate(ctx *gin.Context) {
+	var request getExchangeRateRequest
+	err := ctx.ShouldBindQuery(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.Date.IsZero() {
+		request.Date = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+
+	rate, err := server.store.GetExchangeRate(ctx, db.GetExchangeRateParams{
+		FromCurrency: request.From,
+		ToCurrency:   request.To,
+		OnDate:       request.Date,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exchangeRateResponse{
+		From: request.From,
+		To:   request.To,
+		Date: request.Date,
+		Rate: rate,
+	})
+}
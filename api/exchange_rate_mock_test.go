@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetExchangeRate(t *testing.T) {
+	user, _ := randomUser(t)
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	expectRate := func(store *mockdb.MockStore, date time.Time, rate string, err error) {
+		store.EXPECT().GetExchangeRate(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, arg db.GetExchangeRateParams) (string, error) {
+				require.Equal(t, "USD", arg.FromCurrency)
+				require.Equal(t, "BRL", arg.ToCurrency)
+				require.True(t, date.Equal(arg.OnDate))
+				return rate, err
+			})
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withAPIKey(user, scopeReportsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectRate(store, date, "4.9876", nil)
+			},
+			status:   http.StatusOK,
+			response: exchangeRateResponse{From: "USD", To: "BRL", Date: date, Rate: "4.9876"},
+		},
+		{
+			name:      "Today",
+			url:       "/exchange-rates?from=USD&to=BRL",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectRate(store, time.Now().UTC().Truncate(24*time.Hour), "5.1", nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "InvalidCurrency",
+			url:       "/exchange-rates?from=USD&to=XYZ",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExchangeRate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectRate(store, date, "", sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExchangeRate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExchangeRate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectRate(store, date, "", errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/exchange-rates?from=USD&to=BRL&date=2024-03-15", testCases)
+}
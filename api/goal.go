@@ -0,0 +1,465 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/goal"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+var (
+	errGoalAmount              = errors.New("amount must be positive")
+	errGoalLink                = errors.New("link the goal to either a category or a wallet")
+	errGoalCategoryType        = errors.New("goals can only be linked to credit categories")
+	errGoalCurrency            = errors.New("currency must match the goal currency")
+	errContributionNotIncome   = errors.New("only credit accounts can contribute to a goal")
+	errContributionNotLinked   = errors.New("account is not in the category or wallet linked to the goal")
+	errContributionOverAccount = errors.New("amount is larger than the account value")
+	errContributionExists      = errors.New("account already contributes to this goal")
+	errAccountOtherHousehold   = errors.New("account does not belong to the same household")
+)
+
+// goalResponse é a meta com o andamento calculado
+type goalResponse struct {
+	db.Goal
+	Progress goal.Progress `json:"progress"`
+}
+
+func newGoalResponse(row db.GetGoalRow, today time.Time) goalResponse {
+	return goalResponse{
+		Goal: db.Goal{
+			ID:           row.ID,
+			UserID:       row.UserID,
+			HouseholdID:  row.HouseholdID,
+			Name:         row.Name,
+			TargetAmount: row.TargetAmount,
+			Currency:     row.Currency,
+			Deadline:     row.Deadline,
+			CategoryID:   row.CategoryID,
+			WalletID:     row.WalletID,
+			CreatedAt:    row.CreatedAt,
+		},
+		Progress: goal.Compute(row, today),
+	}
+}
+
+type createGoalRequest struct {
+	HouseholdID  int32      `json:"household_id"`
+	Name         string     `json:"name" binding:"required"`
+	TargetAmount util.Money `json:"target_amount" binding:"required"`
+	Currency     string     `json:"currency" binding:"omitempty,iso4217"`
+	Deadline     *time.Time `json:"deadline"`
+	CategoryID   int32      `json:"category_id"`
+	WalletID     int32      `json:"wallet_id"`
+}
+
+// createGoal cria uma meta; ligada a uma carteira, a meta fica na moeda dela, e sem moeda
+// fica na moeda base do usuário
+func (server *Server) createGoal(ctx *gin.Context) {
+	var request createGoalRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.TargetAmount <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errGoalAmount))
+		return
+	}
+	if request.CategoryID > 0 && request.WalletID > 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errGoalLink))
+		return
+	}
+
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleEditor) {
+		return
+	}
+
+	arg := db.CreateGoalParams{
+		UserID:       authClaims(ctx).UserID,
+		HouseholdID:  householdID(request.HouseholdID),
+		Name:         request.Name,
+		TargetAmount: request.TargetAmount,
+		Currency:     sql.NullString{String: request.Currency, Valid: request.Currency != ""},
+		CategoryID:   sql.NullInt32{Int32: request.CategoryID, Valid: request.CategoryID > 0},
+		WalletID:     sql.NullInt32{Int32: request.WalletID, Valid: request.WalletID > 0},
+	}
+	if request.Deadline != nil {
+		arg.Deadline = sql.NullTime{Time: *request.Deadline, Valid: true}
+	}
+
+	if arg.CategoryID.Valid {
+		category, err := server.store.GetCategory(ctx, db.GetCategoryParams{ID: request.CategoryID, UserID: arg.UserID})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if category.HouseholdID != arg.HouseholdID {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errCategoryOtherHousehold))
+			return
+		}
+		if category.Type != "credit" {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errGoalCategoryType))
+			return
+		}
+	}
+
+	if arg.WalletID.Valid {
+		wallet, err := server.store.GetWallet(ctx, db.GetWalletParams{ID: request.WalletID, UserID: arg.UserID})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if wallet.HouseholdID != arg.HouseholdID {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errWalletOtherHousehold))
+			return
+		}
+		if arg.Currency.Valid && arg.Currency.String != wallet.Currency {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errGoalCurrency))
+			return
+		}
+		arg.Currency = sql.NullString{String: wallet.Currency, Valid: true}
+	}
+
+	created, err := server.store.CreateGoal(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errHouseholdForbidden))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// a meta nova ainda não tem contribuições
+	today := time.Now()
+	ctx.JSON(http.StatusOK, goalResponse{Goal: created, Progress: goal.Compute(db.GetGoalRow{
+		TargetAmount:      created.TargetAmount,
+		Deadline:          created.Deadline,
+		FirstContribution: today,
+	}, today)})
+}
+
+type goalRequest struct {
+	ID int32 `uri:"id" binding:"required"`
+}
+
+// getGoal mostra a meta com o andamento
+func (server *Server) getGoal(ctx *gin.Context) {
+	var request goalRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	row, err := server.store.GetGoal(ctx, db.GetGoalParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newGoalResponse(row, time.Now()))
+}
+
+// getGoals lista as metas do escopo com o andamento
+func (server *Server) getGoals(ctx *gin.Context) {
+	var request householdScopeRequest
+	err := ctx.ShouldBindQuery(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleViewer) {
+		return
+	}
+
+	rows, err := server.store.GetGoals(ctx, db.GetGoalsParams{
+		HouseholdID: householdID(request.HouseholdID),
+		UserID:      authClaims(ctx).UserID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	today := time.Now()
+	goals := make([]goalResponse, 0, len(rows))
+	for _, row := range rows {
+		goals = append(goals, newGoalResponse(db.GetGoalRow(row), today))
+	}
+	ctx.JSON(http.StatusOK, goals)
+}
+
+type updateGoalRequest struct {
+	Name         string     `json:"name" binding:"required"`
+	TargetAmount util.Money `json:"target_amount" binding:"required"`
+	Deadline     *time.Time `json:"deadline"`
+}
+
+// updateGoal altera o nome, o alvo e o prazo; a moeda e a ligação ficam
+func (server *Server) updateGoal(ctx *gin.Context) {
+	var uri goalRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var request updateGoalRequest
+	err = ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.TargetAmount <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errGoalAmount))
+		return
+	}
+
+	arg := db.UpdateGoalParams{
+		ID:           uri.ID,
+		UserID:       authClaims(ctx).UserID,
+		Name:         request.Name,
+		TargetAmount: request.TargetAmount,
+	}
+	if request.Deadline != nil {
+		arg.Deadline = sql.NullTime{Time: *request.Deadline, Valid: true}
+	}
+
+	_, err = server.store.UpdateGoal(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			_, err = server.store.GetGoal(ctx, db.GetGoalParams{ID: arg.ID, UserID: arg.UserID})
+			notWritable(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	row, err := server.store.GetGoal(ctx, db.GetGoalParams{ID: arg.ID, UserID: arg.UserID})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newGoalResponse(row, time.Now()))
+}
+
+// deleteGoal apaga a meta e as contribuições; as transações ficam
+func (server *Server) deleteGoal(ctx *gin.Context) {
+	var request goalRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.DeleteGoalParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	}
+
+	rowsDeleted, err := server.store.DeleteGoal(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rowsDeleted == 0 {
+		_, err = server.store.GetGoal(ctx, db.GetGoalParams(arg))
+		notWritable(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
+
+// getGoalContributions lista as contribuições da meta
+func (server *Server) getGoalContributions(ctx *gin.Context) {
+	var request goalRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	row, err := server.store.GetGoal(ctx, db.GetGoalParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	contributions, err := server.store.GetGoalContributions(ctx, row.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, contributions)
+}
+
+type createGoalContributionRequest struct {
+	AccountID int32      `json:"account_id"`
+	Amount    util.Money `json:"amount"`
+	Date      time.Time  `json:"date"`
+	Note      string     `json:"note"`
+}
+
+// createGoalContribution registra uma contribuição manual ou parte de uma transação de
+// receita. Com account_id, o valor padrão é o da transação e a data é a dela; a transação
+// precisa estar na categoria ou carteira ligada à meta, se houver
+func (server *Server) createGoalContribution(ctx *gin.Context) {
+	var uri goalRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var request createGoalContributionRequest
+	err = ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.Amount < 0 || (request.AccountID == 0 && request.Amount == 0) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errGoalAmount))
+		return
+	}
+
+	userID := authClaims(ctx).UserID
+	row, err := server.store.GetGoal(ctx, db.GetGoalParams{ID: uri.ID, UserID: userID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateGoalContributionParams{
+		GoalID: row.ID,
+		UserID: userID,
+		Amount: request.Amount,
+		Date:   request.Date,
+		Note:   request.Note,
+	}
+	if arg.Date.IsZero() {
+		arg.Date = time.Now()
+	}
+
+	if request.AccountID > 0 {
+		account, err := server.store.GetAccount(ctx, db.GetAccountParams{ID: request.AccountID, UserID: userID})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		switch {
+		case account.HouseholdID != row.HouseholdID:
+			err = errAccountOtherHousehold
+		case account.Type != "credit":
+			err = errContributionNotIncome
+		case row.CategoryID.Valid && account.CategoryID != row.CategoryID,
+			row.WalletID.Valid && account.WalletID != row.WalletID.Int32:
+			err = errContributionNotLinked
+		case account.Currency != row.Currency:
+			err = errGoalCurrency
+		case arg.Amount > account.Value:
+			err = errContributionOverAccount
+		}
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		arg.AccountID = sql.NullInt32{Int32: account.ID, Valid: true}
+		arg.Date = account.Date
+		if arg.Amount == 0 {
+			arg.Amount = account.Value
+		}
+	}
+
+	contribution, err := server.store.CreateGoalContribution(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errHouseholdForbidden))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(errContributionExists))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, contribution)
+}
+
+type goalContributionRequest struct {
+	ID             int32 `uri:"id" binding:"required"`
+	ContributionID int32 `uri:"contribution_id" binding:"required"`
+}
+
+// deleteGoalContribution apaga uma contribuição; a transação de origem fica
+func (server *Server) deleteGoalContribution(ctx *gin.Context) {
+	var request goalContributionRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.DeleteGoalContributionParams{
+		ID:     request.ContributionID,
+		GoalID: request.ID,
+		UserID: authClaims(ctx).UserID,
+	}
+
+	rowsDeleted, err := server.store.DeleteGoalContribution(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rowsDeleted == 0 {
+		_, err = server.store.GetGoalContribution(ctx, db.GetGoalContributionParams(arg))
+		notWritable(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
@@ -0,0 +1,635 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/lib/pq"
+	"go.uber.org/mock/gomock"
+)
+
+func randomGoal(user db.User) db.GetGoalRow {
+	return db.GetGoalRow{
+		ID:                randomID(),
+		UserID:            user.ID,
+		Name:              util.RandomString(10),
+		TargetAmount:      util.NewMoney(10000, 0),
+		Currency:          user.BaseCurrency,
+		CreatedAt:         time.Now().UTC().Truncate(time.Second),
+		Saved:             util.NewMoney(2500, 0),
+		FirstContribution: time.Now().UTC().AddDate(0, -2, 0).Truncate(24 * time.Hour),
+	}
+}
+
+func TestCreateGoal(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomCategory(user, "credit")
+	wallet := randomWallet(user, "EUR")
+	deadline := time.Now().UTC().AddDate(1, 0, 0).Truncate(24 * time.Hour)
+	created := db.Goal{
+		ID:           randomID(),
+		UserID:       user.ID,
+		Name:         util.RandomString(10),
+		TargetAmount: util.NewMoney(5000, 0),
+		Currency:     user.BaseCurrency,
+		Deadline:     sql.NullTime{Time: deadline, Valid: true},
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+	request := createGoalRequest{Name: created.Name, TargetAmount: created.TargetAmount, Deadline: &deadline}
+	arg := db.CreateGoalParams{
+		UserID:       user.ID,
+		Name:         created.Name,
+		TargetAmount: created.TargetAmount,
+		Deadline:     created.Deadline,
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoal(gomock.Any(), gomock.Eq(arg)).Times(1).Return(created, nil)
+			},
+			status: http.StatusOK,
+			response: newGoalResponse(db.GetGoalRow{
+				ID:                created.ID,
+				UserID:            created.UserID,
+				Name:              created.Name,
+				TargetAmount:      created.TargetAmount,
+				Currency:          created.Currency,
+				Deadline:          created.Deadline,
+				CreatedAt:         created.CreatedAt,
+				FirstContribution: time.Now(),
+			}, time.Now()),
+		},
+		{
+			name:      "Category",
+			body:      createGoalRequest{Name: created.Name, TargetAmount: created.TargetAmount, CategoryID: category.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(db.GetCategoryParams{ID: category.ID, UserID: user.ID})).Times(1).Return(category, nil)
+				store.EXPECT().CreateGoal(gomock.Any(), gomock.Eq(db.CreateGoalParams{
+					UserID:       user.ID,
+					Name:         created.Name,
+					TargetAmount: created.TargetAmount,
+					CategoryID:   sql.NullInt32{Int32: category.ID, Valid: true},
+				})).Times(1).Return(created, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "WalletCurrency",
+			body:      createGoalRequest{Name: created.Name, TargetAmount: created.TargetAmount, WalletID: wallet.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(db.GetWalletParams{ID: wallet.ID, UserID: user.ID})).Times(1).Return(wallet, nil)
+				store.EXPECT().CreateGoal(gomock.Any(), gomock.Eq(db.CreateGoalParams{
+					UserID:       user.ID,
+					Name:         created.Name,
+					TargetAmount: created.TargetAmount,
+					Currency:     sql.NullString{String: "EUR", Valid: true},
+					WalletID:     sql.NullInt32{Int32: wallet.ID, Valid: true},
+				})).Times(1).Return(created, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "WalletOtherCurrency",
+			body:      createGoalRequest{Name: created.Name, TargetAmount: created.TargetAmount, WalletID: wallet.ID, Currency: "USD"},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(1).Return(wallet, nil)
+				store.EXPECT().CreateGoal(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errGoalCurrency),
+		},
+		{
+			name:      "DebitCategory",
+			body:      createGoalRequest{Name: created.Name, TargetAmount: created.TargetAmount, CategoryID: category.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				debit := category
+				debit.Type = "debit"
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(1).Return(debit, nil)
+				store.EXPECT().CreateGoal(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errGoalCategoryType),
+		},
+		{
+			name:      "CategoryAndWallet",
+			body:      createGoalRequest{Name: created.Name, TargetAmount: created.TargetAmount, CategoryID: category.ID, WalletID: wallet.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoal(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errGoalLink),
+		},
+		{
+			name:      "NegativeTarget",
+			body:      createGoalRequest{Name: created.Name, TargetAmount: util.NewMoney(-1, 0)},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoal(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errGoalAmount),
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeGoalsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoal(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			body: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoal(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeGoalsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoal(gomock.Any(), gomock.Any()).Times(1).Return(db.Goal{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/goals", testCases)
+}
+
+func TestGetGoal(t *testing.T) {
+	user, _ := randomUser(t)
+	row := randomGoal(user)
+	params := db.GetGoalParams{ID: row.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withAPIKey(user, scopeGoalsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Eq(params)).Times(1).Return(row, nil)
+			},
+			status:   http.StatusOK,
+			response: newGoalResponse(row, time.Now()),
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.GetGoalRow{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeBudgetsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Any()).Times(1).Return(db.GetGoalRow{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, fmt.Sprintf("/goals/%d", row.ID), testCases)
+}
+
+func TestGetGoals(t *testing.T) {
+	user, _ := randomUser(t)
+	rows := []db.GetGoalsRow{db.GetGoalsRow(randomGoal(user)), db.GetGoalsRow(randomGoal(user))}
+	householdID := randomID()
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoals(gomock.Any(), gomock.Eq(db.GetGoalsParams{UserID: user.ID})).Times(1).Return(rows, nil)
+			},
+			status: http.StatusOK,
+			response: []goalResponse{
+				newGoalResponse(db.GetGoalRow(rows[0]), time.Now()),
+				newGoalResponse(db.GetGoalRow(rows[1]), time.Now()),
+			},
+		},
+		{
+			name:      "Household",
+			url:       fmt.Sprintf("/goals?household_id=%d", householdID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				store.EXPECT().GetGoals(gomock.Any(), gomock.Eq(db.GetGoalsParams{
+					HouseholdID: sql.NullInt32{Int32: householdID, Valid: true},
+					UserID:      user.ID,
+				})).Times(1).Return([]db.GetGoalsRow{}, nil)
+			},
+			status:   http.StatusOK,
+			response: []goalResponse{},
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoals(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/goals", testCases)
+}
+
+func TestUpdateGoal(t *testing.T) {
+	user, _ := randomUser(t)
+	row := randomGoal(user)
+	params := db.GetGoalParams{ID: row.ID, UserID: user.ID}
+	request := updateGoalRequest{Name: util.RandomString(10), TargetAmount: util.NewMoney(12000, 0)}
+	updated := row
+	updated.Name = request.Name
+	updated.TargetAmount = request.TargetAmount
+	arg := db.UpdateGoalParams{ID: row.ID, UserID: user.ID, Name: request.Name, TargetAmount: request.TargetAmount}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoal(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Goal{ID: row.ID}, nil)
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Eq(params)).Times(1).Return(updated, nil)
+			},
+			status:   http.StatusOK,
+			response: newGoalResponse(updated, time.Now()),
+		},
+		{
+			name:      "NotWritable",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoal(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Goal{}, sql.ErrNoRows)
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Eq(params)).Times(1).Return(row, nil)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "NotFound",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoal(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Goal{}, sql.ErrNoRows)
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.GetGoalRow{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "ZeroTarget",
+			body:      map[string]interface{}{"name": request.Name, "target_amount": "0.00"},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoal(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeGoalsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoal(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoal(gomock.Any(), gomock.Any()).Times(1).Return(db.Goal{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPut, fmt.Sprintf("/goals/%d", row.ID), testCases)
+}
+
+func TestDeleteGoal(t *testing.T) {
+	user, _ := randomUser(t)
+	row := randomGoal(user)
+	params := db.DeleteGoalParams{ID: row.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGoal(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGoal(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Eq(db.GetGoalParams(params))).Times(1).Return(db.GetGoalRow{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeGoalsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGoal(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGoal(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodDelete, fmt.Sprintf("/goals/%d", row.ID), testCases)
+}
+
+func TestGetGoalContributions(t *testing.T) {
+	user, _ := randomUser(t)
+	row := randomGoal(user)
+	contributions := []db.GoalContribution{
+		{ID: randomID(), GoalID: row.ID, Amount: util.NewMoney(100, 0), Date: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Eq(db.GetGoalParams{ID: row.ID, UserID: user.ID})).Times(1).Return(row, nil)
+				store.EXPECT().GetGoalContributions(gomock.Any(), gomock.Eq(row.ID)).Times(1).Return(contributions, nil)
+			},
+			status:   http.StatusOK,
+			response: contributions,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Any()).Times(1).Return(db.GetGoalRow{}, sql.ErrNoRows)
+				store.EXPECT().GetGoalContributions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Any()).Times(1).Return(row, nil)
+				store.EXPECT().GetGoalContributions(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, fmt.Sprintf("/goals/%d/contributions", row.ID), testCases)
+}
+
+func TestCreateGoalContribution(t *testing.T) {
+	user, _ := randomUser(t)
+	row := randomGoal(user)
+	category := randomCategory(user, "credit")
+	wallet := randomWallet(user, user.BaseCurrency)
+	account := randomAccount(user, category, wallet)
+	date := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	contribution := db.GoalContribution{ID: randomID(), GoalID: row.ID, Amount: util.NewMoney(300, 0), Date: date}
+	linked := row
+	linked.WalletID = sql.NullInt32{Int32: randomID(), Valid: true}
+
+	expectGoal := func(store *mockdb.MockStore, row db.GetGoalRow) {
+		store.EXPECT().GetGoal(gomock.Any(), gomock.Eq(db.GetGoalParams{ID: row.ID, UserID: user.ID})).Times(1).Return(row, nil)
+	}
+	expectAccount := func(store *mockdb.MockStore, account db.Account) {
+		store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(db.GetAccountParams{ID: account.ID, UserID: user.ID})).Times(1).Return(account, nil)
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "Manual",
+			body:      createGoalContributionRequest{Amount: contribution.Amount, Date: date, Note: "bônus"},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectGoal(store, row)
+				store.EXPECT().CreateGoalContribution(gomock.Any(), gomock.Eq(db.CreateGoalContributionParams{
+					GoalID: row.ID,
+					UserID: user.ID,
+					Amount: contribution.Amount,
+					Date:   date,
+					Note:   "bônus",
+				})).Times(1).Return(contribution, nil)
+			},
+			status:   http.StatusOK,
+			response: contribution,
+		},
+		{
+			name:      "FromAccount",
+			body:      createGoalContributionRequest{AccountID: account.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectGoal(store, row)
+				expectAccount(store, account)
+				store.EXPECT().CreateGoalContribution(gomock.Any(), gomock.Eq(db.CreateGoalContributionParams{
+					GoalID:    row.ID,
+					UserID:    user.ID,
+					AccountID: sql.NullInt32{Int32: account.ID, Valid: true},
+					Amount:    account.Value,
+					Date:      account.Date,
+				})).Times(1).Return(contribution, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "DebitAccount",
+			body:      createGoalContributionRequest{AccountID: account.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				debit := account
+				debit.Type = "debit"
+				expectGoal(store, row)
+				expectAccount(store, debit)
+				store.EXPECT().CreateGoalContribution(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errContributionNotIncome),
+		},
+		{
+			name:      "NotLinked",
+			url:       fmt.Sprintf("/goals/%d/contributions", linked.ID),
+			body:      createGoalContributionRequest{AccountID: account.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectGoal(store, linked)
+				expectAccount(store, account)
+				store.EXPECT().CreateGoalContribution(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errContributionNotLinked),
+		},
+		{
+			name:      "OverAccount",
+			body:      createGoalContributionRequest{AccountID: account.ID, Amount: account.Value + 1},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectGoal(store, row)
+				expectAccount(store, account)
+				store.EXPECT().CreateGoalContribution(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errContributionOverAccount),
+		},
+		{
+			name:      "AlreadyContributes",
+			body:      createGoalContributionRequest{AccountID: account.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectGoal(store, row)
+				expectAccount(store, account)
+				store.EXPECT().CreateGoalContribution(gomock.Any(), gomock.Any()).Times(1).Return(db.GoalContribution{}, &pq.Error{Code: "23505"})
+			},
+			status:   http.StatusConflict,
+			response: errorResponse(errContributionExists),
+		},
+		{
+			name:      "NoAmount",
+			body:      createGoalContributionRequest{Date: date},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errGoalAmount),
+		},
+		{
+			name:      "NotWritable",
+			body:      createGoalContributionRequest{Amount: contribution.Amount, Date: date},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectGoal(store, row)
+				store.EXPECT().CreateGoalContribution(gomock.Any(), gomock.Any()).Times(1).Return(db.GoalContribution{}, sql.ErrNoRows)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "GoalNotFound",
+			body:      createGoalContributionRequest{Amount: contribution.Amount, Date: date},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Any()).Times(1).Return(db.GetGoalRow{}, sql.ErrNoRows)
+				store.EXPECT().CreateGoalContribution(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "MissingScope",
+			body:      createGoalContributionRequest{Amount: contribution.Amount, Date: date},
+			setupAuth: withAPIKey(user, scopeGoalsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoal(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, fmt.Sprintf("/goals/%d/contributions", row.ID), testCases)
+}
+
+func TestDeleteGoalContribution(t *testing.T) {
+	user, _ := randomUser(t)
+	goalID := randomID()
+	contributionID := randomID()
+	params := db.DeleteGoalContributionParams{ID: contributionID, GoalID: goalID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGoalContribution(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "NotWritable",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGoalContribution(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetGoalContribution(gomock.Any(), gomock.Eq(db.GetGoalContributionParams(params))).Times(1).Return(db.GoalContribution{ID: contributionID}, nil)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGoalContribution(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetGoalContribution(gomock.Any(), gomock.Any()).Times(1).Return(db.GoalContribution{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGoalContribution(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodDelete, fmt.Sprintf("/goals/%d/contributions/%d", goalID, contributionID), testCases)
+}
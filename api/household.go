@@ -0,0 +1,497 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/mail"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	householdRoleOwner  = "owner"
+	householdRoleEditor = "editor"
+	householdRoleViewer = "viewer"
+
+	householdInvitationDuration = 7 * 24 * time.Hour
+)
+
+// householdRoleRank ordena os papéis: quem tem um papel pode tudo que os de baixo podem
+var householdRoleRank = map[string]int{
+	householdRoleViewer: 1,
+	householdRoleEditor: 2,
+	householdRoleOwner:  3,
+}
+
+var (
+	errHouseholdForbidden     = errors.New("you do not have permission on this household")
+	errHouseholdLastOwner     = errors.New("a household must keep at least one owner")
+	errInvalidInvitation      = errors.New("invitation is invalid or expired")
+	errInvitationToOtherEmail = errors.New("invitation was sent to another email")
+	errEmailNotVerified       = errors.New("verify your email first")
+	errCategoryOtherHousehold = errors.New("category does not belong to the same household")
+)
+
+// householdID converte o id opcional dos requests; zero é o escopo pessoal
+func householdID(id int32) sql.NullInt32 {
+	return sql.NullInt32{Int32: id, Valid: id > 0}
+}
+
+// authorizeHousehold confere se o usuário tem pelo menos o papel pedido no household e
+// responde 403 se não tiver
+func (server *Server) authorizeHousehold(ctx *gin.Context, householdID int32, role string) bool {
+	member, err := server.store.GetHouseholdMember(ctx, db.GetHouseholdMemberParams{
+		HouseholdID: householdID,
+		UserID:      authClaims(ctx).UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errHouseholdForbidden))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if householdRoleRank[member.Role] < householdRoleRank[role] {
+		ctx.JSON(http.StatusForbidden, errorResponse(errHouseholdForbidden))
+		return false
+	}
+	return true
+}
+
+// notWritable responde depois de uma escrita que não afetou nada: 403 se o registro é visível
+// para o usuário (só leitura) e 404 se não existe; err vem da busca com permissão de leitura
+func notWritable(ctx *gin.Context, err error) {
+	switch err {
+	case nil:
+		ctx.JSON(http.StatusForbidden, errorResponse(errHouseholdForbidden))
+	case sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+type householdScopeRequest struct {
+	HouseholdID int32 `form:"household_id"`
+}
+
+type createHouseholdRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// createHousehold cria o household com o usuário como owner
+func (server *Server) createHousehold(ctx *gin.Context) {
+	var request createHouseholdRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	household, err := server.store.CreateHousehold(ctx, db.CreateHouseholdParams{
+		Name:      request.Name,
+		CreatedBy: authClaims(ctx).UserID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, household)
+}
+
+// getHouseholds lista os households do usuário com o papel dele em cada um
+func (server *Server) getHouseholds(ctx *gin.Context) {
+	households, err := server.store.GetHouseholds(ctx, authClaims(ctx).UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, households)
+}
+
+type householdRequest struct {
+	ID int32 `uri:"id" binding:"required"`
+}
+
+type householdResponse struct {
+	db.Household
+	Members []db.GetHouseholdMembersRow `json:"members"`
+}
+
+// getHousehold retorna o household com os membros; só para membros
+func (server *Server) getHousehold(ctx *gin.Context) {
+	var request householdRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	household, err := server.store.GetHousehold(ctx, db.GetHouseholdParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	members, err := server.store.GetHouseholdMembers(ctx, household.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, householdResponse{Household: household, Members: members})
+}
+
+type householdMemberRequest struct {
+	ID     int32 `uri:"id" binding:"required"`
+	UserID int32 `uri:"user_id" binding:"required"`
+}
+
+type updateHouseholdMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner editor viewer"`
+}
+
+// updateHouseholdMember troca o papel de um membro; só o owner pode
+func (server *Server) updateHouseholdMember(ctx *gin.Context) {
+	var uri householdMemberRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var request updateHouseholdMemberRequest
+	err = ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeHousehold(ctx, uri.ID, householdRoleOwner) {
+		return
+	}
+
+	var member db.HouseholdMember
+	err = server.store.ExecTx(ctx, func(q db.Querier) error {
+		var err error
+		if request.Role != householdRoleOwner {
+			err = keepsHouseholdOwner(ctx, q, uri.ID, uri.UserID)
+			if err != nil {
+				return err
+			}
+		}
+
+		member, err = q.UpdateHouseholdMemberRole(ctx, db.UpdateHouseholdMemberRoleParams{
+			HouseholdID: uri.ID,
+			UserID:      uri.UserID,
+			Role:        request.Role,
+		})
+		return err
+	}, db.WithIsolation(sql.LevelSerializable))
+	if err != nil {
+		householdMemberError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+// deleteHouseholdMember remove um membro; o owner remove qualquer um e os outros só saem
+func (server *Server) deleteHouseholdMember(ctx *gin.Context) {
+	var uri householdMemberRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if uri.UserID != authClaims(ctx).UserID && !server.authorizeHousehold(ctx, uri.ID, householdRoleOwner) {
+		return
+	}
+
+	err = server.store.ExecTx(ctx, func(q db.Querier) error {
+		err := keepsHouseholdOwner(ctx, q, uri.ID, uri.UserID)
+		if err != nil {
+			return err
+		}
+
+		rowsDeleted, err := q.DeleteHouseholdMember(ctx, db.DeleteHouseholdMemberParams{
+			HouseholdID: uri.ID,
+			UserID:      uri.UserID,
+		})
+		if err == nil && rowsDeleted == 0 {
+			return sql.ErrNoRows
+		}
+		return err
+	}, db.WithIsolation(sql.LevelSerializable))
+	if err != nil {
+		householdMemberError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
+
+// keepsHouseholdOwner impede que o último owner saia ou perca o papel; deve rodar na mesma
+// transação serializável da alteração, para dois owners não saírem ao mesmo tempo
+func keepsHouseholdOwner(ctx context.Context, q db.Querier, householdID int32, userID int32) error {
+	member, err := q.GetHouseholdMember(ctx, db.GetHouseholdMemberParams{
+		HouseholdID: householdID,
+		UserID:      userID,
+	})
+	if err != nil {
+		return err
+	}
+	if member.Role != householdRoleOwner {
+		return nil
+	}
+
+	owners, err := q.CountHouseholdOwners(ctx, householdID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return errHouseholdLastOwner
+	}
+	return nil
+}
+
+// householdMemberError responde às falhas da alteração de um membro
+func householdMemberError(ctx *gin.Context, err error) {
+	switch err {
+	case sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errHouseholdLastOwner:
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+type createHouseholdInvitationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=owner editor viewer"`
+}
+
+// householdInvitationResponse é o convite sem o hash do token
+type householdInvitationResponse struct {
+	ID            int32     `json:"id"`
+	HouseholdID   int32     `json:"household_id"`
+	HouseholdName string    `json:"household_name,omitempty"`
+	Email         string    `json:"email"`
+	Role          string    `json:"role"`
+	InvitedBy     int32     `json:"invited_by,omitempty"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func newHouseholdInvitationResponse(invitation db.HouseholdInvitation) householdInvitationResponse {
+	return householdInvitationResponse{
+		ID:          invitation.ID,
+		HouseholdID: invitation.HouseholdID,
+		Email:       invitation.Email,
+		Role:        invitation.Role,
+		InvitedBy:   invitation.InvitedBy,
+		ExpiresAt:   invitation.ExpiresAt,
+		CreatedAt:   invitation.CreatedAt,
+	}
+}
+
+// createHouseholdInvitation convida alguém por e-mail; só o owner pode
+func (server *Server) createHouseholdInvitation(ctx *gin.Context) {
+	var uri householdRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var request createHouseholdInvitationRequest
+	err = ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeHousehold(ctx, uri.ID, householdRoleOwner) {
+		return
+	}
+
+	claims := authClaims(ctx)
+	household, err := server.store.GetHousehold(ctx, db.GetHouseholdParams{
+		ID:     uri.ID,
+		UserID: claims.UserID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	token, tokenHash, err := util.NewOpaqueToken()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	invitation, err := server.store.CreateHouseholdInvitation(ctx, db.CreateHouseholdInvitationParams{
+		HouseholdID: household.ID,
+		Email:       strings.ToLower(request.Email),
+		Role:        request.Role,
+		TokenHash:   tokenHash,
+		InvitedBy:   claims.UserID,
+		ExpiresAt:   time.Now().UTC().Add(householdInvitationDuration),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.mailer.Send(ctx, mail.Message{
+		To:      []string{invitation.Email},
+		Subject: fmt.Sprintf("You were invited to %s on gofinance", household.Name),
+		Body: fmt.Sprintf("Hi,\n\n%s invited you to join %s as %s. Accept or decline the invitation with: %s\n\nThis invitation expires in %s.\n",
+			claims.Username, household.Name, invitation.Role, server.tokenLink("/households/invitation", token), householdInvitationDuration),
+	})
+	if err != nil {
+		log.Printf("cannot send household invitation %d: %v", invitation.ID, err)
+	}
+
+	ctx.JSON(http.StatusOK, newHouseholdInvitationResponse(invitation))
+}
+
+// getHouseholdInvitations lista os convites pendentes para o e-mail do usuário; o e-mail
+// precisa estar confirmado
+func (server *Server) getHouseholdInvitations(ctx *gin.Context) {
+	user, err := server.store.GetUserById(ctx, authClaims(ctx).UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !user.EmailVerifiedAt.Valid {
+		ctx.JSON(http.StatusForbidden, errorResponse(errEmailNotVerified))
+		return
+	}
+
+	invitations, err := server.store.GetPendingHouseholdInvitationsByEmail(ctx, user.Email)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := make([]householdInvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		response[i] = householdInvitationResponse{
+			ID:            invitation.ID,
+			HouseholdID:   invitation.HouseholdID,
+			HouseholdName: invitation.HouseholdName,
+			Email:         invitation.Email,
+			Role:          invitation.Role,
+			ExpiresAt:     invitation.ExpiresAt,
+			CreatedAt:     invitation.CreatedAt,
+		}
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+type householdInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// pendingInvitation busca o convite do token e confere se foi enviado para o e-mail, já
+// confirmado, do usuário
+func (server *Server) pendingInvitation(ctx *gin.Context) (db.HouseholdInvitation, bool) {
+	var request householdInvitationRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.HouseholdInvitation{}, false
+	}
+
+	invitation, err := server.store.GetPendingHouseholdInvitation(ctx, util.HashOpaqueToken(request.Token))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidInvitation))
+			return db.HouseholdInvitation{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.HouseholdInvitation{}, false
+	}
+
+	user, err := server.store.GetUserById(ctx, authClaims(ctx).UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.HouseholdInvitation{}, false
+	}
+	if !user.EmailVerifiedAt.Valid {
+		ctx.JSON(http.StatusForbidden, errorResponse(errEmailNotVerified))
+		return db.HouseholdInvitation{}, false
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		ctx.JSON(http.StatusForbidden, errorResponse(errInvitationToOtherEmail))
+		return db.HouseholdInvitation{}, false
+	}
+
+	return invitation, true
+}
+
+// acceptHouseholdInvitation aceita o convite e adiciona o usuário ao household
+func (server *Server) acceptHouseholdInvitation(ctx *gin.Context) {
+	invitation, ok := server.pendingInvitation(ctx)
+	if !ok {
+		return
+	}
+
+	member, err := server.store.AcceptHouseholdInvitation(ctx, db.AcceptHouseholdInvitationParams{
+		ID:     invitation.ID,
+		UserID: authClaims(ctx).UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidInvitation))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+// declineHouseholdInvitation recusa o convite
+func (server *Server) declineHouseholdInvitation(ctx *gin.Context) {
+	invitation, ok := server.pendingInvitation(ctx)
+	if !ok {
+		return
+	}
+
+	rows, err := server.store.DeclineHouseholdInvitation(ctx, invitation.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rows == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidInvitation))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
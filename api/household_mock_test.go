@@ -0,0 +1,739 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func randomHousehold(owner db.User) db.Household {
+	return db.Household{
+		ID:        randomID(),
+		Name:      util.RandomString(10),
+		CreatedBy: owner.ID,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func TestCreateHousehold(t *testing.T) {
+	user, _ := randomUser(t)
+	household := db.CreateHouseholdRow(randomHousehold(user))
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      createHouseholdRequest{Name: household.Name},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateHousehold(gomock.Any(), gomock.Eq(db.CreateHouseholdParams{
+					Name:      household.Name,
+					CreatedBy: user.ID,
+				})).Times(1).Return(household, nil)
+			},
+			status:   http.StatusOK,
+			response: household,
+		},
+		{
+			name:      "BadRequest",
+			body:      createHouseholdRequest{},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateHousehold(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "NoAuthorization",
+			body: createHouseholdRequest{Name: household.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateHousehold(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      createHouseholdRequest{Name: household.Name},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateHousehold(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateHouseholdRow{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/households", testCases)
+}
+
+func TestGetHouseholds(t *testing.T) {
+	user, _ := randomUser(t)
+	household := randomHousehold(user)
+	households := []db.GetHouseholdsRow{{
+		ID:        household.ID,
+		Name:      household.Name,
+		CreatedBy: household.CreatedBy,
+		CreatedAt: household.CreatedAt,
+		Role:      householdRoleOwner,
+	}}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHouseholds(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(households, nil)
+			},
+			status:   http.StatusOK,
+			response: households,
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHouseholds(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHouseholds(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/households", testCases)
+}
+
+func TestGetHousehold(t *testing.T) {
+	user, _ := randomUser(t)
+	household := randomHousehold(user)
+	params := db.GetHouseholdParams{ID: household.ID, UserID: user.ID}
+	members := []db.GetHouseholdMembersRow{{
+		HouseholdID: household.ID,
+		UserID:      user.ID,
+		Role:        householdRoleOwner,
+		Username:    user.Username,
+		Email:       user.Email,
+	}}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHousehold(gomock.Any(), gomock.Eq(params)).Times(1).Return(household, nil)
+				store.EXPECT().GetHouseholdMembers(gomock.Any(), gomock.Eq(household.ID)).Times(1).Return(members, nil)
+			},
+			status:   http.StatusOK,
+			response: householdResponse{Household: household, Members: members},
+		},
+		{
+			name:      "InvalidID",
+			url:       "/households/abc",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHousehold(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHousehold(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.Household{}, sql.ErrNoRows)
+				store.EXPECT().GetHouseholdMembers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHousehold(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHousehold(gomock.Any(), gomock.Eq(params)).Times(1).Return(household, nil)
+				store.EXPECT().GetHouseholdMembers(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, fmt.Sprintf("/households/%d", household.ID), testCases)
+}
+
+func TestCreateHouseholdInvitation(t *testing.T) {
+	owner, _ := randomUser(t)
+	editor, _ := randomUser(t)
+	household := randomHousehold(owner)
+	email := util.RamdomEmail(11)
+	request := createHouseholdInvitationRequest{Email: strings.ToUpper(email), Role: householdRoleEditor}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, household.ID, owner, householdRoleOwner)
+				store.EXPECT().GetHousehold(gomock.Any(), gomock.Eq(db.GetHouseholdParams{ID: household.ID, UserID: owner.ID})).Times(1).Return(household, nil)
+				store.EXPECT().CreateHouseholdInvitation(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateHouseholdInvitationParams) (db.HouseholdInvitation, error) {
+						require.Equal(t, household.ID, arg.HouseholdID)
+						require.Equal(t, email, arg.Email)
+						require.Equal(t, householdRoleEditor, arg.Role)
+						require.Equal(t, owner.ID, arg.InvitedBy)
+						require.NotEmpty(t, arg.TokenHash)
+						return db.HouseholdInvitation{ID: randomID(), HouseholdID: arg.HouseholdID, Email: arg.Email, Role: arg.Role, TokenHash: arg.TokenHash}, nil
+					})
+			},
+			status: http.StatusOK,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.NotContains(t, recorder.Body.String(), "token_hash")
+			},
+		},
+		{
+			name:      "InvalidRole",
+			body:      createHouseholdInvitationRequest{Email: email, Role: "admin"},
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHouseholdMember(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateHouseholdInvitation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "InvalidEmail",
+			body:      createHouseholdInvitationRequest{Email: "invalid-email", Role: householdRoleViewer},
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateHouseholdInvitation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotOwner",
+			body:      request,
+			setupAuth: withSession(editor),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, household.ID, editor, householdRoleEditor)
+				store.EXPECT().CreateHouseholdInvitation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "NotMember",
+			body:      request,
+			setupAuth: withSession(editor),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHouseholdMember(gomock.Any(), gomock.Any()).Times(1).Return(db.HouseholdMember{}, sql.ErrNoRows)
+				store.EXPECT().CreateHouseholdInvitation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name: "NoAuthorization",
+			body: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateHouseholdInvitation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, household.ID, owner, householdRoleOwner)
+				store.EXPECT().GetHousehold(gomock.Any(), gomock.Any()).Times(1).Return(household, nil)
+				store.EXPECT().CreateHouseholdInvitation(gomock.Any(), gomock.Any()).Times(1).Return(db.HouseholdInvitation{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, fmt.Sprintf("/households/%d/invitations", household.ID), testCases)
+}
+
+func TestGetHouseholdInvitations(t *testing.T) {
+	user, _ := randomUser(t)
+	user.EmailVerifiedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	unverified, _ := randomUser(t)
+	invitations := []db.GetPendingHouseholdInvitationsByEmailRow{{
+		ID:            randomID(),
+		HouseholdID:   randomID(),
+		Email:         user.Email,
+		Role:          householdRoleViewer,
+		HouseholdName: util.RandomString(10),
+	}}
+	response := []householdInvitationResponse{{
+		ID:            invitations[0].ID,
+		HouseholdID:   invitations[0].HouseholdID,
+		HouseholdName: invitations[0].HouseholdName,
+		Email:         invitations[0].Email,
+		Role:          invitations[0].Role,
+	}}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetPendingHouseholdInvitationsByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(invitations, nil)
+			},
+			status:   http.StatusOK,
+			response: response,
+		},
+		{
+			// quem cadastrou o e-mail de outra pessoa não vê os convites dela
+			name:      "EmailNotVerified",
+			setupAuth: withSession(unverified),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(unverified.ID)).Times(1).Return(unverified, nil)
+				store.EXPECT().GetPendingHouseholdInvitationsByEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errEmailNotVerified),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPendingHouseholdInvitationsByEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetPendingHouseholdInvitationsByEmail(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/households/invitations", testCases)
+}
+
+func TestAcceptHouseholdInvitation(t *testing.T) {
+	user, _ := randomUser(t)
+	user.EmailVerifiedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	unverified := user
+	unverified.EmailVerifiedAt = sql.NullTime{}
+	token := util.RandomString(32)
+	invitation := db.HouseholdInvitation{ID: randomID(), HouseholdID: randomID(), Email: strings.ToUpper(user.Email), Role: householdRoleEditor}
+	member := db.HouseholdMember{HouseholdID: invitation.HouseholdID, UserID: user.ID, Role: invitation.Role}
+	other, _ := randomUser(t)
+	other.EmailVerifiedAt = user.EmailVerifiedAt
+
+	expectInvitation := func(store *mockdb.MockStore, by db.User) {
+		store.EXPECT().GetPendingHouseholdInvitation(gomock.Any(), gomock.Eq(util.HashOpaqueToken(token))).Times(1).Return(invitation, nil)
+		store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(by.ID)).Times(1).Return(by, nil)
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      householdInvitationRequest{Token: token},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectInvitation(store, user)
+				store.EXPECT().AcceptHouseholdInvitation(gomock.Any(), gomock.Eq(db.AcceptHouseholdInvitationParams{
+					ID:     invitation.ID,
+					UserID: user.ID,
+				})).Times(1).Return(member, nil)
+			},
+			status:   http.StatusOK,
+			response: member,
+		},
+		{
+			name:      "BadRequest",
+			body:      householdInvitationRequest{},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPendingHouseholdInvitation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "InvalidInvitation",
+			body:      householdInvitationRequest{Token: token},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPendingHouseholdInvitation(gomock.Any(), gomock.Any()).Times(1).Return(db.HouseholdInvitation{}, sql.ErrNoRows)
+				store.EXPECT().AcceptHouseholdInvitation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errInvalidInvitation),
+		},
+		{
+			name:      "OtherEmail",
+			body:      householdInvitationRequest{Token: token},
+			setupAuth: withSession(other),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectInvitation(store, other)
+				store.EXPECT().AcceptHouseholdInvitation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errInvitationToOtherEmail),
+		},
+		{
+			// o e-mail do convite só conta depois de confirmado
+			name:      "EmailNotVerified",
+			body:      householdInvitationRequest{Token: token},
+			setupAuth: withSession(unverified),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectInvitation(store, unverified)
+				store.EXPECT().AcceptHouseholdInvitation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errEmailNotVerified),
+		},
+		{
+			name:      "AlreadyAnswered",
+			body:      householdInvitationRequest{Token: token},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectInvitation(store, user)
+				store.EXPECT().AcceptHouseholdInvitation(gomock.Any(), gomock.Any()).Times(1).Return(db.HouseholdMember{}, sql.ErrNoRows)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errInvalidInvitation),
+		},
+		{
+			name: "NoAuthorization",
+			body: householdInvitationRequest{Token: token},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPendingHouseholdInvitation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      householdInvitationRequest{Token: token},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectInvitation(store, user)
+				store.EXPECT().AcceptHouseholdInvitation(gomock.Any(), gomock.Any()).Times(1).Return(db.HouseholdMember{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/households/invitations/accept", testCases)
+}
+
+func TestDeclineHouseholdInvitation(t *testing.T) {
+	user, _ := randomUser(t)
+	user.EmailVerifiedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	token := util.RandomString(32)
+	invitation := db.HouseholdInvitation{ID: randomID(), HouseholdID: randomID(), Email: user.Email, Role: householdRoleViewer}
+
+	expectInvitation := func(store *mockdb.MockStore) {
+		store.EXPECT().GetPendingHouseholdInvitation(gomock.Any(), gomock.Eq(util.HashOpaqueToken(token))).Times(1).Return(invitation, nil)
+		store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      householdInvitationRequest{Token: token},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectInvitation(store)
+				store.EXPECT().DeclineHouseholdInvitation(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "BadRequest",
+			body:      householdInvitationRequest{},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeclineHouseholdInvitation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "AlreadyAnswered",
+			body:      householdInvitationRequest{Token: token},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectInvitation(store)
+				store.EXPECT().DeclineHouseholdInvitation(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(int64(0), nil)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errInvalidInvitation),
+		},
+		{
+			name: "NoAuthorization",
+			body: householdInvitationRequest{Token: token},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeclineHouseholdInvitation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      householdInvitationRequest{Token: token},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPendingHouseholdInvitation(gomock.Any(), gomock.Any()).Times(1).Return(db.HouseholdInvitation{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/households/invitations/decline", testCases)
+}
+
+func TestUpdateHouseholdMember(t *testing.T) {
+	owner, _ := randomUser(t)
+	viewer, _ := randomUser(t)
+	household := randomHousehold(owner)
+	url := fmt.Sprintf("/households/%d/members/%d", household.ID, viewer.ID)
+	member := db.HouseholdMember{HouseholdID: household.ID, UserID: viewer.ID, Role: householdRoleEditor}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      updateHouseholdMemberRequest{Role: householdRoleEditor},
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, household.ID, owner, householdRoleOwner)
+				expectExecTx(store)
+				expectHouseholdRole(store, household.ID, viewer, householdRoleViewer)
+				store.EXPECT().UpdateHouseholdMemberRole(gomock.Any(), gomock.Eq(db.UpdateHouseholdMemberRoleParams{
+					HouseholdID: household.ID,
+					UserID:      viewer.ID,
+					Role:        householdRoleEditor,
+				})).Times(1).Return(member, nil)
+			},
+			status:   http.StatusOK,
+			response: member,
+		},
+		{
+			name:      "PromoteToOwner",
+			body:      updateHouseholdMemberRequest{Role: householdRoleOwner},
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, household.ID, owner, householdRoleOwner)
+				expectExecTx(store)
+				store.EXPECT().CountHouseholdOwners(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateHouseholdMemberRole(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.HouseholdMember{HouseholdID: household.ID, UserID: viewer.ID, Role: householdRoleOwner}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "LastOwner",
+			url:       fmt.Sprintf("/households/%d/members/%d", household.ID, owner.ID),
+			body:      updateHouseholdMemberRequest{Role: householdRoleViewer},
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetHouseholdMember(gomock.Any(), gomock.Eq(db.GetHouseholdMemberParams{HouseholdID: household.ID, UserID: owner.ID})).
+					Times(2).
+					Return(db.HouseholdMember{HouseholdID: household.ID, UserID: owner.ID, Role: householdRoleOwner}, nil)
+				expectExecTx(store)
+				store.EXPECT().CountHouseholdOwners(gomock.Any(), gomock.Eq(household.ID)).Times(1).Return(int64(1), nil)
+				store.EXPECT().UpdateHouseholdMemberRole(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusConflict,
+			response: errorResponse(errHouseholdLastOwner),
+		},
+		{
+			name:      "InvalidRole",
+			body:      updateHouseholdMemberRequest{Role: "admin"},
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "InvalidID",
+			url:       fmt.Sprintf("/households/abc/members/%d", viewer.ID),
+			body:      updateHouseholdMemberRequest{Role: householdRoleEditor},
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotOwner",
+			body:      updateHouseholdMemberRequest{Role: householdRoleOwner},
+			setupAuth: withSession(viewer),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, household.ID, viewer, householdRoleViewer)
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "NotFound",
+			body:      updateHouseholdMemberRequest{Role: householdRoleEditor},
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, household.ID, owner, householdRoleOwner)
+				expectExecTx(store)
+				store.EXPECT().
+					GetHouseholdMember(gomock.Any(), gomock.Eq(db.GetHouseholdMemberParams{HouseholdID: household.ID, UserID: viewer.ID})).
+					Times(1).
+					Return(db.HouseholdMember{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "NoAuthorization",
+			body: updateHouseholdMemberRequest{Role: householdRoleEditor},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      updateHouseholdMemberRequest{Role: householdRoleEditor},
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, household.ID, owner, householdRoleOwner)
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPut, url, testCases)
+}
+
+func TestDeleteHouseholdMember(t *testing.T) {
+	owner, _ := randomUser(t)
+	viewer, _ := randomUser(t)
+	household := randomHousehold(owner)
+	url := fmt.Sprintf("/households/%d/members/%d", household.ID, viewer.ID)
+	ownerURL := fmt.Sprintf("/households/%d/members/%d", household.ID, owner.ID)
+	deleteParams := db.DeleteHouseholdMemberParams{HouseholdID: household.ID, UserID: viewer.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, household.ID, owner, householdRoleOwner)
+				expectExecTx(store)
+				expectHouseholdRole(store, household.ID, viewer, householdRoleViewer)
+				store.EXPECT().DeleteHouseholdMember(gomock.Any(), gomock.Eq(deleteParams)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "Leave",
+			setupAuth: withSession(viewer),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				expectHouseholdRole(store, household.ID, viewer, householdRoleViewer)
+				store.EXPECT().DeleteHouseholdMember(gomock.Any(), gomock.Eq(deleteParams)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "LastOwner",
+			url:       ownerURL,
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				expectHouseholdRole(store, household.ID, owner, householdRoleOwner)
+				store.EXPECT().CountHouseholdOwners(gomock.Any(), gomock.Eq(household.ID)).Times(1).Return(int64(1), nil)
+				store.EXPECT().DeleteHouseholdMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusConflict,
+			response: errorResponse(errHouseholdLastOwner),
+		},
+		{
+			name:      "OneOfTwoOwners",
+			url:       ownerURL,
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				expectHouseholdRole(store, household.ID, owner, householdRoleOwner)
+				store.EXPECT().CountHouseholdOwners(gomock.Any(), gomock.Eq(household.ID)).Times(1).Return(int64(2), nil)
+				store.EXPECT().DeleteHouseholdMember(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "InvalidID",
+			url:       fmt.Sprintf("/households/%d/members/abc", household.ID),
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotOwner",
+			url:       ownerURL,
+			setupAuth: withSession(viewer),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, household.ID, viewer, householdRoleViewer)
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(owner),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, household.ID, owner, householdRoleOwner)
+				expectExecTx(store)
+				expectHouseholdRole(store, household.ID, viewer, householdRoleViewer)
+				store.EXPECT().DeleteHouseholdMember(gomock.Any(), gomock.Eq(deleteParams)).Times(1).Return(int64(0), nil)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(viewer),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodDelete, url, testCases)
+}
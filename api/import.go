@@ -0,0 +1,496 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/importer"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+const (
+	// maxImportBytes limita o tamanho do arquivo importado
+	maxImportBytes = 5 << 20
+	// maxImportRows limita as linhas de uma importação, que entra numa transação só
+	maxImportRows = 5000
+	// maxImportBodyBytes limita o corpo da requisição: o arquivo em base64 ocupa 4/3 do
+	// tamanho, e sobra espaço para os outros campos
+	maxImportBodyBytes = maxImportBytes/3*4 + 64<<10
+)
+
+var (
+	errImportTooLarge      = errors.New("import files have at most 5 MB and 5000 rows")
+	errImportProfileExists = errors.New("an import profile with this name already exists")
+)
+
+// status das linhas de uma importação
+const (
+	importCreated = "created"
+	importSkipped = "skipped"
+	importFailed  = "failed"
+)
+
+type importRequest struct {
+	Format string `json:"format" binding:"required,oneof=csv ofx qfx qif camt053"`
+	// File é o conteúdo do extrato em base64
+	File []byte `json:"file" binding:"required"`
+	// Mapping e ProfileID só valem para CSV, e DayFirst para QIF
+	Mapping   *importer.CSVMapping `json:"mapping"`
+	ProfileID int32                `json:"profile_id"`
+	DayFirst  bool                 `json:"day_first"`
+}
+
+type previewImportRequest struct {
+	importRequest
+	// WalletID, opcional, confere a moeda das linhas com a da carteira
+	WalletID int32 `json:"wallet_id"`
+}
+
+type importPreviewResponse struct {
+	Rows   []importer.Row `json:"rows"`
+	Valid  int            `json:"valid"`
+	Failed int            `json:"failed"`
+}
+
+// bindImport lê o JSON da importação sem passar de maxImportBodyBytes, para não guardar um
+// corpo enorme na memória antes de conferir o tamanho; responde o erro e retorna false se não
+// conseguir
+func bindImport(ctx *gin.Context, request interface{}) bool {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportBodyBytes)
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(errImportTooLarge))
+			return false
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return false
+	}
+	return true
+}
+
+// parseImport lê o extrato com o mapeamento enviado ou o do perfil salvo; responde o erro
+// e retorna false se não conseguir
+func (server *Server) parseImport(ctx *gin.Context, request importRequest) ([]importer.Row, bool) {
+	if len(request.File) > maxImportBytes {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(errImportTooLarge))
+		return nil, false
+	}
+
+	options := importer.Options{CSV: request.Mapping, DayFirst: request.DayFirst}
+	if request.ProfileID > 0 {
+		profile, err := server.store.GetImportProfile(ctx, db.GetImportProfileParams{
+			ID:     request.ProfileID,
+			UserID: authClaims(ctx).UserID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return nil, false
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return nil, false
+		}
+		options.CSV = &importer.CSVMapping{}
+		err = json.Unmarshal(profile.Mapping, options.CSV)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return nil, false
+		}
+	}
+
+	rows, err := importer.Parse(request.Format, request.File, options)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return nil, false
+	}
+	if len(rows) > maxImportRows {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(errImportTooLarge))
+		return nil, false
+	}
+	return rows, true
+}
+
+// checkImportCurrency troca por erro as linhas em moeda diferente da carteira; só busca a
+// carteira se alguma linha tiver moeda. Responde o erro e retorna false se não conseguir
+func (server *Server) checkImportCurrency(ctx *gin.Context, rows []importer.Row, walletID int32) bool {
+	currency := false
+	for _, row := range rows {
+		if row.Transaction != nil && row.Transaction.Currency != "" {
+			currency = true
+			break
+		}
+	}
+	if !currency {
+		return true
+	}
+
+	wallet, err := server.store.GetWallet(ctx, db.GetWalletParams{ID: walletID, UserID: authClaims(ctx).UserID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	importer.CheckCurrency(rows, wallet.Currency)
+	return true
+}
+
+// previewImport lê o extrato sem gravar nada, mostrando as linhas lidas e os erros; com a
+// carteira, as linhas em outra moeda também são erros
+func (server *Server) previewImport(ctx *gin.Context) {
+	var request previewImportRequest
+	if !bindImport(ctx, &request) {
+		return
+	}
+
+	rows, ok := server.parseImport(ctx, request.importRequest)
+	if !ok {
+		return
+	}
+	if request.WalletID > 0 && !server.checkImportCurrency(ctx, rows, request.WalletID) {
+		return
+	}
+
+	response := importPreviewResponse{Rows: rows}
+	for _, row := range rows {
+		if row.Transaction == nil {
+			response.Failed++
+		} else {
+			response.Valid++
+		}
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+type commitImportRequest struct {
+	importRequest
+	HouseholdID      int32 `json:"household_id"`
+	WalletID         int32 `json:"wallet_id" binding:"required"`
+	CreditCategoryID int32 `json:"credit_category_id" binding:"required"`
+	DebitCategoryID  int32 `json:"debit_category_id" binding:"required"`
+}
+
+type importRowResult struct {
+	Line       int    `json:"line"`
+	Status     string `json:"status"`
+	AccountID  int32  `json:"account_id,omitempty"`
+	CategoryID int32  `json:"category_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type importSummary struct {
+	Created int               `json:"created"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Rows    []importRowResult `json:"rows"`
+}
+
+// commitImport grava as linhas válidas do extrato na carteira, numa transação só. Linhas
+// com sugestão de categoria vão para a categoria do escopo com esse título; as demais, para
+// a categoria de crédito ou de débito. Linhas com erro ou em moeda diferente da carteira
+// ficam de fora, e lançamentos com ID do banco já importado na carteira são pulados
+func (server *Server) commitImport(ctx *gin.Context) {
+	var request commitImportRequest
+	if !bindImport(ctx, &request) {
+		return
+	}
+	claims := authClaims(ctx)
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleEditor) {
+		return
+	}
+
+	rows, ok := server.parseImport(ctx, request.importRequest)
+	if !ok {
+		return
+	}
+	if !server.checkImportCurrency(ctx, rows, request.WalletID) {
+		return
+	}
+
+	var summary importSummary
+	err := server.store.ExecTx(ctx, func(q db.Querier) error {
+		summary = importSummary{Rows: []importRowResult{}}
+
+		err := checkAccountTemplate(ctx, q, claims.UserID, request.HouseholdID, request.CreditCategoryID, request.WalletID, "credit")
+		if err != nil {
+			return err
+		}
+		err = checkAccountTemplate(ctx, q, claims.UserID, request.HouseholdID, request.DebitCategoryID, request.WalletID, "debit")
+		if err != nil {
+			return err
+		}
+		categories, err := importCategories(ctx, q, claims.UserID, request.HouseholdID, rows)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			if row.Transaction == nil {
+				summary.Failed++
+				summary.Rows = append(summary.Rows, importRowResult{Line: row.Line, Status: importFailed, Error: row.Error})
+				continue
+			}
+
+			transaction := row.Transaction
+			if transaction.ID != "" {
+				exists, err := q.AccountExternalIDExists(ctx, db.AccountExternalIDExistsParams{
+					WalletID:   request.WalletID,
+					ExternalID: transaction.ID,
+				})
+				if err != nil {
+					return err
+				}
+				if exists {
+					summary.Skipped++
+					summary.Rows = append(summary.Rows, importRowResult{Line: row.Line, Status: importSkipped})
+					continue
+				}
+			}
+
+			arg := db.CreateAccountParams{
+				UserID:      claims.UserID,
+				HouseholdID: householdID(request.HouseholdID),
+				WalletID:    request.WalletID,
+				CategoryID:  request.CreditCategoryID,
+				Title:       transaction.Title(),
+				Type:        "credit",
+				Description: transaction.Details(),
+				Value:       transaction.Amount,
+				Date:        transaction.Date,
+				ExternalID:  sql.NullString{String: transaction.ID, Valid: transaction.ID != ""},
+			}
+			if transaction.Amount < 0 {
+				arg.CategoryID = request.DebitCategoryID
+				arg.Type = "debit"
+				arg.Value = -transaction.Amount
+			}
+			if categoryID, ok := matchImportCategory(categories[arg.Type], transaction.Category); ok {
+				arg.CategoryID = categoryID
+			}
+
+			account, err := q.CreateAccount(ctx, arg)
+			if err == sql.ErrNoRows {
+				return errHouseholdForbidden
+			}
+			if err != nil {
+				return err
+			}
+			summary.Created++
+			summary.Rows = append(summary.Rows, importRowResult{Line: row.Line, Status: importCreated, AccountID: account.ID, CategoryID: arg.CategoryID})
+		}
+		return nil
+	})
+	if err != nil {
+		accountTemplateError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+// importCategories indexa por tipo e título as categorias do escopo, para as sugestões de
+// categoria do extrato; sem sugestões, não consulta nada
+func importCategories(ctx context.Context, q db.Querier, userID int32, household int32, rows []importer.Row) (map[string]map[string]int32, error) {
+	categories := map[string]map[string]int32{}
+	hints := false
+	for _, row := range rows {
+		if row.Transaction != nil && row.Transaction.Category != "" {
+			hints = true
+			break
+		}
+	}
+	if !hints {
+		return categories, nil
+	}
+
+	for _, categoryType := range []string{"credit", "debit"} {
+		list, err := q.GetCategories(ctx, db.GetCategoriesParams{
+			HouseholdID: householdID(household),
+			UserID:      userID,
+			Type:        categoryType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		categories[categoryType] = map[string]int32{}
+		for _, category := range list {
+			categories[categoryType][strings.ToLower(strings.TrimSpace(category.Title))] = category.ID
+		}
+	}
+	return categories, nil
+}
+
+// matchImportCategory procura a sugestão inteira e depois cada nível dela, do mais
+// específico ao mais geral: "Casa:Aluguel" casa com "Casa:Aluguel", "Aluguel" ou "Casa".
+// Transferências do QIF, como "[Poupança]", não são categorias
+func matchImportCategory(categories map[string]int32, hint string) (int32, bool) {
+	hint = strings.ToLower(strings.TrimSpace(hint))
+	if hint == "" || strings.HasPrefix(hint, "[") {
+		return 0, false
+	}
+	if id, ok := categories[hint]; ok {
+		return id, true
+	}
+	levels := strings.Split(hint, ":")
+	for i := len(levels) - 1; i >= 0; i-- {
+		if id, ok := categories[strings.TrimSpace(levels[i])]; ok {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+type importProfileRequest struct {
+	Name    string              `json:"name" binding:"required"`
+	Mapping importer.CSVMapping `json:"mapping"`
+}
+
+// importProfileMapping normaliza e valida o mapeamento antes de salvar
+func importProfileMapping(mapping importer.CSVMapping) (json.RawMessage, error) {
+	mapping = mapping.Normalize()
+	err := mapping.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mapping)
+}
+
+// createImportProfile salva um mapeamento de CSV com o nome do banco
+func (server *Server) createImportProfile(ctx *gin.Context) {
+	var request importProfileRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	mapping, err := importProfileMapping(request.Mapping)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	profile, err := server.store.CreateImportProfile(ctx, db.CreateImportProfileParams{
+		UserID:  authClaims(ctx).UserID,
+		Name:    request.Name,
+		Mapping: mapping,
+	})
+	if err != nil {
+		importProfileError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+type importProfileURIRequest struct {
+	ID int32 `uri:"id" binding:"required"`
+}
+
+func (server *Server) getImportProfile(ctx *gin.Context) {
+	var request importProfileURIRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	profile, err := server.store.GetImportProfile(ctx, db.GetImportProfileParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	})
+	if err != nil {
+		importProfileError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+func (server *Server) getImportProfiles(ctx *gin.Context) {
+	profiles, err := server.store.GetImportProfiles(ctx, authClaims(ctx).UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profiles)
+}
+
+func (server *Server) updateImportProfile(ctx *gin.Context) {
+	var uri importProfileURIRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var request importProfileRequest
+	err = ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	mapping, err := importProfileMapping(request.Mapping)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	profile, err := server.store.UpdateImportProfile(ctx, db.UpdateImportProfileParams{
+		ID:      uri.ID,
+		UserID:  authClaims(ctx).UserID,
+		Name:    request.Name,
+		Mapping: mapping,
+	})
+	if err != nil {
+		importProfileError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+func (server *Server) deleteImportProfile(ctx *gin.Context) {
+	var request importProfileURIRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rowsDeleted, err := server.store.DeleteImportProfile(ctx, db.DeleteImportProfileParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rowsDeleted == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
+
+func importProfileError(ctx *gin.Context, err error) {
+	if err == sql.ErrNoRows {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+		ctx.JSON(http.StatusConflict, errorResponse(errImportProfileExists))
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+}
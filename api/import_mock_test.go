@@ -0,0 +1,573 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/importer"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testCSVMapping = importer.CSVMapping{
+	Delimiter:         ";",
+	HeaderRows:        1,
+	DateColumn:        1,
+	DateFormat:        "DD/MM/YYYY",
+	DescriptionColumn: 2,
+	AmountColumn:      3,
+	DecimalSeparator:  ",",
+}
+
+const testCSV = "Data;Descrição;Valor\n05/01/2024;Salário;5.000,00\n06/01/2024;Mercado;-123,45\n07/01/2024;Inválida;abc\n"
+
+// testCurrencyOFX tem o extrato em BRL e um lançamento em USD
+const testCurrencyOFX = "<OFX><STMTRS><CURDEF>BRL<BANKTRANLIST>\n" +
+	"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240107<TRNAMT>-50.00<FITID>A1<NAME>Farmácia</STMTTRN>\n" +
+	"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240108<TRNAMT>-20.00<FITID>A2<NAME>Loja<CURRENCY><CURRATE>5.0<CURSYM>USD</CURRENCY></STMTTRN>\n" +
+	"</BANKTRANLIST></STMTRS></OFX>"
+
+func TestPreviewImport(t *testing.T) {
+	user, _ := randomUser(t)
+	mapping, err := json.Marshal(testCSVMapping.Normalize())
+	require.NoError(t, err)
+	profile := db.ImportProfile{ID: randomID(), UserID: user.ID, Name: "Banco", Mapping: mapping}
+
+	rows, err := importer.ParseCSV([]byte(testCSV), testCSVMapping)
+	require.NoError(t, err)
+	preview := importPreviewResponse{Rows: rows, Valid: 2, Failed: 1}
+
+	wallet := randomWallet(user, "BRL")
+	currencyRows, err := importer.ParseOFX([]byte(testCurrencyOFX))
+	require.NoError(t, err)
+	importer.CheckCurrency(currencyRows, wallet.Currency)
+	require.Equal(t, "currency USD differs from the wallet currency BRL", currencyRows[1].Error)
+
+	testCases := []routeTestCase{
+		{
+			name:      "InlineMapping",
+			body:      importRequest{Format: importer.FormatCSV, File: []byte(testCSV), Mapping: &testCSVMapping},
+			setupAuth: withSession(user),
+			status:    http.StatusOK,
+			response:  preview,
+		},
+		{
+			name:      "Profile",
+			body:      importRequest{Format: importer.FormatCSV, File: []byte(testCSV), ProfileID: profile.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetImportProfile(gomock.Any(), gomock.Eq(db.GetImportProfileParams{ID: profile.ID, UserID: user.ID})).Times(1).Return(profile, nil)
+			},
+			status:   http.StatusOK,
+			response: preview,
+		},
+		{
+			name:      "ProfileNotFound",
+			body:      importRequest{Format: importer.FormatCSV, File: []byte(testCSV), ProfileID: profile.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetImportProfile(gomock.Any(), gomock.Any()).Times(1).Return(db.ImportProfile{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			// sem a carteira, a moeda não é conferida
+			name:      "CurrencyWithoutWallet",
+			body:      importRequest{Format: importer.FormatOFX, File: []byte(testCurrencyOFX)},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "WalletCurrency",
+			body:      previewImportRequest{importRequest: importRequest{Format: importer.FormatOFX, File: []byte(testCurrencyOFX)}, WalletID: wallet.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(db.GetWalletParams{ID: wallet.ID, UserID: user.ID})).Times(1).Return(wallet, nil)
+			},
+			status:   http.StatusOK,
+			response: importPreviewResponse{Rows: currencyRows, Valid: 1, Failed: 1},
+		},
+		{
+			name:      "WalletNotFound",
+			body:      previewImportRequest{importRequest: importRequest{Format: importer.FormatOFX, File: []byte(testCurrencyOFX)}, WalletID: wallet.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(1).Return(db.GetWalletRow{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "MissingMapping",
+			body:      importRequest{Format: importer.FormatCSV, File: []byte(testCSV)},
+			setupAuth: withSession(user),
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "InvalidMapping",
+			body:      importRequest{Format: importer.FormatCSV, File: []byte(testCSV), Mapping: &importer.CSVMapping{DateColumn: 1}},
+			setupAuth: withSession(user),
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "UnknownFormat",
+			body:      importRequest{Format: "xls", File: []byte(testCSV), Mapping: &testCSVMapping},
+			setupAuth: withSession(user),
+			status:    http.StatusBadRequest,
+		},
+		{
+			// o corpo passa do limite e a leitura para antes de terminar
+			name:      "BodyTooLarge",
+			body:      fmt.Sprintf(`{"format": "csv", "file": "%s"}`, strings.Repeat("A", maxImportBodyBytes)),
+			setupAuth: withSession(user),
+			status:    http.StatusRequestEntityTooLarge,
+			response:  errorResponse(errImportTooLarge),
+		},
+		{
+			name:      "FileTooLarge",
+			body:      importRequest{Format: importer.FormatCSV, File: make([]byte, maxImportBytes+1), Mapping: &testCSVMapping},
+			setupAuth: withSession(user),
+			status:    http.StatusRequestEntityTooLarge,
+			response:  errorResponse(errImportTooLarge),
+		},
+		{
+			name:      "ReadOnlyKey",
+			body:      importRequest{Format: importer.FormatCSV, File: []byte(testCSV), Mapping: &testCSVMapping},
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			status:    http.StatusForbidden,
+		},
+		{
+			name:   "NoAuthorization",
+			body:   importRequest{Format: importer.FormatCSV, File: []byte(testCSV), Mapping: &testCSVMapping},
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/imports/preview", testCases)
+}
+
+func TestCommitImport(t *testing.T) {
+	user, _ := randomUser(t)
+	credit := randomCategory(user, "credit")
+	debit := randomCategory(user, "debit")
+	wallet := randomWallet(user, "BRL")
+	request := commitImportRequest{
+		importRequest:    importRequest{Format: importer.FormatCSV, File: []byte(testCSV), Mapping: &testCSVMapping},
+		WalletID:         wallet.ID,
+		CreditCategoryID: credit.ID,
+		DebitCategoryID:  debit.ID,
+	}
+
+	expectTemplates := func(store *mockdb.MockStore) {
+		store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(db.GetCategoryParams{ID: credit.ID, UserID: user.ID})).Times(1).Return(credit, nil)
+		store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(db.GetCategoryParams{ID: debit.ID, UserID: user.ID})).Times(1).Return(debit, nil)
+		store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(db.GetWalletParams{ID: wallet.ID, UserID: user.ID})).Times(2).Return(wallet, nil)
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				expectTemplates(store)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Eq(db.CreateAccountParams{
+					UserID:     user.ID,
+					WalletID:   wallet.ID,
+					CategoryID: credit.ID,
+					Title:      "Salário",
+					Type:       "credit",
+					Value:      util.NewMoney(5000, 0),
+					Date:       time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+				})).Times(1).Return(db.Account{ID: 10}, nil)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Eq(db.CreateAccountParams{
+					UserID:     user.ID,
+					WalletID:   wallet.ID,
+					CategoryID: debit.ID,
+					Title:      "Mercado",
+					Type:       "debit",
+					Value:      util.NewMoney(123, 45),
+					Date:       time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+				})).Times(1).Return(db.Account{ID: 11}, nil)
+			},
+			status: http.StatusOK,
+			response: importSummary{
+				Created: 2,
+				Failed:  1,
+				Rows: []importRowResult{
+					{Line: 2, Status: importCreated, AccountID: 10, CategoryID: credit.ID},
+					{Line: 3, Status: importCreated, AccountID: 11, CategoryID: debit.ID},
+					{Line: 4, Status: importFailed, Error: `invalid amount "abc"`},
+				},
+			},
+		},
+		{
+			name: "CategoryTypeMismatch",
+			body: commitImportRequest{
+				importRequest:    request.importRequest,
+				WalletID:         wallet.ID,
+				CreditCategoryID: debit.ID,
+				DebitCategoryID:  debit.ID,
+			},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(1).Return(debit, nil)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "WalletNotFound",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(1).Return(credit, nil)
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(1).Return(db.GetWalletRow{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "HouseholdViewer",
+			body:      commitImportRequest{importRequest: request.importRequest, HouseholdID: 7, WalletID: wallet.ID, CreditCategoryID: credit.ID, DebitCategoryID: debit.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, 7, user, householdRoleViewer)
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				expectTemplates(store)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrConnDone)
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:      "MissingWallet",
+			body:      commitImportRequest{importRequest: request.importRequest, CreditCategoryID: credit.ID, DebitCategoryID: debit.ID},
+			setupAuth: withSession(user),
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "BodyTooLarge",
+			body:      fmt.Sprintf(`{"format": "csv", "wallet_id": %d, "file": "%s"}`, wallet.ID, strings.Repeat("A", maxImportBodyBytes)),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusRequestEntityTooLarge,
+			response: errorResponse(errImportTooLarge),
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/imports", testCases)
+}
+
+func TestCommitImportOFX(t *testing.T) {
+	user, _ := randomUser(t)
+	credit := randomCategory(user, "credit")
+	debit := randomCategory(user, "debit")
+	wallet := randomWallet(user, "BRL")
+	ofx := "<OFX><BANKTRANLIST>\n" +
+		"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240107<TRNAMT>-50.00<FITID>A1<NAME>Farmácia</STMTTRN>\n" +
+		"<STMTTRN><TRNTYPE>CREDIT<DTPOSTED>20240108<TRNAMT>80.00<FITID>A2<NAME>Pix recebido<MEMO>Maria</STMTTRN>\n" +
+		"</BANKTRANLIST></OFX>"
+	request := commitImportRequest{
+		importRequest:    importRequest{Format: importer.FormatOFX, File: []byte(ofx)},
+		WalletID:         wallet.ID,
+		CreditCategoryID: credit.ID,
+		DebitCategoryID:  debit.ID,
+	}
+
+	runRouteTests(t, http.MethodPost, "/imports", []routeTestCase{
+		{
+			name:      "SkipsImportedFITID",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(ctx context.Context, arg db.GetCategoryParams) (db.Category, error) {
+					if arg.ID == credit.ID {
+						return credit, nil
+					}
+					return debit, nil
+				})
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(2).Return(wallet, nil)
+				store.EXPECT().AccountExternalIDExists(gomock.Any(), gomock.Eq(db.AccountExternalIDExistsParams{WalletID: wallet.ID, ExternalID: "A1"})).Times(1).Return(true, nil)
+				store.EXPECT().AccountExternalIDExists(gomock.Any(), gomock.Eq(db.AccountExternalIDExistsParams{WalletID: wallet.ID, ExternalID: "A2"})).Times(1).Return(false, nil)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Eq(db.CreateAccountParams{
+					UserID:      user.ID,
+					WalletID:    wallet.ID,
+					CategoryID:  credit.ID,
+					Title:       "Pix recebido",
+					Type:        "credit",
+					Description: "Maria",
+					Value:       util.NewMoney(80, 0),
+					Date:        time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+					ExternalID:  sql.NullString{String: "A2", Valid: true},
+				})).Times(1).Return(db.Account{ID: 12}, nil)
+			},
+			status: http.StatusOK,
+			response: importSummary{
+				Created: 1,
+				Skipped: 1,
+				Rows: []importRowResult{
+					{Line: 2, Status: importSkipped},
+					{Line: 3, Status: importCreated, AccountID: 12, CategoryID: credit.ID},
+				},
+			},
+		},
+		{
+			// o lançamento em USD não entra na carteira em BRL
+			name:      "OtherCurrency",
+			body:      commitImportRequest{importRequest: importRequest{Format: importer.FormatOFX, File: []byte(testCurrencyOFX)}, WalletID: wallet.ID, CreditCategoryID: credit.ID, DebitCategoryID: debit.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(ctx context.Context, arg db.GetCategoryParams) (db.Category, error) {
+					if arg.ID == credit.ID {
+						return credit, nil
+					}
+					return debit, nil
+				})
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(3).Return(wallet, nil)
+				store.EXPECT().AccountExternalIDExists(gomock.Any(), gomock.Eq(db.AccountExternalIDExistsParams{WalletID: wallet.ID, ExternalID: "A1"})).Times(1).Return(false, nil)
+				store.EXPECT().AccountExternalIDExists(gomock.Any(), gomock.Eq(db.AccountExternalIDExistsParams{WalletID: wallet.ID, ExternalID: "A2"})).Times(0)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{ID: 13}, nil)
+			},
+			status: http.StatusOK,
+			response: importSummary{
+				Created: 1,
+				Failed:  1,
+				Rows: []importRowResult{
+					{Line: 2, Status: importCreated, AccountID: 13, CategoryID: debit.ID},
+					{Line: 3, Status: importFailed, Error: "currency USD differs from the wallet currency BRL"},
+				},
+			},
+		},
+		{
+			name:      "InvalidFile",
+			body:      commitImportRequest{importRequest: importRequest{Format: importer.FormatOFX, File: []byte(testCSV)}, WalletID: wallet.ID, CreditCategoryID: credit.ID, DebitCategoryID: debit.ID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+	})
+}
+
+func TestImportProfiles(t *testing.T) {
+	user, _ := randomUser(t)
+	mapping, err := json.Marshal(testCSVMapping.Normalize())
+	require.NoError(t, err)
+	profile := db.ImportProfile{
+		ID:        randomID(),
+		UserID:    user.ID,
+		Name:      "Banco",
+		Mapping:   mapping,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	request := importProfileRequest{Name: profile.Name, Mapping: testCSVMapping}
+	arg := db.CreateImportProfileParams{UserID: user.ID, Name: profile.Name, Mapping: mapping}
+
+	runRouteTests(t, http.MethodPost, "/import-profiles", []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateImportProfile(gomock.Any(), gomock.Eq(arg)).Times(1).Return(profile, nil)
+			},
+			status:   http.StatusOK,
+			response: profile,
+		},
+		{
+			name:      "DuplicateName",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateImportProfile(gomock.Any(), gomock.Any()).Times(1).Return(db.ImportProfile{}, &pq.Error{Code: "23505"})
+			},
+			status: http.StatusConflict,
+		},
+		{
+			name:      "InvalidMapping",
+			body:      importProfileRequest{Name: profile.Name, Mapping: importer.CSVMapping{DateColumn: 1}},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateImportProfile(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+	})
+
+	url := fmt.Sprintf("/import-profiles/%d", profile.ID)
+	runRouteTests(t, http.MethodGet, url, []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetImportProfile(gomock.Any(), gomock.Eq(db.GetImportProfileParams{ID: profile.ID, UserID: user.ID})).Times(1).Return(profile, nil)
+			},
+			status:   http.StatusOK,
+			response: profile,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetImportProfile(gomock.Any(), gomock.Any()).Times(1).Return(db.ImportProfile{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+	})
+
+	runRouteTests(t, http.MethodGet, "/import-profiles", []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetImportProfiles(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return([]db.ImportProfile{profile}, nil)
+			},
+			status:   http.StatusOK,
+			response: []db.ImportProfile{profile},
+		},
+	})
+
+	runRouteTests(t, http.MethodPut, url, []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateImportProfile(gomock.Any(), gomock.Eq(db.UpdateImportProfileParams{
+					ID:      profile.ID,
+					UserID:  user.ID,
+					Name:    profile.Name,
+					Mapping: mapping,
+				})).Times(1).Return(profile, nil)
+			},
+			status:   http.StatusOK,
+			response: profile,
+		},
+		{
+			name:      "ReadOnlyKey",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			status:    http.StatusForbidden,
+		},
+	})
+
+	runRouteTests(t, http.MethodDelete, url, []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteImportProfile(gomock.Any(), gomock.Eq(db.DeleteImportProfileParams{ID: profile.ID, UserID: user.ID})).Times(1).Return(int64(1), nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteImportProfile(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+			},
+			status: http.StatusNotFound,
+		},
+	})
+}
+
+func TestCommitImportCategoryHints(t *testing.T) {
+	user, _ := randomUser(t)
+	credit := randomCategory(user, "credit")
+	debit := randomCategory(user, "debit")
+	rent := randomCategory(user, "debit")
+	rent.Title = "Aluguel"
+	wallet := randomWallet(user, "BRL")
+	qif := "!Type:Bank\nD01/05/2024\nT-1500.00\nPImobiliária\nLCasa:aluguel\n^\n" +
+		"D01/06/2024\nT-30.00\nPPadaria\nLAlimentação\n^\n" +
+		"D01/07/2024\nT-200.00\nPPoupança\nL[Poupança]\n^\n"
+	request := commitImportRequest{
+		importRequest:    importRequest{Format: importer.FormatQIF, File: []byte(qif)},
+		WalletID:         wallet.ID,
+		CreditCategoryID: credit.ID,
+		DebitCategoryID:  debit.ID,
+	}
+
+	runRouteTests(t, http.MethodPost, "/imports", []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(ctx context.Context, arg db.GetCategoryParams) (db.Category, error) {
+					if arg.ID == credit.ID {
+						return credit, nil
+					}
+					return debit, nil
+				})
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(2).Return(wallet, nil)
+				store.EXPECT().GetCategories(gomock.Any(), gomock.Eq(db.GetCategoriesParams{UserID: user.ID, Type: "credit"})).Times(1).Return([]db.Category{credit}, nil)
+				store.EXPECT().GetCategories(gomock.Any(), gomock.Eq(db.GetCategoriesParams{UserID: user.ID, Type: "debit"})).Times(1).Return([]db.Category{debit, rent}, nil)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(func(ctx context.Context, arg db.CreateAccountParams) (db.Account, error) {
+					return db.Account{ID: int32(arg.Value)}, nil
+				})
+			},
+			status: http.StatusOK,
+			response: importSummary{
+				Created: 3,
+				Rows: []importRowResult{
+					{Line: 2, Status: importCreated, AccountID: 150000, CategoryID: rent.ID},
+					{Line: 7, Status: importCreated, AccountID: 3000, CategoryID: debit.ID},
+					{Line: 12, Status: importCreated, AccountID: 20000, CategoryID: debit.ID},
+				},
+			},
+		},
+	})
+}
+
+func TestMatchImportCategory(t *testing.T) {
+	categories := map[string]int32{"casa": 1, "aluguel": 2, "casa:luz": 3}
+
+	testCases := []struct {
+		hint string
+		id   int32
+		ok   bool
+	}{
+		{"Casa:Luz", 3, true},
+		{"Casa:Aluguel", 2, true},
+		{"Casa:Internet", 1, true},
+		{" ALUGUEL ", 2, true},
+		{"Mercado", 0, false},
+		{"[Casa]", 0, false},
+		{"", 0, false},
+	}
+	for _, tc := range testCases {
+		id, ok := matchImportCategory(categories, tc.hint)
+		require.Equal(t, tc.ok, ok, tc.hint)
+		require.Equal(t, tc.id, id, tc.hint)
+	}
+}
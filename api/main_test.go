@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/mail"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	testTokenKey   = util.RandomString(32)
+	testTokenMaker *util.TokenMaker
+
+	testPasswordHasher = util.NewPasswordHasher(util.PasswordParams{
+		Memory:      1024,
+		Iterations:  1,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	})
+)
+
+// testMailer guarda os e-mails enviados para os testes inspecionarem
+type testMailer struct {
+	mu       sync.Mutex
+	messages []mail.Message
+}
+
+func (mailer *testMailer) Send(ctx context.Context, message mail.Message) error {
+	mailer.mu.Lock()
+	defer mailer.mu.Unlock()
+	mailer.messages = append(mailer.messages, message)
+	return nil
+}
+
+func (mailer *testMailer) lastMessageTo(t *testing.T, email string) mail.Message {
+	mailer.mu.Lock()
+	defer mailer.mu.Unlock()
+	for i := len(mailer.messages) - 1; i >= 0; i-- {
+		for _, to := range mailer.messages[i].To {
+			if to == email {
+				return mailer.messages[i]
+			}
+		}
+	}
+	t.Fatalf("no message sent to %s", email)
+	return mail.Message{}
+}
+
+// TestMain roda antes dos testes
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+
+	var err error
+	testTokenMaker, err = util.NewTokenMaker(util.TokenConfig{
+		Duration: time.Minute,
+		Keys:     map[string][]byte{"test": []byte(testTokenKey)},
+	})
+	if err != nil {
+		log.Fatal("cannot create token maker: ", err)
+	}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	authorizationHeaderKey  = "authorization"
+	authorizationTypeBearer = "bearer"
+	authorizationPayloadKey = "authorization_payload"
+	authorizationAPIKeyKey  = "authorization_api_key"
+)
+
+var (
+	errInvalidAuthorizationFormat = errors.New("invalid authorization format")
+	errInvalidAPIKey              = errors.New("api key is invalid, expired or revoked")
+	errAPIKeyNotAllowed           = errors.New("api keys are not allowed on this route")
+	errMissingScope               = errors.New("api key is missing the required scope")
+)
+
+// authMiddleware valida o token do header e a sessão e guarda as claims no contexto
+func authMiddleware(tokenMaker *util.TokenMaker, store db.Store) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token, ok := bearerToken(ctx)
+		if !ok {
+			return
+		}
+		if util.IsAPIKey(token) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(errAPIKeyNotAllowed))
+			return
+		}
+
+		authenticateSession(ctx, tokenMaker, store, token)
+	}
+}
+
+// apiKeyAuthMiddleware aceita o JWT da sessão ou uma API key; as rotas exigem o escopo com requireScope
+func apiKeyAuthMiddleware(tokenMaker *util.TokenMaker, store db.Store) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token, ok := bearerToken(ctx)
+		if !ok {
+			return
+		}
+		if !util.IsAPIKey(token) {
+			authenticateSession(ctx, tokenMaker, store, token)
+			return
+		}
+
+		apiKey, err := store.GetValidAPIKey(ctx, util.HashOpaqueToken(token))
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errInvalidAPIKey))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		err = store.TouchAPIKey(ctx, apiKey.ID)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.Set(authorizationPayloadKey, &util.Claims{UserID: apiKey.UserID})
+		ctx.Set(authorizationAPIKeyKey, apiKey)
+		ctx.Next()
+	}
+}
+
+// requireScope barra as API keys sem o escopo da rota; o JWT da sessão tem acesso total
+func requireScope(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		value, ok := ctx.Get(authorizationAPIKeyKey)
+		if ok && !hasScope(value.(db.ApiKey).Scopes, scope) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(errMissingScope))
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, granted := range scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// bearerToken lê o token do header Authorization e aborta a requisição se o formato for inválido
+func bearerToken(ctx *gin.Context) (string, bool) {
+	authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
+	fields := strings.Fields(authorizationHeader)
+	if len(fields) != 2 || strings.ToLower(fields[0]) != authorizationTypeBearer {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errInvalidAuthorizationFormat))
+		return "", false
+	}
+	return fields[1], true
+}
+
+// authenticateSession valida o JWT e a sessão ligada a ele
+func authenticateSession(ctx *gin.Context, tokenMaker *util.TokenMaker, store db.Store, token string) {
+	claims, err := tokenMaker.ValidateToken(token)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	session, err := store.GetSession(ctx, claims.SessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errSessionRevoked))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if session.RevokedAt.Valid || session.UserID != claims.UserID {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errSessionRevoked))
+		return
+	}
+
+	ctx.Set(authorizationPayloadKey, claims)
+	ctx.Next()
+}
+
+// authClaims retorna as claims do usuário autenticado pelo authMiddleware
+func authClaims(ctx *gin.Context) *util.Claims {
+	return ctx.MustGet(authorizationPayloadKey).(*util.Claims)
+}
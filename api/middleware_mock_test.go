@@ -0,0 +1,212 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// withSessionResult autentica com um JWT cuja sessão o store devolve como pedido
+func withSessionResult(user db.User, session db.Session, err error) authSetup {
+	return func(t *testing.T, request *http.Request, store *mockdb.MockStore) {
+		token, _, tokenErr := testTokenMaker.CreateToken(user.ID, user.Username, session.ID)
+		require.NoError(t, tokenErr)
+		request.Header.Set(authorizationHeaderKey, fmt.Sprintf("Bearer %s", token))
+
+		store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, err)
+	}
+}
+
+// withAPIKeyResult autentica com uma API key que o store devolve como pedido
+func withAPIKeyResult(apiKey db.ApiKey, err error, touchErr error) authSetup {
+	return func(t *testing.T, request *http.Request, store *mockdb.MockStore) {
+		key, _, keyHash, keyErr := util.NewAPIKey()
+		require.NoError(t, keyErr)
+		request.Header.Set(authorizationHeaderKey, fmt.Sprintf("Bearer %s", key))
+
+		store.EXPECT().GetValidAPIKey(gomock.Any(), gomock.Eq(keyHash)).Times(1).Return(apiKey, err)
+		if err == nil {
+			store.EXPECT().TouchAPIKey(gomock.Any(), gomock.Eq(apiKey.ID)).Times(1).Return(touchErr)
+		}
+	}
+}
+
+func withHeader(value string) authSetup {
+	return func(t *testing.T, request *http.Request, store *mockdb.MockStore) {
+		request.Header.Set(authorizationHeaderKey, value)
+	}
+}
+
+// newTestTokenMaker cria um gerador de tokens com a chave e a duração dadas
+func newTestTokenMaker(t *testing.T, key string, duration time.Duration) *util.TokenMaker {
+	tokenMaker, err := util.NewTokenMaker(util.TokenConfig{
+		Duration: duration,
+		Keys:     map[string][]byte{"test": []byte(key)},
+	})
+	require.NoError(t, err)
+	return tokenMaker
+}
+
+func TestAuthMiddlewareSession(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	session := db.Session{ID: randomID(), UserID: user.ID}
+	expiredTokenMaker := newTestTokenMaker(t, testTokenKey, -time.Minute)
+	otherKeyTokenMaker := newTestTokenMaker(t, util.RandomString(32), time.Minute)
+
+	tokenFrom := func(tokenMaker *util.TokenMaker) authSetup {
+		return func(t *testing.T, request *http.Request, store *mockdb.MockStore) {
+			token, _, err := tokenMaker.CreateToken(user.ID, user.Username, session.ID)
+			require.NoError(t, err)
+			request.Header.Set(authorizationHeaderKey, fmt.Sprintf("Bearer %s", token))
+		}
+	}
+	okStubs := func(store *mockdb.MockStore) {
+		store.EXPECT().GetAPIKeys(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return([]db.ApiKey{}, nil)
+	}
+	noCalls := func(store *mockdb.MockStore) {
+		store.EXPECT().GetAPIKeys(gomock.Any(), gomock.Any()).Times(0)
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:       "OK",
+			setupAuth:  withSessionResult(user, session, nil),
+			buildStubs: okStubs,
+			status:     http.StatusOK,
+		},
+		{
+			name:       "NoAuthorization",
+			buildStubs: noCalls,
+			status:     http.StatusUnauthorized,
+			response:   errorResponse(errInvalidAuthorizationFormat),
+		},
+		{
+			name:       "UnsupportedAuthorization",
+			setupAuth:  withHeader("Basic dXNlcjpwYXNz"),
+			buildStubs: noCalls,
+			status:     http.StatusUnauthorized,
+			response:   errorResponse(errInvalidAuthorizationFormat),
+		},
+		{
+			name:       "ExpiredToken",
+			setupAuth:  tokenFrom(expiredTokenMaker),
+			buildStubs: noCalls,
+			status:     http.StatusUnauthorized,
+		},
+		{
+			name:       "InvalidSignature",
+			setupAuth:  tokenFrom(otherKeyTokenMaker),
+			buildStubs: noCalls,
+			status:     http.StatusUnauthorized,
+		},
+		{
+			name:       "SessionNotFound",
+			setupAuth:  withSessionResult(user, session, sql.ErrNoRows),
+			buildStubs: noCalls,
+			status:     http.StatusUnauthorized,
+			response:   errorResponse(errSessionRevoked),
+		},
+		{
+			name:       "SessionRevoked",
+			setupAuth:  withSessionResult(user, db.Session{ID: session.ID, UserID: user.ID, RevokedAt: sql.NullTime{Time: time.Now(), Valid: true}}, nil),
+			buildStubs: noCalls,
+			status:     http.StatusUnauthorized,
+			response:   errorResponse(errSessionRevoked),
+		},
+		{
+			name:       "SessionOfOtherUser",
+			setupAuth:  withSessionResult(user, db.Session{ID: session.ID, UserID: other.ID}, nil),
+			buildStubs: noCalls,
+			status:     http.StatusUnauthorized,
+			response:   errorResponse(errSessionRevoked),
+		},
+		{
+			name:       "SessionError",
+			setupAuth:  withSessionResult(user, session, errStore),
+			buildStubs: noCalls,
+			status:     http.StatusInternalServerError,
+		},
+		{
+			name:       "APIKeyNotAllowed",
+			setupAuth:  withAPIKeyHeader,
+			buildStubs: noCalls,
+			status:     http.StatusForbidden,
+			response:   errorResponse(errAPIKeyNotAllowed),
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/api-keys", testCases)
+}
+
+func TestAPIKeyAuthMiddleware(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomCategory(user, "debit")
+	url := fmt.Sprintf("/category/id/%d", category.ID)
+
+	okStubs := func(store *mockdb.MockStore) {
+		store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(db.GetCategoryParams{ID: category.ID, UserID: user.ID})).Times(1).Return(category, nil)
+	}
+	noCalls := func(store *mockdb.MockStore) {
+		store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(0)
+	}
+	apiKey := db.ApiKey{ID: randomID(), UserID: user.ID, Scopes: []string{scopeCategoriesRead}}
+
+	testCases := []routeTestCase{
+		{
+			name:       "Session",
+			setupAuth:  withSession(user),
+			buildStubs: okStubs,
+			status:     http.StatusOK,
+			response:   category,
+		},
+		{
+			name:       "APIKey",
+			setupAuth:  withAPIKeyResult(apiKey, nil, nil),
+			buildStubs: okStubs,
+			status:     http.StatusOK,
+			response:   category,
+		},
+		{
+			name:       "MissingScope",
+			setupAuth:  withAPIKey(user, scopeCategoriesWrite, scopeAccountsRead),
+			buildStubs: noCalls,
+			status:     http.StatusForbidden,
+			response:   errorResponse(errMissingScope),
+		},
+		{
+			name:       "InvalidAPIKey",
+			setupAuth:  withAPIKeyResult(db.ApiKey{}, sql.ErrNoRows, nil),
+			buildStubs: noCalls,
+			status:     http.StatusUnauthorized,
+			response:   errorResponse(errInvalidAPIKey),
+		},
+		{
+			name:       "APIKeyError",
+			setupAuth:  withAPIKeyResult(db.ApiKey{}, errStore, nil),
+			buildStubs: noCalls,
+			status:     http.StatusInternalServerError,
+		},
+		{
+			name:       "TouchAPIKeyError",
+			setupAuth:  withAPIKeyResult(apiKey, nil, errStore),
+			buildStubs: noCalls,
+			status:     http.StatusInternalServerError,
+		},
+		{
+			name:       "NoAuthorization",
+			buildStubs: noCalls,
+			status:     http.StatusUnauthorized,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, url, testCases)
+}
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/ratelimit"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// errStore simula uma falha do banco
+var errStore = sql.ErrConnDone
+
+// newMockServer cria o servidor com o store mockado, sem precisar do Postgres
+func newMockServer(t *testing.T, store db.Store) *Server {
+	return newMockServerWithRateLimit(t, store, util.RateLimitConfig{})
+}
+
+func newMockServerWithRateLimit(t *testing.T, store db.Store, rateLimit util.RateLimitConfig) *Server {
+	server, err := NewServer(store, testTokenMaker, testPasswordHasher, &testMailer{}, "", ratelimit.NewMemoryStore(), rateLimit)
+	require.NoError(t, err)
+	return server
+}
+
+// authSetup autentica a requisição e registra no store as buscas feitas pelo middleware
+type authSetup func(t *testing.T, request *http.Request, store *mockdb.MockStore)
+
+// withSession autentica com o JWT de uma sessão ativa do usuário
+func withSession(user db.User) authSetup {
+	return func(t *testing.T, request *http.Request, store *mockdb.MockStore) {
+		sessionID := randomID()
+		token, _, err := testTokenMaker.CreateToken(user.ID, user.Username, sessionID)
+		require.NoError(t, err)
+		request.Header.Set(authorizationHeaderKey, fmt.Sprintf("Bearer %s", token))
+
+		store.EXPECT().
+			GetSession(gomock.Any(), gomock.Eq(sessionID)).
+			Times(1).
+			Return(db.Session{ID: sessionID, UserID: user.ID}, nil)
+	}
+}
+
+// withAPIKey autentica com uma API key válida do usuário com os escopos pedidos
+func withAPIKey(user db.User, scopes ...string) authSetup {
+	return func(t *testing.T, request *http.Request, store *mockdb.MockStore) {
+		key, prefix, keyHash, err := util.NewAPIKey()
+		require.NoError(t, err)
+		request.Header.Set(authorizationHeaderKey, fmt.Sprintf("Bearer %s", key))
+
+		apiKey := db.ApiKey{ID: randomID(), UserID: user.ID, Prefix: prefix, KeyHash: keyHash, Scopes: scopes}
+		store.EXPECT().
+			GetValidAPIKey(gomock.Any(), gomock.Eq(keyHash)).
+			Times(1).
+			Return(apiKey, nil)
+		store.EXPECT().
+			TouchAPIKey(gomock.Any(), gomock.Eq(apiKey.ID)).
+			Times(1).
+			Return(nil)
+	}
+}
+
+// withAPIKeyHeader manda uma API key qualquer, para as rotas que só aceitam a sessão
+func withAPIKeyHeader(t *testing.T, request *http.Request, store *mockdb.MockStore) {
+	key, _, _, err := util.NewAPIKey()
+	require.NoError(t, err)
+	request.Header.Set(authorizationHeaderKey, fmt.Sprintf("Bearer %s", key))
+}
+
+// routeTestCase é um caso de teste de uma rota; url substitui a URL padrão da rota. Sem
+// checkResponse, confere o status e, se response não for nil, o corpo em JSON
+type routeTestCase struct {
+	name          string
+	url           string
+	body          interface{}
+	setupAuth     authSetup
+	buildStubs    func(store *mockdb.MockStore)
+	status        int
+	response      interface{}
+	checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+}
+
+// runRouteTests roda cada caso com um store mockado novo
+func runRouteTests(t *testing.T, method string, url string, testCases []routeTestCase) {
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			if tc.buildStubs != nil {
+				tc.buildStubs(store)
+			}
+
+			server := newMockServer(t, store)
+			recorder := httptest.NewRecorder()
+			if tc.url == "" {
+				tc.url = url
+			}
+			request := newRouteRequest(t, method, tc.url, tc.body)
+			if tc.setupAuth != nil {
+				tc.setupAuth(t, request, store)
+			}
+
+			server.router.ServeHTTP(recorder, request)
+
+			if tc.checkResponse != nil {
+				tc.checkResponse(t, recorder)
+				return
+			}
+			require.Equal(t, tc.status, recorder.Code, recorder.Body.String())
+			if tc.response != nil {
+				requireBodyMatch(t, recorder, tc.response)
+			}
+		})
+	}
+}
+
+// newRouteRequest monta a requisição; body em string vai como está, o resto vira JSON
+func newRouteRequest(t *testing.T, method string, url string, body interface{}) *http.Request {
+	var data []byte
+	switch body := body.(type) {
+	case nil:
+	case string:
+		data = []byte(body)
+	default:
+		var err error
+		data, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	request, err := http.NewRequest(method, url, bytes.NewReader(data))
+	require.NoError(t, err)
+	return request
+}
+
+func requireBodyMatch(t *testing.T, recorder *httptest.ResponseRecorder, expected interface{}) {
+	data, err := json.Marshal(expected)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), recorder.Body.String())
+}
+
+// expectExecTx faz o ExecTx do mock rodar a função com o próprio mock como Querier
+func expectExecTx(store *mockdb.MockStore) {
+	store.EXPECT().
+		ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, fn func(db.Querier) error, options ...db.TxOption) error {
+			return fn(store)
+		})
+}
+
+// expectHouseholdRole registra o papel do usuário no household
+func expectHouseholdRole(store *mockdb.MockStore, householdID int32, user db.User, role string) {
+	store.EXPECT().
+		GetHouseholdMember(gomock.Any(), gomock.Eq(db.GetHouseholdMemberParams{HouseholdID: householdID, UserID: user.ID})).
+		Times(1).
+		Return(db.HouseholdMember{HouseholdID: householdID, UserID: user.ID, Role: role}, nil)
+}
+
+func randomID() int32 {
+	return rand.Int31n(1000000) + 1
+}
+
+// randomUser cria um usuário em memória e devolve a senha usada no hash
+func randomUser(t *testing.T) (db.User, string) {
+	password := util.RandomString(12)
+	passwordHashed, err := testPasswordHasher.Hash(password)
+	require.NoError(t, err)
+
+	return db.User{
+		ID:           randomID(),
+		Username:     util.RandomString(8),
+		Password:     passwordHashed,
+		Email:        util.RamdomEmail(11),
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+		BaseCurrency: "BRL",
+	}, password
+}
+
+func randomCategory(user db.User, categoryType string) db.Category {
+	return db.Category{
+		ID:          randomID(),
+		UserID:      user.ID,
+		Title:       util.RandomString(12),
+		Type:        categoryType,
+		Description: util.RandomString(20),
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func randomWallet(user db.User, currency string) db.GetWalletRow {
+	return db.GetWalletRow{
+		ID:        randomID(),
+		UserID:    user.ID,
+		Name:      util.RandomString(8),
+		Kind:      "checking",
+		Currency:  currency,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Balance:   util.NewMoney(rand.Int63n(1000), 0),
+	}
+}
+
+func randomAccount(user db.User, category db.Category, wallet db.GetWalletRow) db.Account {
+	return db.Account{
+		ID:          randomID(),
+		UserID:      user.ID,
+		CategoryID:  sql.NullInt32{Int32: category.ID, Valid: true},
+		Title:       util.RandomString(12),
+		Type:        category.Type,
+		Description: util.RandomString(20),
+		Value:       util.NewMoney(rand.Int63n(1000)+1, rand.Int63n(100)),
+		Date:        time.Now().UTC().Truncate(time.Second),
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+		Currency:    wallet.Currency,
+		WalletID:    wallet.ID,
+	}
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errTooManyRequests = errors.New("too many requests, try again later")
+	errLoginLocked     = errors.New("too many failed login attempts, try again later")
+)
+
+// rateLimitMiddleware limita as requisições de cada IP com um token bucket
+func rateLimitMiddleware(store ratelimit.Store, limit ratelimit.Limit) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		retryAfter, err := store.Take(ctx, "api:ip:"+ctx.ClientIP(), limit)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if retryAfter > 0 {
+			abortTooManyRequests(ctx, retryAfter, errTooManyRequests)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// abortTooManyRequests responde 429 com o Retry-After em segundos
+func abortTooManyRequests(ctx *gin.Context, retryAfter time.Duration, err error) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	ctx.Header("Retry-After", strconv.Itoa(seconds))
+	ctx.AbortWithStatusJSON(http.StatusTooManyRequests, errorResponse(err))
+}
+
+func (server *Server) loginLockoutPolicy() ratelimit.LockoutPolicy {
+	return ratelimit.LockoutPolicy{
+		Threshold: server.rateLimit.LockoutThreshold,
+		BaseDelay: server.rateLimit.LockoutBaseDelay,
+		MaxDelay:  server.rateLimit.LockoutMaxDelay,
+		Window:    server.rateLimit.LockoutWindow,
+	}
+}
+
+func loginLockoutKey(username string) string {
+	return "login:lockout:" + strings.ToLower(username)
+}
+
+// checkLoginLimit aplica o limite por IP e por usuário e o bloqueio por falhas antes de
+// conferir a senha; se a tentativa não puder seguir, já responde 429 e retorna false
+func (server *Server) checkLoginLimit(ctx *gin.Context, username string) bool {
+	if server.loginLockoutPolicy().Enabled() {
+		lockedFor, err := server.rateLimitStore.LockedFor(ctx, loginLockoutKey(username))
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return false
+		}
+		if lockedFor > 0 {
+			abortTooManyRequests(ctx, lockedFor, errLoginLocked)
+			return false
+		}
+	}
+
+	limits := []struct {
+		key   string
+		limit ratelimit.Limit
+	}{
+		{"login:ip:" + ctx.ClientIP(), ratelimit.PerMinute(server.rateLimit.LoginIPPerMinute)},
+		{"login:user:" + strings.ToLower(username), ratelimit.PerMinute(server.rateLimit.LoginUsernamePerMinute)},
+	}
+	for _, limit := range limits {
+		if !limit.limit.Enabled() {
+			continue
+		}
+		retryAfter, err := server.rateLimitStore.Take(ctx, limit.key, limit.limit)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return false
+		}
+		if retryAfter > 0 {
+			abortTooManyRequests(ctx, retryAfter, errTooManyRequests)
+			return false
+		}
+	}
+
+	return true
+}
+
+// loginFailed registra a falha do usuário; ao passar do limite a resposta vira 429 com o bloqueio
+func (server *Server) loginFailed(ctx *gin.Context, username string, status int, failure error) {
+	policy := server.loginLockoutPolicy()
+	if !policy.Enabled() {
+		ctx.JSON(status, errorResponse(failure))
+		return
+	}
+
+	lockedFor, err := server.rateLimitStore.AddFailure(ctx, loginLockoutKey(username), policy)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if lockedFor > 0 {
+		abortTooManyRequests(ctx, lockedFor, errLoginLocked)
+		return
+	}
+
+	ctx.JSON(status, errorResponse(failure))
+}
+
+// loginSucceeded zera as falhas do usuário depois de um login completo
+func (server *Server) loginSucceeded(ctx *gin.Context, username string) error {
+	if !server.loginLockoutPolicy().Enabled() {
+		return nil
+	}
+	return server.rateLimitStore.Reset(ctx, loginLockoutKey(username))
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/ratelimit"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	router := gin.New()
+	router.GET("/limited", rateLimitMiddleware(ratelimit.NewMemoryStore(), ratelimit.Limit{Rate: 0.5, Burst: 2}), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, true)
+	})
+
+	for i := 0; i < 2; i++ {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/limited", nil)
+		router.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusOK, recorder.Code)
+	}
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/limited", nil)
+	router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "2", recorder.Header().Get("Retry-After"))
+}
+
+// postLogin faz o login no servidor, que guarda o estado dos limites entre as chamadas
+func postLogin(t *testing.T, server *Server, request loginRequest) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, newRouteRequest(t, http.MethodPost, "/login", request))
+	return recorder
+}
+
+func TestLoginLockout(t *testing.T) {
+	user, password := randomUser(t)
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newMockServerWithRateLimit(t, store, util.RateLimitConfig{
+		LockoutThreshold: 3,
+		LockoutBaseDelay: time.Minute,
+		LockoutMaxDelay:  time.Hour,
+		LockoutWindow:    time.Hour,
+	})
+
+	// o bloqueio acontece antes de buscar o usuário: só as três primeiras tentativas chegam ao store
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(3).Return(user, nil)
+	store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+
+	for i := 0; i < 2; i++ {
+		recorder := postLogin(t, server, loginRequest{Username: user.Username, Password: "wrong-" + password})
+		require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	}
+
+	recorder := postLogin(t, server, loginRequest{Username: user.Username, Password: "wrong-" + password})
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "60", recorder.Header().Get("Retry-After"))
+
+	// nem a senha certa passa enquanto o usuário está bloqueado
+	recorder = postLogin(t, server, loginRequest{Username: user.Username, Password: password})
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.NotEmpty(t, recorder.Header().Get("Retry-After"))
+}
+
+func TestLoginUsernameRateLimit(t *testing.T) {
+	user, password := randomUser(t)
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newMockServerWithRateLimit(t, store, util.RateLimitConfig{LoginUsernamePerMinute: 2})
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(2).Return(user, nil)
+	store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(2).Return(db.UserTotp{}, sql.ErrNoRows)
+	store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(2).Return(db.Session{ID: randomID(), UserID: user.ID}, nil)
+
+	for i := 0; i < 2; i++ {
+		recorder := postLogin(t, server, loginRequest{Username: user.Username, Password: password})
+		require.Equal(t, http.StatusOK, recorder.Code)
+	}
+
+	recorder := postLogin(t, server, loginRequest{Username: user.Username, Password: password})
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "30", recorder.Header().Get("Retry-After"))
+}
+
+func TestRateLimitForwardedFor(t *testing.T) {
+	get := func(server *Server, forwardedFor string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+		request.Header.Set("X-Forwarded-For", forwardedFor)
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+	limit := util.RateLimitConfig{RequestsPerSecond: 0.5, Burst: 2}
+
+	// sem proxies confiáveis, trocar o X-Forwarded-For não dá um bucket novo
+	server := newMockServerWithRateLimit(t, mockdb.NewMockStore(gomock.NewController(t)), limit)
+	for i := 0; i < 2; i++ {
+		require.Equal(t, http.StatusOK, get(server, fmt.Sprintf("203.0.113.%d", i)).Code)
+	}
+	require.Equal(t, http.StatusTooManyRequests, get(server, "203.0.113.99").Code)
+
+	// atrás de um proxy confiável, cada cliente encaminhado tem o seu bucket
+	limit.TrustedProxies = []string{"192.0.2.0/24"}
+	server = newMockServerWithRateLimit(t, mockdb.NewMockStore(gomock.NewController(t)), limit)
+	for i := 0; i < 2; i++ {
+		require.Equal(t, http.StatusOK, get(server, "203.0.113.1").Code)
+	}
+	require.Equal(t, http.StatusTooManyRequests, get(server, "203.0.113.1").Code)
+	require.Equal(t, http.StatusOK, get(server, "203.0.113.2").Code)
+}
+
+func TestLoginSessionClientIP(t *testing.T) {
+	user, password := randomUser(t)
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newMockServer(t, store)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+	store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(db.UserTotp{}, sql.ErrNoRows)
+	store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateSessionParams) (db.Session, error) {
+			// o IP gravado é o da conexão, não o informado pelo cliente
+			require.Equal(t, "192.0.2.1", arg.ClientIp)
+			return db.Session{ID: randomID(), UserID: user.ID}, nil
+		})
+
+	recorder := httptest.NewRecorder()
+	request := newRouteRequest(t, http.MethodPost, "/login", loginRequest{Username: user.Username, Password: password})
+	request.RemoteAddr = "192.0.2.1:1234"
+	request.Header.Set("X-Forwarded-For", "203.0.113.7")
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestDisableTwoFactorLockout(t *testing.T) {
+	user, password := randomUser(t)
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newMockServerWithRateLimit(t, store, util.RateLimitConfig{
+		LockoutThreshold: 3,
+		LockoutBaseDelay: time.Minute,
+		LockoutMaxDelay:  time.Hour,
+		LockoutWindow:    time.Hour,
+	})
+
+	disable := func(password string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := newRouteRequest(t, http.MethodPost, "/user/2fa/disable", disableTwoFactorRequest{Password: password})
+		withSession(user)(t, request, store)
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// com a sessão roubada, as senhas erradas bloqueiam o usuário como no login
+	store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(4).Return(user, nil)
+	store.EXPECT().DeleteUserTOTP(gomock.Any(), gomock.Any()).Times(0)
+
+	for i := 0; i < 2; i++ {
+		require.Equal(t, http.StatusUnauthorized, disable("wrong-"+password).Code)
+	}
+	require.Equal(t, http.StatusTooManyRequests, disable("wrong-"+password).Code)
+
+	recorder := disable(password)
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.NotEmpty(t, recorder.Header().Get("Retry-After"))
+
+	// o bloqueio também vale para o login
+	recorder = postLogin(t, server, loginRequest{Username: user.Username, Password: password})
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+}
@@ -0,0 +1,465 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/recurring"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// defaultRecurringPreviewDays é o período da prévia quando to não é informado
+	defaultRecurringPreviewDays = 90
+	maxRecurringPreviewDays     = 366
+)
+
+var (
+	errRecurringValue           = errors.New("value must be positive")
+	errRecurringPreviewTooLong  = errors.New("the preview period must be at most 366 days")
+	errOccurrenceNotFound       = errors.New("the rule has no occurrence on this date")
+	errOccurrenceAlreadyCreated = errors.New("the occurrence was already processed; edit its transaction instead")
+)
+
+type createRecurringRuleRequest struct {
+	HouseholdID     int32      `json:"household_id"`
+	WalletID        int32      `json:"wallet_id" binding:"required"`
+	CategoryID      int32      `json:"category_id" binding:"required"`
+	Title           string     `json:"title" binding:"required"`
+	Type            string     `json:"type" binding:"required,oneof=credit debit"`
+	Description     string     `json:"description" binding:"required"`
+	Value           util.Money `json:"value" binding:"required"`
+	Frequency       string     `json:"frequency" binding:"required,oneof=daily weekly monthly last_business_day yearly"`
+	Interval        int32      `json:"interval" binding:"omitempty,min=1"`
+	DayOfMonth      int32      `json:"day_of_month" binding:"omitempty,min=1,max=31"`
+	StartDate       time.Time  `json:"start_date" binding:"required"`
+	EndDate         *time.Time `json:"end_date"`
+	OccurrenceCount int32      `json:"occurrence_count" binding:"omitempty,min=1"`
+}
+
+// recurringEnd converte o fim opcional da regra
+func recurringEnd(endDate *time.Time, occurrenceCount int32) (sql.NullTime, sql.NullInt32) {
+	end := sql.NullTime{}
+	if endDate != nil {
+		end = sql.NullTime{Time: recurring.Date(*endDate), Valid: true}
+	}
+	return end, sql.NullInt32{Int32: occurrenceCount, Valid: occurrenceCount > 0}
+}
+
+// createRecurringRule cria uma regra com o modelo da transação e o agendamento; as
+// transações são criadas pelo agendador quando cada ocorrência vence
+func (server *Server) createRecurringRule(ctx *gin.Context) {
+	var request createRecurringRuleRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.Value <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errRecurringValue))
+		return
+	}
+	if request.Interval == 0 {
+		request.Interval = 1
+	}
+
+	arg := db.CreateRecurringRuleParams{
+		UserID:      authClaims(ctx).UserID,
+		HouseholdID: householdID(request.HouseholdID),
+		WalletID:    request.WalletID,
+		CategoryID:  request.CategoryID,
+		Title:       request.Title,
+		Type:        request.Type,
+		Description: request.Description,
+		Value:       request.Value,
+		Frequency:   request.Frequency,
+		Interval:    request.Interval,
+		DayOfMonth:  sql.NullInt32{Int32: request.DayOfMonth, Valid: request.DayOfMonth > 0},
+		StartDate:   recurring.Date(request.StartDate),
+	}
+	arg.EndDate, arg.OccurrenceCount = recurringEnd(request.EndDate, request.OccurrenceCount)
+
+	err = recurring.ScheduleOf(db.RecurringRule{
+		Frequency:       arg.Frequency,
+		Interval:        arg.Interval,
+		DayOfMonth:      arg.DayOfMonth,
+		StartDate:       arg.StartDate,
+		EndDate:         arg.EndDate,
+		OccurrenceCount: arg.OccurrenceCount,
+	}).Validate()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleEditor) {
+		return
+	}
+
+	var rule db.RecurringRule
+	err = server.store.ExecTx(ctx, func(q db.Querier) error {
+		err := checkAccountTemplate(ctx, q, arg.UserID, request.HouseholdID, arg.CategoryID, arg.WalletID, arg.Type)
+		if err != nil {
+			return err
+		}
+
+		rule, err = q.CreateRecurringRule(ctx, arg)
+		if err == sql.ErrNoRows {
+			return errHouseholdForbidden
+		}
+		return err
+	})
+	if err != nil {
+		accountTemplateError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rule)
+}
+
+type recurringRuleRequest struct {
+	ID int32 `uri:"id" binding:"required"`
+}
+
+// getRecurringRule mostra a regra
+func (server *Server) getRecurringRule(ctx *gin.Context) {
+	var request recurringRuleRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rule, err := server.store.GetRecurringRule(ctx, db.GetRecurringRuleParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rule)
+}
+
+// getRecurringRules lista as regras do escopo
+func (server *Server) getRecurringRules(ctx *gin.Context) {
+	var request householdScopeRequest
+	err := ctx.ShouldBindQuery(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleViewer) {
+		return
+	}
+
+	rules, err := server.store.GetRecurringRules(ctx, db.GetRecurringRulesParams{
+		HouseholdID: householdID(request.HouseholdID),
+		UserID:      authClaims(ctx).UserID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
+
+type updateRecurringRuleRequest struct {
+	Title           string     `json:"title" binding:"required"`
+	Description     string     `json:"description"`
+	Value           util.Money `json:"value" binding:"required"`
+	EndDate         *time.Time `json:"end_date"`
+	OccurrenceCount int32      `json:"occurrence_count" binding:"omitempty,min=1"`
+}
+
+// updateRecurringRule altera o modelo e o fim da regra; vale para as ocorrências ainda não
+// criadas e sem alteração pontual
+func (server *Server) updateRecurringRule(ctx *gin.Context) {
+	var uri recurringRuleRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var request updateRecurringRuleRequest
+	err = ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.Value <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errRecurringValue))
+		return
+	}
+
+	arg := db.UpdateRecurringRuleParams{
+		ID:          uri.ID,
+		UserID:      authClaims(ctx).UserID,
+		Title:       request.Title,
+		Description: request.Description,
+		Value:       request.Value,
+	}
+	arg.EndDate, arg.OccurrenceCount = recurringEnd(request.EndDate, request.OccurrenceCount)
+
+	rule, err := server.store.GetRecurringRule(ctx, db.GetRecurringRuleParams{ID: arg.ID, UserID: arg.UserID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	rule.EndDate, rule.OccurrenceCount = arg.EndDate, arg.OccurrenceCount
+	err = recurring.ScheduleOf(rule).Validate()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rule, err = server.store.UpdateRecurringRule(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// a regra é visível, então falta permissão de escrita
+			ctx.JSON(http.StatusForbidden, errorResponse(errHouseholdForbidden))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rule)
+}
+
+// deleteRecurringRule apaga a regra; as transações já criadas ficam
+func (server *Server) deleteRecurringRule(ctx *gin.Context) {
+	var request recurringRuleRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.DeleteRecurringRuleParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	}
+
+	rowsDeleted, err := server.store.DeleteRecurringRule(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rowsDeleted == 0 {
+		_, err = server.store.GetRecurringRule(ctx, db.GetRecurringRuleParams(arg))
+		notWritable(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
+
+type getRecurringOccurrencesRequest struct {
+	From time.Time `form:"from" time_format:"2006-01-02"`
+	To   time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+// getRecurringOccurrences mostra a prévia das ocorrências no período (por padrão, de hoje
+// até 90 dias), com as alterações pontuais e as transações já criadas
+func (server *Server) getRecurringOccurrences(ctx *gin.Context) {
+	var uri recurringRuleRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var request getRecurringOccurrencesRequest
+	err = ctx.ShouldBindQuery(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.From.IsZero() {
+		request.From = time.Now().UTC()
+	}
+	request.From = recurring.Date(request.From)
+	if request.To.IsZero() {
+		request.To = request.From.AddDate(0, 0, defaultRecurringPreviewDays)
+	}
+	request.To = recurring.Date(request.To)
+	if request.From.After(request.To) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidPeriod))
+		return
+	}
+	if request.To.After(request.From.AddDate(0, 0, maxRecurringPreviewDays)) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errRecurringPreviewTooLong))
+		return
+	}
+
+	rule, err := server.store.GetRecurringRule(ctx, db.GetRecurringRuleParams{
+		ID:     uri.ID,
+		UserID: authClaims(ctx).UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	changes, err := server.store.GetRecurringOccurrences(ctx, db.GetRecurringOccurrencesParams{
+		RuleID:   rule.ID,
+		FromDate: request.From,
+		ToDate:   request.To,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recurring.Expand(rule, changes, request.From, request.To))
+}
+
+type recurringOccurrenceRequest struct {
+	ID   int32     `uri:"id" binding:"required"`
+	Date time.Time `uri:"date" binding:"required" time_format:"2006-01-02"`
+}
+
+type updateRecurringOccurrenceRequest struct {
+	Date        time.Time  `json:"date"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Value       util.Money `json:"value"`
+}
+
+// updateRecurringOccurrence altera uma única ocorrência ainda não criada; campos vazios ficam
+// como estão e uma ocorrência pulada volta a valer. A transação é criada quando a data
+// original vence, com a data informada aqui
+func (server *Server) updateRecurringOccurrence(ctx *gin.Context) {
+	var uri recurringOccurrenceRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var request updateRecurringOccurrenceRequest
+	err = ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.Value < 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errRecurringValue))
+		return
+	}
+
+	server.changeRecurringOccurrence(ctx, uri, func(occurrence *recurring.Occurrence) {
+		if !request.Date.IsZero() {
+			occurrence.Date = recurring.Date(request.Date)
+		}
+		if request.Title != "" {
+			occurrence.Title = request.Title
+		}
+		if request.Description != "" {
+			occurrence.Description = request.Description
+		}
+		if request.Value != 0 {
+			occurrence.Value = request.Value
+		}
+		occurrence.Skipped = false
+	})
+}
+
+// skipRecurringOccurrence pula uma única ocorrência ainda não criada
+func (server *Server) skipRecurringOccurrence(ctx *gin.Context) {
+	var uri recurringOccurrenceRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.changeRecurringOccurrence(ctx, uri, func(occurrence *recurring.Occurrence) {
+		occurrence.Skipped = true
+	})
+}
+
+// changeRecurringOccurrence grava a alteração pontual com a regra travada, para o agendador
+// não criar a transação no meio da alteração
+func (server *Server) changeRecurringOccurrence(ctx *gin.Context, uri recurringOccurrenceRequest, change func(*recurring.Occurrence)) {
+	userID := authClaims(ctx).UserID
+	date := recurring.Date(uri.Date)
+
+	var occurrence recurring.Occurrence
+	err := server.store.ExecTx(ctx, func(q db.Querier) error {
+		rule, err := q.GetRecurringRule(ctx, db.GetRecurringRuleParams{ID: uri.ID, UserID: userID})
+		if err != nil {
+			return err
+		}
+		rule, err = q.LockRecurringRule(ctx, rule.ID)
+		if err != nil {
+			return err
+		}
+		if rule.MaterializedThrough.Valid && !date.After(recurring.Date(rule.MaterializedThrough.Time)) {
+			return errOccurrenceAlreadyCreated
+		}
+
+		changes, err := q.GetRecurringOccurrences(ctx, db.GetRecurringOccurrencesParams{
+			RuleID:   rule.ID,
+			FromDate: date,
+			ToDate:   date,
+		})
+		if err != nil {
+			return err
+		}
+		occurrences := recurring.Expand(rule, changes, date, date)
+		if len(occurrences) == 0 {
+			return errOccurrenceNotFound
+		}
+		occurrence = occurrences[0]
+		change(&occurrence)
+
+		_, err = q.UpsertRecurringOccurrence(ctx, db.UpsertRecurringOccurrenceParams{
+			RuleID:         rule.ID,
+			UserID:         userID,
+			OccurrenceDate: occurrence.OccurrenceDate,
+			Date:           occurrence.Date,
+			Title:          occurrence.Title,
+			Description:    occurrence.Description,
+			Value:          occurrence.Value,
+			Skipped:        occurrence.Skipped,
+		})
+		if err == sql.ErrNoRows {
+			return errHouseholdForbidden
+		}
+		return err
+	})
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows, errOccurrenceNotFound:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errHouseholdForbidden:
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+		case errOccurrenceAlreadyCreated:
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, occurrence)
+}
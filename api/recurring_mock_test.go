@@ -0,0 +1,792 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/recurring"
+	"github.com/SraReaper/gofinance-backend/util"
+	"go.uber.org/mock/gomock"
+)
+
+// randomRecurringRule cria uma regra mensal no dia 10, a partir de janeiro de 2024
+func randomRecurringRule(user db.User, category db.Category, wallet db.GetWalletRow) db.RecurringRule {
+	return db.RecurringRule{
+		ID:          randomID(),
+		UserID:      user.ID,
+		WalletID:    wallet.ID,
+		CategoryID:  category.ID,
+		Title:       util.RandomString(12),
+		Type:        category.Type,
+		Description: util.RandomString(20),
+		Value:       util.NewMoney(1500, 0),
+		Frequency:   recurring.Monthly,
+		Interval:    1,
+		DayOfMonth:  sql.NullInt32{Int32: 10, Valid: true},
+		StartDate:   time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func TestCreateRecurringRule(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomCategory(user, "debit")
+	wallet := randomWallet(user, "BRL")
+	rule := randomRecurringRule(user, category, wallet)
+	householdID := randomID()
+
+	request := createRecurringRuleRequest{
+		WalletID:    wallet.ID,
+		CategoryID:  category.ID,
+		Title:       rule.Title,
+		Type:        rule.Type,
+		Description: rule.Description,
+		Value:       rule.Value,
+		Frequency:   rule.Frequency,
+		DayOfMonth:  rule.DayOfMonth.Int32,
+		StartDate:   rule.StartDate,
+	}
+	arg := db.CreateRecurringRuleParams{
+		UserID:      user.ID,
+		WalletID:    wallet.ID,
+		CategoryID:  category.ID,
+		Title:       rule.Title,
+		Type:        rule.Type,
+		Description: rule.Description,
+		Value:       rule.Value,
+		Frequency:   rule.Frequency,
+		Interval:    1,
+		DayOfMonth:  rule.DayOfMonth,
+		StartDate:   rule.StartDate,
+	}
+	endDate := rule.StartDate.AddDate(1, 0, 0)
+	bothEnds := request
+	bothEnds.EndDate = &endDate
+	bothEnds.OccurrenceCount = 12
+	weeklyOnDay := request
+	weeklyOnDay.Frequency = recurring.Weekly
+	zeroValue := request
+	zeroValue.Value = 0
+	negativeValue := request
+	negativeValue.Value = util.NewMoney(-10, 0)
+	householdRequest := request
+	householdRequest.HouseholdID = householdID
+	invalidType := request
+	invalidType.Type = "expense"
+
+	expectTemplate := func(store *mockdb.MockStore) {
+		expectExecTx(store)
+		store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(db.GetCategoryParams{ID: category.ID, UserID: user.ID})).Times(1).Return(category, nil)
+		store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(db.GetWalletParams{ID: wallet.ID, UserID: user.ID})).Times(1).Return(wallet, nil)
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectTemplate(store)
+				store.EXPECT().CreateRecurringRule(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rule, nil)
+			},
+			status:   http.StatusOK,
+			response: rule,
+		},
+		{
+			name:      "InvalidFrequency",
+			body:      map[string]interface{}{"wallet_id": wallet.ID, "category_id": category.ID, "title": rule.Title, "type": rule.Type, "description": rule.Description, "value": "10.00", "frequency": "hourly", "start_date": rule.StartDate},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "InvalidType",
+			body:      invalidType,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "EndDateAndCount",
+			body:      bothEnds,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(recurring.ErrInvalidEnd),
+		},
+		{
+			name:      "DayOfMonthNotMonthly",
+			body:      weeklyOnDay,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(recurring.ErrInvalidDay),
+		},
+		{
+			name:      "ZeroValue",
+			body:      zeroValue,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NegativeValue",
+			body:      negativeValue,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errRecurringValue),
+		},
+		{
+			name:      "CategoryNotFound",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(1).Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().CreateRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "WalletOtherHousehold",
+			body:      householdRequest,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				householdCategory := category
+				householdCategory.HouseholdID = sql.NullInt32{Int32: householdID, Valid: true}
+				expectHouseholdRole(store, householdID, user, householdRoleEditor)
+				expectExecTx(store)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(1).Return(householdCategory, nil)
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(1).Return(wallet, nil)
+				store.EXPECT().CreateRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errWalletOtherHousehold),
+		},
+		{
+			name:      "HouseholdViewer",
+			body:      householdRequest,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			body: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeAccountsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectTemplate(store)
+				store.EXPECT().CreateRecurringRule(gomock.Any(), gomock.Any()).Times(1).Return(db.RecurringRule{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/recurring", testCases)
+}
+
+func TestGetRecurringRules(t *testing.T) {
+	user, _ := randomUser(t)
+	rules := []db.RecurringRule{
+		randomRecurringRule(user, randomCategory(user, "debit"), randomWallet(user, "BRL")),
+		randomRecurringRule(user, randomCategory(user, "credit"), randomWallet(user, "BRL")),
+	}
+	householdID := randomID()
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRules(gomock.Any(), gomock.Eq(db.GetRecurringRulesParams{UserID: user.ID})).Times(1).Return(rules, nil)
+			},
+			status:   http.StatusOK,
+			response: rules,
+		},
+		{
+			name:      "Household",
+			url:       fmt.Sprintf("/recurring?household_id=%d", householdID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				store.EXPECT().GetRecurringRules(gomock.Any(), gomock.Eq(db.GetRecurringRulesParams{
+					HouseholdID: sql.NullInt32{Int32: householdID, Valid: true},
+					UserID:      user.ID,
+				})).Times(1).Return(rules, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeWalletsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRules(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRules(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRules(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/recurring", testCases)
+}
+
+func TestGetRecurringRule(t *testing.T) {
+	user, _ := randomUser(t)
+	rule := randomRecurringRule(user, randomCategory(user, "debit"), randomWallet(user, "BRL"))
+	params := db.GetRecurringRuleParams{ID: rule.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(rule, nil)
+			},
+			status:   http.StatusOK,
+			response: rule,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.RecurringRule{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "InvalidID",
+			url:       "/recurring/0",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Any()).Times(1).Return(db.RecurringRule{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, fmt.Sprintf("/recurring/%d", rule.ID), testCases)
+}
+
+func TestUpdateRecurringRule(t *testing.T) {
+	user, _ := randomUser(t)
+	rule := randomRecurringRule(user, randomCategory(user, "debit"), randomWallet(user, "BRL"))
+	params := db.GetRecurringRuleParams{ID: rule.ID, UserID: user.ID}
+	request := updateRecurringRuleRequest{
+		Title:           util.RandomString(12),
+		Description:     util.RandomString(20),
+		Value:           util.NewMoney(1600, 0),
+		OccurrenceCount: 24,
+	}
+	updated := rule
+	updated.Title = request.Title
+	updated.Description = request.Description
+	updated.Value = request.Value
+	updated.OccurrenceCount = sql.NullInt32{Int32: 24, Valid: true}
+	endBeforeStart := rule.StartDate.AddDate(0, -1, 0)
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(rule, nil)
+				store.EXPECT().UpdateRecurringRule(gomock.Any(), gomock.Eq(db.UpdateRecurringRuleParams{
+					ID:              rule.ID,
+					UserID:          user.ID,
+					Title:           request.Title,
+					Description:     request.Description,
+					Value:           request.Value,
+					OccurrenceCount: updated.OccurrenceCount,
+				})).Times(1).Return(updated, nil)
+			},
+			status:   http.StatusOK,
+			response: updated,
+		},
+		{
+			name:      "EndBeforeStart",
+			body:      updateRecurringRuleRequest{Title: request.Title, Value: request.Value, EndDate: &endBeforeStart},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(rule, nil)
+				store.EXPECT().UpdateRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(recurring.ErrInvalidEnd),
+		},
+		{
+			name:      "InvalidValue",
+			body:      updateRecurringRuleRequest{Title: request.Title, Value: util.NewMoney(-1, 0)},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errRecurringValue),
+		},
+		{
+			name:      "NotFound",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.RecurringRule{}, sql.ErrNoRows)
+				store.EXPECT().UpdateRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "NotWritable",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(rule, nil)
+				store.EXPECT().UpdateRecurringRule(gomock.Any(), gomock.Any()).Times(1).Return(db.RecurringRule{}, sql.ErrNoRows)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeAccountsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(rule, nil)
+				store.EXPECT().UpdateRecurringRule(gomock.Any(), gomock.Any()).Times(1).Return(db.RecurringRule{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPut, fmt.Sprintf("/recurring/%d", rule.ID), testCases)
+}
+
+func TestDeleteRecurringRule(t *testing.T) {
+	user, _ := randomUser(t)
+	rule := randomRecurringRule(user, randomCategory(user, "debit"), randomWallet(user, "BRL"))
+	params := db.DeleteRecurringRuleParams{ID: rule.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(db.GetRecurringRuleParams(params))).Times(1).Return(db.RecurringRule{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "NotWritable",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(db.GetRecurringRuleParams(params))).Times(1).Return(rule, nil)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withAPIKey(user, scopeAccountsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteRecurringRule(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodDelete, fmt.Sprintf("/recurring/%d", rule.ID), testCases)
+}
+
+func TestGetRecurringOccurrences(t *testing.T) {
+	user, _ := randomUser(t)
+	rule := randomRecurringRule(user, randomCategory(user, "debit"), randomWallet(user, "BRL"))
+	params := db.GetRecurringRuleParams{ID: rule.ID, UserID: user.ID}
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	changes := []db.RecurringOccurrence{
+		{
+			RuleID:         rule.ID,
+			OccurrenceDate: time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC),
+			Date:           time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC),
+			Title:          rule.Title,
+			Description:    rule.Description,
+			Value:          rule.Value,
+			Skipped:        true,
+		},
+	}
+	url := fmt.Sprintf("/recurring/%d/occurrences?from=2024-03-01&to=2024-05-31", rule.ID)
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(rule, nil)
+				store.EXPECT().GetRecurringOccurrences(gomock.Any(), gomock.Eq(db.GetRecurringOccurrencesParams{
+					RuleID:   rule.ID,
+					FromDate: from,
+					ToDate:   to,
+				})).Times(1).Return(changes, nil)
+			},
+			status:   http.StatusOK,
+			response: recurring.Expand(rule, changes, from, to),
+		},
+		{
+			name:      "InvalidPeriod",
+			url:       fmt.Sprintf("/recurring/%d/occurrences?from=2024-05-31&to=2024-03-01", rule.ID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errInvalidPeriod),
+		},
+		{
+			name:      "PeriodTooLong",
+			url:       fmt.Sprintf("/recurring/%d/occurrences?from=2024-01-01&to=2026-01-01", rule.ID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errRecurringPreviewTooLong),
+		},
+		{
+			name:      "InvalidDate",
+			url:       fmt.Sprintf("/recurring/%d/occurrences?from=01/03/2024", rule.ID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.RecurringRule{}, sql.ErrNoRows)
+				store.EXPECT().GetRecurringOccurrences(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeWalletsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(rule, nil)
+				store.EXPECT().GetRecurringOccurrences(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, url, testCases)
+}
+
+func TestUpdateRecurringOccurrence(t *testing.T) {
+	user, _ := randomUser(t)
+	rule := randomRecurringRule(user, randomCategory(user, "debit"), randomWallet(user, "BRL"))
+	params := db.GetRecurringRuleParams{ID: rule.ID, UserID: user.ID}
+	date := time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)
+	moved := time.Date(2024, 4, 12, 0, 0, 0, 0, time.UTC)
+	value := util.NewMoney(1750, 50)
+	skipped := db.RecurringOccurrence{
+		RuleID:         rule.ID,
+		OccurrenceDate: date,
+		Date:           date,
+		Title:          rule.Title,
+		Description:    rule.Description,
+		Value:          rule.Value,
+		Skipped:        true,
+	}
+	materialized := rule
+	materialized.MaterializedThrough = sql.NullTime{Time: date, Valid: true}
+	url := fmt.Sprintf("/recurring/%d/occurrences/2024-04-10", rule.ID)
+	request := updateRecurringOccurrenceRequest{Date: moved, Value: value}
+
+	expectRule := func(store *mockdb.MockStore, rule db.RecurringRule) {
+		expectExecTx(store)
+		store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(rule, nil)
+		store.EXPECT().LockRecurringRule(gomock.Any(), gomock.Eq(rule.ID)).Times(1).Return(rule, nil)
+	}
+	expectChanges := func(store *mockdb.MockStore, changes []db.RecurringOccurrence) {
+		store.EXPECT().GetRecurringOccurrences(gomock.Any(), gomock.Eq(db.GetRecurringOccurrencesParams{
+			RuleID:   rule.ID,
+			FromDate: date,
+			ToDate:   date,
+		})).Times(1).Return(changes, nil)
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectRule(store, rule)
+				expectChanges(store, []db.RecurringOccurrence{skipped})
+				store.EXPECT().UpsertRecurringOccurrence(gomock.Any(), gomock.Eq(db.UpsertRecurringOccurrenceParams{
+					RuleID:         rule.ID,
+					UserID:         user.ID,
+					OccurrenceDate: date,
+					Date:           moved,
+					Title:          rule.Title,
+					Description:    rule.Description,
+					Value:          value,
+				})).Times(1).Return(db.RecurringOccurrence{}, nil)
+			},
+			status: http.StatusOK,
+			response: recurring.Occurrence{
+				OccurrenceDate: date,
+				Date:           moved,
+				Title:          rule.Title,
+				Description:    rule.Description,
+				Value:          value,
+			},
+		},
+		{
+			name:      "NotAnOccurrence",
+			url:       fmt.Sprintf("/recurring/%d/occurrences/2024-04-11", rule.ID),
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectRule(store, rule)
+				store.EXPECT().GetRecurringOccurrences(gomock.Any(), gomock.Any()).Times(1).Return([]db.RecurringOccurrence{}, nil)
+				store.EXPECT().UpsertRecurringOccurrence(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusNotFound,
+			response: errorResponse(errOccurrenceNotFound),
+		},
+		{
+			name:      "AlreadyMaterialized",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectRule(store, materialized)
+				store.EXPECT().UpsertRecurringOccurrence(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusConflict,
+			response: errorResponse(errOccurrenceAlreadyCreated),
+		},
+		{
+			name:      "InvalidDate",
+			url:       fmt.Sprintf("/recurring/%d/occurrences/10-04-2024", rule.ID),
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "RuleNotFound",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.RecurringRule{}, sql.ErrNoRows)
+				store.EXPECT().LockRecurringRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "NotWritable",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectRule(store, rule)
+				expectChanges(store, nil)
+				store.EXPECT().UpsertRecurringOccurrence(gomock.Any(), gomock.Any()).Times(1).Return(db.RecurringOccurrence{}, sql.ErrNoRows)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeAccountsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectRule(store, rule)
+				expectChanges(store, nil)
+				store.EXPECT().UpsertRecurringOccurrence(gomock.Any(), gomock.Any()).Times(1).Return(db.RecurringOccurrence{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPut, url, testCases)
+}
+
+func TestSkipRecurringOccurrence(t *testing.T) {
+	user, _ := randomUser(t)
+	rule := randomRecurringRule(user, randomCategory(user, "debit"), randomWallet(user, "BRL"))
+	date := time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().GetRecurringRule(gomock.Any(), gomock.Any()).Times(1).Return(rule, nil)
+				store.EXPECT().LockRecurringRule(gomock.Any(), gomock.Eq(rule.ID)).Times(1).Return(rule, nil)
+				store.EXPECT().GetRecurringOccurrences(gomock.Any(), gomock.Any()).Times(1).Return([]db.RecurringOccurrence{}, nil)
+				store.EXPECT().UpsertRecurringOccurrence(gomock.Any(), gomock.Eq(db.UpsertRecurringOccurrenceParams{
+					RuleID:         rule.ID,
+					UserID:         user.ID,
+					OccurrenceDate: date,
+					Date:           date,
+					Title:          rule.Title,
+					Description:    rule.Description,
+					Value:          rule.Value,
+					Skipped:        true,
+				})).Times(1).Return(db.RecurringOccurrence{}, nil)
+			},
+			status: http.StatusOK,
+			response: recurring.Occurrence{
+				OccurrenceDate: date,
+				Date:           date,
+				Title:          rule.Title,
+				Description:    rule.Description,
+				Value:          rule.Value,
+				Skipped:        true,
+			},
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, fmt.Sprintf("/recurring/%d/occurrences/2024-04-10/skip", rule.ID), testCases)
+}
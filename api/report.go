@@ -0,0 +1,192 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/report"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxDailyReportDays limita o número de dias de um relatório agrupado por dia
+	maxDailyReportDays = 366
+	// maxGraphPoints limita o número de intervalos de um gráfico
+	maxGraphPoints = 366
+)
+
+var (
+	errReportTooLong = errors.New("daily reports cover at most 366 days")
+	errGraphTooLong  = errors.New("graphs have at most 366 points")
+)
+
+type getReportRequest struct {
+	HouseholdID int32     `form:"household_id"`
+	From        time.Time `form:"from" time_format:"2006-01-02"`
+	To          time.Time `form:"to" time_format:"2006-01-02"`
+	GroupBy     string    `form:"group_by" binding:"omitempty,oneof=day week month year category wallet tag"`
+}
+
+// getReport mostra receitas, despesas e saldo do período (por padrão o mês atual) agrupados
+// por tempo, categoria, carteira ou tag, comparados com o período anterior de mesmo tamanho
+func (server *Server) getReport(ctx *gin.Context) {
+	var request getReportRequest
+	err := ctx.ShouldBindQuery(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.GroupBy == "" {
+		request.GroupBy = report.GroupMonth
+	}
+	if request.From.IsZero() {
+		now := time.Now().UTC()
+		request.From = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	if request.To.IsZero() {
+		request.To = request.From.AddDate(0, 1, -1)
+	}
+	if request.From.After(request.To) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidPeriod))
+		return
+	}
+	if request.GroupBy == report.GroupDay && request.To.After(request.From.AddDate(0, 0, maxDailyReportDays-1)) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errReportTooLong))
+		return
+	}
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleViewer) {
+		return
+	}
+
+	period := report.Period{From: report.Date(request.From), To: report.Date(request.To)}
+	previousPeriod := report.Previous(period)
+	arg := db.GetReportParams{
+		GroupBy:     request.GroupBy,
+		UserID:      authClaims(ctx).UserID,
+		HouseholdID: householdID(request.HouseholdID),
+	}
+
+	arg.FromDate, arg.ToDate = period.From, period.To
+	current, err := server.store.GetReport(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	arg.FromDate, arg.ToDate = previousPeriod.From, previousPeriod.To
+	previous, err := server.store.GetReport(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	results := [][]db.GetReportRow{current, previous}
+
+	// por tag os grupos se sobrepõem, e os totais vêm de uma consulta sem agrupamento
+	if request.GroupBy == report.GroupTag {
+		arg.GroupBy = report.GroupTotal
+		for _, dates := range []report.Period{period, previousPeriod} {
+			arg.FromDate, arg.ToDate = dates.From, dates.To
+			rows, err := server.store.GetReport(ctx, arg)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+			results = append(results, rows)
+		}
+	}
+
+	for _, rows := range results {
+		for _, row := range rows {
+			if row.MissingRates > 0 {
+				ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errMissingExchangeRate))
+				return
+			}
+		}
+	}
+
+	response := report.Build(request.GroupBy, period, current, previous)
+	if request.GroupBy == report.GroupTag {
+		response.SetTotals(results[2], results[3])
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+type getReportGraphRequest struct {
+	HouseholdID int32     `form:"household_id"`
+	From        time.Time `form:"from" time_format:"2006-01-02"`
+	To          time.Time `form:"to" time_format:"2006-01-02"`
+	Interval    string    `form:"interval" binding:"omitempty,oneof=day week month"`
+	Cumulative  bool      `form:"cumulative"`
+}
+
+// getReportGraph mostra receitas, despesas e saldo por dia, semana ou mês do período (por
+// padrão os dias do mês atual), com zero nos intervalos sem transações
+func (server *Server) getReportGraph(ctx *gin.Context) {
+	var request getReportGraphRequest
+	err := ctx.ShouldBindQuery(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.Interval == "" {
+		request.Interval = report.GroupDay
+	}
+	if request.From.IsZero() {
+		now := time.Now().UTC()
+		request.From = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	if request.To.IsZero() {
+		request.To = request.From.AddDate(0, 1, -1)
+	}
+	if request.From.After(request.To) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidPeriod))
+		return
+	}
+	period := report.Period{From: report.Date(request.From), To: report.Date(request.To)}
+	if len(report.Intervals(request.Interval, period)) > maxGraphPoints {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errGraphTooLong))
+		return
+	}
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleViewer) {
+		return
+	}
+
+	// o acumulado começa nos totais anteriores ao período, não em zero
+	var opening db.GetReportTotalsBeforeRow
+	if request.Cumulative {
+		opening, err = server.store.GetReportTotalsBefore(ctx, db.GetReportTotalsBeforeParams{
+			UserID:      authClaims(ctx).UserID,
+			HouseholdID: householdID(request.HouseholdID),
+			BeforeDate:  period.From,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if opening.MissingRates > 0 {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errMissingExchangeRate))
+			return
+		}
+	}
+
+	rows, err := server.store.GetReport(ctx, db.GetReportParams{
+		GroupBy:     request.Interval,
+		FromDate:    period.From,
+		ToDate:      period.To,
+		UserID:      authClaims(ctx).UserID,
+		HouseholdID: householdID(request.HouseholdID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	for _, row := range rows {
+		if row.MissingRates > 0 {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errMissingExchangeRate))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, report.BuildGraph(request.Interval, period, request.Cumulative, opening, rows))
+}
@@ -0,0 +1,299 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/report"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetReport(t *testing.T) {
+	user, _ := randomUser(t)
+	householdID := randomID()
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	period := report.Period{From: from, To: to}
+	previousFrom := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	previousTo := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	current := []db.GetReportRow{
+		{Period: from, GroupID: 3, Label: "Mercado", Expense: util.NewMoney(250, 0)},
+		{Period: from, GroupID: 4, Label: "Salário", Income: util.NewMoney(4000, 0)},
+	}
+	previous := []db.GetReportRow{
+		{Period: previousFrom, GroupID: 3, Label: "Mercado", Expense: util.NewMoney(200, 0)},
+	}
+	tagged := []db.GetReportRow{
+		{Period: from, GroupID: 3, Label: "Férias", Expense: util.NewMoney(250, 0)},
+		{Period: from, GroupID: 4, Label: "Viagem", Expense: util.NewMoney(250, 0)},
+	}
+	total := []db.GetReportRow{{Period: from, Expense: util.NewMoney(250, 0)}}
+	params := func(groupBy string, from time.Time, to time.Time) db.GetReportParams {
+		return db.GetReportParams{GroupBy: groupBy, FromDate: from, UserID: user.ID, ToDate: to}
+	}
+	url := "/reports?from=2024-03-01&to=2024-03-31"
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			url:       url + "&group_by=category",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Eq(params("category", from, to))).Times(1).Return(current, nil)
+				store.EXPECT().GetReport(gomock.Any(), gomock.Eq(params("category", previousFrom, previousTo))).Times(1).Return(previous, nil)
+			},
+			status:   http.StatusOK,
+			response: report.Build("category", period, current, previous),
+		},
+		{
+			name:      "DefaultGroup",
+			setupAuth: withAPIKey(user, scopeReportsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Eq(params("month", from, to))).Times(1).Return([]db.GetReportRow{}, nil)
+				store.EXPECT().GetReport(gomock.Any(), gomock.Eq(params("month", previousFrom, previousTo))).Times(1).Return([]db.GetReportRow{}, nil)
+			},
+			status:   http.StatusOK,
+			response: report.Build("month", period, nil, nil),
+		},
+		{
+			name:      "Household",
+			url:       fmt.Sprintf("%s&household_id=%d", url, householdID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				arg := params("month", from, to)
+				arg.HouseholdID = sql.NullInt32{Int32: householdID, Valid: true}
+				store.EXPECT().GetReport(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.GetReportRow{}, nil)
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(1).Return([]db.GetReportRow{}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "MissingRate",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(1).Return(current, nil)
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(1).Return([]db.GetReportRow{{MissingRates: 1}}, nil)
+			},
+			status:   http.StatusUnprocessableEntity,
+			response: errorResponse(errMissingExchangeRate),
+		},
+		{
+			name:      "InvalidPeriod",
+			url:       "/reports?from=2024-03-31&to=2024-03-01",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errInvalidPeriod),
+		},
+		{
+			name:      "DailyTooLong",
+			url:       "/reports?from=2023-01-01&to=2024-01-02&group_by=day",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errReportTooLong),
+		},
+		{
+			// a transação com as tags 3 e 4 entra nos dois grupos, mas só uma vez nos totais
+			name:      "Tag",
+			url:       url + "&group_by=tag",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Eq(params("tag", from, to))).Times(1).Return(tagged, nil)
+				store.EXPECT().GetReport(gomock.Any(), gomock.Eq(params("tag", previousFrom, previousTo))).Times(1).Return(nil, nil)
+				store.EXPECT().GetReport(gomock.Any(), gomock.Eq(params("total", from, to))).Times(1).Return(total, nil)
+				store.EXPECT().GetReport(gomock.Any(), gomock.Eq(params("total", previousFrom, previousTo))).Times(1).Return(nil, nil)
+			},
+			status: http.StatusOK,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response report.Report
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, "tag", response.GroupBy)
+				require.Len(t, response.Buckets, 2)
+				require.Equal(t, "Viagem", response.Buckets[1].Label)
+				require.Equal(t, util.NewMoney(250, 0), response.Buckets[1].Expense)
+				require.Equal(t, util.NewMoney(250, 0), response.Totals.Expense)
+			},
+		},
+		{
+			name:      "InvalidGroup",
+			url:       url + "&group_by=payee",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, url, testCases)
+}
+
+func TestGetReportGraph(t *testing.T) {
+	user, _ := randomUser(t)
+	householdID := randomID()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	period := report.Period{From: from, To: to}
+	rows := []db.GetReportRow{
+		{Period: from, Income: util.NewMoney(900, 0), Expense: util.NewMoney(100, 0)},
+		{Period: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Expense: util.NewMoney(50, 0)},
+	}
+	params := db.GetReportParams{GroupBy: "month", FromDate: from, ToDate: to, UserID: user.ID}
+	url := "/reports/graph?from=2024-01-01&to=2024-03-31&interval=month"
+	opening := db.GetReportTotalsBeforeRow{Income: util.NewMoney(2000, 0), Expense: util.NewMoney(500, 0)}
+	openingParams := db.GetReportTotalsBeforeParams{UserID: user.ID, BeforeDate: from}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReportTotalsBefore(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetReport(gomock.Any(), gomock.Eq(params)).Times(1).Return(rows, nil)
+			},
+			status:   http.StatusOK,
+			response: report.BuildGraph("month", period, false, db.GetReportTotalsBeforeRow{}, rows),
+		},
+		{
+			name:      "Cumulative",
+			url:       url + "&cumulative=true",
+			setupAuth: withAPIKey(user, scopeReportsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReportTotalsBefore(gomock.Any(), gomock.Eq(openingParams)).Times(1).Return(opening, nil)
+				store.EXPECT().GetReport(gomock.Any(), gomock.Eq(params)).Times(1).Return(rows, nil)
+			},
+			status:   http.StatusOK,
+			response: report.BuildGraph("month", period, true, opening, rows),
+		},
+		{
+			name:      "CumulativeMissingRate",
+			url:       url + "&cumulative=true",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReportTotalsBefore(gomock.Any(), gomock.Any()).Times(1).Return(db.GetReportTotalsBeforeRow{MissingRates: 2}, nil)
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusUnprocessableEntity,
+			response: errorResponse(errMissingExchangeRate),
+		},
+		{
+			name:      "CumulativeInternalError",
+			url:       url + "&cumulative=true",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReportTotalsBefore(gomock.Any(), gomock.Any()).Times(1).Return(db.GetReportTotalsBeforeRow{}, errStore)
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:      "Household",
+			url:       fmt.Sprintf("%s&household_id=%d", url, householdID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				arg := params
+				arg.HouseholdID = sql.NullInt32{Int32: householdID, Valid: true}
+				store.EXPECT().GetReport(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.GetReportRow{}, nil)
+			},
+			status:   http.StatusOK,
+			response: report.BuildGraph("month", period, false, db.GetReportTotalsBeforeRow{}, nil),
+		},
+		{
+			name:      "MissingRate",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(1).Return([]db.GetReportRow{{MissingRates: 1}}, nil)
+			},
+			status:   http.StatusUnprocessableEntity,
+			response: errorResponse(errMissingExchangeRate),
+		},
+		{
+			name:      "TooManyPoints",
+			url:       "/reports/graph?from=2023-01-01&to=2024-01-02",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errGraphTooLong),
+		},
+		{
+			name:      "InvalidInterval",
+			url:       "/reports/graph?interval=year",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "InvalidPeriod",
+			url:       "/reports/graph?from=2024-03-31&to=2024-03-01",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errInvalidPeriod),
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReport(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, url, testCases)
+}
@@ -2,43 +2,158 @@ package api
 
 import (
 	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/mail"
+	"github.com/SraReaper/gofinance-backend/ratelimit"
+	"github.com/SraReaper/gofinance-backend/util"
 	"github.com/gin-gonic/gin"
 )
 
 type Server struct {
-	store  *db.SQLStore
-	router *gin.Engine
+	store          db.Store
+	tokenMaker     *util.TokenMaker
+	passwordHasher *util.PasswordHasher
+	mailer         mail.Mailer
+	appURL         string
+	rateLimitStore ratelimit.Store
+	rateLimit      util.RateLimitConfig
+	router         *gin.Engine
 }
 
 // newServer função para criar rotas
-func NewServer(store *db.SQLStore) *Server {
-	server := &Server{store: store}
+func NewServer(store db.Store, tokenMaker *util.TokenMaker, passwordHasher *util.PasswordHasher, mailer mail.Mailer, appURL string, rateLimitStore ratelimit.Store, rateLimit util.RateLimitConfig) (*Server, error) {
+	server := &Server{
+		store:          store,
+		tokenMaker:     tokenMaker,
+		passwordHasher: passwordHasher,
+		mailer:         mailer,
+		appURL:         appURL,
+		rateLimitStore: rateLimitStore,
+		rateLimit:      rateLimit,
+	}
 	router := gin.Default()
+	// por padrão o gin confia em qualquer proxy, e o X-Forwarded-For decidiria o IP dos
+	// limites de requisições e das sessões
+	err := router.SetTrustedProxies(rateLimit.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	generalLimit := ratelimit.Limit{Rate: rateLimit.RequestsPerSecond, Burst: rateLimit.Burst}
+	if generalLimit.Enabled() {
+		router.Use(rateLimitMiddleware(rateLimitStore, generalLimit))
+	}
 
 	//Rotas
+	//Login
+	router.POST("/login", server.login)
+	router.POST("/login/2fa", server.loginTwoFactor)
+	router.POST("/token/refresh", server.refreshToken)
+	router.GET("/.well-known/jwks.json", server.getJWKS)
+	router.POST("/password/forgot", server.forgotPassword)
+	router.POST("/password/reset", server.resetPassword)
 	//User
 	router.POST("/user", server.createUser)
-	router.GET("/user/:username", server.getUser)
-	router.GET("/user/id/:id", server.getUserById)
+	router.POST("/user/verify-email", server.verifyEmail)
+
+	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker, server.store))
+	authRoutes.POST("/logout", server.logout)
+	authRoutes.POST("/logout/all", server.logoutAll)
+	//User
+	authRoutes.POST("/user/verify-email/resend", server.resendVerificationEmail)
+	authRoutes.POST("/user/2fa/enroll", server.enrollTwoFactor)
+	authRoutes.POST("/user/2fa/confirm", server.confirmTwoFactor)
+	authRoutes.POST("/user/2fa/disable", server.disableTwoFactor)
+	authRoutes.PUT("/user/currency", server.updateUserBaseCurrency)
+	authRoutes.GET("/user/:username", server.getUser)
+	authRoutes.GET("/user/id/:id", server.getUserById)
+	//API keys
+	authRoutes.POST("/api-keys", server.createAPIKey)
+	authRoutes.GET("/api-keys", server.getAPIKeys)
+	authRoutes.DELETE("/api-keys/:id", server.revokeAPIKey)
+	//Household
+	authRoutes.POST("/households", server.createHousehold)
+	authRoutes.GET("/households", server.getHouseholds)
+	authRoutes.GET("/households/invitations", server.getHouseholdInvitations)
+	authRoutes.POST("/households/invitations/accept", server.acceptHouseholdInvitation)
+	authRoutes.POST("/households/invitations/decline", server.declineHouseholdInvitation)
+	authRoutes.GET("/households/:id", server.getHousehold)
+	authRoutes.POST("/households/:id/invitations", server.createHouseholdInvitation)
+	authRoutes.PUT("/households/:id/members/:user_id", server.updateHouseholdMember)
+	authRoutes.DELETE("/households/:id/members/:user_id", server.deleteHouseholdMember)
+
+	// rotas que aceitam também API keys, com o escopo exigido por rota
+	apiKeyRoutes := router.Group("/").Use(apiKeyAuthMiddleware(server.tokenMaker, server.store))
 	//Category
-	router.POST("/category", server.createCategory)
-	router.GET("/category/id/:id", server.getCategory)
-	router.GET("/category", server.getCategories)
-	router.DELETE("/category/:id", server.deleteCategory)
-	router.PUT("/category/:id", server.updateCategory)
+	apiKeyRoutes.POST("/category", requireScope(scopeCategoriesWrite), server.createCategory)
+	apiKeyRoutes.GET("/category/id/:id", requireScope(scopeCategoriesRead), server.getCategory)
+	apiKeyRoutes.GET("/category", requireScope(scopeCategoriesRead), server.getCategories)
+	apiKeyRoutes.DELETE("/category/:id", requireScope(scopeCategoriesWrite), server.deleteCategory)
+	apiKeyRoutes.PUT("/category/:id", requireScope(scopeCategoriesWrite), server.updateCategory)
 	//Account
-	router.POST("/account", server.createAccount)
-	router.GET("/account/id/:id", server.getAccount)
-	router.GET("/account", server.getAccounts)
-	router.GET("/account/graph/:user_id/:type", server.getAccountGraph)
-	router.GET("/account/reports/:user_id/:type", server.getAccountReports)
-	router.DELETE("/account/:id", server.deleteAccount)
-	router.PUT("/account/:id", server.updateAccount)
-	//Login
-	router.POST("/login", server.login)
+	apiKeyRoutes.POST("/account", requireScope(scopeAccountsWrite), server.createAccount)
+	apiKeyRoutes.GET("/account/id/:id", requireScope(scopeAccountsRead), server.getAccount)
+	apiKeyRoutes.GET("/account", requireScope(scopeAccountsRead), server.getAccounts)
+	apiKeyRoutes.GET("/account/reports/:type", requireScope(scopeReportsRead), server.getAccountReports)
+	apiKeyRoutes.GET("/reports", requireScope(scopeReportsRead), server.getReport)
+	apiKeyRoutes.GET("/reports/graph", requireScope(scopeReportsRead), server.getReportGraph)
+	apiKeyRoutes.DELETE("/account/:id", requireScope(scopeAccountsWrite), server.deleteAccount)
+	apiKeyRoutes.PUT("/account/:id", requireScope(scopeAccountsWrite), server.updateAccount)
+	apiKeyRoutes.GET("/account/:id/tags", requireScope(scopeAccountsRead), server.getAccountTags)
+	apiKeyRoutes.PUT("/account/:id/tags", requireScope(scopeAccountsWrite), server.setAccountTags)
+	//Tag
+	apiKeyRoutes.POST("/tags", requireScope(scopeCategoriesWrite), server.createTag)
+	apiKeyRoutes.GET("/tags", requireScope(scopeCategoriesRead), server.getTags)
+	apiKeyRoutes.DELETE("/tags/:id", requireScope(scopeCategoriesWrite), server.deleteTag)
+	//Wallet
+	apiKeyRoutes.POST("/wallets", requireScope(scopeWalletsWrite), server.createWallet)
+	apiKeyRoutes.GET("/wallets", requireScope(scopeWalletsRead), server.getWallets)
+	apiKeyRoutes.GET("/wallets/:id", requireScope(scopeWalletsRead), server.getWallet)
+	apiKeyRoutes.GET("/wallets/:id/balances", requireScope(scopeWalletsRead), server.getWalletBalances)
+	apiKeyRoutes.PUT("/wallets/:id", requireScope(scopeWalletsWrite), server.updateWallet)
+	apiKeyRoutes.DELETE("/wallets/:id", requireScope(scopeWalletsWrite), server.deleteWallet)
+	//Transfer
+	apiKeyRoutes.POST("/transfers", requireScope(scopeAccountsWrite), server.createTransfer)
+	apiKeyRoutes.GET("/transfers/:id", requireScope(scopeAccountsRead), server.getTransfer)
+	apiKeyRoutes.PUT("/transfers/:id", requireScope(scopeAccountsWrite), server.updateTransfer)
+	apiKeyRoutes.DELETE("/transfers/:id", requireScope(scopeAccountsWrite), server.deleteTransfer)
+	//Recurring
+	apiKeyRoutes.POST("/recurring", requireScope(scopeAccountsWrite), server.createRecurringRule)
+	apiKeyRoutes.GET("/recurring", requireScope(scopeAccountsRead), server.getRecurringRules)
+	apiKeyRoutes.GET("/recurring/:id", requireScope(scopeAccountsRead), server.getRecurringRule)
+	apiKeyRoutes.PUT("/recurring/:id", requireScope(scopeAccountsWrite), server.updateRecurringRule)
+	apiKeyRoutes.DELETE("/recurring/:id", requireScope(scopeAccountsWrite), server.deleteRecurringRule)
+	apiKeyRoutes.GET("/recurring/:id/occurrences", requireScope(scopeAccountsRead), server.getRecurringOccurrences)
+	apiKeyRoutes.PUT("/recurring/:id/occurrences/:date", requireScope(scopeAccountsWrite), server.updateRecurringOccurrence)
+	apiKeyRoutes.POST("/recurring/:id/occurrences/:date/skip", requireScope(scopeAccountsWrite), server.skipRecurringOccurrence)
+	//Budgets
+	apiKeyRoutes.POST("/budgets", requireScope(scopeBudgetsWrite), server.createBudget)
+	apiKeyRoutes.GET("/budgets", requireScope(scopeBudgetsRead), server.getBudgets)
+	apiKeyRoutes.GET("/budgets/:id", requireScope(scopeBudgetsRead), server.getBudget)
+	apiKeyRoutes.PUT("/budgets/:id", requireScope(scopeBudgetsWrite), server.updateBudget)
+	apiKeyRoutes.DELETE("/budgets/:id", requireScope(scopeBudgetsWrite), server.deleteBudget)
+	apiKeyRoutes.GET("/budget/:year/:month", requireScope(scopeBudgetsRead), server.getBudgetMonth)
+	//Goals
+	apiKeyRoutes.POST("/goals", requireScope(scopeGoalsWrite), server.createGoal)
+	apiKeyRoutes.GET("/goals", requireScope(scopeGoalsRead), server.getGoals)
+	apiKeyRoutes.GET("/goals/:id", requireScope(scopeGoalsRead), server.getGoal)
+	apiKeyRoutes.PUT("/goals/:id", requireScope(scopeGoalsWrite), server.updateGoal)
+	apiKeyRoutes.DELETE("/goals/:id", requireScope(scopeGoalsWrite), server.deleteGoal)
+	apiKeyRoutes.GET("/goals/:id/contributions", requireScope(scopeGoalsRead), server.getGoalContributions)
+	apiKeyRoutes.POST("/goals/:id/contributions", requireScope(scopeGoalsWrite), server.createGoalContribution)
+	apiKeyRoutes.DELETE("/goals/:id/contributions/:contribution_id", requireScope(scopeGoalsWrite), server.deleteGoalContribution)
+	//Imports
+	apiKeyRoutes.POST("/imports/preview", requireScope(scopeAccountsWrite), server.previewImport)
+	apiKeyRoutes.POST("/imports", requireScope(scopeAccountsWrite), server.commitImport)
+	apiKeyRoutes.POST("/import-profiles", requireScope(scopeAccountsWrite), server.createImportProfile)
+	apiKeyRoutes.GET("/import-profiles", requireScope(scopeAccountsRead), server.getImportProfiles)
+	apiKeyRoutes.GET("/import-profiles/:id", requireScope(scopeAccountsRead), server.getImportProfile)
+	apiKeyRoutes.PUT("/import-profiles/:id", requireScope(scopeAccountsWrite), server.updateImportProfile)
+	apiKeyRoutes.DELETE("/import-profiles/:id", requireScope(scopeAccountsWrite), server.deleteImportProfile)
+	//Exchange rates
+	apiKeyRoutes.GET("/exchange-rates", requireScope(scopeReportsRead), server.getExchangeRate)
 
 	server.router = router
-	return server
+	return server, nil
 }
 
 func (server *Server) Start(address string) error {
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errInvalidRefreshToken = errors.New("refresh token is invalid")
+	errRefreshTokenReused  = errors.New("refresh token reuse detected, session revoked")
+	errSessionRevoked      = errors.New("session is revoked")
+)
+
+type sessionResponse struct {
+	UserID                int32     `json:"user_id"`
+	Token                 string    `json:"token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+// createSession abre uma sessão nova e gera o par access/refresh token
+func (server *Server) createSession(ctx *gin.Context, user db.User) (sessionResponse, error) {
+	refreshToken, refreshTokenHash, err := util.NewOpaqueToken()
+	if err != nil {
+		return sessionResponse{}, err
+	}
+
+	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
+		UserID:           user.ID,
+		RefreshTokenHash: refreshTokenHash,
+		UserAgent:        ctx.Request.UserAgent(),
+		ClientIp:         ctx.ClientIP(),
+		ExpiresAt:        time.Now().UTC().Add(server.tokenMaker.RefreshDuration()),
+	})
+	if err != nil {
+		return sessionResponse{}, err
+	}
+
+	return server.newSessionResponse(user, session.ID, session.ExpiresAt, refreshToken)
+}
+
+func (server *Server) newSessionResponse(user db.User, sessionID int32, sessionExpiresAt time.Time, refreshToken string) (sessionResponse, error) {
+	accessToken, claims, err := server.tokenMaker.CreateToken(user.ID, user.Username, sessionID)
+	if err != nil {
+		return sessionResponse{}, err
+	}
+
+	return sessionResponse{
+		UserID:                user.ID,
+		Token:                 accessToken,
+		AccessTokenExpiresAt:  claims.ExpiresAt.Time,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: sessionExpiresAt,
+	}, nil
+}
+
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// refreshToken troca o refresh token por um par novo e detecta reuso de tokens já rotacionados
+func (server *Server) refreshToken(ctx *gin.Context) {
+	var request refreshTokenRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	newRefreshToken, newRefreshTokenHash, err := util.NewOpaqueToken()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	refreshTokenHash := util.HashOpaqueToken(request.RefreshToken)
+	session, err := server.store.RotateSessionRefreshToken(ctx, db.RotateSessionRefreshTokenParams{
+		NewRefreshTokenHash: newRefreshTokenHash,
+		OldRefreshTokenHash: refreshTokenHash,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			server.handleInvalidRefreshToken(ctx, refreshTokenHash)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUserById(ctx, session.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response, err := server.newSessionResponse(user, session.ID, session.ExpiresAt, newRefreshToken)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// handleInvalidRefreshToken revoga a sessão quando um token já rotacionado é apresentado de novo
+func (server *Server) handleInvalidRefreshToken(ctx *gin.Context, refreshTokenHash string) {
+	sessionID, err := server.store.GetRotatedRefreshTokenSession(ctx, refreshTokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidRefreshToken))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.store.RevokeSession(ctx, sessionID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusUnauthorized, errorResponse(errRefreshTokenReused))
+}
+
+// logout revoga a sessão do token atual
+func (server *Server) logout(ctx *gin.Context) {
+	err := server.store.RevokeSession(ctx, authClaims(ctx).SessionID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
+
+// logoutAll revoga todas as sessões do usuário
+func (server *Server) logoutAll(ctx *gin.Context) {
+	_, err := server.store.RevokeUserSessions(ctx, authClaims(ctx).UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
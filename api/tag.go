@@ -0,0 +1,215 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+var (
+	errTagExists         = errors.New("a tag with this name already exists")
+	errTagOtherHousehold = errors.New("tag does not belong to the same household")
+)
+
+type createTagRequest struct {
+	HouseholdID int32  `json:"household_id"`
+	Name        string `json:"name" binding:"required"`
+}
+
+// createTag cria uma tag do usuário ou do household
+func (server *Server) createTag(ctx *gin.Context) {
+	var request createTagRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleEditor) {
+		return
+	}
+
+	tag, err := server.store.CreateTag(ctx, db.CreateTagParams{
+		UserID:      authClaims(ctx).UserID,
+		HouseholdID: householdID(request.HouseholdID),
+		Name:        request.Name,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errHouseholdForbidden))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(errTagExists))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tag)
+}
+
+// getTags lista as tags do usuário ou do household
+func (server *Server) getTags(ctx *gin.Context) {
+	var request householdScopeRequest
+	err := ctx.ShouldBindQuery(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleViewer) {
+		return
+	}
+
+	tags, err := server.store.GetTags(ctx, db.GetTagsParams{
+		HouseholdID: householdID(request.HouseholdID),
+		UserID:      authClaims(ctx).UserID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tags)
+}
+
+type tagRequest struct {
+	ID int32 `uri:"id" binding:"required"`
+}
+
+// deleteTag deleta a tag e tira ela das transações
+func (server *Server) deleteTag(ctx *gin.Context) {
+	var request tagRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.DeleteTagParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	}
+
+	rowsDeleted, err := server.store.DeleteTag(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rowsDeleted == 0 {
+		_, err = server.store.GetTag(ctx, db.GetTagParams(arg))
+		notWritable(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
+
+// getAccountTags lista as tags da transação
+func (server *Server) getAccountTags(ctx *gin.Context) {
+	var request tagRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, db.GetAccountParams{ID: request.ID, UserID: authClaims(ctx).UserID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	tags, err := server.store.GetAccountTags(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tags)
+}
+
+type setAccountTagsRequest struct {
+	TagIDs []int32 `json:"tag_ids" binding:"dive,gt=0"`
+}
+
+// setAccountTags troca as tags da transação pelas da lista; a lista vazia tira todas. As tags
+// precisam ser do mesmo escopo da transação
+func (server *Server) setAccountTags(ctx *gin.Context) {
+	var uri tagRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var request setAccountTagsRequest
+	err = ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userID := authClaims(ctx).UserID
+	account, err := server.store.GetAccount(ctx, db.GetAccountParams{ID: uri.ID, UserID: userID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if account.HouseholdID.Valid && !server.authorizeHousehold(ctx, account.HouseholdID.Int32, householdRoleEditor) {
+		return
+	}
+
+	var tags []db.Tag
+	err = server.store.ExecTx(ctx, func(q db.Querier) error {
+		for _, tagID := range request.TagIDs {
+			tag, err := q.GetTag(ctx, db.GetTagParams{ID: tagID, UserID: userID})
+			if err != nil {
+				return err
+			}
+			if tag.HouseholdID != account.HouseholdID {
+				return errTagOtherHousehold
+			}
+		}
+
+		err := q.DeleteAccountTags(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+		for _, tagID := range request.TagIDs {
+			err = q.AddAccountTag(ctx, db.AddAccountTagParams{AccountID: account.ID, TagID: tagID})
+			if err != nil {
+				return err
+			}
+		}
+
+		tags, err = q.GetAccountTags(ctx, account.ID)
+		return err
+	})
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errTagOtherHousehold:
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tags)
+}
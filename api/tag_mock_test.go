@@ -0,0 +1,366 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/lib/pq"
+	"go.uber.org/mock/gomock"
+)
+
+func randomTag(user db.User) db.Tag {
+	return db.Tag{
+		ID:        randomID(),
+		UserID:    user.ID,
+		Name:      util.RandomString(8),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func TestCreateTag(t *testing.T) {
+	user, _ := randomUser(t)
+	tag := randomTag(user)
+	householdID := randomID()
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      createTagRequest{Name: tag.Name},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTag(gomock.Any(), gomock.Eq(db.CreateTagParams{UserID: user.ID, Name: tag.Name})).Times(1).Return(tag, nil)
+			},
+			status:   http.StatusOK,
+			response: tag,
+		},
+		{
+			name:      "Household",
+			body:      createTagRequest{HouseholdID: householdID, Name: tag.Name},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleEditor)
+				store.EXPECT().CreateTag(gomock.Any(), gomock.Eq(db.CreateTagParams{
+					UserID:      user.ID,
+					HouseholdID: sql.NullInt32{Int32: householdID, Valid: true},
+					Name:        tag.Name,
+				})).Times(1).Return(tag, nil)
+			},
+			status:   http.StatusOK,
+			response: tag,
+		},
+		{
+			name:      "HouseholdViewer",
+			body:      createTagRequest{HouseholdID: householdID, Name: tag.Name},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				store.EXPECT().CreateTag(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "Duplicate",
+			body:      createTagRequest{Name: tag.Name},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTag(gomock.Any(), gomock.Any()).Times(1).Return(db.Tag{}, &pq.Error{Code: "23505"})
+			},
+			status:   http.StatusConflict,
+			response: errorResponse(errTagExists),
+		},
+		{
+			name:      "BadRequest",
+			body:      createTagRequest{},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTag(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "MissingScope",
+			body:      createTagRequest{Name: tag.Name},
+			setupAuth: withAPIKey(user, scopeCategoriesRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTag(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name:      "InternalError",
+			body:      createTagRequest{Name: tag.Name},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTag(gomock.Any(), gomock.Any()).Times(1).Return(db.Tag{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/tags", testCases)
+}
+
+func TestGetTags(t *testing.T) {
+	user, _ := randomUser(t)
+	tags := []db.Tag{randomTag(user), randomTag(user)}
+	householdID := randomID()
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withAPIKey(user, scopeCategoriesRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTags(gomock.Any(), gomock.Eq(db.GetTagsParams{UserID: user.ID})).Times(1).Return(tags, nil)
+			},
+			status:   http.StatusOK,
+			response: tags,
+		},
+		{
+			name:      "Household",
+			url:       fmt.Sprintf("/tags?household_id=%d", householdID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				store.EXPECT().GetTags(gomock.Any(), gomock.Eq(db.GetTagsParams{
+					HouseholdID: sql.NullInt32{Int32: householdID, Valid: true},
+					UserID:      user.ID,
+				})).Times(1).Return(tags, nil)
+			},
+			status:   http.StatusOK,
+			response: tags,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTags(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/tags", testCases)
+}
+
+func TestDeleteTag(t *testing.T) {
+	user, _ := randomUser(t)
+	tag := randomTag(user)
+	arg := db.DeleteTagParams{ID: tag.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteTag(gomock.Any(), gomock.Eq(arg)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteTag(gomock.Any(), gomock.Eq(arg)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetTag(gomock.Any(), gomock.Eq(db.GetTagParams(arg))).Times(1).Return(db.Tag{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "NotWritable",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteTag(gomock.Any(), gomock.Eq(arg)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetTag(gomock.Any(), gomock.Eq(db.GetTagParams(arg))).Times(1).Return(tag, nil)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "InvalidID",
+			url:       "/tags/0",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteTag(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	runRouteTests(t, http.MethodDelete, fmt.Sprintf("/tags/%d", tag.ID), testCases)
+}
+
+func TestSetAccountTags(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user, randomCategory(user, "debit"), randomWallet(user, "BRL"))
+	tags := []db.Tag{randomTag(user), randomTag(user)}
+	householdID := randomID()
+	householdAccount := account
+	householdAccount.HouseholdID = sql.NullInt32{Int32: householdID, Valid: true}
+	householdTag := tags[0]
+	householdTag.HouseholdID = householdAccount.HouseholdID
+
+	expectAccount := func(store *mockdb.MockStore, account db.Account) {
+		store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(db.GetAccountParams{ID: account.ID, UserID: user.ID})).Times(1).Return(account, nil)
+	}
+	expectTag := func(store *mockdb.MockStore, tag db.Tag) {
+		store.EXPECT().GetTag(gomock.Any(), gomock.Eq(db.GetTagParams{ID: tag.ID, UserID: user.ID})).Times(1).Return(tag, nil)
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      setAccountTagsRequest{TagIDs: []int32{tags[0].ID, tags[1].ID}},
+			setupAuth: withAPIKey(user, scopeAccountsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectAccount(store, account)
+				expectExecTx(store)
+				expectTag(store, tags[0])
+				expectTag(store, tags[1])
+				store.EXPECT().DeleteAccountTags(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(nil)
+				for _, tag := range tags {
+					store.EXPECT().AddAccountTag(gomock.Any(), gomock.Eq(db.AddAccountTagParams{AccountID: account.ID, TagID: tag.ID})).Times(1).Return(nil)
+				}
+				store.EXPECT().GetAccountTags(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(tags, nil)
+			},
+			status:   http.StatusOK,
+			response: tags,
+		},
+		{
+			name:      "Clear",
+			body:      setAccountTagsRequest{},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectAccount(store, account)
+				expectExecTx(store)
+				store.EXPECT().DeleteAccountTags(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(nil)
+				store.EXPECT().AddAccountTag(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetAccountTags(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return([]db.Tag{}, nil)
+			},
+			status:   http.StatusOK,
+			response: []db.Tag{},
+		},
+		{
+			name:      "Household",
+			body:      setAccountTagsRequest{TagIDs: []int32{householdTag.ID}},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectAccount(store, householdAccount)
+				expectHouseholdRole(store, householdID, user, householdRoleEditor)
+				expectExecTx(store)
+				expectTag(store, householdTag)
+				store.EXPECT().DeleteAccountTags(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(nil)
+				store.EXPECT().AddAccountTag(gomock.Any(), gomock.Eq(db.AddAccountTagParams{AccountID: account.ID, TagID: householdTag.ID})).Times(1).Return(nil)
+				store.EXPECT().GetAccountTags(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return([]db.Tag{householdTag}, nil)
+			},
+			status:   http.StatusOK,
+			response: []db.Tag{householdTag},
+		},
+		{
+			name:      "HouseholdViewer",
+			body:      setAccountTagsRequest{TagIDs: []int32{householdTag.ID}},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectAccount(store, householdAccount)
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				store.EXPECT().DeleteAccountTags(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "TagOtherHousehold",
+			body:      setAccountTagsRequest{TagIDs: []int32{householdTag.ID}},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectAccount(store, account)
+				expectExecTx(store)
+				expectTag(store, householdTag)
+				store.EXPECT().DeleteAccountTags(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errTagOtherHousehold),
+		},
+		{
+			name:      "TagNotFound",
+			body:      setAccountTagsRequest{TagIDs: []int32{tags[0].ID}},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectAccount(store, account)
+				expectExecTx(store)
+				store.EXPECT().GetTag(gomock.Any(), gomock.Any()).Times(1).Return(db.Tag{}, sql.ErrNoRows)
+				store.EXPECT().DeleteAccountTags(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "AccountNotFound",
+			body:      setAccountTagsRequest{TagIDs: []int32{tags[0].ID}},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "InvalidTagID",
+			body:      setAccountTagsRequest{TagIDs: []int32{0}},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "MissingScope",
+			body:      setAccountTagsRequest{},
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+	}
+
+	runRouteTests(t, http.MethodPut, fmt.Sprintf("/account/%d/tags", account.ID), testCases)
+}
+
+func TestGetAccountTags(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user, randomCategory(user, "debit"), randomWallet(user, "BRL"))
+	tags := []db.Tag{randomTag(user)}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(db.GetAccountParams{ID: account.ID, UserID: user.ID})).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountTags(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(tags, nil)
+			},
+			status:   http.StatusOK,
+			response: tags,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().GetAccountTags(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, fmt.Sprintf("/account/%d/tags", account.ID), testCases)
+}
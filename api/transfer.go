@@ -0,0 +1,306 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultTransferTitle é o título das transações de uma transferência sem título
+const defaultTransferTitle = "Transferência"
+
+var (
+	errTransferValue        = errors.New("transfer values must be positive")
+	errTransferToValue      = errors.New("to_value is required when the wallets have different currencies")
+	errTransferSameCurrency = errors.New("to_value must equal value when the wallets have the same currency")
+)
+
+// transferToValue define o valor creditado no destino: na mesma moeda é sempre o valor
+// debitado; entre moedas diferentes precisa ser informado
+func transferToValue(fromCurrency string, toCurrency string, value util.Money, toValue util.Money) (util.Money, error) {
+	if value <= 0 || toValue < 0 {
+		return 0, errTransferValue
+	}
+	if fromCurrency == toCurrency {
+		if toValue != 0 && toValue != value {
+			return 0, errTransferSameCurrency
+		}
+		return value, nil
+	}
+	if toValue == 0 {
+		return 0, errTransferToValue
+	}
+	return toValue, nil
+}
+
+type createTransferRequest struct {
+	HouseholdID  int32      `json:"household_id"`
+	FromWalletID int32      `json:"from_wallet_id" binding:"required"`
+	ToWalletID   int32      `json:"to_wallet_id" binding:"required,nefield=FromWalletID"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	Value        util.Money `json:"value" binding:"required"`
+	ToValue      util.Money `json:"to_value"`
+	Date         time.Time  `json:"date" binding:"required"`
+}
+
+// createTransfer move dinheiro entre duas carteiras do mesmo escopo
+func (server *Server) createTransfer(ctx *gin.Context) {
+	var request createTransferRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	claims := authClaims(ctx)
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleEditor) {
+		return
+	}
+
+	wallets := make([]db.GetWalletRow, 2)
+	for i, walletID := range []int32{request.FromWalletID, request.ToWalletID} {
+		wallets[i], err = server.store.GetWallet(ctx, db.GetWalletParams{ID: walletID, UserID: claims.UserID})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if wallets[i].HouseholdID != householdID(request.HouseholdID) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errWalletOtherHousehold))
+			return
+		}
+	}
+
+	toValue, err := transferToValue(wallets[0].Currency, wallets[1].Currency, request.Value, request.ToValue)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.Title == "" {
+		request.Title = defaultTransferTitle
+	}
+
+	result, err := server.store.TransferTx(ctx, db.TransferTxParams{
+		UserID:       claims.UserID,
+		HouseholdID:  householdID(request.HouseholdID),
+		FromWalletID: request.FromWalletID,
+		ToWalletID:   request.ToWalletID,
+		Title:        request.Title,
+		Description:  request.Description,
+		Value:        request.Value,
+		ToValue:      toValue,
+		Date:         request.Date,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errHouseholdForbidden))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type transferRequest struct {
+	ID int32 `uri:"id" binding:"required"`
+}
+
+// getTransferResult busca a transferência visível para o usuário com as duas transações
+func (server *Server) getTransferResult(ctx *gin.Context, id int32) (db.TransferTxResult, error) {
+	transfer, err := server.store.GetTransfer(ctx, db.GetTransferParams{ID: id, UserID: authClaims(ctx).UserID})
+	if err != nil {
+		return db.TransferTxResult{}, err
+	}
+
+	accounts, err := server.store.GetTransferAccounts(ctx, transfer.ID)
+	if err != nil {
+		return db.TransferTxResult{}, err
+	}
+	if len(accounts) != 2 {
+		return db.TransferTxResult{}, errors.New("transfer is missing one of its accounts")
+	}
+	return db.TransferTxResult{Transfer: transfer, FromAccount: accounts[0], ToAccount: accounts[1]}, nil
+}
+
+// getTransfer mostra a transferência com o débito e o crédito
+func (server *Server) getTransfer(ctx *gin.Context) {
+	var request transferRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.getTransferResult(ctx, request.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type updateTransferRequest struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Value       util.Money `json:"value" binding:"required"`
+	ToValue     util.Money `json:"to_value"`
+	Date        time.Time  `json:"date" binding:"required"`
+}
+
+// updateTransfer altera a transferência e as duas transações
+func (server *Server) updateTransfer(ctx *gin.Context) {
+	var uri transferRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var request updateTransferRequest
+	err = ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	current, err := server.getTransferResult(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	toValue, err := transferToValue(current.FromAccount.Currency, current.ToAccount.Currency, request.Value, request.ToValue)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.Title == "" {
+		request.Title = current.Transfer.Title
+	}
+
+	result, ok := server.saveTransfer(ctx, db.UpdateTransferParams{
+		ID:          current.Transfer.ID,
+		UserID:      authClaims(ctx).UserID,
+		Title:       request.Title,
+		Description: request.Description,
+		Value:       request.Value,
+		ToValue:     toValue,
+		Date:        request.Date,
+	})
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// saveTransfer grava a alteração; se falhar, já responde e retorna false
+func (server *Server) saveTransfer(ctx *gin.Context, arg db.UpdateTransferParams) (db.TransferTxResult, bool) {
+	result, err := server.store.UpdateTransferTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// a transferência é visível, então falta permissão de escrita
+			ctx.JSON(http.StatusForbidden, errorResponse(errHouseholdForbidden))
+			return result, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return result, false
+	}
+	return result, true
+}
+
+// updateTransferSide aplica a alteração de um lado da transferência feita pela rota de contas;
+// na mesma moeda o outro lado acompanha o valor
+func (server *Server) updateTransferSide(ctx *gin.Context, account db.Account, request updateAccountRequest) {
+	current, err := server.getTransferResult(ctx, account.TransferID.Int32)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	value, toValue := current.Transfer.Value, current.Transfer.ToValue
+	if account.ID == current.FromAccount.ID {
+		value = request.Value
+	} else {
+		toValue = request.Value
+	}
+	if current.FromAccount.Currency == current.ToAccount.Currency {
+		value, toValue = request.Value, request.Value
+	}
+	if value <= 0 || toValue <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errTransferValue))
+		return
+	}
+	if request.Title == "" {
+		request.Title = current.Transfer.Title
+	}
+
+	result, ok := server.saveTransfer(ctx, db.UpdateTransferParams{
+		ID:          current.Transfer.ID,
+		UserID:      authClaims(ctx).UserID,
+		Title:       request.Title,
+		Description: request.Description,
+		Value:       value,
+		ToValue:     toValue,
+		Date:        current.Transfer.Date,
+	})
+	if !ok {
+		return
+	}
+
+	if account.ID == result.FromAccount.ID {
+		ctx.JSON(http.StatusOK, result.FromAccount)
+		return
+	}
+	ctx.JSON(http.StatusOK, result.ToAccount)
+}
+
+// deleteTransfer apaga a transferência e as duas transações
+func (server *Server) deleteTransfer(ctx *gin.Context) {
+	var request transferRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.removeTransfer(ctx, request.ID)
+}
+
+func (server *Server) removeTransfer(ctx *gin.Context, id int32) {
+	arg := db.DeleteTransferParams{
+		ID:     id,
+		UserID: authClaims(ctx).UserID,
+	}
+
+	rowsDeleted, err := server.store.DeleteTransfer(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rowsDeleted == 0 {
+		_, err = server.store.GetTransfer(ctx, db.GetTransferParams(arg))
+		notWritable(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
@@ -0,0 +1,426 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateTransfer(t *testing.T) {
+	user, _ := randomUser(t)
+	from := randomWallet(user, "BRL")
+	to := randomWallet(user, "BRL")
+	usd := randomWallet(user, "USD")
+	result := randomTransfer(user, from, to)
+	date := time.Now().UTC().Truncate(time.Second)
+
+	request := createTransferRequest{FromWalletID: from.ID, ToWalletID: to.ID, Value: util.NewMoney(100, 0), Date: date}
+	toUSD := createTransferRequest{FromWalletID: from.ID, ToWalletID: usd.ID, Value: util.NewMoney(100, 0), Date: date}
+	householdWallet := to
+	householdWallet.HouseholdID = sql.NullInt32{Int32: randomID(), Valid: true}
+
+	expectWallets := func(store *mockdb.MockStore, from db.GetWalletRow, to db.GetWalletRow) {
+		store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(db.GetWalletParams{ID: from.ID, UserID: user.ID})).Times(1).Return(from, nil)
+		store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(db.GetWalletParams{ID: to.ID, UserID: user.ID})).Times(1).Return(to, nil)
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectWallets(store, from, to)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+					UserID:       user.ID,
+					FromWalletID: from.ID,
+					ToWalletID:   to.ID,
+					Title:        defaultTransferTitle,
+					Value:        request.Value,
+					ToValue:      request.Value,
+					Date:         date,
+				})).Times(1).Return(result, nil)
+			},
+			status:   http.StatusOK,
+			response: result,
+		},
+		{
+			name: "OtherCurrency",
+			body: createTransferRequest{
+				FromWalletID: from.ID,
+				ToWalletID:   usd.ID,
+				Title:        "Viagem",
+				Value:        util.NewMoney(550, 0),
+				ToValue:      util.NewMoney(100, 0),
+				Date:         date,
+			},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectWallets(store, from, usd)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+					UserID:       user.ID,
+					FromWalletID: from.ID,
+					ToWalletID:   usd.ID,
+					Title:        "Viagem",
+					Value:        util.NewMoney(550, 0),
+					ToValue:      util.NewMoney(100, 0),
+					Date:         date,
+				})).Times(1).Return(result, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "MissingToValue",
+			body:      toUSD,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectWallets(store, from, usd)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errTransferToValue),
+		},
+		{
+			name:      "NegativeValue",
+			body:      createTransferRequest{FromWalletID: from.ID, ToWalletID: to.ID, Value: -util.NewMoney(5, 0), Date: date},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectWallets(store, from, to)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errTransferValue),
+		},
+		{
+			name:      "SameWallet",
+			body:      createTransferRequest{FromWalletID: from.ID, ToWalletID: from.ID, Value: request.Value, Date: date},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "WalletNotFound",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(1).Return(db.GetWalletRow{}, sql.ErrNoRows)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "WalletOtherHousehold",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectWallets(store, from, householdWallet)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errWalletOtherHousehold),
+		},
+		{
+			name:      "NotWritable",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectWallets(store, from, to)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, sql.ErrNoRows)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeAccountsRead, scopeWalletsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			body: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeAccountsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectWallets(store, from, to)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/transfers", testCases)
+}
+
+func TestGetTransfer(t *testing.T) {
+	user, _ := randomUser(t)
+	result := randomTransfer(user, randomWallet(user, "BRL"), randomWallet(user, "BRL"))
+	params := db.GetTransferParams{ID: result.Transfer.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(params)).Times(1).Return(result.Transfer, nil)
+				store.EXPECT().GetTransferAccounts(gomock.Any(), gomock.Eq(result.Transfer.ID)).Times(1).
+					Return([]db.Account{result.FromAccount, result.ToAccount}, nil)
+			},
+			status:   http.StatusOK,
+			response: result,
+		},
+		{
+			name:      "InvalidID",
+			url:       "/transfers/0",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.Transfer{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "MissingAccount",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(params)).Times(1).Return(result.Transfer, nil)
+				store.EXPECT().GetTransferAccounts(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{result.FromAccount}, nil)
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeWalletsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(1).Return(db.Transfer{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, fmt.Sprintf("/transfers/%d", result.Transfer.ID), testCases)
+}
+
+func TestUpdateTransfer(t *testing.T) {
+	user, _ := randomUser(t)
+	current := randomTransfer(user, randomWallet(user, "BRL"), randomWallet(user, "BRL"))
+	date := time.Now().UTC().Truncate(time.Second)
+	request := updateTransferRequest{Description: "aluguel", Value: util.NewMoney(300, 0), Date: date}
+	params := db.UpdateTransferParams{
+		ID:          current.Transfer.ID,
+		UserID:      user.ID,
+		Title:       current.Transfer.Title,
+		Description: request.Description,
+		Value:       request.Value,
+		ToValue:     request.Value,
+		Date:        date,
+	}
+	updated := current
+	updated.Transfer.Value, updated.Transfer.ToValue = request.Value, request.Value
+
+	expectCurrent := func(store *mockdb.MockStore) {
+		store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(db.GetTransferParams{ID: current.Transfer.ID, UserID: user.ID})).Times(1).Return(current.Transfer, nil)
+		store.EXPECT().GetTransferAccounts(gomock.Any(), gomock.Eq(current.Transfer.ID)).Times(1).
+			Return([]db.Account{current.FromAccount, current.ToAccount}, nil)
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectCurrent(store)
+				store.EXPECT().UpdateTransferTx(gomock.Any(), gomock.Eq(params)).Times(1).Return(updated, nil)
+			},
+			status:   http.StatusOK,
+			response: updated,
+		},
+		{
+			name:      "DifferentToValue",
+			body:      updateTransferRequest{Value: request.Value, ToValue: util.NewMoney(1, 0), Date: date},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectCurrent(store)
+				store.EXPECT().UpdateTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errTransferSameCurrency),
+		},
+		{
+			name:      "BadRequest",
+			body:      updateTransferRequest{Date: date},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(1).Return(db.Transfer{}, sql.ErrNoRows)
+				store.EXPECT().UpdateTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "NotWritable",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectCurrent(store)
+				store.EXPECT().UpdateTransferTx(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.TransferTxResult{}, sql.ErrNoRows)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			body: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectCurrent(store)
+				store.EXPECT().UpdateTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPut, fmt.Sprintf("/transfers/%d", current.Transfer.ID), testCases)
+}
+
+func TestDeleteTransfer(t *testing.T) {
+	user, _ := randomUser(t)
+	result := randomTransfer(user, randomWallet(user, "BRL"), randomWallet(user, "BRL"))
+	params := db.DeleteTransferParams{ID: result.Transfer.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteTransfer(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "InvalidID",
+			url:       "/transfers/abc",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteTransfer(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(db.GetTransferParams(params))).Times(1).Return(db.Transfer{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "NotWritable",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteTransfer(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(db.GetTransferParams(params))).Times(1).Return(result.Transfer, nil)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteTransfer(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodDelete, fmt.Sprintf("/transfers/%d", result.Transfer.ID), testCases)
+}
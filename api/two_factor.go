@@ -0,0 +1,340 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	tokenPurposeTwoFactorChallenge = "two_factor_challenge"
+
+	twoFactorChallengeDuration = 5 * time.Minute
+	twoFactorIssuer            = "gofinance"
+	recoveryCodeCount          = 10
+)
+
+var (
+	errTwoFactorEnabled          = errors.New("two-factor authentication is already enabled")
+	errTwoFactorNotStarted       = errors.New("two-factor enrollment was not started")
+	errInvalidTwoFactorCode      = errors.New("two-factor code is invalid")
+	errInvalidTwoFactorChallenge = errors.New("two-factor challenge is invalid or expired")
+)
+
+type twoFactorChallengeResponse struct {
+	TwoFactorRequired  bool      `json:"two_factor_required"`
+	ChallengeToken     string    `json:"challenge_token"`
+	ChallengeExpiresAt time.Time `json:"challenge_expires_at"`
+}
+
+// twoFactorChallenge gera o token curto que o cliente troca pela sessão junto com o código TOTP
+func (server *Server) twoFactorChallenge(ctx *gin.Context, user db.User) (twoFactorChallengeResponse, error) {
+	token, err := server.createUserToken(ctx, user, tokenPurposeTwoFactorChallenge, twoFactorChallengeDuration)
+	if err != nil {
+		return twoFactorChallengeResponse{}, err
+	}
+
+	return twoFactorChallengeResponse{
+		TwoFactorRequired:  true,
+		ChallengeToken:     token,
+		ChallengeExpiresAt: time.Now().UTC().Add(twoFactorChallengeDuration),
+	}, nil
+}
+
+// twoFactorEnabled indica se o usuário já confirmou o 2FA
+func (server *Server) twoFactorEnabled(ctx *gin.Context, userID int32) (bool, error) {
+	userTOTP, err := server.store.GetUserTOTP(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	return userTOTP.ConfirmedAt.Valid, nil
+}
+
+type enrollTwoFactorResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+	QRCodePNG       []byte `json:"qr_code_png"`
+}
+
+// enrollTwoFactor gera um segredo TOTP novo; só vale depois de confirmado com um código
+func (server *Server) enrollTwoFactor(ctx *gin.Context) {
+	user, err := server.store.GetUserById(ctx, authClaims(ctx).UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	key, err := util.GenerateTOTPKey(twoFactorIssuer, user.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_, err = server.store.UpsertPendingUserTOTP(ctx, db.UpsertPendingUserTOTPParams{
+		UserID: user.ID,
+		Secret: key.Secret,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(errTwoFactorEnabled))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, enrollTwoFactorResponse{
+		Secret:          key.Secret,
+		ProvisioningURI: key.ProvisioningURI,
+		QRCodePNG:       key.QRCodePNG,
+	})
+}
+
+type confirmTwoFactorRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type confirmTwoFactorResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// confirmTwoFactor ativa o 2FA e devolve os códigos de recuperação, mostrados só desta vez
+func (server *Server) confirmTwoFactor(ctx *gin.Context) {
+	var request confirmTwoFactorRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userID := authClaims(ctx).UserID
+	userTOTP, err := server.store.GetUserTOTP(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errTwoFactorNotStarted))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if userTOTP.ConfirmedAt.Valid {
+		ctx.JSON(http.StatusConflict, errorResponse(errTwoFactorEnabled))
+		return
+	}
+
+	counter, ok := util.ValidateTOTPCode(userTOTP.Secret, request.Code, time.Now(), userTOTP.LastUsedCounter)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidTwoFactorCode))
+		return
+	}
+
+	rows, err := server.store.ConfirmUserTOTP(ctx, db.ConfirmUserTOTPParams{
+		UserID:          userID,
+		LastUsedCounter: counter,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rows == 0 {
+		ctx.JSON(http.StatusConflict, errorResponse(errTwoFactorEnabled))
+		return
+	}
+
+	codes, err := server.createRecoveryCodes(ctx, userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, confirmTwoFactorResponse{RecoveryCodes: codes})
+}
+
+// createRecoveryCodes troca os códigos de recuperação do usuário, guardando só o hash
+func (server *Server) createRecoveryCodes(ctx *gin.Context, userID int32) ([]string, error) {
+	err := server.store.DeleteRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := util.NewRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range codes {
+		err = server.store.CreateRecoveryCode(ctx, db.CreateRecoveryCodeParams{
+			UserID:   userID,
+			CodeHash: util.HashRecoveryCode(code),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return codes, nil
+}
+
+type disableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// disableTwoFactor desliga o 2FA depois de conferir a senha de novo; a senha errada conta
+// para o bloqueio do login, para um token roubado não servir para adivinhar a senha
+func (server *Server) disableTwoFactor(ctx *gin.Context) {
+	var request disableTwoFactorRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUserById(ctx, authClaims(ctx).UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !server.checkLoginLimit(ctx, user.Username) {
+		return
+	}
+
+	_, err = server.passwordHasher.Verify(request.Password, user.Password)
+	if err != nil {
+		server.loginFailed(ctx, user.Username, http.StatusUnauthorized, err)
+		return
+	}
+
+	err = server.loginSucceeded(ctx, user.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.store.DeleteRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.store.DeleteUserTOTP(ctx, user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
+
+type loginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// loginTwoFactor conclui o login com o código TOTP ou um código de recuperação
+func (server *Server) loginTwoFactor(ctx *gin.Context) {
+	var request loginTwoFactorRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	challengeHash := util.HashOpaqueToken(request.ChallengeToken)
+	challenge, err := server.store.GetValidUserToken(ctx, db.GetValidUserTokenParams{
+		TokenHash: challengeHash,
+		Purpose:   tokenPurposeTwoFactorChallenge,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidTwoFactorChallenge))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUserById(ctx, challenge.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !server.checkLoginLimit(ctx, user.Username) {
+		return
+	}
+
+	ok, err := server.checkTwoFactorCode(ctx, user.ID, request.Code)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !ok {
+		server.loginFailed(ctx, user.Username, http.StatusUnauthorized, errInvalidTwoFactorCode)
+		return
+	}
+
+	_, err = server.store.ConsumeUserToken(ctx, db.ConsumeUserTokenParams{
+		TokenHash: challengeHash,
+		Purpose:   tokenPurposeTwoFactorChallenge,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidTwoFactorChallenge))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.loginSucceeded(ctx, user.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response, err := server.createSession(ctx, user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// checkTwoFactorCode aceita um código TOTP ainda não usado ou queima um código de recuperação
+func (server *Server) checkTwoFactorCode(ctx *gin.Context, userID int32, code string) (bool, error) {
+	userTOTP, err := server.store.GetUserTOTP(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	if !userTOTP.ConfirmedAt.Valid {
+		return false, nil
+	}
+
+	counter, ok := util.ValidateTOTPCode(userTOTP.Secret, code, time.Now(), userTOTP.LastUsedCounter)
+	if ok {
+		rows, err := server.store.UpdateUserTOTPCounter(ctx, db.UpdateUserTOTPCounterParams{
+			UserID:          userID,
+			LastUsedCounter: counter,
+		})
+		return rows == 1, err
+	}
+
+	rows, err := server.store.UseRecoveryCode(ctx, db.UseRecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: util.HashRecoveryCode(code),
+	})
+	return rows == 1, err
+}
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEnrollTwoFactor(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().UpsertPendingUserTOTP(gomock.Any(), gomock.Any()).Times(1).Return(db.UserTotp{UserID: user.ID}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var enrolled enrollTwoFactorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &enrolled)
+				require.NoError(t, err)
+				require.NotEmpty(t, enrolled.Secret)
+				require.NotEmpty(t, enrolled.QRCodePNG)
+				require.Contains(t, enrolled.ProvisioningURI, enrolled.Secret)
+			},
+		},
+		{
+			name:      "AlreadyEnabled",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().UpsertPendingUserTOTP(gomock.Any(), gomock.Any()).Times(1).Return(db.UserTotp{}, sql.ErrNoRows)
+			},
+			status:   http.StatusConflict,
+			response: errorResponse(errTwoFactorEnabled),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertPendingUserTOTP(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(db.User{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/user/2fa/enroll", testCases)
+}
+
+func TestConfirmTwoFactor(t *testing.T) {
+	user, _ := randomUser(t)
+	key, err := util.GenerateTOTPKey(twoFactorIssuer, user.Username)
+	require.NoError(t, err)
+	pending := db.UserTotp{UserID: user.ID, Secret: key.Secret}
+	confirmed := pending
+	confirmed.ConfirmedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	code, err := totp.GenerateCode(key.Secret, time.Now())
+	require.NoError(t, err)
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      confirmTwoFactorRequest{Code: code},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(pending, nil)
+				store.EXPECT().ConfirmUserTOTP(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				store.EXPECT().DeleteRecoveryCodes(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(nil)
+				store.EXPECT().CreateRecoveryCode(gomock.Any(), gomock.Any()).Times(recoveryCodeCount).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response confirmTwoFactorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Len(t, response.RecoveryCodes, recoveryCodeCount)
+			},
+		},
+		{
+			name:      "BadRequest",
+			body:      confirmTwoFactorRequest{},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotStarted",
+			body:      confirmTwoFactorRequest{Code: code},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(db.UserTotp{}, sql.ErrNoRows)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errTwoFactorNotStarted),
+		},
+		{
+			name:      "AlreadyEnabled",
+			body:      confirmTwoFactorRequest{Code: code},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(confirmed, nil)
+				store.EXPECT().ConfirmUserTOTP(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusConflict,
+			response: errorResponse(errTwoFactorEnabled),
+		},
+		{
+			name:      "InvalidCode",
+			body:      confirmTwoFactorRequest{Code: "invalid"},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(pending, nil)
+				store.EXPECT().ConfirmUserTOTP(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusUnauthorized,
+			response: errorResponse(errInvalidTwoFactorCode),
+		},
+		{
+			name: "NoAuthorization",
+			body: confirmTwoFactorRequest{Code: code},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      confirmTwoFactorRequest{Code: code},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(pending, nil)
+				store.EXPECT().ConfirmUserTOTP(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				store.EXPECT().DeleteRecoveryCodes(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/user/2fa/confirm", testCases)
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	user, password := randomUser(t)
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      disableTwoFactorRequest{Password: password},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().DeleteRecoveryCodes(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(nil)
+				store.EXPECT().DeleteUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "BadRequest",
+			body:      disableTwoFactorRequest{},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "WrongPassword",
+			body:      disableTwoFactorRequest{Password: "wrong-" + password},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().DeleteUserTOTP(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "NoAuthorization",
+			body: disableTwoFactorRequest{Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      disableTwoFactorRequest{Password: password},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().DeleteRecoveryCodes(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(nil)
+				store.EXPECT().DeleteUserTOTP(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/user/2fa/disable", testCases)
+}
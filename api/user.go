@@ -1,21 +1,18 @@
 package api
 
 import (
-	"bytes"
-	"crypto/sha512"
 	"database/sql"
+	"log"
 	"net/http"
 
 	db "github.com/SraReaper/gofinance-backend/db/sqlc"
 	"github.com/gin-gonic/gin"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
 type createUserRequest struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
-	Email    string `json:"email" binding:"required"`
+	Email    string `json:"email" binding:"required,email"`
 }
 
 // createUser para criar um usuário
@@ -24,16 +21,14 @@ func (server *Server) createUser(ctx *gin.Context) {
 	err := ctx.ShouldBindJSON(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
-	hashedInput := sha512.Sum512([]byte(request.Password))
-	trimmedHash := bytes.Trim(hashedInput[:], "\x00")
-	preparedPassword := string(trimmedHash)
-	passwordHashInBytes, err := bcrypt.GenerateFromPassword([]byte(preparedPassword), bcrypt.DefaultCost)
+	passwordHashed, err := server.passwordHasher.Hash(request.Password)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
-	var passwordHashed = string(passwordHashInBytes)
 
 	arg := db.CreateUserParams{
 		Username: request.Username,
@@ -44,6 +39,12 @@ func (server *Server) createUser(ctx *gin.Context) {
 	user, err := server.store.CreateUser(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.sendVerificationEmail(ctx, user)
+	if err != nil {
+		log.Printf("cannot send verification email to user %d: %v", user.ID, err)
 	}
 
 	ctx.JSON(http.StatusOK, user)
@@ -59,6 +60,7 @@ func (server *Server) getUser(ctx *gin.Context) {
 	err := ctx.ShouldBindUri(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
 	user, err := server.store.GetUser(ctx, request.Username)
@@ -84,6 +86,7 @@ func (server *Server) getUserById(ctx *gin.Context) {
 	err := ctx.ShouldBindUri(&request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
 	user, err := server.store.GetUserById(ctx, request.ID)
@@ -98,3 +101,28 @@ func (server *Server) getUserById(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, user)
 }
+
+type updateUserBaseCurrencyRequest struct {
+	BaseCurrency string `json:"base_currency" binding:"required,iso4217"`
+}
+
+// updateUserBaseCurrency muda a moeda em que os relatórios do usuário são convertidos
+func (server *Server) updateUserBaseCurrency(ctx *gin.Context) {
+	var request updateUserBaseCurrencyRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.UpdateUserBaseCurrency(ctx, db.UpdateUserBaseCurrencyParams{
+		ID:           authClaims(ctx).UserID,
+		BaseCurrency: request.BaseCurrency,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user)
+}
@@ -0,0 +1,433 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// expectUserToken registra a troca do token do tipo pedido feita antes de cada e-mail
+func expectUserToken(store *mockdb.MockStore, user db.User, purpose string) {
+	store.EXPECT().
+		InvalidateUserTokens(gomock.Any(), gomock.Eq(db.InvalidateUserTokensParams{UserID: user.ID, Purpose: purpose})).
+		Times(1).
+		Return(nil)
+	store.EXPECT().
+		CreateUserToken(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateUserTokenParams) (db.UserToken, error) {
+			if arg.UserID != user.ID || arg.Purpose != purpose || arg.TokenHash == "" {
+				return db.UserToken{}, fmt.Errorf("unexpected user token %+v", arg)
+			}
+			return db.UserToken{ID: randomID(), UserID: arg.UserID, Purpose: arg.Purpose, TokenHash: arg.TokenHash}, nil
+		})
+}
+
+func TestCreateUser(t *testing.T) {
+	user, password := randomUser(t)
+
+	testCases := []routeTestCase{
+		{
+			name: "OK",
+			body: createUserRequest{Username: user.Username, Password: password, Email: user.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateUserParams) (db.User, error) {
+						_, err := testPasswordHasher.Verify(password, arg.Password)
+						if err != nil || arg.Username != user.Username || arg.Email != user.Email {
+							return db.User{}, fmt.Errorf("unexpected user %+v", arg)
+						}
+						return user, nil
+					})
+				expectUserToken(store, user, tokenPurposeEmailVerification)
+			},
+			status:   http.StatusOK,
+			response: user,
+		},
+		{
+			name: "EmailError",
+			body: createUserRequest{Username: user.Username, Password: password, Email: user.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).Return(user, nil)
+				store.EXPECT().InvalidateUserTokens(gomock.Any(), gomock.Any()).Times(1).Return(errStore)
+			},
+			// o cadastro não falha por causa do e-mail, que pode ser reenviado
+			status:   http.StatusOK,
+			response: user,
+		},
+		{
+			name: "InvalidEmail",
+			body: createUserRequest{Username: user.Username, Password: password, Email: "invalid-email"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "MissingPassword",
+			body: createUserRequest{Username: user.Username, Email: user.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "InternalError",
+			body: createUserRequest{Username: user.Username, Password: password, Email: user.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/user", testCases)
+}
+
+func TestVerifyEmail(t *testing.T) {
+	user, _ := randomUser(t)
+	token := util.RandomString(32)
+	params := db.ConsumeUserTokenParams{TokenHash: util.HashOpaqueToken(token), Purpose: tokenPurposeEmailVerification}
+
+	testCases := []routeTestCase{
+		{
+			name: "OK",
+			body: verifyEmailRequest{Token: token},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.UserToken{UserID: user.ID}, nil)
+				store.EXPECT().VerifyUserEmail(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name: "BadRequest",
+			body: verifyEmailRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "InvalidToken",
+			body: verifyEmailRequest{Token: token},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.UserToken{}, sql.ErrNoRows)
+				store.EXPECT().VerifyUserEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errInvalidUserToken),
+		},
+		{
+			name: "InternalError",
+			body: verifyEmailRequest{Token: token},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.UserToken{UserID: user.ID}, nil)
+				store.EXPECT().VerifyUserEmail(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/user/verify-email", testCases)
+}
+
+func TestResendVerificationEmail(t *testing.T) {
+	user, _ := randomUser(t)
+	verified := user
+	verified.EmailVerifiedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				expectUserToken(store, user, tokenPurposeEmailVerification)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "AlreadyVerified",
+			setupAuth: withSession(verified),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(verified, nil)
+				store.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().InvalidateUserTokens(gomock.Any(), gomock.Any()).Times(1).Return(errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/user/verify-email/resend", testCases)
+}
+
+func TestForgotPassword(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []routeTestCase{
+		{
+			name: "OK",
+			body: forgotPasswordRequest{Email: user.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+				expectUserToken(store, user, tokenPurposePasswordReset)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name: "UnknownEmail",
+			body: forgotPasswordRequest{Email: user.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name: "InvalidEmail",
+			body: forgotPasswordRequest{Email: "invalid-email"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "InternalError",
+			body: forgotPasswordRequest{Email: user.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/password/forgot", testCases)
+}
+
+func TestResetPassword(t *testing.T) {
+	user, _ := randomUser(t)
+	token := util.RandomString(32)
+	password := util.RandomString(12)
+	params := db.ConsumeUserTokenParams{TokenHash: util.HashOpaqueToken(token), Purpose: tokenPurposePasswordReset}
+
+	testCases := []routeTestCase{
+		{
+			name: "OK",
+			body: resetPasswordRequest{Token: token, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.UserToken{UserID: user.ID}, nil)
+				store.EXPECT().UpdateUserPassword(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateUserPasswordParams) error {
+						require.Equal(t, user.ID, arg.ID)
+						_, err := testPasswordHasher.Verify(password, arg.Password)
+						return err
+					})
+				store.EXPECT().RevokeUserSessions(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name: "BadRequest",
+			body: resetPasswordRequest{Token: token},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "InvalidToken",
+			body: resetPasswordRequest{Token: token, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.UserToken{}, sql.ErrNoRows)
+				store.EXPECT().UpdateUserPassword(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errInvalidUserToken),
+		},
+		{
+			name: "InternalError",
+			body: resetPasswordRequest{Token: token, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.UserToken{UserID: user.ID}, nil)
+				store.EXPECT().UpdateUserPassword(gomock.Any(), gomock.Any()).Times(1).Return(errStore)
+				store.EXPECT().RevokeUserSessions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/password/reset", testCases)
+}
+
+func TestGetUser(t *testing.T) {
+	user, _ := randomUser(t)
+	url := fmt.Sprintf("/user/%s", user.Username)
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+			},
+			status:   http.StatusOK,
+			response: user,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.User{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, url, testCases)
+}
+
+func TestGetUserById(t *testing.T) {
+	user, _ := randomUser(t)
+	url := fmt.Sprintf("/user/id/%d", user.ID)
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+			},
+			status:   http.StatusOK,
+			response: user,
+		},
+		{
+			name:      "InvalidID",
+			url:       "/user/id/abc",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(db.User{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, url, testCases)
+}
+
+func TestUpdateUserBaseCurrency(t *testing.T) {
+	user, _ := randomUser(t)
+	updated := user
+	updated.BaseCurrency = "USD"
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      updateUserBaseCurrencyRequest{BaseCurrency: "USD"},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUserBaseCurrency(gomock.Any(), gomock.Eq(db.UpdateUserBaseCurrencyParams{
+					ID:           user.ID,
+					BaseCurrency: "USD",
+				})).Times(1).Return(updated, nil)
+			},
+			status:   http.StatusOK,
+			response: updated,
+		},
+		{
+			name:      "InvalidCurrency",
+			body:      updateUserBaseCurrencyRequest{BaseCurrency: "ABC"},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUserBaseCurrency(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "NoAuthorization",
+			body: updateUserBaseCurrencyRequest{BaseCurrency: "USD"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUserBaseCurrency(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      updateUserBaseCurrencyRequest{BaseCurrency: "USD"},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUserBaseCurrency(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPut, "/user/currency", testCases)
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/mail"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	tokenPurposeEmailVerification = "email_verification"
+	tokenPurposePasswordReset     = "password_reset"
+
+	emailVerificationDuration = 24 * time.Hour
+	passwordResetDuration     = time.Hour
+)
+
+var errInvalidUserToken = errors.New("token is invalid or expired")
+
+// createUserToken invalida os tokens anteriores do mesmo tipo e gera um novo
+func (server *Server) createUserToken(ctx *gin.Context, user db.User, purpose string, duration time.Duration) (string, error) {
+	err := server.store.InvalidateUserTokens(ctx, db.InvalidateUserTokensParams{
+		UserID:  user.ID,
+		Purpose: purpose,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	token, tokenHash, err := util.NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = server.store.CreateUserToken(ctx, db.CreateUserTokenParams{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().UTC().Add(duration),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// tokenLink monta o link do frontend; sem APP_URL o e-mail leva só o token
+func (server *Server) tokenLink(path string, token string) string {
+	if server.appURL == "" {
+		return token
+	}
+	return fmt.Sprintf("%s%s?token=%s", server.appURL, path, token)
+}
+
+// sendVerificationEmail envia o e-mail de confirmação do endereço do usuário
+func (server *Server) sendVerificationEmail(ctx *gin.Context, user db.User) error {
+	token, err := server.createUserToken(ctx, user, tokenPurposeEmailVerification, emailVerificationDuration)
+	if err != nil {
+		return err
+	}
+
+	return server.mailer.Send(ctx, mail.Message{
+		To:      []string{user.Email},
+		Subject: "Confirm your gofinance email",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm your email address with: %s\n\nThis link expires in %s.\n",
+			user.Username, server.tokenLink("/verify-email", token), emailVerificationDuration),
+	})
+}
+
+// sendPasswordResetEmail envia o e-mail com o token de redefinição de senha
+func (server *Server) sendPasswordResetEmail(ctx *gin.Context, user db.User) error {
+	token, err := server.createUserToken(ctx, user, tokenPurposePasswordReset, passwordResetDuration)
+	if err != nil {
+		return err
+	}
+
+	return server.mailer.Send(ctx, mail.Message{
+		To:      []string{user.Email},
+		Subject: "Reset your gofinance password",
+		Body: fmt.Sprintf("Hi %s,\n\nReset your password with: %s\n\nThis link expires in %s. If you did not ask for it, ignore this email.\n",
+			user.Username, server.tokenLink("/reset-password", token), passwordResetDuration),
+	})
+}
+
+type verifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// verifyEmail confirma o e-mail com o token enviado no cadastro
+func (server *Server) verifyEmail(ctx *gin.Context) {
+	var request verifyEmailRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userToken, err := server.store.ConsumeUserToken(ctx, db.ConsumeUserTokenParams{
+		TokenHash: util.HashOpaqueToken(request.Token),
+		Purpose:   tokenPurposeEmailVerification,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidUserToken))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.store.VerifyUserEmail(ctx, userToken.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
+
+// resendVerificationEmail envia de novo o e-mail de confirmação do usuário logado
+func (server *Server) resendVerificationEmail(ctx *gin.Context) {
+	user, err := server.store.GetUserById(ctx, authClaims(ctx).UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if user.EmailVerifiedAt.Valid {
+		ctx.JSON(http.StatusOK, true)
+		return
+	}
+
+	err = server.sendVerificationEmail(ctx, user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
+
+type forgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// forgotPassword envia o e-mail de redefinição; responde igual exista ou não o e-mail
+func (server *Server) forgotPassword(ctx *gin.Context) {
+	var request forgotPasswordRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUserByEmail(ctx, request.Email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusOK, true)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.sendPasswordResetEmail(ctx, user)
+	if err != nil {
+		log.Printf("cannot send password reset email to user %d: %v", user.ID, err)
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
+
+type resetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// resetPassword troca a senha com o token de uso único e encerra todas as sessões
+func (server *Server) resetPassword(ctx *gin.Context) {
+	var request resetPasswordRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userToken, err := server.store.ConsumeUserToken(ctx, db.ConsumeUserTokenParams{
+		TokenHash: util.HashOpaqueToken(request.Token),
+		Purpose:   tokenPurposePasswordReset,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidUserToken))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	passwordHashed, err := server.passwordHasher.Hash(request.Password)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.store.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{
+		ID:       userToken.UserID,
+		Password: passwordHashed,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_, err = server.store.RevokeUserSessions(ctx, userToken.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
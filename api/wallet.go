@@ -0,0 +1,271 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+// defaultBalanceHistoryDays é o período do histórico de saldo quando from não é informado
+const defaultBalanceHistoryDays = 30
+
+var (
+	errWalletOtherHousehold  = errors.New("wallet does not belong to the same household")
+	errWalletHasTransactions = errors.New("wallet still has transactions")
+	errInvalidPeriod         = errors.New("from must not be after to")
+)
+
+type createWalletRequest struct {
+	HouseholdID    int32      `json:"household_id"`
+	Name           string     `json:"name" binding:"required"`
+	Kind           string     `json:"kind" binding:"required,oneof=checking savings credit_card cash"`
+	Currency       string     `json:"currency" binding:"omitempty,iso4217"`
+	OpeningBalance util.Money `json:"opening_balance"`
+}
+
+// createWallet cria uma carteira; sem moeda, usa a moeda base do usuário
+func (server *Server) createWallet(ctx *gin.Context) {
+	var request createWalletRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleEditor) {
+		return
+	}
+
+	wallet, err := server.store.CreateWallet(ctx, db.CreateWalletParams{
+		UserID:         authClaims(ctx).UserID,
+		HouseholdID:    householdID(request.HouseholdID),
+		Name:           request.Name,
+		Kind:           request.Kind,
+		Currency:       currency(request.Currency),
+		OpeningBalance: request.OpeningBalance,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errHouseholdForbidden))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, wallet)
+}
+
+type walletRequest struct {
+	ID int32 `uri:"id" binding:"required"`
+}
+
+// getWallet mostra a carteira com o saldo atual
+func (server *Server) getWallet(ctx *gin.Context) {
+	var request walletRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wallet, err := server.store.GetWallet(ctx, db.GetWalletParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, wallet)
+}
+
+// getWallets lista as carteiras do escopo com o saldo atual de cada uma
+func (server *Server) getWallets(ctx *gin.Context) {
+	var request householdScopeRequest
+	err := ctx.ShouldBindQuery(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if request.HouseholdID > 0 && !server.authorizeHousehold(ctx, request.HouseholdID, householdRoleViewer) {
+		return
+	}
+
+	wallets, err := server.store.GetWallets(ctx, db.GetWalletsParams{
+		HouseholdID: householdID(request.HouseholdID),
+		UserID:      authClaims(ctx).UserID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, wallets)
+}
+
+type updateWalletRequest struct {
+	Name string `json:"name" binding:"required"`
+	Kind string `json:"kind" binding:"required,oneof=checking savings credit_card cash"`
+}
+
+// updateWallet renomeia a carteira ou muda o tipo; a moeda não muda porque as transações estão nela
+func (server *Server) updateWallet(ctx *gin.Context) {
+	var uri walletRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var request updateWalletRequest
+	err = ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateWalletParams{
+		ID:     uri.ID,
+		UserID: authClaims(ctx).UserID,
+		Name:   request.Name,
+		Kind:   request.Kind,
+	}
+
+	wallet, err := server.store.UpdateWallet(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			_, err = server.store.GetWallet(ctx, db.GetWalletParams{ID: arg.ID, UserID: arg.UserID})
+			notWritable(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, wallet)
+}
+
+// deleteWallet apaga uma carteira sem transações
+func (server *Server) deleteWallet(ctx *gin.Context) {
+	var request walletRequest
+	err := ctx.ShouldBindUri(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.DeleteWalletParams{
+		ID:     request.ID,
+		UserID: authClaims(ctx).UserID,
+	}
+
+	rowsDeleted, err := server.store.DeleteWallet(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(errWalletHasTransactions))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rowsDeleted == 0 {
+		_, err = server.store.GetWallet(ctx, db.GetWalletParams(arg))
+		notWritable(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, true)
+}
+
+type getWalletBalancesRequest struct {
+	From time.Time `form:"from" time_format:"2006-01-02"`
+	To   time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+type walletBalancesResponse struct {
+	WalletID int32     `json:"wallet_id"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	// OpeningBalance é o saldo no início de from
+	OpeningBalance util.Money                      `json:"opening_balance"`
+	Balances       []db.GetWalletBalanceHistoryRow `json:"balances"`
+}
+
+// getWalletBalances mostra o saldo ao fim de cada dia com movimento no período
+// (por padrão os últimos 30 dias)
+func (server *Server) getWalletBalances(ctx *gin.Context) {
+	var uri walletRequest
+	err := ctx.ShouldBindUri(&uri)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var request getWalletBalancesRequest
+	err = ctx.ShouldBindQuery(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if request.To.IsZero() {
+		request.To = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	if request.From.IsZero() {
+		request.From = request.To.AddDate(0, 0, -defaultBalanceHistoryDays)
+	}
+	if request.From.After(request.To) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidPeriod))
+		return
+	}
+
+	wallet, err := server.store.GetWallet(ctx, db.GetWalletParams{
+		ID:     uri.ID,
+		UserID: authClaims(ctx).UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	openingBalance, err := server.store.GetWalletBalanceAt(ctx, db.GetWalletBalanceAtParams{
+		WalletID: wallet.ID,
+		OnDate:   request.From.AddDate(0, 0, -1),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	balances, err := server.store.GetWalletBalanceHistory(ctx, db.GetWalletBalanceHistoryParams{
+		WalletID: wallet.ID,
+		FromDate: request.From,
+		ToDate:   request.To,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, walletBalancesResponse{
+		WalletID:       wallet.ID,
+		From:           request.From,
+		To:             request.To,
+		OpeningBalance: openingBalance,
+		Balances:       balances,
+	})
+}
@@ -0,0 +1,524 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWallet(t *testing.T) {
+	user, _ := randomUser(t)
+	householdID := randomID()
+	wallet := db.Wallet{
+		ID:             randomID(),
+		UserID:         user.ID,
+		Name:           util.RandomString(8),
+		Kind:           "savings",
+		Currency:       user.BaseCurrency,
+		OpeningBalance: util.NewMoney(100, 0),
+	}
+	request := createWalletRequest{Name: wallet.Name, Kind: wallet.Kind, OpeningBalance: wallet.OpeningBalance}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWallet(gomock.Any(), gomock.Eq(db.CreateWalletParams{
+					UserID:         user.ID,
+					Name:           wallet.Name,
+					Kind:           wallet.Kind,
+					OpeningBalance: wallet.OpeningBalance,
+				})).Times(1).Return(wallet, nil)
+			},
+			status:   http.StatusOK,
+			response: wallet,
+		},
+		{
+			name:      "Currency",
+			body:      createWalletRequest{Name: wallet.Name, Kind: wallet.Kind, Currency: "USD", HouseholdID: householdID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleOwner)
+				store.EXPECT().CreateWallet(gomock.Any(), gomock.Eq(db.CreateWalletParams{
+					UserID:      user.ID,
+					HouseholdID: sql.NullInt32{Int32: householdID, Valid: true},
+					Name:        wallet.Name,
+					Kind:        wallet.Kind,
+					Currency:    sql.NullString{String: "USD", Valid: true},
+				})).Times(1).Return(wallet, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "InvalidKind",
+			body:      createWalletRequest{Name: wallet.Name, Kind: "stocks"},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "InvalidCurrency",
+			body:      createWalletRequest{Name: wallet.Name, Kind: wallet.Kind, Currency: "XYZ"},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "HouseholdViewer",
+			body:      createWalletRequest{Name: wallet.Name, Kind: wallet.Kind, HouseholdID: householdID},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				store.EXPECT().CreateWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeWalletsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			body: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeWalletsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWallet(gomock.Any(), gomock.Any()).Times(1).Return(db.Wallet{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPost, "/wallets", testCases)
+}
+
+func TestGetWallet(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := randomWallet(user, "EUR")
+	params := db.GetWalletParams{ID: wallet.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(params)).Times(1).Return(wallet, nil)
+			},
+			status:   http.StatusOK,
+			response: wallet,
+		},
+		{
+			name:      "InvalidID",
+			url:       "/wallets/0",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.GetWalletRow{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeAccountsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(1).Return(db.GetWalletRow{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, fmt.Sprintf("/wallets/%d", wallet.ID), testCases)
+}
+
+func TestGetWallets(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := randomWallet(user, "BRL")
+	wallets := []db.GetWalletsRow{db.GetWalletsRow(wallet)}
+	householdID := randomID()
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withAPIKey(user, scopeWalletsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallets(gomock.Any(), gomock.Eq(db.GetWalletsParams{UserID: user.ID})).Times(1).Return(wallets, nil)
+			},
+			status:   http.StatusOK,
+			response: wallets,
+		},
+		{
+			name:      "Household",
+			url:       fmt.Sprintf("/wallets?household_id=%d", householdID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectHouseholdRole(store, householdID, user, householdRoleViewer)
+				store.EXPECT().GetWallets(gomock.Any(), gomock.Eq(db.GetWalletsParams{
+					HouseholdID: sql.NullInt32{Int32: householdID, Valid: true},
+					UserID:      user.ID,
+				})).Times(1).Return(wallets, nil)
+			},
+			status:   http.StatusOK,
+			response: wallets,
+		},
+		{
+			name:      "InvalidHousehold",
+			url:       "/wallets?household_id=abc",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallets(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeWalletsWrite),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallets(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallets(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallets(gomock.Any(), gomock.Any()).Times(1).Return(nil, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, "/wallets", testCases)
+}
+
+func TestUpdateWallet(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := randomWallet(user, "BRL")
+	request := updateWalletRequest{Name: "Nubank", Kind: "credit_card"}
+	params := db.UpdateWalletParams{ID: wallet.ID, UserID: user.ID, Name: request.Name, Kind: request.Kind}
+	updated := db.Wallet{ID: wallet.ID, UserID: user.ID, Name: request.Name, Kind: request.Kind, Currency: wallet.Currency}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateWallet(gomock.Any(), gomock.Eq(params)).Times(1).Return(updated, nil)
+			},
+			status:   http.StatusOK,
+			response: updated,
+		},
+		{
+			name:      "InvalidKind",
+			body:      updateWalletRequest{Name: request.Name, Kind: "stocks"},
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "InvalidID",
+			url:       "/wallets/abc",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateWallet(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.Wallet{}, sql.ErrNoRows)
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(db.GetWalletParams{ID: wallet.ID, UserID: user.ID})).Times(1).Return(db.GetWalletRow{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "NotWritable",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateWallet(gomock.Any(), gomock.Eq(params)).Times(1).Return(db.Wallet{}, sql.ErrNoRows)
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(1).Return(wallet, nil)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			body:      request,
+			setupAuth: withAPIKey(user, scopeWalletsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			body: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			body:      request,
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateWallet(gomock.Any(), gomock.Any()).Times(1).Return(db.Wallet{}, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodPut, fmt.Sprintf("/wallets/%d", wallet.ID), testCases)
+}
+
+func TestDeleteWallet(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := randomWallet(user, "BRL")
+	params := db.DeleteWalletParams{ID: wallet.ID, UserID: user.ID}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteWallet(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(1), nil)
+			},
+			status:   http.StatusOK,
+			response: true,
+		},
+		{
+			name:      "HasTransactions",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteWallet(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), &pq.Error{Code: "23503"})
+			},
+			status:   http.StatusConflict,
+			response: errorResponse(errWalletHasTransactions),
+		},
+		{
+			name:      "InvalidID",
+			url:       "/wallets/0",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteWallet(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(db.GetWalletParams(params))).Times(1).Return(db.GetWalletRow{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "NotWritable",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteWallet(gomock.Any(), gomock.Eq(params)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(db.GetWalletParams(params))).Times(1).Return(wallet, nil)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errHouseholdForbidden),
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeWalletsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteWallet(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodDelete, fmt.Sprintf("/wallets/%d", wallet.ID), testCases)
+}
+
+func TestGetWalletBalances(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := randomWallet(user, "BRL")
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	openingBalance := util.NewMoney(500, 0)
+	balances := []db.GetWalletBalanceHistoryRow{
+		{Date: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), Balance: util.NewMoney(450, 0)},
+		{Date: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), Balance: util.NewMoney(900, 0)},
+	}
+
+	expectBalances := func(store *mockdb.MockStore, historyErr error) {
+		store.EXPECT().GetWallet(gomock.Any(), gomock.Eq(db.GetWalletParams{ID: wallet.ID, UserID: user.ID})).Times(1).Return(wallet, nil)
+		store.EXPECT().GetWalletBalanceAt(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, arg db.GetWalletBalanceAtParams) (util.Money, error) {
+				require.Equal(t, wallet.ID, arg.WalletID)
+				require.True(t, from.AddDate(0, 0, -1).Equal(arg.OnDate))
+				return openingBalance, nil
+			})
+		store.EXPECT().GetWalletBalanceHistory(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, arg db.GetWalletBalanceHistoryParams) ([]db.GetWalletBalanceHistoryRow, error) {
+				require.Equal(t, wallet.ID, arg.WalletID)
+				require.True(t, from.Equal(arg.FromDate))
+				require.True(t, to.Equal(arg.ToDate))
+				return balances, historyErr
+			})
+	}
+
+	testCases := []routeTestCase{
+		{
+			name:      "OK",
+			setupAuth: withAPIKey(user, scopeWalletsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectBalances(store, nil)
+			},
+			status: http.StatusOK,
+			response: walletBalancesResponse{
+				WalletID:       wallet.ID,
+				From:           from,
+				To:             to,
+				OpeningBalance: openingBalance,
+				Balances:       balances,
+			},
+		},
+		{
+			name:      "InvalidPeriod",
+			url:       fmt.Sprintf("/wallets/%d/balances?from=2024-02-01&to=2024-01-31", wallet.ID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusBadRequest,
+			response: errorResponse(errInvalidPeriod),
+		},
+		{
+			name:      "InvalidDate",
+			url:       fmt.Sprintf("/wallets/%d/balances?from=01/01/2024", wallet.ID),
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "NotFound",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(1).Return(db.GetWalletRow{}, sql.ErrNoRows)
+				store.EXPECT().GetWalletBalanceAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "MissingScope",
+			setupAuth: withAPIKey(user, scopeReportsRead),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status:   http.StatusForbidden,
+			response: errorResponse(errMissingScope),
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "InternalError",
+			setupAuth: withSession(user),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectBalances(store, errStore)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	runRouteTests(t, http.MethodGet, fmt.Sprintf("/wallets/%d/balances?from=2024-01-01&to=2024-01-31", wallet.ID), testCases)
+}
@@ -0,0 +1,150 @@
+package budget
+
+import (
+	"math"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+// Progress é o orçamento de uma categoria no mês comparado com o gasto. Available é o
+// limite do mês mais o que sobrou do mês anterior, quando o orçamento tem rollover
+type Progress struct {
+	BudgetID      int32      `json:"budget_id"`
+	CategoryID    int32      `json:"category_id"`
+	CategoryTitle string     `json:"category_title"`
+	Recurring     bool       `json:"recurring"`
+	Rollover      bool       `json:"rollover"`
+	Amount        util.Money `json:"amount"`
+	RolledOver    util.Money `json:"rolled_over"`
+	Available     util.Money `json:"available"`
+	Spent         util.Money `json:"spent"`
+	Remaining     util.Money `json:"remaining"`
+	PercentUsed   float64    `json:"percent_used"`
+}
+
+// Summary é o orçamento do mês inteiro, com o total das categorias
+type Summary struct {
+	Year        int        `json:"year"`
+	Month       int        `json:"month"`
+	Categories  []Progress `json:"categories"`
+	Available   util.Money `json:"available"`
+	Spent       util.Money `json:"spent"`
+	Remaining   util.Money `json:"remaining"`
+	PercentUsed float64    `json:"percent_used"`
+}
+
+// Month normaliza a data para o primeiro dia do mês
+func Month(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Summarize monta o orçamento do mês. budgets vêm de GetBudgetsUntil e spending de
+// GetBudgetSpending, desde o mês do primeiro orçamento, para calcular o rollover
+func Summarize(budgets []db.GetBudgetsUntilRow, spending []db.GetBudgetSpendingRow, month time.Time) Summary {
+	month = Month(month)
+	summary := Summary{
+		Year:       month.Year(),
+		Month:      int(month.Month()),
+		Categories: []Progress{},
+	}
+
+	spent := make(map[int32]map[time.Time]util.Money)
+	for _, row := range spending {
+		if spent[row.CategoryID] == nil {
+			spent[row.CategoryID] = make(map[time.Time]util.Money)
+		}
+		spent[row.CategoryID][Month(row.Month)] += row.Spent
+	}
+
+	byCategory := make(map[int32][]db.GetBudgetsUntilRow)
+	categories := []int32{}
+	for _, budget := range budgets {
+		if _, ok := byCategory[budget.CategoryID]; !ok {
+			categories = append(categories, budget.CategoryID)
+		}
+		byCategory[budget.CategoryID] = append(byCategory[budget.CategoryID], budget)
+	}
+
+	for _, categoryID := range categories {
+		progress, ok := categoryProgress(byCategory[categoryID], spent[categoryID], month)
+		if !ok {
+			continue
+		}
+		summary.Categories = append(summary.Categories, progress)
+		summary.Available += progress.Available
+		summary.Spent += progress.Spent
+	}
+	summary.Remaining = summary.Available - summary.Spent
+	summary.PercentUsed = percent(summary.Spent, summary.Available)
+	return summary
+}
+
+// categoryProgress percorre os meses desde o primeiro orçamento da categoria, levando a
+// sobra de um mês para o seguinte; budgets estão ordenados por mês
+func categoryProgress(budgets []db.GetBudgetsUntilRow, spent map[time.Time]util.Money, month time.Time) (Progress, bool) {
+	var progress Progress
+	found := false
+	var carry util.Money
+	for current := Month(budgets[0].Month); !current.After(month); current = current.AddDate(0, 1, 0) {
+		budget, ok := budgetFor(budgets, current)
+		if !ok {
+			found = false
+			carry = 0
+			continue
+		}
+
+		found = true
+		progress = Progress{
+			BudgetID:      budget.ID,
+			CategoryID:    budget.CategoryID,
+			CategoryTitle: budget.CategoryTitle,
+			Recurring:     budget.Recurring,
+			Rollover:      budget.Rollover,
+			Amount:        budget.Amount,
+			Spent:         spent[current],
+		}
+		if budget.Rollover {
+			progress.RolledOver = carry
+		}
+		progress.Available = progress.Amount + progress.RolledOver
+		progress.Remaining = progress.Available - progress.Spent
+		progress.PercentUsed = percent(progress.Spent, progress.Available)
+
+		carry = 0
+		if progress.Remaining > 0 {
+			carry = progress.Remaining
+		}
+	}
+	return progress, found
+}
+
+// budgetFor escolhe o orçamento do mês: o do próprio mês ou o último recorrente antes dele;
+// um orçamento avulso vale só no próprio mês e não encerra o recorrente
+func budgetFor(budgets []db.GetBudgetsUntilRow, month time.Time) (db.GetBudgetsUntilRow, bool) {
+	var chosen db.GetBudgetsUntilRow
+	found := false
+	for _, budget := range budgets {
+		start := Month(budget.Month)
+		if start.After(month) {
+			break
+		}
+		if start.Equal(month) {
+			return budget, true
+		}
+		if budget.Recurring {
+			chosen = budget
+			found = true
+		}
+	}
+	return chosen, found
+}
+
+// percent é o gasto em porcentagem do disponível, com duas casas
+func percent(spent util.Money, available util.Money) float64 {
+	if available <= 0 {
+		return 0
+	}
+	return math.Round(float64(spent)/float64(available)*10000) / 100
+}
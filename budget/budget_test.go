@@ -0,0 +1,125 @@
+package budget
+
+import (
+	"testing"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func spending(categoryID int32, m time.Time, spent util.Money) db.GetBudgetSpendingRow {
+	return db.GetBudgetSpendingRow{CategoryID: categoryID, Month: m, Spent: spent}
+}
+
+func TestSummarizeRecurring(t *testing.T) {
+	budgets := []db.GetBudgetsUntilRow{
+		{ID: 1, CategoryID: 10, CategoryTitle: "Mercado", Month: month(2024, 1), Amount: util.NewMoney(800, 0), Recurring: true},
+		{ID: 2, CategoryID: 20, CategoryTitle: "Lazer", Month: month(2024, 3), Amount: util.NewMoney(200, 0)},
+	}
+	rows := []db.GetBudgetSpendingRow{
+		spending(10, month(2024, 3), util.NewMoney(600, 0)),
+		spending(20, month(2024, 3), util.NewMoney(250, 0)),
+	}
+
+	summary := Summarize(budgets, rows, time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC))
+	require.Equal(t, 2024, summary.Year)
+	require.Equal(t, 3, summary.Month)
+	require.Equal(t, []Progress{
+		{
+			BudgetID:      1,
+			CategoryID:    10,
+			CategoryTitle: "Mercado",
+			Recurring:     true,
+			Amount:        util.NewMoney(800, 0),
+			Available:     util.NewMoney(800, 0),
+			Spent:         util.NewMoney(600, 0),
+			Remaining:     util.NewMoney(200, 0),
+			PercentUsed:   75,
+		},
+		{
+			BudgetID:      2,
+			CategoryID:    20,
+			CategoryTitle: "Lazer",
+			Amount:        util.NewMoney(200, 0),
+			Available:     util.NewMoney(200, 0),
+			Spent:         util.NewMoney(250, 0),
+			Remaining:     util.NewMoney(-50, 0),
+			PercentUsed:   125,
+		},
+	}, summary.Categories)
+	require.Equal(t, util.NewMoney(1000, 0), summary.Available)
+	require.Equal(t, util.NewMoney(850, 0), summary.Spent)
+	require.Equal(t, util.NewMoney(150, 0), summary.Remaining)
+	require.Equal(t, 85.0, summary.PercentUsed)
+
+	// o orçamento avulso de março não vale em abril
+	summary = Summarize(budgets, nil, month(2024, 4))
+	require.Len(t, summary.Categories, 1)
+	require.Equal(t, int32(10), summary.Categories[0].CategoryID)
+}
+
+func TestSummarizeSingleMonthOverride(t *testing.T) {
+	budgets := []db.GetBudgetsUntilRow{
+		{ID: 1, CategoryID: 10, Month: month(2024, 1), Amount: util.NewMoney(800, 0), Recurring: true},
+		{ID: 2, CategoryID: 10, Month: month(2024, 2), Amount: util.NewMoney(1200, 0)},
+		{ID: 3, CategoryID: 10, Month: month(2024, 4), Amount: util.NewMoney(900, 0), Recurring: true},
+	}
+
+	// o avulso de fevereiro vale só em fevereiro: em março volta o recorrente de janeiro
+	testCases := map[time.Month]int32{
+		time.January:  1,
+		time.February: 2,
+		time.March:    1,
+		time.April:    3,
+		time.May:      3,
+	}
+	for m, budgetID := range testCases {
+		summary := Summarize(budgets, nil, month(2024, m))
+		require.Len(t, summary.Categories, 1, m.String())
+		require.Equal(t, budgetID, summary.Categories[0].BudgetID, m.String())
+	}
+}
+
+func TestSummarizeRollover(t *testing.T) {
+	budgets := []db.GetBudgetsUntilRow{
+		{ID: 1, CategoryID: 10, Month: month(2024, 1), Amount: util.NewMoney(100, 0), Recurring: true, Rollover: true},
+	}
+	rows := []db.GetBudgetSpendingRow{
+		spending(10, month(2024, 1), util.NewMoney(60, 0)),
+		spending(10, month(2024, 2), util.NewMoney(90, 0)),
+		spending(10, month(2024, 3), util.NewMoney(200, 0)),
+	}
+
+	// janeiro sobra 40, fevereiro tem 140 e sobra 50, março tem 150 e estoura
+	summary := Summarize(budgets, rows, month(2024, 3))
+	progress := summary.Categories[0]
+	require.Equal(t, util.NewMoney(50, 0), progress.RolledOver)
+	require.Equal(t, util.NewMoney(150, 0), progress.Available)
+	require.Equal(t, util.NewMoney(-50, 0), progress.Remaining)
+	require.Equal(t, 133.33, progress.PercentUsed)
+
+	// o estouro não é descontado do mês seguinte
+	summary = Summarize(budgets, rows, month(2024, 4))
+	require.Zero(t, summary.Categories[0].RolledOver)
+	require.Equal(t, util.NewMoney(100, 0), summary.Categories[0].Available)
+}
+
+func TestSummarizeRolloverNeedsBudgetInPreviousMonth(t *testing.T) {
+	budgets := []db.GetBudgetsUntilRow{
+		{ID: 1, CategoryID: 10, Month: month(2024, 1), Amount: util.NewMoney(100, 0), Rollover: true},
+		{ID: 2, CategoryID: 10, Month: month(2024, 3), Amount: util.NewMoney(100, 0), Rollover: true},
+	}
+
+	summary := Summarize(budgets, nil, month(2024, 3))
+	require.Zero(t, summary.Categories[0].RolledOver)
+
+	summary = Summarize(budgets, nil, month(2024, 2))
+	require.Empty(t, summary.Categories)
+	require.Zero(t, summary.PercentUsed)
+}
@@ -0,0 +1,65 @@
+// loadrates carrega taxas de câmbio de um arquivo CSV ou XML do BCE na tabela exchange_rates
+//
+//	go run ./cmd/loadrates -file eurofxref-hist.xml
+//	go run ./cmd/loadrates -file rates.csv -format csv
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/exchangerate"
+	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	path := flag.String("file", "", "arquivo com as taxas de câmbio")
+	format := flag.String("format", "", "csv ou ecb; pela extensão do arquivo quando vazio")
+	flag.Parse()
+
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format == "" {
+		*format = exchangerate.FormatCSV
+		if strings.EqualFold(filepath.Ext(*path), ".xml") {
+			*format = exchangerate.FormatECB
+		}
+	}
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatal("cannot open rates file: ", err)
+	}
+	defer file.Close()
+
+	rates, err := exchangerate.Parse(file, *format)
+	if err != nil {
+		log.Fatal("cannot parse rates file: ", err)
+	}
+
+	conn, err := sql.Open(os.Getenv("DB_DRIVER"), os.Getenv("DB_SOURCE"))
+	if err != nil {
+		log.Fatal("cannot connect to db: ", err)
+	}
+	defer conn.Close()
+
+	loaded, err := exchangerate.Load(context.Background(), db.New(conn), rates)
+	if err != nil {
+		log.Fatal("cannot load rates: ", err)
+	}
+	log.Printf("loaded %d exchange rates from %s", loaded, *path)
+}
@@ -0,0 +1,23 @@
+CREATE TABLE "sessions" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "user_id" int NOT NULL,
+  "refresh_token_hash" varchar UNIQUE NOT NULL,
+  "user_agent" varchar NOT NULL,
+  "client_ip" varchar NOT NULL,
+  "expires_at" timestamp NOT NULL,
+  "revoked_at" timestamp,
+  "last_used_at" timestamp NOT NULL DEFAULT (now()),
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "sessions" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE INDEX ON "sessions" ("user_id");
+
+CREATE TABLE "session_rotated_tokens" (
+  "refresh_token_hash" varchar PRIMARY KEY NOT NULL,
+  "session_id" int NOT NULL,
+  "rotated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "session_rotated_tokens" ADD FOREIGN KEY ("session_id") REFERENCES "sessions" ("id") ON DELETE CASCADE;
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE "users" ADD COLUMN "email_verified_at" timestamp;
+
+CREATE TABLE "user_tokens" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "user_id" int NOT NULL,
+  "purpose" varchar NOT NULL,
+  "token_hash" varchar UNIQUE NOT NULL,
+  "expires_at" timestamp NOT NULL,
+  "used_at" timestamp,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "user_tokens" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE INDEX ON "user_tokens" ("user_id", "purpose");
\ No newline at end of file
@@ -0,0 +1,21 @@
+CREATE TABLE "user_totp" (
+  "user_id" int PRIMARY KEY NOT NULL,
+  "secret" varchar NOT NULL,
+  "confirmed_at" timestamp,
+  "last_used_counter" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "user_totp" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE TABLE "user_recovery_codes" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "user_id" int NOT NULL,
+  "code_hash" varchar NOT NULL,
+  "used_at" timestamp,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "user_recovery_codes" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE UNIQUE INDEX ON "user_recovery_codes" ("user_id", "code_hash");
\ No newline at end of file
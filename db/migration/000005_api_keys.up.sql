@@ -0,0 +1,16 @@
+CREATE TABLE "api_keys" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "user_id" int NOT NULL,
+  "name" varchar NOT NULL,
+  "prefix" varchar NOT NULL,
+  "key_hash" varchar UNIQUE NOT NULL,
+  "scopes" varchar[] NOT NULL,
+  "expires_at" timestamp,
+  "last_used_at" timestamp,
+  "revoked_at" timestamp,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "api_keys" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE INDEX ON "api_keys" ("user_id");
\ No newline at end of file
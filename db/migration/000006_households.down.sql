@@ -0,0 +1,5 @@
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "household_id";
+ALTER TABLE "categories" DROP COLUMN IF EXISTS "household_id";
+DROP TABLE IF EXISTS "household_invitations";
+DROP TABLE IF EXISTS "household_members";
+DROP TABLE IF EXISTS "households";
\ No newline at end of file
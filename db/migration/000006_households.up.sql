@@ -0,0 +1,45 @@
+CREATE TABLE "households" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "name" varchar NOT NULL,
+  "created_by" int NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "households" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+CREATE TABLE "household_members" (
+  "household_id" int NOT NULL,
+  "user_id" int NOT NULL,
+  "role" varchar NOT NULL CHECK ("role" IN ('owner', 'editor', 'viewer')),
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("household_id", "user_id")
+);
+
+ALTER TABLE "household_members" ADD FOREIGN KEY ("household_id") REFERENCES "households" ("id") ON DELETE CASCADE;
+ALTER TABLE "household_members" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE INDEX ON "household_members" ("user_id");
+
+CREATE TABLE "household_invitations" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "household_id" int NOT NULL,
+  "email" varchar NOT NULL,
+  "role" varchar NOT NULL CHECK ("role" IN ('owner', 'editor', 'viewer')),
+  "token_hash" varchar UNIQUE NOT NULL,
+  "invited_by" int NOT NULL,
+  "expires_at" timestamp NOT NULL,
+  "accepted_at" timestamp,
+  "declined_at" timestamp,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "household_invitations" ADD FOREIGN KEY ("household_id") REFERENCES "households" ("id") ON DELETE CASCADE;
+ALTER TABLE "household_invitations" ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("id");
+
+ALTER TABLE "categories" ADD COLUMN "household_id" int;
+ALTER TABLE "categories" ADD FOREIGN KEY ("household_id") REFERENCES "households" ("id");
+CREATE INDEX ON "categories" ("household_id");
+
+ALTER TABLE "accounts" ADD COLUMN "household_id" int;
+ALTER TABLE "accounts" ADD FOREIGN KEY ("household_id") REFERENCES "households" ("id");
+CREATE INDEX ON "accounts" ("household_id");
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE "accounts" ALTER COLUMN "value" TYPE integer USING ROUND("value" / 100.0)::integer;
+DROP DOMAIN IF EXISTS "money_minor";
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- valores em centavos; o domínio permite mapear todas as colunas de dinheiro para util.Money
+CREATE DOMAIN "money_minor" AS bigint;
+
+-- os valores eram guardados em unidades inteiras; passam a ser centavos
+ALTER TABLE "accounts" ALTER COLUMN "value" TYPE money_minor USING "value"::bigint * 100;
\ No newline at end of file
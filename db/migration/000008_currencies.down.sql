@@ -0,0 +1,4 @@
+DROP FUNCTION IF EXISTS exchange_rate(varchar, varchar, date);
+DROP TABLE IF EXISTS "exchange_rates";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "currency";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "base_currency";
\ No newline at end of file
@@ -0,0 +1,43 @@
+ALTER TABLE "users" ADD COLUMN "base_currency" varchar(3) NOT NULL DEFAULT 'BRL';
+
+-- as transações existentes ficam na moeda base do usuário
+ALTER TABLE "accounts" ADD COLUMN "currency" varchar(3);
+UPDATE "accounts" SET "currency" = "users"."base_currency" FROM "users" WHERE "users"."id" = "accounts"."user_id";
+ALTER TABLE "accounts" ALTER COLUMN "currency" SET NOT NULL;
+
+-- 1 base_currency vale rate quote_currency a partir de effective_date
+CREATE TABLE "exchange_rates" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "base_currency" varchar(3) NOT NULL,
+  "quote_currency" varchar(3) NOT NULL,
+  "rate" numeric(20, 10) NOT NULL CHECK ("rate" > 0),
+  "effective_date" date NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("base_currency", "quote_currency", "effective_date")
+);
+
+CREATE INDEX ON "exchange_rates" ("quote_currency", "base_currency", "effective_date");
+
+-- exchange_rate devolve a taxa em vigor na data: direta, inversa ou cruzada por uma moeda
+-- em comum (as taxas do BCE são todas contra EUR); NULL quando não há taxa
+CREATE FUNCTION exchange_rate(from_currency varchar, to_currency varchar, on_date date) RETURNS numeric AS $$
+  SELECT CASE WHEN from_currency = to_currency THEN 1::numeric ELSE COALESCE(
+    (
+      SELECT r.rate FROM exchange_rates r
+      WHERE r.base_currency = from_currency AND r.quote_currency = to_currency AND r.effective_date <= on_date
+      ORDER BY r.effective_date DESC LIMIT 1
+    ),
+    (
+      SELECT 1 / r.rate FROM exchange_rates r
+      WHERE r.base_currency = to_currency AND r.quote_currency = from_currency AND r.effective_date <= on_date
+      ORDER BY r.effective_date DESC LIMIT 1
+    ),
+    (
+      SELECT q.rate / f.rate FROM exchange_rates f
+      JOIN exchange_rates q ON q.base_currency = f.base_currency
+      WHERE f.quote_currency = from_currency AND q.quote_currency = to_currency
+      AND f.effective_date <= on_date AND q.effective_date <= on_date
+      ORDER BY f.effective_date DESC, q.effective_date DESC LIMIT 1
+    )
+  ) END
+$$ LANGUAGE sql STABLE;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP FUNCTION IF EXISTS balance_change(varchar, bigint);
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "wallet_id";
+DROP TABLE IF EXISTS "wallets";
\ No newline at end of file
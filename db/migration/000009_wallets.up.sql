@@ -0,0 +1,41 @@
+CREATE TABLE "wallets" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "user_id" int NOT NULL,
+  "household_id" int,
+  "name" varchar NOT NULL,
+  "kind" varchar NOT NULL CHECK ("kind" IN ('checking', 'savings', 'credit_card', 'cash')),
+  "currency" varchar(3) NOT NULL,
+  "opening_balance" money_minor NOT NULL DEFAULT 0,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("id", "currency")
+);
+
+ALTER TABLE "wallets" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "wallets" ADD FOREIGN KEY ("household_id") REFERENCES "households" ("id");
+CREATE INDEX ON "wallets" ("user_id");
+CREATE INDEX ON "wallets" ("household_id");
+
+-- as transações existentes vão para uma carteira por escopo e moeda
+INSERT INTO "wallets" ("user_id", "household_id", "name", "kind", "currency")
+SELECT DISTINCT ON ("household_id", CASE WHEN "household_id" IS NULL THEN "user_id" END, "currency")
+  "user_id", "household_id", 'Carteira', 'cash', "currency"
+FROM "accounts"
+ORDER BY "household_id", CASE WHEN "household_id" IS NULL THEN "user_id" END, "currency", "id";
+
+ALTER TABLE "accounts" ADD COLUMN "wallet_id" int;
+UPDATE "accounts" SET "wallet_id" = "wallets"."id" FROM "wallets"
+WHERE "wallets"."currency" = "accounts"."currency"
+AND (
+  ("accounts"."household_id" IS NULL AND "wallets"."household_id" IS NULL AND "wallets"."user_id" = "accounts"."user_id")
+  OR "wallets"."household_id" = "accounts"."household_id"
+);
+ALTER TABLE "accounts" ALTER COLUMN "wallet_id" SET NOT NULL;
+
+-- a transação fica sempre na moeda da carteira
+ALTER TABLE "accounts" ADD FOREIGN KEY ("wallet_id", "currency") REFERENCES "wallets" ("id", "currency");
+CREATE INDEX ON "accounts" ("wallet_id", "date");
+
+-- balance_change é o efeito da transação no saldo: crédito soma e débito subtrai
+CREATE FUNCTION balance_change(type varchar, value bigint) RETURNS bigint AS $$
+  SELECT CASE type WHEN 'credit' THEN value WHEN 'debit' THEN -value ELSE 0 END
+$$ LANGUAGE sql IMMUTABLE;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DELETE FROM "accounts" WHERE "transfer_id" IS NOT NULL;
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "transfer_id";
+ALTER TABLE "accounts" ALTER COLUMN "category_id" SET NOT NULL;
+DROP TABLE IF EXISTS "transfers";
\ No newline at end of file
@@ -0,0 +1,28 @@
+CREATE TABLE "transfers" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "user_id" int NOT NULL,
+  "household_id" int,
+  "from_wallet_id" int NOT NULL,
+  "to_wallet_id" int NOT NULL,
+  "title" varchar NOT NULL,
+  "description" varchar NOT NULL,
+  "value" money_minor NOT NULL CHECK ("value" > 0),
+  "to_value" money_minor NOT NULL CHECK ("to_value" > 0),
+  "date" date NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  CHECK ("from_wallet_id" <> "to_wallet_id")
+);
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "transfers" ADD FOREIGN KEY ("household_id") REFERENCES "households" ("id");
+ALTER TABLE "transfers" ADD FOREIGN KEY ("from_wallet_id") REFERENCES "wallets" ("id");
+ALTER TABLE "transfers" ADD FOREIGN KEY ("to_wallet_id") REFERENCES "wallets" ("id");
+
+-- cada transferência gera um débito na carteira de origem e um crédito na de destino;
+-- essas transações não têm categoria e saem dos relatórios
+ALTER TABLE "accounts" ADD COLUMN "transfer_id" int;
+ALTER TABLE "accounts" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id") ON DELETE CASCADE;
+CREATE INDEX ON "accounts" ("transfer_id");
+
+ALTER TABLE "accounts" ALTER COLUMN "category_id" DROP NOT NULL;
+ALTER TABLE "accounts" ADD CHECK (("transfer_id" IS NULL) = ("category_id" IS NOT NULL));
\ No newline at end of file
@@ -0,0 +1,50 @@
+-- regras de transações recorrentes: a regra guarda o modelo da transação e o agendamento
+CREATE TABLE "recurring_rules" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "user_id" int NOT NULL,
+  "household_id" int,
+  "wallet_id" int NOT NULL,
+  "category_id" int NOT NULL,
+  "title" varchar NOT NULL,
+  "type" varchar NOT NULL,
+  "description" varchar NOT NULL,
+  "value" money_minor NOT NULL CHECK ("value" > 0),
+  "frequency" varchar NOT NULL CHECK ("frequency" IN ('daily', 'weekly', 'monthly', 'last_business_day', 'yearly')),
+  "interval" int NOT NULL DEFAULT 1 CHECK ("interval" > 0),
+  "day_of_month" int CHECK ("day_of_month" BETWEEN 1 AND 31),
+  "start_date" date NOT NULL,
+  "end_date" date,
+  "occurrence_count" int CHECK ("occurrence_count" > 0),
+  -- materialized_through é o último dia já processado pelo agendador
+  "materialized_through" date,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  CHECK ("end_date" IS NULL OR "occurrence_count" IS NULL),
+  CHECK ("end_date" IS NULL OR "end_date" >= "start_date")
+);
+
+ALTER TABLE "recurring_rules" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "recurring_rules" ADD FOREIGN KEY ("household_id") REFERENCES "households" ("id");
+-- o modelo não sobrevive à carteira ou à categoria
+ALTER TABLE "recurring_rules" ADD FOREIGN KEY ("wallet_id") REFERENCES "wallets" ("id") ON DELETE CASCADE;
+ALTER TABLE "recurring_rules" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE CASCADE;
+CREATE INDEX ON "recurring_rules" ("user_id");
+CREATE INDEX ON "recurring_rules" ("household_id");
+
+-- uma linha por ocorrência pulada, alterada ou já materializada; occurrence_date é a data
+-- gerada pela regra e date a data da transação
+CREATE TABLE "recurring_occurrences" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "rule_id" int NOT NULL,
+  "occurrence_date" date NOT NULL,
+  "date" date NOT NULL,
+  "title" varchar NOT NULL,
+  "description" varchar NOT NULL,
+  "value" money_minor NOT NULL CHECK ("value" > 0),
+  "skipped" boolean NOT NULL DEFAULT false,
+  "account_id" int,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("rule_id", "occurrence_date")
+);
+
+ALTER TABLE "recurring_occurrences" ADD FOREIGN KEY ("rule_id") REFERENCES "recurring_rules" ("id") ON DELETE CASCADE;
+ALTER TABLE "recurring_occurrences" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE SET NULL;
\ No newline at end of file
@@ -0,0 +1,21 @@
+-- limite de gastos por categoria e mês; um orçamento recorrente vale do mês dele em diante,
+-- até o próximo recorrente da categoria, e um orçamento avulso vale só no próprio mês
+CREATE TABLE "budgets" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "user_id" int NOT NULL,
+  "household_id" int,
+  "category_id" int NOT NULL,
+  "month" date NOT NULL CHECK (EXTRACT(DAY FROM "month") = 1),
+  "amount" money_minor NOT NULL CHECK ("amount" > 0),
+  "recurring" boolean NOT NULL DEFAULT false,
+  -- rollover soma ao limite o que sobrou do mês anterior
+  "rollover" boolean NOT NULL DEFAULT false,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("category_id", "month")
+);
+
+ALTER TABLE "budgets" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "budgets" ADD FOREIGN KEY ("household_id") REFERENCES "households" ("id");
+ALTER TABLE "budgets" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE CASCADE;
+CREATE INDEX ON "budgets" ("user_id");
+CREATE INDEX ON "budgets" ("household_id");
\ No newline at end of file
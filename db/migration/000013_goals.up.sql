@@ -0,0 +1,38 @@
+-- metas de economia; a meta pode ficar ligada a uma categoria de receita ou a uma carteira,
+-- e aí só aceita contribuições de transações dela
+CREATE TABLE "goals" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "user_id" int NOT NULL,
+  "household_id" int,
+  "name" varchar NOT NULL,
+  "target_amount" money_minor NOT NULL CHECK ("target_amount" > 0),
+  "currency" varchar(3) NOT NULL,
+  "deadline" date,
+  "category_id" int,
+  "wallet_id" int,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  CHECK ("category_id" IS NULL OR "wallet_id" IS NULL)
+);
+
+ALTER TABLE "goals" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "goals" ADD FOREIGN KEY ("household_id") REFERENCES "households" ("id");
+ALTER TABLE "goals" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE SET NULL;
+ALTER TABLE "goals" ADD FOREIGN KEY ("wallet_id") REFERENCES "wallets" ("id") ON DELETE SET NULL;
+CREATE INDEX ON "goals" ("user_id");
+CREATE INDEX ON "goals" ("household_id");
+
+-- contribuição manual (sem account_id) ou parte de uma transação de receita, na moeda da meta
+CREATE TABLE "goal_contributions" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "goal_id" int NOT NULL,
+  "account_id" int,
+  "amount" money_minor NOT NULL CHECK ("amount" > 0),
+  "date" date NOT NULL,
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("goal_id", "account_id")
+);
+
+ALTER TABLE "goal_contributions" ADD FOREIGN KEY ("goal_id") REFERENCES "goals" ("id") ON DELETE CASCADE;
+ALTER TABLE "goal_contributions" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+CREATE INDEX ON "goal_contributions" ("goal_id", "date");
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "accounts_household_id_type_date_idx";
+DROP INDEX IF EXISTS "accounts_user_id_type_date_idx";
\ No newline at end of file
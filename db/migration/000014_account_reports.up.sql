@@ -0,0 +1,3 @@
+-- relatórios filtram as transações por escopo, tipo e período
+CREATE INDEX "accounts_user_id_type_date_idx" ON "accounts" ("user_id", "type", "date");
+CREATE INDEX "accounts_household_id_type_date_idx" ON "accounts" ("household_id", "type", "date");
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- mapeamento de colunas de CSV salvo por banco, para reaproveitar em outras importações
+CREATE TABLE "import_profiles" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "user_id" int NOT NULL,
+  "name" varchar NOT NULL,
+  "mapping" jsonb NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  UNIQUE ("user_id", "name")
+);
+
+ALTER TABLE "import_profiles" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
\ No newline at end of file
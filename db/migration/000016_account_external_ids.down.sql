@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "accounts_wallet_id_external_id_idx";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "external_id";
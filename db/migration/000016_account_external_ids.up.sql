@@ -0,0 +1,4 @@
+-- identificador da transação no banco (o FITID do OFX), para não importar o mesmo
+-- lançamento duas vezes na carteira
+ALTER TABLE "accounts" ADD COLUMN "external_id" varchar;
+CREATE UNIQUE INDEX "accounts_wallet_id_external_id_idx" ON "accounts" ("wallet_id", "external_id") WHERE "external_id" IS NOT NULL;
@@ -0,0 +1,24 @@
+-- tags marcam transações livremente, várias por transação, e agrupam os relatórios; como as
+-- categorias, são do usuário ou de um household
+CREATE TABLE "tags" (
+  "id" serial PRIMARY KEY NOT NULL,
+  "user_id" int NOT NULL,
+  "household_id" int,
+  "name" varchar NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "tags" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "tags" ADD FOREIGN KEY ("household_id") REFERENCES "households" ("id");
+CREATE UNIQUE INDEX "tags_user_id_name_idx" ON "tags" ("user_id", "name") WHERE "household_id" IS NULL;
+CREATE UNIQUE INDEX "tags_household_id_name_idx" ON "tags" ("household_id", "name") WHERE "household_id" IS NOT NULL;
+
+CREATE TABLE "account_tags" (
+  "account_id" int NOT NULL,
+  "tag_id" int NOT NULL,
+  PRIMARY KEY ("account_id", "tag_id")
+);
+
+ALTER TABLE "account_tags" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+ALTER TABLE "account_tags" ADD FOREIGN KEY ("tag_id") REFERENCES "tags" ("id") ON DELETE CASCADE;
+CREATE INDEX "account_tags_tag_id_idx" ON "account_tags" ("tag_id");
@@ -0,0 +1,3 @@
+ALTER TABLE "recurring_rules" DROP CONSTRAINT IF EXISTS "recurring_rules_type_check";
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "accounts_type_check";
+ALTER TABLE "categories" DROP CONSTRAINT IF EXISTS "categories_type_check";
@@ -0,0 +1,4 @@
+-- o saldo da carteira e os relatórios só conhecem crédito e débito; outro tipo seria ignorado
+ALTER TABLE "categories" ADD CONSTRAINT "categories_type_check" CHECK ("type" IN ('credit', 'debit'));
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_type_check" CHECK ("type" IN ('credit', 'debit'));
+ALTER TABLE "recurring_rules" ADD CONSTRAINT "recurring_rules_type_check" CHECK ("type" IN ('credit', 'debit'));
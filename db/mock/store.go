@@ -0,0 +1,1776 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/SraReaper/gofinance-backend/db/sqlc (interfaces: Store)
+//
+// Generated by this command:
+//
+//	mockgen -package mockdb -destination db/mock/store.go github.com/SraReaper/gofinance-backend/db/sqlc Store
+//
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	util "github.com/SraReaper/gofinance-backend/util"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// AcceptHouseholdInvitation mocks base method.
+func (m *MockStore) AcceptHouseholdInvitation(arg0 context.Context, arg1 db.AcceptHouseholdInvitationParams) (db.HouseholdMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptHouseholdInvitation", arg0, arg1)
+	ret0, _ := ret[0].(db.HouseholdMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptHouseholdInvitation indicates an expected call of AcceptHouseholdInvitation.
+func (mr *MockStoreMockRecorder) AcceptHouseholdInvitation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptHouseholdInvitation", reflect.TypeOf((*MockStore)(nil).AcceptHouseholdInvitation), arg0, arg1)
+}
+
+// AccountExternalIDExists mocks base method.
+func (m *MockStore) AccountExternalIDExists(arg0 context.Context, arg1 db.AccountExternalIDExistsParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountExternalIDExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountExternalIDExists indicates an expected call of AccountExternalIDExists.
+func (mr *MockStoreMockRecorder) AccountExternalIDExists(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountExternalIDExists", reflect.TypeOf((*MockStore)(nil).AccountExternalIDExists), arg0, arg1)
+}
+
+// AddAccountTag mocks base method.
+func (m *MockStore) AddAccountTag(arg0 context.Context, arg1 db.AddAccountTagParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAccountTag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAccountTag indicates an expected call of AddAccountTag.
+func (mr *MockStoreMockRecorder) AddAccountTag(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountTag", reflect.TypeOf((*MockStore)(nil).AddAccountTag), arg0, arg1)
+}
+
+// ConfirmUserTOTP mocks base method.
+func (m *MockStore) ConfirmUserTOTP(arg0 context.Context, arg1 db.ConfirmUserTOTPParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmUserTOTP", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmUserTOTP indicates an expected call of ConfirmUserTOTP.
+func (mr *MockStoreMockRecorder) ConfirmUserTOTP(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmUserTOTP", reflect.TypeOf((*MockStore)(nil).ConfirmUserTOTP), arg0, arg1)
+}
+
+// ConsumeUserToken mocks base method.
+func (m *MockStore) ConsumeUserToken(arg0 context.Context, arg1 db.ConsumeUserTokenParams) (db.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeUserToken", arg0, arg1)
+	ret0, _ := ret[0].(db.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeUserToken indicates an expected call of ConsumeUserToken.
+func (mr *MockStoreMockRecorder) ConsumeUserToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeUserToken", reflect.TypeOf((*MockStore)(nil).ConsumeUserToken), arg0, arg1)
+}
+
+// CountHouseholdOwners mocks base method.
+func (m *MockStore) CountHouseholdOwners(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountHouseholdOwners", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountHouseholdOwners indicates an expected call of CountHouseholdOwners.
+func (mr *MockStoreMockRecorder) CountHouseholdOwners(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountHouseholdOwners", reflect.TypeOf((*MockStore)(nil).CountHouseholdOwners), arg0, arg1)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockStore) CreateAPIKey(arg0 context.Context, arg1 db.CreateAPIKeyParams) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockStoreMockRecorder) CreateAPIKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockStore)(nil).CreateAPIKey), arg0, arg1)
+}
+
+// CreateAccount mocks base method.
+func (m *MockStore) CreateAccount(arg0 context.Context, arg1 db.CreateAccountParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockStoreMockRecorder) CreateAccount(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), arg0, arg1)
+}
+
+// CreateBudget mocks base method.
+func (m *MockStore) CreateBudget(arg0 context.Context, arg1 db.CreateBudgetParams) (db.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBudget", arg0, arg1)
+	ret0, _ := ret[0].(db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBudget indicates an expected call of CreateBudget.
+func (mr *MockStoreMockRecorder) CreateBudget(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBudget", reflect.TypeOf((*MockStore)(nil).CreateBudget), arg0, arg1)
+}
+
+// CreateCategory mocks base method.
+func (m *MockStore) CreateCategory(arg0 context.Context, arg1 db.CreateCategoryParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockStoreMockRecorder) CreateCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0, arg1)
+}
+
+// CreateGoal mocks base method.
+func (m *MockStore) CreateGoal(arg0 context.Context, arg1 db.CreateGoalParams) (db.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoal", arg0, arg1)
+	ret0, _ := ret[0].(db.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoal indicates an expected call of CreateGoal.
+func (mr *MockStoreMockRecorder) CreateGoal(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoal", reflect.TypeOf((*MockStore)(nil).CreateGoal), arg0, arg1)
+}
+
+// CreateGoalContribution mocks base method.
+func (m *MockStore) CreateGoalContribution(arg0 context.Context, arg1 db.CreateGoalContributionParams) (db.GoalContribution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoalContribution", arg0, arg1)
+	ret0, _ := ret[0].(db.GoalContribution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoalContribution indicates an expected call of CreateGoalContribution.
+func (mr *MockStoreMockRecorder) CreateGoalContribution(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoalContribution", reflect.TypeOf((*MockStore)(nil).CreateGoalContribution), arg0, arg1)
+}
+
+// CreateHousehold mocks base method.
+func (m *MockStore) CreateHousehold(arg0 context.Context, arg1 db.CreateHouseholdParams) (db.CreateHouseholdRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHousehold", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateHouseholdRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHousehold indicates an expected call of CreateHousehold.
+func (mr *MockStoreMockRecorder) CreateHousehold(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHousehold", reflect.TypeOf((*MockStore)(nil).CreateHousehold), arg0, arg1)
+}
+
+// CreateHouseholdInvitation mocks base method.
+func (m *MockStore) CreateHouseholdInvitation(arg0 context.Context, arg1 db.CreateHouseholdInvitationParams) (db.HouseholdInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHouseholdInvitation", arg0, arg1)
+	ret0, _ := ret[0].(db.HouseholdInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHouseholdInvitation indicates an expected call of CreateHouseholdInvitation.
+func (mr *MockStoreMockRecorder) CreateHouseholdInvitation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHouseholdInvitation", reflect.TypeOf((*MockStore)(nil).CreateHouseholdInvitation), arg0, arg1)
+}
+
+// CreateImportProfile mocks base method.
+func (m *MockStore) CreateImportProfile(arg0 context.Context, arg1 db.CreateImportProfileParams) (db.ImportProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImportProfile", arg0, arg1)
+	ret0, _ := ret[0].(db.ImportProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateImportProfile indicates an expected call of CreateImportProfile.
+func (mr *MockStoreMockRecorder) CreateImportProfile(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImportProfile", reflect.TypeOf((*MockStore)(nil).CreateImportProfile), arg0, arg1)
+}
+
+// CreateRecoveryCode mocks base method.
+func (m *MockStore) CreateRecoveryCode(arg0 context.Context, arg1 db.CreateRecoveryCodeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRecoveryCode indicates an expected call of CreateRecoveryCode.
+func (mr *MockStoreMockRecorder) CreateRecoveryCode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecoveryCode", reflect.TypeOf((*MockStore)(nil).CreateRecoveryCode), arg0, arg1)
+}
+
+// CreateRecurringRule mocks base method.
+func (m *MockStore) CreateRecurringRule(arg0 context.Context, arg1 db.CreateRecurringRuleParams) (db.RecurringRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurringRule", arg0, arg1)
+	ret0, _ := ret[0].(db.RecurringRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurringRule indicates an expected call of CreateRecurringRule.
+func (mr *MockStoreMockRecorder) CreateRecurringRule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurringRule", reflect.TypeOf((*MockStore)(nil).CreateRecurringRule), arg0, arg1)
+}
+
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+}
+
+// CreateTag mocks base method.
+func (m *MockStore) CreateTag(arg0 context.Context, arg1 db.CreateTagParams) (db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", arg0, arg1)
+	ret0, _ := ret[0].(db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockStoreMockRecorder) CreateTag(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockStore)(nil).CreateTag), arg0, arg1)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockStore) CreateTransfer(arg0 context.Context, arg1 db.CreateTransferParams) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", arg0, arg1)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockStoreMockRecorder) CreateTransfer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), arg0, arg1)
+}
+
+// CreateTransferAccount mocks base method.
+func (m *MockStore) CreateTransferAccount(arg0 context.Context, arg1 db.CreateTransferAccountParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferAccount indicates an expected call of CreateTransferAccount.
+func (mr *MockStoreMockRecorder) CreateTransferAccount(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferAccount", reflect.TypeOf((*MockStore)(nil).CreateTransferAccount), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// CreateUserToken mocks base method.
+func (m *MockStore) CreateUserToken(arg0 context.Context, arg1 db.CreateUserTokenParams) (db.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserToken", arg0, arg1)
+	ret0, _ := ret[0].(db.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserToken indicates an expected call of CreateUserToken.
+func (mr *MockStoreMockRecorder) CreateUserToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserToken", reflect.TypeOf((*MockStore)(nil).CreateUserToken), arg0, arg1)
+}
+
+// CreateWallet mocks base method.
+func (m *MockStore) CreateWallet(arg0 context.Context, arg1 db.CreateWalletParams) (db.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWallet", arg0, arg1)
+	ret0, _ := ret[0].(db.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWallet indicates an expected call of CreateWallet.
+func (mr *MockStoreMockRecorder) CreateWallet(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallet", reflect.TypeOf((*MockStore)(nil).CreateWallet), arg0, arg1)
+}
+
+// DeclineHouseholdInvitation mocks base method.
+func (m *MockStore) DeclineHouseholdInvitation(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineHouseholdInvitation", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclineHouseholdInvitation indicates an expected call of DeclineHouseholdInvitation.
+func (mr *MockStoreMockRecorder) DeclineHouseholdInvitation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineHouseholdInvitation", reflect.TypeOf((*MockStore)(nil).DeclineHouseholdInvitation), arg0, arg1)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockStore) DeleteAccount(arg0 context.Context, arg1 db.DeleteAccountParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockStoreMockRecorder) DeleteAccount(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), arg0, arg1)
+}
+
+// DeleteAccountTags mocks base method.
+func (m *MockStore) DeleteAccountTags(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccountTags", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccountTags indicates an expected call of DeleteAccountTags.
+func (mr *MockStoreMockRecorder) DeleteAccountTags(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountTags", reflect.TypeOf((*MockStore)(nil).DeleteAccountTags), arg0, arg1)
+}
+
+// DeleteBudget mocks base method.
+func (m *MockStore) DeleteBudget(arg0 context.Context, arg1 db.DeleteBudgetParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBudget", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBudget indicates an expected call of DeleteBudget.
+func (mr *MockStoreMockRecorder) DeleteBudget(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBudget", reflect.TypeOf((*MockStore)(nil).DeleteBudget), arg0, arg1)
+}
+
+// DeleteCategories mocks base method.
+func (m *MockStore) DeleteCategories(arg0 context.Context, arg1 db.DeleteCategoriesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategories", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCategories indicates an expected call of DeleteCategories.
+func (mr *MockStoreMockRecorder) DeleteCategories(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategories", reflect.TypeOf((*MockStore)(nil).DeleteCategories), arg0, arg1)
+}
+
+// DeleteGoal mocks base method.
+func (m *MockStore) DeleteGoal(arg0 context.Context, arg1 db.DeleteGoalParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoal", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGoal indicates an expected call of DeleteGoal.
+func (mr *MockStoreMockRecorder) DeleteGoal(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoal", reflect.TypeOf((*MockStore)(nil).DeleteGoal), arg0, arg1)
+}
+
+// DeleteGoalContribution mocks base method.
+func (m *MockStore) DeleteGoalContribution(arg0 context.Context, arg1 db.DeleteGoalContributionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoalContribution", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGoalContribution indicates an expected call of DeleteGoalContribution.
+func (mr *MockStoreMockRecorder) DeleteGoalContribution(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoalContribution", reflect.TypeOf((*MockStore)(nil).DeleteGoalContribution), arg0, arg1)
+}
+
+// DeleteHouseholdMember mocks base method.
+func (m *MockStore) DeleteHouseholdMember(arg0 context.Context, arg1 db.DeleteHouseholdMemberParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHouseholdMember", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHouseholdMember indicates an expected call of DeleteHouseholdMember.
+func (mr *MockStoreMockRecorder) DeleteHouseholdMember(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHouseholdMember", reflect.TypeOf((*MockStore)(nil).DeleteHouseholdMember), arg0, arg1)
+}
+
+// DeleteImportProfile mocks base method.
+func (m *MockStore) DeleteImportProfile(arg0 context.Context, arg1 db.DeleteImportProfileParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImportProfile", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteImportProfile indicates an expected call of DeleteImportProfile.
+func (mr *MockStoreMockRecorder) DeleteImportProfile(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImportProfile", reflect.TypeOf((*MockStore)(nil).DeleteImportProfile), arg0, arg1)
+}
+
+// DeleteRecoveryCodes mocks base method.
+func (m *MockStore) DeleteRecoveryCodes(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecoveryCodes indicates an expected call of DeleteRecoveryCodes.
+func (mr *MockStoreMockRecorder) DeleteRecoveryCodes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecoveryCodes", reflect.TypeOf((*MockStore)(nil).DeleteRecoveryCodes), arg0, arg1)
+}
+
+// DeleteRecurringRule mocks base method.
+func (m *MockStore) DeleteRecurringRule(arg0 context.Context, arg1 db.DeleteRecurringRuleParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecurringRule", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRecurringRule indicates an expected call of DeleteRecurringRule.
+func (mr *MockStoreMockRecorder) DeleteRecurringRule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecurringRule", reflect.TypeOf((*MockStore)(nil).DeleteRecurringRule), arg0, arg1)
+}
+
+// DeleteTag mocks base method.
+func (m *MockStore) DeleteTag(arg0 context.Context, arg1 db.DeleteTagParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockStoreMockRecorder) DeleteTag(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockStore)(nil).DeleteTag), arg0, arg1)
+}
+
+// DeleteTransfer mocks base method.
+func (m *MockStore) DeleteTransfer(arg0 context.Context, arg1 db.DeleteTransferParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransfer", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTransfer indicates an expected call of DeleteTransfer.
+func (mr *MockStoreMockRecorder) DeleteTransfer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransfer", reflect.TypeOf((*MockStore)(nil).DeleteTransfer), arg0, arg1)
+}
+
+// DeleteUserTOTP mocks base method.
+func (m *MockStore) DeleteUserTOTP(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTOTP", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserTOTP indicates an expected call of DeleteUserTOTP.
+func (mr *MockStoreMockRecorder) DeleteUserTOTP(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTOTP", reflect.TypeOf((*MockStore)(nil).DeleteUserTOTP), arg0, arg1)
+}
+
+// DeleteWallet mocks base method.
+func (m *MockStore) DeleteWallet(arg0 context.Context, arg1 db.DeleteWalletParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWallet", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWallet indicates an expected call of DeleteWallet.
+func (mr *MockStoreMockRecorder) DeleteWallet(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWallet", reflect.TypeOf((*MockStore)(nil).DeleteWallet), arg0, arg1)
+}
+
+// ExecTx mocks base method.
+func (m *MockStore) ExecTx(arg0 context.Context, arg1 func(db.Querier) error, arg2 ...db.TxOption) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExecTx", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecTx indicates an expected call of ExecTx.
+func (mr *MockStoreMockRecorder) ExecTx(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecTx", reflect.TypeOf((*MockStore)(nil).ExecTx), varargs...)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockStore) GetAPIKeys(arg0 context.Context, arg1 int32) ([]db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", arg0, arg1)
+	ret0, _ := ret[0].([]db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockStoreMockRecorder) GetAPIKeys(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockStore)(nil).GetAPIKeys), arg0, arg1)
+}
+
+// GetAccount mocks base method.
+func (m *MockStore) GetAccount(arg0 context.Context, arg1 db.GetAccountParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockStoreMockRecorder) GetAccount(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), arg0, arg1)
+}
+
+// GetAccountTags mocks base method.
+func (m *MockStore) GetAccountTags(arg0 context.Context, arg1 int32) ([]db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountTags", arg0, arg1)
+	ret0, _ := ret[0].([]db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountTags indicates an expected call of GetAccountTags.
+func (mr *MockStoreMockRecorder) GetAccountTags(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTags", reflect.TypeOf((*MockStore)(nil).GetAccountTags), arg0, arg1)
+}
+
+// GetAccounts mocks base method.
+func (m *MockStore) GetAccounts(arg0 context.Context, arg1 db.GetAccountsParams) ([]db.GetAccountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetAccountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccounts indicates an expected call of GetAccounts.
+func (mr *MockStoreMockRecorder) GetAccounts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccounts", reflect.TypeOf((*MockStore)(nil).GetAccounts), arg0, arg1)
+}
+
+// GetAccountsReports mocks base method.
+func (m *MockStore) GetAccountsReports(arg0 context.Context, arg1 db.GetAccountsReportsParams) (db.GetAccountsReportsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountsReports", arg0, arg1)
+	ret0, _ := ret[0].(db.GetAccountsReportsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountsReports indicates an expected call of GetAccountsReports.
+func (mr *MockStoreMockRecorder) GetAccountsReports(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountsReports", reflect.TypeOf((*MockStore)(nil).GetAccountsReports), arg0, arg1)
+}
+
+// GetAccountsSummary mocks base method.
+func (m *MockStore) GetAccountsSummary(arg0 context.Context, arg1 db.GetAccountsSummaryParams) (db.GetAccountsSummaryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountsSummary", arg0, arg1)
+	ret0, _ := ret[0].(db.GetAccountsSummaryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountsSummary indicates an expected call of GetAccountsSummary.
+func (mr *MockStoreMockRecorder) GetAccountsSummary(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountsSummary", reflect.TypeOf((*MockStore)(nil).GetAccountsSummary), arg0, arg1)
+}
+
+// GetBudget mocks base method.
+func (m *MockStore) GetBudget(arg0 context.Context, arg1 db.GetBudgetParams) (db.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudget", arg0, arg1)
+	ret0, _ := ret[0].(db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBudget indicates an expected call of GetBudget.
+func (mr *MockStoreMockRecorder) GetBudget(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudget", reflect.TypeOf((*MockStore)(nil).GetBudget), arg0, arg1)
+}
+
+// GetBudgetSpending mocks base method.
+func (m *MockStore) GetBudgetSpending(arg0 context.Context, arg1 db.GetBudgetSpendingParams) ([]db.GetBudgetSpendingRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudgetSpending", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetBudgetSpendingRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBudgetSpending indicates an expected call of GetBudgetSpending.
+func (mr *MockStoreMockRecorder) GetBudgetSpending(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgetSpending", reflect.TypeOf((*MockStore)(nil).GetBudgetSpending), arg0, arg1)
+}
+
+// GetBudgets mocks base method.
+func (m *MockStore) GetBudgets(arg0 context.Context, arg1 db.GetBudgetsParams) ([]db.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudgets", arg0, arg1)
+	ret0, _ := ret[0].([]db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBudgets indicates an expected call of GetBudgets.
+func (mr *MockStoreMockRecorder) GetBudgets(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgets", reflect.TypeOf((*MockStore)(nil).GetBudgets), arg0, arg1)
+}
+
+// GetBudgetsUntil mocks base method.
+func (m *MockStore) GetBudgetsUntil(arg0 context.Context, arg1 db.GetBudgetsUntilParams) ([]db.GetBudgetsUntilRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudgetsUntil", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetBudgetsUntilRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBudgetsUntil indicates an expected call of GetBudgetsUntil.
+func (mr *MockStoreMockRecorder) GetBudgetsUntil(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgetsUntil", reflect.TypeOf((*MockStore)(nil).GetBudgetsUntil), arg0, arg1)
+}
+
+// GetCategories mocks base method.
+func (m *MockStore) GetCategories(arg0 context.Context, arg1 db.GetCategoriesParams) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategories", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *MockStoreMockRecorder) GetCategories(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockStore)(nil).GetCategories), arg0, arg1)
+}
+
+// GetCategory mocks base method.
+func (m *MockStore) GetCategory(arg0 context.Context, arg1 db.GetCategoryParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockStoreMockRecorder) GetCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0, arg1)
+}
+
+// GetDueRecurringRules mocks base method.
+func (m *MockStore) GetDueRecurringRules(arg0 context.Context, arg1 time.Time) ([]int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueRecurringRules", arg0, arg1)
+	ret0, _ := ret[0].([]int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueRecurringRules indicates an expected call of GetDueRecurringRules.
+func (mr *MockStoreMockRecorder) GetDueRecurringRules(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueRecurringRules", reflect.TypeOf((*MockStore)(nil).GetDueRecurringRules), arg0, arg1)
+}
+
+// GetExchangeRate mocks base method.
+func (m *MockStore) GetExchangeRate(arg0 context.Context, arg1 db.GetExchangeRateParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRate", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRate indicates an expected call of GetExchangeRate.
+func (mr *MockStoreMockRecorder) GetExchangeRate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockStore)(nil).GetExchangeRate), arg0, arg1)
+}
+
+// GetGoal mocks base method.
+func (m *MockStore) GetGoal(arg0 context.Context, arg1 db.GetGoalParams) (db.GetGoalRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoal", arg0, arg1)
+	ret0, _ := ret[0].(db.GetGoalRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoal indicates an expected call of GetGoal.
+func (mr *MockStoreMockRecorder) GetGoal(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoal", reflect.TypeOf((*MockStore)(nil).GetGoal), arg0, arg1)
+}
+
+// GetGoalContribution mocks base method.
+func (m *MockStore) GetGoalContribution(arg0 context.Context, arg1 db.GetGoalContributionParams) (db.GoalContribution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoalContribution", arg0, arg1)
+	ret0, _ := ret[0].(db.GoalContribution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoalContribution indicates an expected call of GetGoalContribution.
+func (mr *MockStoreMockRecorder) GetGoalContribution(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoalContribution", reflect.TypeOf((*MockStore)(nil).GetGoalContribution), arg0, arg1)
+}
+
+// GetGoalContributions mocks base method.
+func (m *MockStore) GetGoalContributions(arg0 context.Context, arg1 int32) ([]db.GoalContribution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoalContributions", arg0, arg1)
+	ret0, _ := ret[0].([]db.GoalContribution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoalContributions indicates an expected call of GetGoalContributions.
+func (mr *MockStoreMockRecorder) GetGoalContributions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoalContributions", reflect.TypeOf((*MockStore)(nil).GetGoalContributions), arg0, arg1)
+}
+
+// GetGoals mocks base method.
+func (m *MockStore) GetGoals(arg0 context.Context, arg1 db.GetGoalsParams) ([]db.GetGoalsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoals", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetGoalsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoals indicates an expected call of GetGoals.
+func (mr *MockStoreMockRecorder) GetGoals(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoals", reflect.TypeOf((*MockStore)(nil).GetGoals), arg0, arg1)
+}
+
+// GetHousehold mocks base method.
+func (m *MockStore) GetHousehold(arg0 context.Context, arg1 db.GetHouseholdParams) (db.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHousehold", arg0, arg1)
+	ret0, _ := ret[0].(db.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHousehold indicates an expected call of GetHousehold.
+func (mr *MockStoreMockRecorder) GetHousehold(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHousehold", reflect.TypeOf((*MockStore)(nil).GetHousehold), arg0, arg1)
+}
+
+// GetHouseholdMember mocks base method.
+func (m *MockStore) GetHouseholdMember(arg0 context.Context, arg1 db.GetHouseholdMemberParams) (db.HouseholdMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseholdMember", arg0, arg1)
+	ret0, _ := ret[0].(db.HouseholdMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHouseholdMember indicates an expected call of GetHouseholdMember.
+func (mr *MockStoreMockRecorder) GetHouseholdMember(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseholdMember", reflect.TypeOf((*MockStore)(nil).GetHouseholdMember), arg0, arg1)
+}
+
+// GetHouseholdMembers mocks base method.
+func (m *MockStore) GetHouseholdMembers(arg0 context.Context, arg1 int32) ([]db.GetHouseholdMembersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseholdMembers", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetHouseholdMembersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHouseholdMembers indicates an expected call of GetHouseholdMembers.
+func (mr *MockStoreMockRecorder) GetHouseholdMembers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseholdMembers", reflect.TypeOf((*MockStore)(nil).GetHouseholdMembers), arg0, arg1)
+}
+
+// GetHouseholds mocks base method.
+func (m *MockStore) GetHouseholds(arg0 context.Context, arg1 int32) ([]db.GetHouseholdsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseholds", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetHouseholdsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHouseholds indicates an expected call of GetHouseholds.
+func (mr *MockStoreMockRecorder) GetHouseholds(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseholds", reflect.TypeOf((*MockStore)(nil).GetHouseholds), arg0, arg1)
+}
+
+// GetImportProfile mocks base method.
+func (m *MockStore) GetImportProfile(arg0 context.Context, arg1 db.GetImportProfileParams) (db.ImportProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportProfile", arg0, arg1)
+	ret0, _ := ret[0].(db.ImportProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportProfile indicates an expected call of GetImportProfile.
+func (mr *MockStoreMockRecorder) GetImportProfile(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportProfile", reflect.TypeOf((*MockStore)(nil).GetImportProfile), arg0, arg1)
+}
+
+// GetImportProfiles mocks base method.
+func (m *MockStore) GetImportProfiles(arg0 context.Context, arg1 int32) ([]db.ImportProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportProfiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.ImportProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportProfiles indicates an expected call of GetImportProfiles.
+func (mr *MockStoreMockRecorder) GetImportProfiles(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportProfiles", reflect.TypeOf((*MockStore)(nil).GetImportProfiles), arg0, arg1)
+}
+
+// GetPendingHouseholdInvitation mocks base method.
+func (m *MockStore) GetPendingHouseholdInvitation(arg0 context.Context, arg1 string) (db.HouseholdInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingHouseholdInvitation", arg0, arg1)
+	ret0, _ := ret[0].(db.HouseholdInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingHouseholdInvitation indicates an expected call of GetPendingHouseholdInvitation.
+func (mr *MockStoreMockRecorder) GetPendingHouseholdInvitation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingHouseholdInvitation", reflect.TypeOf((*MockStore)(nil).GetPendingHouseholdInvitation), arg0, arg1)
+}
+
+// GetPendingHouseholdInvitationsByEmail mocks base method.
+func (m *MockStore) GetPendingHouseholdInvitationsByEmail(arg0 context.Context, arg1 string) ([]db.GetPendingHouseholdInvitationsByEmailRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingHouseholdInvitationsByEmail", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetPendingHouseholdInvitationsByEmailRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingHouseholdInvitationsByEmail indicates an expected call of GetPendingHouseholdInvitationsByEmail.
+func (mr *MockStoreMockRecorder) GetPendingHouseholdInvitationsByEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingHouseholdInvitationsByEmail", reflect.TypeOf((*MockStore)(nil).GetPendingHouseholdInvitationsByEmail), arg0, arg1)
+}
+
+// GetRecurringOccurrences mocks base method.
+func (m *MockStore) GetRecurringOccurrences(arg0 context.Context, arg1 db.GetRecurringOccurrencesParams) ([]db.RecurringOccurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringOccurrences", arg0, arg1)
+	ret0, _ := ret[0].([]db.RecurringOccurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringOccurrences indicates an expected call of GetRecurringOccurrences.
+func (mr *MockStoreMockRecorder) GetRecurringOccurrences(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringOccurrences", reflect.TypeOf((*MockStore)(nil).GetRecurringOccurrences), arg0, arg1)
+}
+
+// GetRecurringRule mocks base method.
+func (m *MockStore) GetRecurringRule(arg0 context.Context, arg1 db.GetRecurringRuleParams) (db.RecurringRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringRule", arg0, arg1)
+	ret0, _ := ret[0].(db.RecurringRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringRule indicates an expected call of GetRecurringRule.
+func (mr *MockStoreMockRecorder) GetRecurringRule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringRule", reflect.TypeOf((*MockStore)(nil).GetRecurringRule), arg0, arg1)
+}
+
+// GetRecurringRules mocks base method.
+func (m *MockStore) GetRecurringRules(arg0 context.Context, arg1 db.GetRecurringRulesParams) ([]db.RecurringRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringRules", arg0, arg1)
+	ret0, _ := ret[0].([]db.RecurringRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringRules indicates an expected call of GetRecurringRules.
+func (mr *MockStoreMockRecorder) GetRecurringRules(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringRules", reflect.TypeOf((*MockStore)(nil).GetRecurringRules), arg0, arg1)
+}
+
+// GetReport mocks base method.
+func (m *MockStore) GetReport(arg0 context.Context, arg1 db.GetReportParams) ([]db.GetReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockStoreMockRecorder) GetReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockStore)(nil).GetReport), arg0, arg1)
+}
+
+// GetReportTotalsBefore mocks base method.
+func (m *MockStore) GetReportTotalsBefore(arg0 context.Context, arg1 db.GetReportTotalsBeforeParams) (db.GetReportTotalsBeforeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportTotalsBefore", arg0, arg1)
+	ret0, _ := ret[0].(db.GetReportTotalsBeforeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportTotalsBefore indicates an expected call of GetReportTotalsBefore.
+func (mr *MockStoreMockRecorder) GetReportTotalsBefore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportTotalsBefore", reflect.TypeOf((*MockStore)(nil).GetReportTotalsBefore), arg0, arg1)
+}
+
+// GetRotatedRefreshTokenSession mocks base method.
+func (m *MockStore) GetRotatedRefreshTokenSession(arg0 context.Context, arg1 string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRotatedRefreshTokenSession", arg0, arg1)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRotatedRefreshTokenSession indicates an expected call of GetRotatedRefreshTokenSession.
+func (mr *MockStoreMockRecorder) GetRotatedRefreshTokenSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRotatedRefreshTokenSession", reflect.TypeOf((*MockStore)(nil).GetRotatedRefreshTokenSession), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 context.Context, arg1 int32) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+}
+
+// GetTag mocks base method.
+func (m *MockStore) GetTag(arg0 context.Context, arg1 db.GetTagParams) (db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTag", arg0, arg1)
+	ret0, _ := ret[0].(db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTag indicates an expected call of GetTag.
+func (mr *MockStoreMockRecorder) GetTag(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTag", reflect.TypeOf((*MockStore)(nil).GetTag), arg0, arg1)
+}
+
+// GetTags mocks base method.
+func (m *MockStore) GetTags(arg0 context.Context, arg1 db.GetTagsParams) ([]db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", arg0, arg1)
+	ret0, _ := ret[0].([]db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockStoreMockRecorder) GetTags(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockStore)(nil).GetTags), arg0, arg1)
+}
+
+// GetTransfer mocks base method.
+func (m *MockStore) GetTransfer(arg0 context.Context, arg1 db.GetTransferParams) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockStoreMockRecorder) GetTransfer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), arg0, arg1)
+}
+
+// GetTransferAccounts mocks base method.
+func (m *MockStore) GetTransferAccounts(arg0 context.Context, arg1 int32) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferAccounts indicates an expected call of GetTransferAccounts.
+func (mr *MockStoreMockRecorder) GetTransferAccounts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferAccounts", reflect.TypeOf((*MockStore)(nil).GetTransferAccounts), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0, arg1)
+}
+
+// GetUserById mocks base method.
+func (m *MockStore) GetUserById(arg0 context.Context, arg1 int32) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockStoreMockRecorder) GetUserById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockStore)(nil).GetUserById), arg0, arg1)
+}
+
+// GetUserTOTP mocks base method.
+func (m *MockStore) GetUserTOTP(arg0 context.Context, arg1 int32) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTOTP", arg0, arg1)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTOTP indicates an expected call of GetUserTOTP.
+func (mr *MockStoreMockRecorder) GetUserTOTP(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTOTP", reflect.TypeOf((*MockStore)(nil).GetUserTOTP), arg0, arg1)
+}
+
+// GetValidAPIKey mocks base method.
+func (m *MockStore) GetValidAPIKey(arg0 context.Context, arg1 string) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidAPIKey indicates an expected call of GetValidAPIKey.
+func (mr *MockStoreMockRecorder) GetValidAPIKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidAPIKey", reflect.TypeOf((*MockStore)(nil).GetValidAPIKey), arg0, arg1)
+}
+
+// GetValidUserToken mocks base method.
+func (m *MockStore) GetValidUserToken(arg0 context.Context, arg1 db.GetValidUserTokenParams) (db.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidUserToken", arg0, arg1)
+	ret0, _ := ret[0].(db.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidUserToken indicates an expected call of GetValidUserToken.
+func (mr *MockStoreMockRecorder) GetValidUserToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidUserToken", reflect.TypeOf((*MockStore)(nil).GetValidUserToken), arg0, arg1)
+}
+
+// GetWallet mocks base method.
+func (m *MockStore) GetWallet(arg0 context.Context, arg1 db.GetWalletParams) (db.GetWalletRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallet", arg0, arg1)
+	ret0, _ := ret[0].(db.GetWalletRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallet indicates an expected call of GetWallet.
+func (mr *MockStoreMockRecorder) GetWallet(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockStore)(nil).GetWallet), arg0, arg1)
+}
+
+// GetWalletBalanceAt mocks base method.
+func (m *MockStore) GetWalletBalanceAt(arg0 context.Context, arg1 db.GetWalletBalanceAtParams) (util.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletBalanceAt", arg0, arg1)
+	ret0, _ := ret[0].(util.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletBalanceAt indicates an expected call of GetWalletBalanceAt.
+func (mr *MockStoreMockRecorder) GetWalletBalanceAt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletBalanceAt", reflect.TypeOf((*MockStore)(nil).GetWalletBalanceAt), arg0, arg1)
+}
+
+// GetWalletBalanceHistory mocks base method.
+func (m *MockStore) GetWalletBalanceHistory(arg0 context.Context, arg1 db.GetWalletBalanceHistoryParams) ([]db.GetWalletBalanceHistoryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletBalanceHistory", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetWalletBalanceHistoryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletBalanceHistory indicates an expected call of GetWalletBalanceHistory.
+func (mr *MockStoreMockRecorder) GetWalletBalanceHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletBalanceHistory", reflect.TypeOf((*MockStore)(nil).GetWalletBalanceHistory), arg0, arg1)
+}
+
+// GetWallets mocks base method.
+func (m *MockStore) GetWallets(arg0 context.Context, arg1 db.GetWalletsParams) ([]db.GetWalletsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallets", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetWalletsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallets indicates an expected call of GetWallets.
+func (mr *MockStoreMockRecorder) GetWallets(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallets", reflect.TypeOf((*MockStore)(nil).GetWallets), arg0, arg1)
+}
+
+// InvalidateUserTokens mocks base method.
+func (m *MockStore) InvalidateUserTokens(arg0 context.Context, arg1 db.InvalidateUserTokensParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateUserTokens", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateUserTokens indicates an expected call of InvalidateUserTokens.
+func (mr *MockStoreMockRecorder) InvalidateUserTokens(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUserTokens", reflect.TypeOf((*MockStore)(nil).InvalidateUserTokens), arg0, arg1)
+}
+
+// LockRecurringRule mocks base method.
+func (m *MockStore) LockRecurringRule(arg0 context.Context, arg1 int32) (db.RecurringRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockRecurringRule", arg0, arg1)
+	ret0, _ := ret[0].(db.RecurringRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockRecurringRule indicates an expected call of LockRecurringRule.
+func (mr *MockStoreMockRecorder) LockRecurringRule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockRecurringRule", reflect.TypeOf((*MockStore)(nil).LockRecurringRule), arg0, arg1)
+}
+
+// MaterializeRecurringOccurrence mocks base method.
+func (m *MockStore) MaterializeRecurringOccurrence(arg0 context.Context, arg1 db.MaterializeRecurringOccurrenceParams) (db.RecurringOccurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaterializeRecurringOccurrence", arg0, arg1)
+	ret0, _ := ret[0].(db.RecurringOccurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MaterializeRecurringOccurrence indicates an expected call of MaterializeRecurringOccurrence.
+func (mr *MockStoreMockRecorder) MaterializeRecurringOccurrence(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaterializeRecurringOccurrence", reflect.TypeOf((*MockStore)(nil).MaterializeRecurringOccurrence), arg0, arg1)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockStore) RevokeAPIKey(arg0 context.Context, arg1 db.RevokeAPIKeyParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockStoreMockRecorder) RevokeAPIKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockStore)(nil).RevokeAPIKey), arg0, arg1)
+}
+
+// RevokeSession mocks base method.
+func (m *MockStore) RevokeSession(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockStoreMockRecorder) RevokeSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockStore)(nil).RevokeSession), arg0, arg1)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockStore) RevokeUserSessions(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockStoreMockRecorder) RevokeUserSessions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockStore)(nil).RevokeUserSessions), arg0, arg1)
+}
+
+// RotateSessionRefreshToken mocks base method.
+func (m *MockStore) RotateSessionRefreshToken(arg0 context.Context, arg1 db.RotateSessionRefreshTokenParams) (db.RotateSessionRefreshTokenRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSessionRefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(db.RotateSessionRefreshTokenRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSessionRefreshToken indicates an expected call of RotateSessionRefreshToken.
+func (mr *MockStoreMockRecorder) RotateSessionRefreshToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSessionRefreshToken", reflect.TypeOf((*MockStore)(nil).RotateSessionRefreshToken), arg0, arg1)
+}
+
+// SetRecurringRuleMaterialized mocks base method.
+func (m *MockStore) SetRecurringRuleMaterialized(arg0 context.Context, arg1 db.SetRecurringRuleMaterializedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecurringRuleMaterialized", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRecurringRuleMaterialized indicates an expected call of SetRecurringRuleMaterialized.
+func (mr *MockStoreMockRecorder) SetRecurringRuleMaterialized(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecurringRuleMaterialized", reflect.TypeOf((*MockStore)(nil).SetRecurringRuleMaterialized), arg0, arg1)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockStore) TouchAPIKey(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockStoreMockRecorder) TouchAPIKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockStore)(nil).TouchAPIKey), arg0, arg1)
+}
+
+// TransferTx mocks base method.
+func (m *MockStore) TransferTx(arg0 context.Context, arg1 db.TransferTxParams) (db.TransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferTx", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferTx indicates an expected call of TransferTx.
+func (mr *MockStoreMockRecorder) TransferTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTx", reflect.TypeOf((*MockStore)(nil).TransferTx), arg0, arg1)
+}
+
+// UpdateAccount mocks base method.
+func (m *MockStore) UpdateAccount(arg0 context.Context, arg1 db.UpdateAccountParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccount indicates an expected call of UpdateAccount.
+func (mr *MockStoreMockRecorder) UpdateAccount(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockStore)(nil).UpdateAccount), arg0, arg1)
+}
+
+// UpdateBudget mocks base method.
+func (m *MockStore) UpdateBudget(arg0 context.Context, arg1 db.UpdateBudgetParams) (db.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBudget", arg0, arg1)
+	ret0, _ := ret[0].(db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBudget indicates an expected call of UpdateBudget.
+func (mr *MockStoreMockRecorder) UpdateBudget(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBudget", reflect.TypeOf((*MockStore)(nil).UpdateBudget), arg0, arg1)
+}
+
+// UpdateCategories mocks base method.
+func (m *MockStore) UpdateCategories(arg0 context.Context, arg1 db.UpdateCategoriesParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategories", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategories indicates an expected call of UpdateCategories.
+func (mr *MockStoreMockRecorder) UpdateCategories(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategories", reflect.TypeOf((*MockStore)(nil).UpdateCategories), arg0, arg1)
+}
+
+// UpdateGoal mocks base method.
+func (m *MockStore) UpdateGoal(arg0 context.Context, arg1 db.UpdateGoalParams) (db.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGoal", arg0, arg1)
+	ret0, _ := ret[0].(db.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGoal indicates an expected call of UpdateGoal.
+func (mr *MockStoreMockRecorder) UpdateGoal(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGoal", reflect.TypeOf((*MockStore)(nil).UpdateGoal), arg0, arg1)
+}
+
+// UpdateHouseholdMemberRole mocks base method.
+func (m *MockStore) UpdateHouseholdMemberRole(arg0 context.Context, arg1 db.UpdateHouseholdMemberRoleParams) (db.HouseholdMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHouseholdMemberRole", arg0, arg1)
+	ret0, _ := ret[0].(db.HouseholdMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHouseholdMemberRole indicates an expected call of UpdateHouseholdMemberRole.
+func (mr *MockStoreMockRecorder) UpdateHouseholdMemberRole(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHouseholdMemberRole", reflect.TypeOf((*MockStore)(nil).UpdateHouseholdMemberRole), arg0, arg1)
+}
+
+// UpdateImportProfile mocks base method.
+func (m *MockStore) UpdateImportProfile(arg0 context.Context, arg1 db.UpdateImportProfileParams) (db.ImportProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateImportProfile", arg0, arg1)
+	ret0, _ := ret[0].(db.ImportProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateImportProfile indicates an expected call of UpdateImportProfile.
+func (mr *MockStoreMockRecorder) UpdateImportProfile(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImportProfile", reflect.TypeOf((*MockStore)(nil).UpdateImportProfile), arg0, arg1)
+}
+
+// UpdateRecurringRule mocks base method.
+func (m *MockStore) UpdateRecurringRule(arg0 context.Context, arg1 db.UpdateRecurringRuleParams) (db.RecurringRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecurringRule", arg0, arg1)
+	ret0, _ := ret[0].(db.RecurringRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRecurringRule indicates an expected call of UpdateRecurringRule.
+func (mr *MockStoreMockRecorder) UpdateRecurringRule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecurringRule", reflect.TypeOf((*MockStore)(nil).UpdateRecurringRule), arg0, arg1)
+}
+
+// UpdateTransfer mocks base method.
+func (m *MockStore) UpdateTransfer(arg0 context.Context, arg1 db.UpdateTransferParams) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransfer", arg0, arg1)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransfer indicates an expected call of UpdateTransfer.
+func (mr *MockStoreMockRecorder) UpdateTransfer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransfer", reflect.TypeOf((*MockStore)(nil).UpdateTransfer), arg0, arg1)
+}
+
+// UpdateTransferAccounts mocks base method.
+func (m *MockStore) UpdateTransferAccounts(arg0 context.Context, arg1 db.UpdateTransferAccountsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransferAccounts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransferAccounts indicates an expected call of UpdateTransferAccounts.
+func (mr *MockStoreMockRecorder) UpdateTransferAccounts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransferAccounts", reflect.TypeOf((*MockStore)(nil).UpdateTransferAccounts), arg0, arg1)
+}
+
+// UpdateTransferTx mocks base method.
+func (m *MockStore) UpdateTransferTx(arg0 context.Context, arg1 db.UpdateTransferParams) (db.TransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransferTx", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransferTx indicates an expected call of UpdateTransferTx.
+func (mr *MockStoreMockRecorder) UpdateTransferTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransferTx", reflect.TypeOf((*MockStore)(nil).UpdateTransferTx), arg0, arg1)
+}
+
+// UpdateUserBaseCurrency mocks base method.
+func (m *MockStore) UpdateUserBaseCurrency(arg0 context.Context, arg1 db.UpdateUserBaseCurrencyParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserBaseCurrency", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserBaseCurrency indicates an expected call of UpdateUserBaseCurrency.
+func (mr *MockStoreMockRecorder) UpdateUserBaseCurrency(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserBaseCurrency", reflect.TypeOf((*MockStore)(nil).UpdateUserBaseCurrency), arg0, arg1)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockStore) UpdateUserPassword(arg0 context.Context, arg1 db.UpdateUserPasswordParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockStoreMockRecorder) UpdateUserPassword(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockStore)(nil).UpdateUserPassword), arg0, arg1)
+}
+
+// UpdateUserTOTPCounter mocks base method.
+func (m *MockStore) UpdateUserTOTPCounter(arg0 context.Context, arg1 db.UpdateUserTOTPCounterParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTOTPCounter", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserTOTPCounter indicates an expected call of UpdateUserTOTPCounter.
+func (mr *MockStoreMockRecorder) UpdateUserTOTPCounter(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTOTPCounter", reflect.TypeOf((*MockStore)(nil).UpdateUserTOTPCounter), arg0, arg1)
+}
+
+// UpdateWallet mocks base method.
+func (m *MockStore) UpdateWallet(arg0 context.Context, arg1 db.UpdateWalletParams) (db.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWallet", arg0, arg1)
+	ret0, _ := ret[0].(db.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWallet indicates an expected call of UpdateWallet.
+func (mr *MockStoreMockRecorder) UpdateWallet(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWallet", reflect.TypeOf((*MockStore)(nil).UpdateWallet), arg0, arg1)
+}
+
+// UpsertExchangeRates mocks base method.
+func (m *MockStore) UpsertExchangeRates(arg0 context.Context, arg1 db.UpsertExchangeRatesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertExchangeRates", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertExchangeRates indicates an expected call of UpsertExchangeRates.
+func (mr *MockStoreMockRecorder) UpsertExchangeRates(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertExchangeRates", reflect.TypeOf((*MockStore)(nil).UpsertExchangeRates), arg0, arg1)
+}
+
+// UpsertPendingUserTOTP mocks base method.
+func (m *MockStore) UpsertPendingUserTOTP(arg0 context.Context, arg1 db.UpsertPendingUserTOTPParams) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPendingUserTOTP", arg0, arg1)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertPendingUserTOTP indicates an expected call of UpsertPendingUserTOTP.
+func (mr *MockStoreMockRecorder) UpsertPendingUserTOTP(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPendingUserTOTP", reflect.TypeOf((*MockStore)(nil).UpsertPendingUserTOTP), arg0, arg1)
+}
+
+// UpsertRecurringOccurrence mocks base method.
+func (m *MockStore) UpsertRecurringOccurrence(arg0 context.Context, arg1 db.UpsertRecurringOccurrenceParams) (db.RecurringOccurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRecurringOccurrence", arg0, arg1)
+	ret0, _ := ret[0].(db.RecurringOccurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertRecurringOccurrence indicates an expected call of UpsertRecurringOccurrence.
+func (mr *MockStoreMockRecorder) UpsertRecurringOccurrence(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRecurringOccurrence", reflect.TypeOf((*MockStore)(nil).UpsertRecurringOccurrence), arg0, arg1)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockStore) UseRecoveryCode(arg0 context.Context, arg1 db.UseRecoveryCodeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockStoreMockRecorder) UseRecoveryCode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockStore)(nil).UseRecoveryCode), arg0, arg1)
+}
+
+// VerifyUserEmail mocks base method.
+func (m *MockStore) VerifyUserEmail(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUserEmail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyUserEmail indicates an expected call of VerifyUserEmail.
+func (mr *MockStoreMockRecorder) VerifyUserEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserEmail", reflect.TypeOf((*MockStore)(nil).VerifyUserEmail), arg0, arg1)
+}
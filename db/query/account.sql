@@ -1,27 +1,68 @@
 -- name: CreateAccount :one
 INSERT INTO accounts (
   user_id,
+  household_id,
+  wallet_id,
   category_id,
   title,
   type,
   description,
   value,
-  date
-) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
-) RETURNING *;  
+  currency,
+  date,
+  external_id
+)
+SELECT
+  sqlc.arg('user_id')::int,
+  sqlc.narg('household_id')::int,
+  sqlc.arg('wallet_id')::int,
+  sqlc.arg('category_id')::int,
+  sqlc.arg('title')::varchar,
+  sqlc.arg('type')::varchar,
+  sqlc.arg('description')::varchar,
+  sqlc.arg('value')::money_minor,
+  (SELECT w.currency FROM wallets w WHERE w.id = sqlc.arg('wallet_id')::int),
+  sqlc.arg('date')::date,
+  sqlc.narg('external_id')::varchar
+WHERE
+  sqlc.narg('household_id')::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = sqlc.narg('household_id')::int AND m.user_id = sqlc.arg('user_id')::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING *;
+
+-- name: AccountExternalIDExists :one
+SELECT EXISTS (
+  SELECT 1 FROM accounts
+  WHERE wallet_id = @wallet_id AND external_id = @external_id::varchar
+);
 
 -- name: GetAccount :one
-SELECT * FROM accounts WHERE id = $1 LIMIT 1;
+SELECT * FROM accounts
+WHERE id = @id
+AND (
+  (accounts.household_id IS NULL AND accounts.user_id = @user_id)
+  OR accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+)
+LIMIT 1;
 
 -- name: GetAccounts :many
+-- paginação por keyset: after_* são a chave de ordenação e o id da última linha da página
+-- anterior; sort_by aceita date, value, title e created_at. As pernas das transferências só
+-- entram com include_transfers, no mesmo filtro de GetAccountsSummary
 SELECT 
 a.id,
 a.user_id,
+a.household_id,
+a.wallet_id,
+a.transfer_id,
 a.title,
 a.type,
 a.description,
 a.value,
+a.currency,
 a.date,
 a.created_at,
 c.title as category_title
@@ -30,7 +71,13 @@ FROM
 LEFT JOIN 
   categories c ON c.id = a.category_id
 WHERE 
-  a.user_id = @user_id
+  (
+    (sqlc.narg('household_id')::int IS NULL AND a.household_id IS NULL AND a.user_id = @user_id)
+    OR (
+      a.household_id = sqlc.narg('household_id')::int
+      AND a.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+    )
+  )
 AND
   a.type = @type
 AND
@@ -38,18 +85,130 @@ AND
 AND
   LOWER(a.description) LIKE CONCAT('%', LOWER(sqlc.arg('description')::text), '%')
 AND
-  a.category_id = COALESCE(sqlc.narg('category_id'), a.category_id)
+  (sqlc.narg('category_id')::int IS NULL OR a.category_id = sqlc.narg('category_id')::int)
+AND
+  a.wallet_id = COALESCE(sqlc.narg('wallet_id'), a.wallet_id)
+AND
+  a.date = COALESCE(sqlc.narg('date'), a.date)
+AND
+  (sqlc.narg('date_from')::date IS NULL OR a.date >= sqlc.narg('date_from')::date)
+AND
+  (sqlc.narg('date_to')::date IS NULL OR a.date <= sqlc.narg('date_to')::date)
 AND
-  a.date = COALESCE(sqlc.narg('date'), a.date);
+  (sqlc.narg('min_value')::bigint IS NULL OR a.value >= sqlc.narg('min_value')::bigint)
+AND
+  (sqlc.narg('max_value')::bigint IS NULL OR a.value <= sqlc.narg('max_value')::bigint)
+AND
+  (@include_transfers::bool OR a.transfer_id IS NULL)
+AND
+  (
+    sqlc.narg('after_id')::int IS NULL
+    OR CASE
+      WHEN @sort_by::varchar = 'value' AND @descending::bool THEN (a.value, a.id) < (sqlc.narg('after_value')::bigint, sqlc.narg('after_id')::int)
+      WHEN @sort_by::varchar = 'value' THEN (a.value, a.id) > (sqlc.narg('after_value')::bigint, sqlc.narg('after_id')::int)
+      WHEN @sort_by::varchar = 'title' AND @descending::bool THEN (a.title, a.id) < (sqlc.narg('after_title')::varchar, sqlc.narg('after_id')::int)
+      WHEN @sort_by::varchar = 'title' THEN (a.title, a.id) > (sqlc.narg('after_title')::varchar, sqlc.narg('after_id')::int)
+      WHEN @sort_by::varchar = 'created_at' AND @descending::bool THEN (a.created_at, a.id) < (sqlc.narg('after_created_at')::timestamp, sqlc.narg('after_id')::int)
+      WHEN @sort_by::varchar = 'created_at' THEN (a.created_at, a.id) > (sqlc.narg('after_created_at')::timestamp, sqlc.narg('after_id')::int)
+      WHEN @descending::bool THEN (a.date, a.id) < (sqlc.narg('after_date')::date, sqlc.narg('after_id')::int)
+      ELSE (a.date, a.id) > (sqlc.narg('after_date')::date, sqlc.narg('after_id')::int)
+    END
+  )
+ORDER BY
+  CASE WHEN @sort_by::varchar = 'value' AND NOT @descending::bool THEN a.value END ASC,
+  CASE WHEN @sort_by::varchar = 'value' AND @descending::bool THEN a.value END DESC,
+  CASE WHEN @sort_by::varchar = 'title' AND NOT @descending::bool THEN a.title END ASC,
+  CASE WHEN @sort_by::varchar = 'title' AND @descending::bool THEN a.title END DESC,
+  CASE WHEN @sort_by::varchar = 'created_at' AND NOT @descending::bool THEN a.created_at END ASC,
+  CASE WHEN @sort_by::varchar = 'created_at' AND @descending::bool THEN a.created_at END DESC,
+  CASE WHEN @sort_by::varchar NOT IN ('value', 'title', 'created_at') AND NOT @descending::bool THEN a.date END ASC,
+  CASE WHEN @sort_by::varchar NOT IN ('value', 'title', 'created_at') AND @descending::bool THEN a.date END DESC,
+  CASE WHEN @descending::bool THEN a.id END DESC,
+  a.id ASC
+LIMIT @page_size;
 
--- name: GetAccountsReports :one
-SELECT SUM(value) AS sum_value FROM accounts WHERE user_id = $1 and type = $2;
+-- name: GetAccountsSummary :one
+-- total das transações com os mesmos filtros de GetAccounts, sem a paginação; a soma é
+-- convertida para a moeda base do usuário
+WITH filtered AS (
+  SELECT
+    a.value,
+    exchange_rate(a.currency, (SELECT u.base_currency FROM users u WHERE u.id = @user_id), a.date) AS rate
+  FROM accounts a
+  WHERE
+    (
+      (sqlc.narg('household_id')::int IS NULL AND a.household_id IS NULL AND a.user_id = @user_id)
+      OR (
+        a.household_id = sqlc.narg('household_id')::int
+        AND a.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+      )
+    )
+  AND
+    a.type = @type
+  AND
+    LOWER(a.title) LIKE CONCAT('%', LOWER(sqlc.arg('title')::text), '%')
+  AND
+    LOWER(a.description) LIKE CONCAT('%', LOWER(sqlc.arg('description')::text), '%')
+  AND
+    (sqlc.narg('category_id')::int IS NULL OR a.category_id = sqlc.narg('category_id')::int)
+  AND
+    a.wallet_id = COALESCE(sqlc.narg('wallet_id'), a.wallet_id)
+  AND
+    a.date = COALESCE(sqlc.narg('date'), a.date)
+  AND
+    (sqlc.narg('date_from')::date IS NULL OR a.date >= sqlc.narg('date_from')::date)
+  AND
+    (sqlc.narg('date_to')::date IS NULL OR a.date <= sqlc.narg('date_to')::date)
+  AND
+    (sqlc.narg('min_value')::bigint IS NULL OR a.value >= sqlc.narg('min_value')::bigint)
+  AND
+    (sqlc.narg('max_value')::bigint IS NULL OR a.value <= sqlc.narg('max_value')::bigint)
+  AND
+    (@include_transfers::bool OR a.transfer_id IS NULL)
+)
+SELECT
+  COUNT(*) AS total_count,
+  COALESCE(SUM(ROUND(filtered.value * filtered.rate)), 0)::money_minor AS total_value,
+  COUNT(*) FILTER (WHERE filtered.rate IS NULL) AS missing_rates
+FROM filtered;
 
--- name: GetAccountsGraph :one
-SELECT COUNT(*) FROM accounts WHERE user_id = $1 and type = $2;
+-- name: GetAccountsReports :one
+-- soma convertida para a moeda base do usuário com a taxa em vigor na data de cada transação
+WITH converted AS (
+  SELECT
+    accounts.value,
+    exchange_rate(accounts.currency, (SELECT u.base_currency FROM users u WHERE u.id = @user_id), accounts.date) AS rate
+  FROM accounts
+  WHERE (
+    (sqlc.narg('household_id')::int IS NULL AND accounts.household_id IS NULL AND accounts.user_id = @user_id)
+    OR (
+      accounts.household_id = sqlc.narg('household_id')::int
+      AND accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+    )
+  )
+  AND accounts.type = @type
+  AND accounts.transfer_id IS NULL
+)
+SELECT
+  COALESCE(SUM(ROUND(converted.value * converted.rate)), 0)::money_minor AS sum_value,
+  COUNT(*) FILTER (WHERE converted.rate IS NULL) AS missing_rates
+FROM converted;
 
 -- name: UpdateAccount :one
-UPDATE accounts SET title = $2, description = $3, value = $4 WHERE id = $1 RETURNING *;
+UPDATE accounts SET title = @title, description = @description, value = @value
+WHERE id = @id
+AND transfer_id IS NULL
+AND (
+  (accounts.household_id IS NULL AND accounts.user_id = @user_id)
+  OR accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+)
+RETURNING *;
 
--- name: DeleteAccount :exec
-DELETE FROM accounts WHERE id = $1;
\ No newline at end of file
+-- name: DeleteAccount :execrows
+DELETE FROM accounts
+WHERE id = @id
+AND transfer_id IS NULL
+AND (
+  (accounts.household_id IS NULL AND accounts.user_id = @user_id)
+  OR accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+);
\ No newline at end of file
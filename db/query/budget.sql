@@ -0,0 +1,111 @@
+-- name: CreateBudget :one
+INSERT INTO budgets (
+  user_id,
+  household_id,
+  category_id,
+  month,
+  amount,
+  recurring,
+  rollover
+)
+SELECT
+  sqlc.arg('user_id')::int,
+  sqlc.narg('household_id')::int,
+  sqlc.arg('category_id')::int,
+  sqlc.arg('month')::date,
+  sqlc.arg('amount')::money_minor,
+  sqlc.arg('recurring')::boolean,
+  sqlc.arg('rollover')::boolean
+WHERE
+  sqlc.narg('household_id')::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = sqlc.narg('household_id')::int AND m.user_id = sqlc.arg('user_id')::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING *;
+
+-- name: GetBudget :one
+SELECT * FROM budgets
+WHERE id = @id
+AND (
+  (budgets.household_id IS NULL AND budgets.user_id = @user_id)
+  OR budgets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+)
+LIMIT 1;
+
+-- name: GetBudgets :many
+SELECT * FROM budgets
+WHERE (
+  (sqlc.narg('household_id')::int IS NULL AND budgets.household_id IS NULL AND budgets.user_id = @user_id)
+  OR (
+    budgets.household_id = sqlc.narg('household_id')::int
+    AND budgets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+  )
+)
+ORDER BY budgets.month, budgets.category_id;
+
+-- name: UpdateBudget :one
+UPDATE budgets SET
+  amount = @amount,
+  recurring = @recurring,
+  rollover = @rollover
+WHERE id = @id
+AND (
+  (budgets.household_id IS NULL AND budgets.user_id = @user_id)
+  OR budgets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+)
+RETURNING *;
+
+-- name: DeleteBudget :execrows
+DELETE FROM budgets
+WHERE id = @id
+AND (
+  (budgets.household_id IS NULL AND budgets.user_id = @user_id)
+  OR budgets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+);
+
+-- name: GetBudgetsUntil :many
+-- orçamentos do escopo até o mês, com o título da categoria
+SELECT budgets.*, categories.title AS category_title FROM budgets
+JOIN categories ON categories.id = budgets.category_id
+WHERE (
+  (sqlc.narg('household_id')::int IS NULL AND budgets.household_id IS NULL AND budgets.user_id = @user_id)
+  OR (
+    budgets.household_id = sqlc.narg('household_id')::int
+    AND budgets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+  )
+)
+AND budgets.month <= sqlc.arg('month')::date
+ORDER BY budgets.category_id, budgets.month;
+
+-- name: GetBudgetSpending :many
+-- gastos por mês das categorias com orçamento, convertidos para a moeda base do usuário
+WITH converted AS (
+  SELECT
+    accounts.category_id,
+    date_trunc('month', accounts.date)::date AS month,
+    accounts.value,
+    exchange_rate(accounts.currency, (SELECT u.base_currency FROM users u WHERE u.id = @user_id), accounts.date) AS rate
+  FROM accounts
+  WHERE (
+    (sqlc.narg('household_id')::int IS NULL AND accounts.household_id IS NULL AND accounts.user_id = @user_id)
+    OR (
+      accounts.household_id = sqlc.narg('household_id')::int
+      AND accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+    )
+  )
+  AND accounts.type = 'debit'
+  AND accounts.transfer_id IS NULL
+  AND accounts.category_id IN (SELECT budgets.category_id FROM budgets)
+  AND accounts.date >= sqlc.arg('from_month')::date
+  AND accounts.date < sqlc.arg('to_month')::date
+)
+SELECT
+  converted.category_id::int AS category_id,
+  converted.month::date AS month,
+  COALESCE(SUM(ROUND(converted.value * converted.rate)), 0)::money_minor AS spent,
+  COUNT(*) FILTER (WHERE converted.rate IS NULL) AS missing_rates
+FROM converted
+GROUP BY converted.category_id, converted.month
+ORDER BY converted.category_id, converted.month;
@@ -1,21 +1,61 @@
 -- name: CreateCategory :one
 INSERT INTO categories (
   user_id,
+  household_id,
   title,
   type,
   description
-) VALUES (
-  $1, $2, $3, $4
-) RETURNING *;
+)
+SELECT
+  sqlc.arg('user_id')::int,
+  sqlc.narg('household_id')::int,
+  sqlc.arg('title')::varchar,
+  sqlc.arg('type')::varchar,
+  sqlc.arg('description')::varchar
+WHERE
+  sqlc.narg('household_id')::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = sqlc.narg('household_id')::int AND m.user_id = sqlc.arg('user_id')::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING *;
 
 -- name: GetCategory :one
-SELECT * FROM categories WHERE id = $1 LIMIT 1;
+SELECT * FROM categories
+WHERE id = @id
+AND (
+  (categories.household_id IS NULL AND categories.user_id = @user_id)
+  OR categories.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+)
+LIMIT 1;
 
 -- name: GetCategories :many
-SELECT * FROM categories WHERE user_id = $1 AND type = $2 AND LOWER(title) LIKE CONCAT('%', LOWER(sqlc.arg('title')::text), '%') AND LOWER(description) LIKE CONCAT('%', LOWER(sqlc.arg('description')::text), '%');
+SELECT * FROM categories
+WHERE (
+  (sqlc.narg('household_id')::int IS NULL AND categories.household_id IS NULL AND categories.user_id = @user_id)
+  OR (
+    categories.household_id = sqlc.narg('household_id')::int
+    AND categories.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+  )
+)
+AND type = @type
+AND LOWER(title) LIKE CONCAT('%', LOWER(sqlc.arg('title')::text), '%')
+AND LOWER(description) LIKE CONCAT('%', LOWER(sqlc.arg('description')::text), '%');
 
 -- name: UpdateCategories :one
-UPDATE categories SET title = $2, description = $3 WHERE id = $1 RETURNING *;
+UPDATE categories SET title = @title, description = @description
+WHERE id = @id
+AND (
+  (categories.household_id IS NULL AND categories.user_id = @user_id)
+  OR categories.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+)
+RETURNING *;
 
--- name: DeleteCategories :exec
-DELETE FROM categories WHERE id = $1;
\ No newline at end of file
+-- name: DeleteCategories :execrows
+DELETE FROM categories
+WHERE id = @id
+AND (
+  (categories.household_id IS NULL AND categories.user_id = @user_id)
+  OR categories.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+);
\ No newline at end of file
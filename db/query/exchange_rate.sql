@@ -0,0 +1,18 @@
+-- name: UpsertExchangeRates :execrows
+INSERT INTO exchange_rates (
+  base_currency,
+  quote_currency,
+  rate,
+  effective_date
+)
+SELECT
+  unnest(@base_currencies::varchar[]),
+  unnest(@quote_currencies::varchar[]),
+  unnest(@rates::numeric[]),
+  unnest(@effective_dates::date[])
+ON CONFLICT (base_currency, quote_currency, effective_date) DO UPDATE SET rate = EXCLUDED.rate;
+
+-- name: GetExchangeRate :one
+SELECT r.rate::numeric AS rate
+FROM (SELECT exchange_rate(@from_currency::varchar, @to_currency::varchar, @on_date::date) AS rate) r
+WHERE r.rate IS NOT NULL;
\ No newline at end of file
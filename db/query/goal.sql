@@ -0,0 +1,130 @@
+-- name: CreateGoal :one
+INSERT INTO goals (
+  user_id,
+  household_id,
+  name,
+  target_amount,
+  currency,
+  deadline,
+  category_id,
+  wallet_id
+)
+SELECT
+  sqlc.arg('user_id')::int,
+  sqlc.narg('household_id')::int,
+  sqlc.arg('name')::varchar,
+  sqlc.arg('target_amount')::money_minor,
+  COALESCE(sqlc.narg('currency')::varchar, (SELECT u.base_currency FROM users u WHERE u.id = sqlc.arg('user_id')::int)),
+  sqlc.narg('deadline')::date,
+  sqlc.narg('category_id')::int,
+  sqlc.narg('wallet_id')::int
+WHERE
+  sqlc.narg('household_id')::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = sqlc.narg('household_id')::int AND m.user_id = sqlc.arg('user_id')::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING *;
+
+-- name: GetGoal :one
+-- saved é o total das contribuições e first_contribution a data da primeira (hoje, se não houver)
+SELECT
+  goals.*,
+  COALESCE(SUM(c.amount), 0)::money_minor AS saved,
+  COALESCE(MIN(c.date), CURRENT_DATE)::date AS first_contribution
+FROM goals
+LEFT JOIN goal_contributions c ON c.goal_id = goals.id
+WHERE goals.id = @id
+AND (
+  (goals.household_id IS NULL AND goals.user_id = @user_id)
+  OR goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+)
+GROUP BY goals.id
+LIMIT 1;
+
+-- name: GetGoals :many
+SELECT
+  goals.*,
+  COALESCE(SUM(c.amount), 0)::money_minor AS saved,
+  COALESCE(MIN(c.date), CURRENT_DATE)::date AS first_contribution
+FROM goals
+LEFT JOIN goal_contributions c ON c.goal_id = goals.id
+WHERE (
+  (sqlc.narg('household_id')::int IS NULL AND goals.household_id IS NULL AND goals.user_id = @user_id)
+  OR (
+    goals.household_id = sqlc.narg('household_id')::int
+    AND goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+  )
+)
+GROUP BY goals.id
+ORDER BY goals.id;
+
+-- name: UpdateGoal :one
+UPDATE goals SET
+  name = @name,
+  target_amount = @target_amount,
+  deadline = sqlc.narg('deadline')::date
+WHERE id = @id
+AND (
+  (goals.household_id IS NULL AND goals.user_id = @user_id)
+  OR goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+)
+RETURNING *;
+
+-- name: DeleteGoal :execrows
+DELETE FROM goals
+WHERE id = @id
+AND (
+  (goals.household_id IS NULL AND goals.user_id = @user_id)
+  OR goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+);
+
+-- name: CreateGoalContribution :one
+INSERT INTO goal_contributions (
+  goal_id,
+  account_id,
+  amount,
+  date,
+  note
+)
+SELECT
+  goals.id,
+  sqlc.narg('account_id')::int,
+  sqlc.arg('amount')::money_minor,
+  sqlc.arg('date')::date,
+  sqlc.arg('note')::varchar
+FROM goals
+WHERE goals.id = sqlc.arg('goal_id')::int
+AND (
+  (goals.household_id IS NULL AND goals.user_id = sqlc.arg('user_id')::int)
+  OR goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = sqlc.arg('user_id')::int AND m.role IN ('owner', 'editor'))
+)
+RETURNING *;
+
+-- name: GetGoalContributions :many
+SELECT * FROM goal_contributions
+WHERE goal_id = @goal_id
+ORDER BY date, id;
+
+-- name: GetGoalContribution :one
+SELECT goal_contributions.* FROM goal_contributions
+JOIN goals ON goals.id = goal_contributions.goal_id
+WHERE goal_contributions.id = @id
+AND goal_contributions.goal_id = @goal_id
+AND (
+  (goals.household_id IS NULL AND goals.user_id = @user_id)
+  OR goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+)
+LIMIT 1;
+
+-- name: DeleteGoalContribution :execrows
+DELETE FROM goal_contributions
+USING goals
+WHERE goal_contributions.id = @id
+AND goal_contributions.goal_id = @goal_id
+AND goals.id = goal_contributions.goal_id
+AND (
+  (goals.household_id IS NULL AND goals.user_id = @user_id)
+  OR goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+);
@@ -0,0 +1,89 @@
+-- name: CreateHousehold :one
+WITH household AS (
+  INSERT INTO households (
+    name,
+    created_by
+  ) VALUES (
+    $1, $2
+  ) RETURNING *
+), owner AS (
+  INSERT INTO household_members (household_id, user_id, role)
+  SELECT household.id, household.created_by, 'owner' FROM household
+)
+SELECT * FROM household;
+
+-- name: GetHouseholds :many
+SELECT h.id, h.name, h.created_by, h.created_at, m.role
+FROM households h
+JOIN household_members m ON m.household_id = h.id
+WHERE m.user_id = $1
+ORDER BY h.name;
+
+-- name: GetHousehold :one
+SELECT h.* FROM households h
+JOIN household_members m ON m.household_id = h.id
+WHERE h.id = $1 AND m.user_id = $2
+LIMIT 1;
+
+-- name: GetHouseholdMember :one
+SELECT * FROM household_members WHERE household_id = $1 AND user_id = $2 LIMIT 1;
+
+-- name: GetHouseholdMembers :many
+SELECT m.household_id, m.user_id, m.role, m.created_at, u.username, u.email
+FROM household_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.household_id = $1
+ORDER BY m.created_at;
+
+-- name: UpdateHouseholdMemberRole :one
+UPDATE household_members SET role = $3
+WHERE household_id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteHouseholdMember :execrows
+DELETE FROM household_members WHERE household_id = $1 AND user_id = $2;
+
+-- name: CountHouseholdOwners :one
+SELECT COUNT(*) FROM household_members WHERE household_id = $1 AND role = 'owner';
+
+-- name: CreateHouseholdInvitation :one
+INSERT INTO household_invitations (
+  household_id,
+  email,
+  role,
+  token_hash,
+  invited_by,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetPendingHouseholdInvitation :one
+SELECT * FROM household_invitations
+WHERE token_hash = $1 AND accepted_at IS NULL AND declined_at IS NULL AND expires_at > now()
+LIMIT 1;
+
+-- name: GetPendingHouseholdInvitationsByEmail :many
+SELECT i.id, i.household_id, i.email, i.role, i.expires_at, i.created_at, h.name AS household_name
+FROM household_invitations i
+JOIN households h ON h.id = i.household_id
+WHERE LOWER(i.email) = LOWER($1) AND i.accepted_at IS NULL AND i.declined_at IS NULL AND i.expires_at > now()
+ORDER BY i.created_at DESC;
+
+-- name: AcceptHouseholdInvitation :one
+WITH accepted AS (
+  UPDATE household_invitations SET accepted_at = now()
+  WHERE household_invitations.id = sqlc.arg('id')
+  AND accepted_at IS NULL
+  AND declined_at IS NULL
+  AND expires_at > now()
+  RETURNING household_id, role
+)
+INSERT INTO household_members (household_id, user_id, role)
+SELECT accepted.household_id, sqlc.arg('user_id')::int, accepted.role FROM accepted
+ON CONFLICT (household_id, user_id) DO UPDATE SET role = household_members.role
+RETURNING *;
+
+-- name: DeclineHouseholdInvitation :execrows
+UPDATE household_invitations SET declined_at = now()
+WHERE id = $1 AND accepted_at IS NULL AND declined_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,27 @@
+-- name: CreateImportProfile :one
+INSERT INTO import_profiles (
+  user_id,
+  name,
+  mapping
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetImportProfile :one
+SELECT * FROM import_profiles
+WHERE id = $1 AND user_id = $2
+LIMIT 1;
+
+-- name: GetImportProfiles :many
+SELECT * FROM import_profiles
+WHERE user_id = $1
+ORDER BY name;
+
+-- name: UpdateImportProfile :one
+UPDATE import_profiles SET name = $3, mapping = $4
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteImportProfile :execrows
+DELETE FROM import_profiles
+WHERE id = $1 AND user_id = $2;
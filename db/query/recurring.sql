@@ -0,0 +1,166 @@
+-- name: CreateRecurringRule :one
+INSERT INTO recurring_rules (
+  user_id,
+  household_id,
+  wallet_id,
+  category_id,
+  title,
+  type,
+  description,
+  value,
+  frequency,
+  interval,
+  day_of_month,
+  start_date,
+  end_date,
+  occurrence_count
+)
+SELECT
+  sqlc.arg('user_id')::int,
+  sqlc.narg('household_id')::int,
+  sqlc.arg('wallet_id')::int,
+  sqlc.arg('category_id')::int,
+  sqlc.arg('title')::varchar,
+  sqlc.arg('type')::varchar,
+  sqlc.arg('description')::varchar,
+  sqlc.arg('value')::money_minor,
+  sqlc.arg('frequency')::varchar,
+  sqlc.arg('interval')::int,
+  sqlc.narg('day_of_month')::int,
+  sqlc.arg('start_date')::date,
+  sqlc.narg('end_date')::date,
+  sqlc.narg('occurrence_count')::int
+WHERE
+  sqlc.narg('household_id')::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = sqlc.narg('household_id')::int AND m.user_id = sqlc.arg('user_id')::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING *;
+
+-- name: GetRecurringRule :one
+SELECT * FROM recurring_rules
+WHERE id = @id
+AND (
+  (recurring_rules.household_id IS NULL AND recurring_rules.user_id = @user_id)
+  OR recurring_rules.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+)
+LIMIT 1;
+
+-- name: GetRecurringRules :many
+SELECT * FROM recurring_rules
+WHERE (
+  (sqlc.narg('household_id')::int IS NULL AND recurring_rules.household_id IS NULL AND recurring_rules.user_id = @user_id)
+  OR (
+    recurring_rules.household_id = sqlc.narg('household_id')::int
+    AND recurring_rules.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+  )
+)
+ORDER BY recurring_rules.start_date, recurring_rules.id;
+
+-- name: UpdateRecurringRule :one
+UPDATE recurring_rules SET
+  title = @title,
+  description = @description,
+  value = @value,
+  end_date = sqlc.narg('end_date')::date,
+  occurrence_count = sqlc.narg('occurrence_count')::int
+WHERE id = @id
+AND (
+  (recurring_rules.household_id IS NULL AND recurring_rules.user_id = @user_id)
+  OR recurring_rules.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+)
+RETURNING *;
+
+-- name: DeleteRecurringRule :execrows
+DELETE FROM recurring_rules
+WHERE id = @id
+AND (
+  (recurring_rules.household_id IS NULL AND recurring_rules.user_id = @user_id)
+  OR recurring_rules.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+);
+
+-- name: GetDueRecurringRules :many
+-- regras com dias ainda não processados até today; a regra com occurrence_count acaba quando
+-- as ocorrências já processadas, puladas ou não, chegam ao limite
+SELECT id FROM recurring_rules
+WHERE start_date <= sqlc.arg('today')::date
+AND (materialized_through IS NULL OR materialized_through < sqlc.arg('today')::date)
+AND (end_date IS NULL OR materialized_through IS NULL OR materialized_through < end_date)
+AND (occurrence_count IS NULL OR materialized_through IS NULL OR (
+  SELECT count(*) FROM recurring_occurrences o
+  WHERE o.rule_id = recurring_rules.id AND o.occurrence_date <= recurring_rules.materialized_through
+) < occurrence_count)
+ORDER BY id;
+
+-- name: LockRecurringRule :one
+-- trava a regra até o fim da transação, para o agendador e as alterações pontuais não se cruzarem
+SELECT * FROM recurring_rules
+WHERE id = @id
+FOR UPDATE;
+
+-- name: SetRecurringRuleMaterialized :exec
+UPDATE recurring_rules SET materialized_through = sqlc.arg('materialized_through')::date
+WHERE id = @id;
+
+-- name: GetRecurringOccurrences :many
+SELECT * FROM recurring_occurrences
+WHERE rule_id = sqlc.arg('rule_id')::int
+AND occurrence_date BETWEEN sqlc.arg('from_date')::date AND sqlc.arg('to_date')::date
+ORDER BY occurrence_date;
+
+-- name: UpsertRecurringOccurrence :one
+-- grava a alteração pontual de uma ocorrência que ainda não virou transação
+INSERT INTO recurring_occurrences (
+  rule_id,
+  occurrence_date,
+  date,
+  title,
+  description,
+  value,
+  skipped
+)
+SELECT
+  recurring_rules.id,
+  sqlc.arg('occurrence_date')::date,
+  sqlc.arg('date')::date,
+  sqlc.arg('title')::varchar,
+  sqlc.arg('description')::varchar,
+  sqlc.arg('value')::money_minor,
+  sqlc.arg('skipped')::boolean
+FROM recurring_rules
+WHERE recurring_rules.id = sqlc.arg('rule_id')::int
+AND (
+  (recurring_rules.household_id IS NULL AND recurring_rules.user_id = sqlc.arg('user_id')::int)
+  OR recurring_rules.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = sqlc.arg('user_id')::int AND m.role IN ('owner', 'editor'))
+)
+ON CONFLICT (rule_id, occurrence_date) DO UPDATE SET
+  date = EXCLUDED.date,
+  title = EXCLUDED.title,
+  description = EXCLUDED.description,
+  value = EXCLUDED.value,
+  skipped = EXCLUDED.skipped
+WHERE recurring_occurrences.account_id IS NULL
+RETURNING *;
+
+-- name: MaterializeRecurringOccurrence :one
+INSERT INTO recurring_occurrences (
+  rule_id,
+  occurrence_date,
+  date,
+  title,
+  description,
+  value,
+  account_id
+) VALUES (
+  sqlc.arg('rule_id')::int,
+  sqlc.arg('occurrence_date')::date,
+  sqlc.arg('date')::date,
+  sqlc.arg('title')::varchar,
+  sqlc.arg('description')::varchar,
+  sqlc.arg('value')::money_minor,
+  sqlc.arg('account_id')::int
+)
+ON CONFLICT (rule_id, occurrence_date) DO UPDATE SET account_id = EXCLUDED.account_id
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,74 @@
+-- name: GetReport :many
+-- receitas e despesas do período, convertidas para a moeda base do usuário, agrupadas por
+-- group_by: day, week, month e year agrupam por period; category, wallet e tag agrupam por
+-- group_id, com period igual a from_date. Por tag, uma transação entra uma vez em cada tag
+-- dela, e as sem tag ficam no grupo 0; qualquer outro valor dá uma linha só com o total
+WITH converted AS (
+  SELECT
+    CASE
+      WHEN sqlc.arg('group_by')::varchar IN ('day', 'week', 'month', 'year')
+      THEN date_trunc(sqlc.arg('group_by')::varchar, accounts.date)::date
+      ELSE sqlc.arg('from_date')::date
+    END AS period,
+    CASE sqlc.arg('group_by')::varchar
+      WHEN 'category' THEN accounts.category_id
+      WHEN 'wallet' THEN accounts.wallet_id
+      WHEN 'tag' THEN account_tags.tag_id
+      ELSE 0
+    END AS group_id,
+    accounts.type,
+    accounts.value,
+    exchange_rate(accounts.currency, (SELECT u.base_currency FROM users u WHERE u.id = @user_id), accounts.date) AS rate
+  FROM accounts
+  LEFT JOIN account_tags ON sqlc.arg('group_by')::varchar = 'tag' AND account_tags.account_id = accounts.id
+  WHERE (
+    (sqlc.narg('household_id')::int IS NULL AND accounts.household_id IS NULL AND accounts.user_id = @user_id)
+    OR (
+      accounts.household_id = sqlc.narg('household_id')::int
+      AND accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+    )
+  )
+  AND accounts.type IN ('credit', 'debit')
+  AND accounts.transfer_id IS NULL
+  AND accounts.date >= sqlc.arg('from_date')::date
+  AND accounts.date <= sqlc.arg('to_date')::date
+)
+SELECT
+  converted.period::date AS period,
+  COALESCE(converted.group_id, 0)::int AS group_id,
+  COALESCE(categories.title, wallets.name, tags.name, '')::varchar AS label,
+  COALESCE(SUM(ROUND(converted.value * converted.rate)) FILTER (WHERE converted.type = 'credit'), 0)::money_minor AS income,
+  COALESCE(SUM(ROUND(converted.value * converted.rate)) FILTER (WHERE converted.type = 'debit'), 0)::money_minor AS expense,
+  COUNT(*) FILTER (WHERE converted.rate IS NULL) AS missing_rates
+FROM converted
+LEFT JOIN categories ON sqlc.arg('group_by')::varchar = 'category' AND categories.id = converted.group_id
+LEFT JOIN wallets ON sqlc.arg('group_by')::varchar = 'wallet' AND wallets.id = converted.group_id
+LEFT JOIN tags ON sqlc.arg('group_by')::varchar = 'tag' AND tags.id = converted.group_id
+GROUP BY converted.period, converted.group_id, categories.title, wallets.name, tags.name
+ORDER BY converted.period, converted.group_id;
+
+-- name: GetReportTotalsBefore :one
+-- receitas e despesas anteriores a before_date, na moeda base do usuário; é o ponto de
+-- partida dos gráficos acumulados
+WITH converted AS (
+  SELECT
+    accounts.type,
+    accounts.value,
+    exchange_rate(accounts.currency, (SELECT u.base_currency FROM users u WHERE u.id = @user_id), accounts.date) AS rate
+  FROM accounts
+  WHERE (
+    (sqlc.narg('household_id')::int IS NULL AND accounts.household_id IS NULL AND accounts.user_id = @user_id)
+    OR (
+      accounts.household_id = sqlc.narg('household_id')::int
+      AND accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+    )
+  )
+  AND accounts.type IN ('credit', 'debit')
+  AND accounts.transfer_id IS NULL
+  AND accounts.date < sqlc.arg('before_date')::date
+)
+SELECT
+  COALESCE(SUM(ROUND(converted.value * converted.rate)) FILTER (WHERE converted.type = 'credit'), 0)::money_minor AS income,
+  COALESCE(SUM(ROUND(converted.value * converted.rate)) FILTER (WHERE converted.type = 'debit'), 0)::money_minor AS expense,
+  COUNT(*) FILTER (WHERE converted.rate IS NULL) AS missing_rates
+FROM converted;
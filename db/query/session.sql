@@ -0,0 +1,36 @@
+-- name: CreateSession :one
+INSERT INTO sessions (
+  user_id,
+  refresh_token_hash,
+  user_agent,
+  client_ip,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetSession :one
+SELECT * FROM sessions WHERE id = $1 LIMIT 1;
+
+-- name: RotateSessionRefreshToken :one
+WITH rotated AS (
+  UPDATE sessions
+  SET refresh_token_hash = sqlc.arg('new_refresh_token_hash')::varchar, last_used_at = now()
+  WHERE sessions.refresh_token_hash = sqlc.arg('old_refresh_token_hash')::varchar
+  AND revoked_at IS NULL
+  AND expires_at > now()
+  RETURNING *
+), history AS (
+  INSERT INTO session_rotated_tokens (refresh_token_hash, session_id)
+  SELECT sqlc.arg('old_refresh_token_hash')::varchar, rotated.id FROM rotated
+)
+SELECT * FROM rotated;
+
+-- name: GetRotatedRefreshTokenSession :one
+SELECT session_id FROM session_rotated_tokens WHERE refresh_token_hash = $1 LIMIT 1;
+
+-- name: RevokeSession :exec
+UPDATE sessions SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeUserSessions :execrows
+UPDATE sessions SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL;
\ No newline at end of file
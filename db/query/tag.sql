@@ -0,0 +1,61 @@
+-- name: CreateTag :one
+INSERT INTO tags (
+  user_id,
+  household_id,
+  name
+)
+SELECT
+  sqlc.arg('user_id')::int,
+  sqlc.narg('household_id')::int,
+  sqlc.arg('name')::varchar
+WHERE
+  sqlc.narg('household_id')::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = sqlc.narg('household_id')::int AND m.user_id = sqlc.arg('user_id')::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING *;
+
+-- name: GetTag :one
+SELECT * FROM tags
+WHERE id = @id
+AND (
+  (tags.household_id IS NULL AND tags.user_id = @user_id)
+  OR tags.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+)
+LIMIT 1;
+
+-- name: GetTags :many
+SELECT * FROM tags
+WHERE (
+  (sqlc.narg('household_id')::int IS NULL AND tags.household_id IS NULL AND tags.user_id = @user_id)
+  OR (
+    tags.household_id = sqlc.narg('household_id')::int
+    AND tags.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+  )
+)
+ORDER BY tags.name;
+
+-- name: DeleteTag :execrows
+DELETE FROM tags
+WHERE id = @id
+AND (
+  (tags.household_id IS NULL AND tags.user_id = @user_id)
+  OR tags.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+);
+
+-- name: GetAccountTags :many
+SELECT tags.* FROM tags
+JOIN account_tags ON account_tags.tag_id = tags.id
+WHERE account_tags.account_id = @account_id
+ORDER BY tags.name;
+
+-- name: AddAccountTag :exec
+INSERT INTO account_tags (account_id, tag_id)
+VALUES (@account_id, @tag_id)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteAccountTags :exec
+DELETE FROM account_tags
+WHERE account_id = @account_id;
@@ -0,0 +1,94 @@
+-- name: CreateTransfer :one
+INSERT INTO transfers (
+  user_id,
+  household_id,
+  from_wallet_id,
+  to_wallet_id,
+  title,
+  description,
+  value,
+  to_value,
+  date
+)
+SELECT
+  sqlc.arg('user_id')::int,
+  sqlc.narg('household_id')::int,
+  sqlc.arg('from_wallet_id')::int,
+  sqlc.arg('to_wallet_id')::int,
+  sqlc.arg('title')::varchar,
+  sqlc.arg('description')::varchar,
+  sqlc.arg('value')::money_minor,
+  sqlc.arg('to_value')::money_minor,
+  sqlc.arg('date')::date
+WHERE
+  sqlc.narg('household_id')::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = sqlc.narg('household_id')::int AND m.user_id = sqlc.arg('user_id')::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING *;
+
+-- name: CreateTransferAccount :one
+INSERT INTO accounts (
+  user_id,
+  household_id,
+  wallet_id,
+  transfer_id,
+  title,
+  type,
+  description,
+  value,
+  currency,
+  date
+) VALUES (
+  sqlc.arg('user_id')::int,
+  sqlc.narg('household_id')::int,
+  sqlc.arg('wallet_id')::int,
+  sqlc.arg('transfer_id')::int,
+  sqlc.arg('title')::varchar,
+  sqlc.arg('type')::varchar,
+  sqlc.arg('description')::varchar,
+  sqlc.arg('value')::money_minor,
+  (SELECT w.currency FROM wallets w WHERE w.id = sqlc.arg('wallet_id')::int),
+  sqlc.arg('date')::date
+) RETURNING *;
+
+-- name: GetTransfer :one
+SELECT * FROM transfers
+WHERE id = @id
+AND (
+  (transfers.household_id IS NULL AND transfers.user_id = @user_id)
+  OR transfers.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+)
+LIMIT 1;
+
+-- name: GetTransferAccounts :many
+SELECT * FROM accounts
+WHERE transfer_id = sqlc.arg('transfer_id')::int
+ORDER BY CASE type WHEN 'debit' THEN 0 ELSE 1 END;
+
+-- name: UpdateTransfer :one
+UPDATE transfers SET title = @title, description = @description, value = @value, to_value = @to_value, date = @date
+WHERE id = @id
+AND (
+  (transfers.household_id IS NULL AND transfers.user_id = @user_id)
+  OR transfers.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+)
+RETURNING *;
+
+-- name: UpdateTransferAccounts :execrows
+UPDATE accounts SET
+  title = @title,
+  description = @description,
+  value = CASE type WHEN 'debit' THEN sqlc.arg('value')::money_minor ELSE sqlc.arg('to_value')::money_minor END,
+  date = @date
+WHERE transfer_id = sqlc.arg('transfer_id')::int;
+
+-- name: DeleteTransfer :execrows
+DELETE FROM transfers
+WHERE id = @id
+AND (
+  (transfers.household_id IS NULL AND transfers.user_id = @user_id)
+  OR transfers.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+);
\ No newline at end of file
@@ -0,0 +1,40 @@
+-- name: UpsertPendingUserTOTP :one
+INSERT INTO user_totp (
+  user_id,
+  secret
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, last_used_counter = 0, created_at = now()
+WHERE user_totp.confirmed_at IS NULL
+RETURNING *;
+
+-- name: GetUserTOTP :one
+SELECT * FROM user_totp WHERE user_id = $1 LIMIT 1;
+
+-- name: ConfirmUserTOTP :execrows
+UPDATE user_totp SET confirmed_at = now(), last_used_counter = $2
+WHERE user_id = $1 AND confirmed_at IS NULL AND last_used_counter < $2;
+
+-- name: UpdateUserTOTPCounter :execrows
+UPDATE user_totp SET last_used_counter = $2
+WHERE user_id = $1 AND confirmed_at IS NOT NULL AND last_used_counter < $2;
+
+-- name: DeleteUserTOTP :exec
+DELETE FROM user_totp WHERE user_id = $1;
+
+-- name: CreateRecoveryCode :exec
+INSERT INTO user_recovery_codes (
+  user_id,
+  code_hash
+) VALUES (
+  $1, $2
+);
+
+-- name: UseRecoveryCode :execrows
+UPDATE user_recovery_codes SET used_at = now()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM user_recovery_codes WHERE user_id = $1;
\ No newline at end of file
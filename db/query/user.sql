@@ -11,4 +11,16 @@ INSERT INTO users (
 SELECT * FROM users WHERE username = $1 LIMIT 1;
 
 -- name: GetUserById :one
-SELECT * FROM users WHERE id = $1 LIMIT 1;
\ No newline at end of file
+SELECT * FROM users WHERE id = $1 LIMIT 1;
+
+-- name: GetUserByEmail :one
+SELECT * FROM users WHERE email = $1 LIMIT 1;
+
+-- name: VerifyUserEmail :exec
+UPDATE users SET email_verified_at = COALESCE(email_verified_at, now()) WHERE id = $1;
+
+-- name: UpdateUserPassword :exec
+UPDATE users SET password = $2 WHERE id = $1;
+
+-- name: UpdateUserBaseCurrency :one
+UPDATE users SET base_currency = $2 WHERE id = $1 RETURNING *;
\ No newline at end of file
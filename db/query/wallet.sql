@@ -0,0 +1,97 @@
+-- name: CreateWallet :one
+INSERT INTO wallets (
+  user_id,
+  household_id,
+  name,
+  kind,
+  currency,
+  opening_balance
+)
+SELECT
+  sqlc.arg('user_id')::int,
+  sqlc.narg('household_id')::int,
+  sqlc.arg('name')::varchar,
+  sqlc.arg('kind')::varchar,
+  COALESCE(sqlc.narg('currency')::varchar, (SELECT u.base_currency FROM users u WHERE u.id = sqlc.arg('user_id')::int)),
+  sqlc.arg('opening_balance')::money_minor
+WHERE
+  sqlc.narg('household_id')::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = sqlc.narg('household_id')::int AND m.user_id = sqlc.arg('user_id')::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING *;
+
+-- name: GetWallet :one
+SELECT
+  wallets.*,
+  (wallets.opening_balance + COALESCE((
+    SELECT SUM(balance_change(a.type, a.value)) FROM accounts a WHERE a.wallet_id = wallets.id
+  ), 0))::money_minor AS balance
+FROM wallets
+WHERE wallets.id = @id
+AND (
+  (wallets.household_id IS NULL AND wallets.user_id = @user_id)
+  OR wallets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+)
+LIMIT 1;
+
+-- name: GetWallets :many
+SELECT
+  wallets.*,
+  (wallets.opening_balance + COALESCE((
+    SELECT SUM(balance_change(a.type, a.value)) FROM accounts a WHERE a.wallet_id = wallets.id
+  ), 0))::money_minor AS balance
+FROM wallets
+WHERE (
+  (sqlc.narg('household_id')::int IS NULL AND wallets.household_id IS NULL AND wallets.user_id = @user_id)
+  OR (
+    wallets.household_id = sqlc.narg('household_id')::int
+    AND wallets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id)
+  )
+)
+ORDER BY wallets.name, wallets.id;
+
+-- name: GetWalletBalanceAt :one
+-- saldo ao fim do dia informado
+SELECT (wallets.opening_balance + COALESCE((
+  SELECT SUM(balance_change(a.type, a.value)) FROM accounts a WHERE a.wallet_id = wallets.id AND a.date <= @on_date::date
+), 0))::money_minor AS balance
+FROM wallets
+WHERE wallets.id = @wallet_id;
+
+-- name: GetWalletBalanceHistory :many
+-- saldo ao fim de cada dia com movimento no período
+SELECT history.date, history.balance::money_minor AS balance
+FROM (
+  SELECT
+    daily.date,
+    wallets.opening_balance + SUM(daily.change) OVER (ORDER BY daily.date) AS balance
+  FROM (
+    SELECT a.date, SUM(balance_change(a.type, a.value)) AS change
+    FROM accounts a
+    WHERE a.wallet_id = @wallet_id AND a.date <= @to_date::date
+    GROUP BY a.date
+  ) daily
+  JOIN wallets ON wallets.id = @wallet_id
+) history
+WHERE history.date >= @from_date::date
+ORDER BY history.date;
+
+-- name: UpdateWallet :one
+UPDATE wallets SET name = @name, kind = @kind
+WHERE id = @id
+AND (
+  (wallets.household_id IS NULL AND wallets.user_id = @user_id)
+  OR wallets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+)
+RETURNING *;
+
+-- name: DeleteWallet :execrows
+DELETE FROM wallets
+WHERE id = @id
+AND (
+  (wallets.household_id IS NULL AND wallets.user_id = @user_id)
+  OR wallets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = @user_id AND m.role IN ('owner', 'editor'))
+);
\ No newline at end of file
@@ -9,41 +9,90 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+const accountExternalIDExists = `-- name: AccountExternalIDExists :one
+SELECT EXISTS (
+  SELECT 1 FROM accounts
+  WHERE wallet_id = $1 AND external_id = $2::varchar
 )
+`
+
+type AccountExternalIDExistsParams struct {
+	WalletID   int32  `json:"wallet_id"`
+	ExternalID string `json:"external_id"`
+}
+
+func (q *Queries) AccountExternalIDExists(ctx context.Context, arg AccountExternalIDExistsParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, accountExternalIDExists, arg.WalletID, arg.ExternalID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
 
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (
   user_id,
+  household_id,
+  wallet_id,
   category_id,
   title,
   type,
   description,
   value,
-  date
-) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
-) RETURNING id, user_id, category_id, title, type, description, value, date, created_at
+  currency,
+  date,
+  external_id
+)
+SELECT
+  $1::int,
+  $2::int,
+  $3::int,
+  $4::int,
+  $5::varchar,
+  $6::varchar,
+  $7::varchar,
+  $8::money_minor,
+  (SELECT w.currency FROM wallets w WHERE w.id = $3::int),
+  $9::date,
+  $10::varchar
+WHERE
+  $2::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = $2::int AND m.user_id = $1::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING id, user_id, category_id, title, type, description, value, date, created_at, household_id, currency, wallet_id, transfer_id, external_id
 `
 
 type CreateAccountParams struct {
-	UserID      int32     `json:"user_id"`
-	CategoryID  int32     `json:"category_id"`
-	Title       string    `json:"title"`
-	Type        string    `json:"type"`
-	Description string    `json:"description"`
-	Value       int32     `json:"value"`
-	Date        time.Time `json:"date"`
+	UserID      int32          `json:"user_id"`
+	HouseholdID sql.NullInt32  `json:"household_id"`
+	WalletID    int32          `json:"wallet_id"`
+	CategoryID  int32          `json:"category_id"`
+	Title       string         `json:"title"`
+	Type        string         `json:"type"`
+	Description string         `json:"description"`
+	Value       util.Money     `json:"value"`
+	Date        time.Time      `json:"date"`
+	ExternalID  sql.NullString `json:"external_id"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
 	row := q.db.QueryRowContext(ctx, createAccount,
 		arg.UserID,
+		arg.HouseholdID,
+		arg.WalletID,
 		arg.CategoryID,
 		arg.Title,
 		arg.Type,
 		arg.Description,
 		arg.Value,
 		arg.Date,
+		arg.ExternalID,
 	)
 	var i Account
 	err := row.Scan(
@@ -56,25 +105,55 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Value,
 		&i.Date,
 		&i.CreatedAt,
+		&i.HouseholdID,
+		&i.Currency,
+		&i.WalletID,
+		&i.TransferID,
+		&i.ExternalID,
 	)
 	return i, err
 }
 
-const deleteAccount = `-- name: DeleteAccount :exec
-DELETE FROM accounts WHERE id = $1
+const deleteAccount = `-- name: DeleteAccount :execrows
+DELETE FROM accounts
+WHERE id = $1
+AND transfer_id IS NULL
+AND (
+  (accounts.household_id IS NULL AND accounts.user_id = $2)
+  OR accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2 AND m.role IN ('owner', 'editor'))
+)
 `
 
-func (q *Queries) DeleteAccount(ctx context.Context, id int32) error {
-	_, err := q.db.ExecContext(ctx, deleteAccount, id)
-	return err
+type DeleteAccountParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) DeleteAccount(ctx context.Context, arg DeleteAccountParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAccount, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, user_id, category_id, title, type, description, value, date, created_at FROM accounts WHERE id = $1 LIMIT 1
+SELECT id, user_id, category_id, title, type, description, value, date, created_at, household_id, currency, wallet_id, transfer_id, external_id FROM accounts
+WHERE id = $1
+AND (
+  (accounts.household_id IS NULL AND accounts.user_id = $2)
+  OR accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+)
+LIMIT 1
 `
 
-func (q *Queries) GetAccount(ctx context.Context, id int32) (Account, error) {
-	row := q.db.QueryRowContext(ctx, getAccount, id)
+type GetAccountParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) GetAccount(ctx context.Context, arg GetAccountParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccount, arg.ID, arg.UserID)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -86,6 +165,11 @@ func (q *Queries) GetAccount(ctx context.Context, id int32) (Account, error) {
 		&i.Value,
 		&i.Date,
 		&i.CreatedAt,
+		&i.HouseholdID,
+		&i.Currency,
+		&i.WalletID,
+		&i.TransferID,
+		&i.ExternalID,
 	)
 	return i, err
 }
@@ -94,10 +178,14 @@ const getAccounts = `-- name: GetAccounts :many
 SELECT 
 a.id,
 a.user_id,
+a.household_id,
+a.wallet_id,
+a.transfer_id,
 a.title,
 a.type,
 a.description,
 a.value,
+a.currency,
 a.date,
 a.created_at,
 c.title as category_title
@@ -106,48 +194,129 @@ FROM
 LEFT JOIN 
   categories c ON c.id = a.category_id
 WHERE 
-  a.user_id = $1
+  (
+    ($1::int IS NULL AND a.household_id IS NULL AND a.user_id = $2)
+    OR (
+      a.household_id = $1::int
+      AND a.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+    )
+  )
+AND
+  a.type = $3
+AND
+  LOWER(a.title) LIKE CONCAT('%', LOWER($4::text), '%')
+AND
+  LOWER(a.description) LIKE CONCAT('%', LOWER($5::text), '%')
 AND
-  a.type = $2
+  ($6::int IS NULL OR a.category_id = $6::int)
 AND
-  LOWER(a.title) LIKE CONCAT('%', LOWER($3::text), '%')
+  a.wallet_id = COALESCE($7, a.wallet_id)
 AND
-  LOWER(a.description) LIKE CONCAT('%', LOWER($4::text), '%')
+  a.date = COALESCE($8, a.date)
 AND
-  a.category_id = COALESCE($5, a.category_id)
+  ($9::date IS NULL OR a.date >= $9::date)
 AND
-  a.date = COALESCE($6, a.date)
+  ($10::date IS NULL OR a.date <= $10::date)
+AND
+  ($11::bigint IS NULL OR a.value >= $11::bigint)
+AND
+  ($12::bigint IS NULL OR a.value <= $12::bigint)
+AND
+  ($13::bool OR a.transfer_id IS NULL)
+AND
+  (
+    $14::int IS NULL
+    OR CASE
+      WHEN $15::varchar = 'value' AND $16::bool THEN (a.value, a.id) < ($17::bigint, $14::int)
+      WHEN $15::varchar = 'value' THEN (a.value, a.id) > ($17::bigint, $14::int)
+      WHEN $15::varchar = 'title' AND $16::bool THEN (a.title, a.id) < ($18::varchar, $14::int)
+      WHEN $15::varchar = 'title' THEN (a.title, a.id) > ($18::varchar, $14::int)
+      WHEN $15::varchar = 'created_at' AND $16::bool THEN (a.created_at, a.id) < ($19::timestamp, $14::int)
+      WHEN $15::varchar = 'created_at' THEN (a.created_at, a.id) > ($19::timestamp, $14::int)
+      WHEN $16::bool THEN (a.date, a.id) < ($20::date, $14::int)
+      ELSE (a.date, a.id) > ($20::date, $14::int)
+    END
+  )
+ORDER BY
+  CASE WHEN $15::varchar = 'value' AND NOT $16::bool THEN a.value END ASC,
+  CASE WHEN $15::varchar = 'value' AND $16::bool THEN a.value END DESC,
+  CASE WHEN $15::varchar = 'title' AND NOT $16::bool THEN a.title END ASC,
+  CASE WHEN $15::varchar = 'title' AND $16::bool THEN a.title END DESC,
+  CASE WHEN $15::varchar = 'created_at' AND NOT $16::bool THEN a.created_at END ASC,
+  CASE WHEN $15::varchar = 'created_at' AND $16::bool THEN a.created_at END DESC,
+  CASE WHEN $15::varchar NOT IN ('value', 'title', 'created_at') AND NOT $16::bool THEN a.date END ASC,
+  CASE WHEN $15::varchar NOT IN ('value', 'title', 'created_at') AND $16::bool THEN a.date END DESC,
+  CASE WHEN $16::bool THEN a.id END DESC,
+  a.id ASC
+LIMIT $21
 `
 
 type GetAccountsParams struct {
-	UserID      int32         `json:"user_id"`
-	Type        string        `json:"type"`
-	Title       string        `json:"title"`
-	Description string        `json:"description"`
-	CategoryID  sql.NullInt32 `json:"category_id"`
-	Date        sql.NullTime  `json:"date"`
+	HouseholdID      sql.NullInt32  `json:"household_id"`
+	UserID           int32          `json:"user_id"`
+	Type             string         `json:"type"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description"`
+	CategoryID       sql.NullInt32  `json:"category_id"`
+	WalletID         sql.NullInt32  `json:"wallet_id"`
+	Date             sql.NullTime   `json:"date"`
+	DateFrom         sql.NullTime   `json:"date_from"`
+	DateTo           sql.NullTime   `json:"date_to"`
+	MinValue         sql.NullInt64  `json:"min_value"`
+	MaxValue         sql.NullInt64  `json:"max_value"`
+	IncludeTransfers bool           `json:"include_transfers"`
+	AfterID          sql.NullInt32  `json:"after_id"`
+	SortBy           string         `json:"sort_by"`
+	Descending       bool           `json:"descending"`
+	AfterValue       sql.NullInt64  `json:"after_value"`
+	AfterTitle       sql.NullString `json:"after_title"`
+	AfterCreatedAt   sql.NullTime   `json:"after_created_at"`
+	AfterDate        sql.NullTime   `json:"after_date"`
+	PageSize         int32          `json:"page_size"`
 }
 
 type GetAccountsRow struct {
 	ID            int32          `json:"id"`
 	UserID        int32          `json:"user_id"`
+	HouseholdID   sql.NullInt32  `json:"household_id"`
+	WalletID      int32          `json:"wallet_id"`
+	TransferID    sql.NullInt32  `json:"transfer_id"`
 	Title         string         `json:"title"`
 	Type          string         `json:"type"`
 	Description   string         `json:"description"`
-	Value         int32          `json:"value"`
+	Value         util.Money     `json:"value"`
+	Currency      string         `json:"currency"`
 	Date          time.Time      `json:"date"`
 	CreatedAt     time.Time      `json:"created_at"`
 	CategoryTitle sql.NullString `json:"category_title"`
 }
 
+// paginação por keyset: after_* são a chave de ordenação e o id da última linha da página
+// anterior; sort_by aceita date, value, title e created_at. As pernas das transferências só
+// entram com include_transfers, no mesmo filtro de GetAccountsSummary
 func (q *Queries) GetAccounts(ctx context.Context, arg GetAccountsParams) ([]GetAccountsRow, error) {
 	rows, err := q.db.QueryContext(ctx, getAccounts,
+		arg.HouseholdID,
 		arg.UserID,
 		arg.Type,
 		arg.Title,
 		arg.Description,
 		arg.CategoryID,
+		arg.WalletID,
 		arg.Date,
+		arg.DateFrom,
+		arg.DateTo,
+		arg.MinValue,
+		arg.MaxValue,
+		arg.IncludeTransfers,
+		arg.AfterID,
+		arg.SortBy,
+		arg.Descending,
+		arg.AfterValue,
+		arg.AfterTitle,
+		arg.AfterCreatedAt,
+		arg.AfterDate,
+		arg.PageSize,
 	)
 	if err != nil {
 		return nil, err
@@ -159,10 +328,14 @@ func (q *Queries) GetAccounts(ctx context.Context, arg GetAccountsParams) ([]Get
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
+			&i.HouseholdID,
+			&i.WalletID,
+			&i.TransferID,
 			&i.Title,
 			&i.Type,
 			&i.Description,
 			&i.Value,
+			&i.Currency,
 			&i.Date,
 			&i.CreatedAt,
 			&i.CategoryTitle,
@@ -180,55 +353,162 @@ func (q *Queries) GetAccounts(ctx context.Context, arg GetAccountsParams) ([]Get
 	return items, nil
 }
 
-const getAccountsGraph = `-- name: GetAccountsGraph :one
-SELECT COUNT(*) FROM accounts WHERE user_id = $1 and type = $2
+const getAccountsReports = `-- name: GetAccountsReports :one
+WITH converted AS (
+  SELECT
+    accounts.value,
+    exchange_rate(accounts.currency, (SELECT u.base_currency FROM users u WHERE u.id = $1), accounts.date) AS rate
+  FROM accounts
+  WHERE (
+    ($2::int IS NULL AND accounts.household_id IS NULL AND accounts.user_id = $1)
+    OR (
+      accounts.household_id = $2::int
+      AND accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $1)
+    )
+  )
+  AND accounts.type = $3
+  AND accounts.transfer_id IS NULL
+)
+SELECT
+  COALESCE(SUM(ROUND(converted.value * converted.rate)), 0)::money_minor AS sum_value,
+  COUNT(*) FILTER (WHERE converted.rate IS NULL) AS missing_rates
+FROM converted
 `
 
-type GetAccountsGraphParams struct {
-	UserID int32  `json:"user_id"`
-	Type   string `json:"type"`
+type GetAccountsReportsParams struct {
+	UserID      int32         `json:"user_id"`
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	Type        string        `json:"type"`
 }
 
-func (q *Queries) GetAccountsGraph(ctx context.Context, arg GetAccountsGraphParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getAccountsGraph, arg.UserID, arg.Type)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+type GetAccountsReportsRow struct {
+	SumValue     util.Money `json:"sum_value"`
+	MissingRates int64      `json:"missing_rates"`
 }
 
-const getAccountsReports = `-- name: GetAccountsReports :one
-SELECT SUM(value) AS sum_value FROM accounts WHERE user_id = $1 and type = $2
+// soma convertida para a moeda base do usuário com a taxa em vigor na data de cada transação
+func (q *Queries) GetAccountsReports(ctx context.Context, arg GetAccountsReportsParams) (GetAccountsReportsRow, error) {
+	row := q.db.QueryRowContext(ctx, getAccountsReports, arg.UserID, arg.HouseholdID, arg.Type)
+	var i GetAccountsReportsRow
+	err := row.Scan(&i.SumValue, &i.MissingRates)
+	return i, err
+}
+
+const getAccountsSummary = `-- name: GetAccountsSummary :one
+WITH filtered AS (
+  SELECT
+    a.value,
+    exchange_rate(a.currency, (SELECT u.base_currency FROM users u WHERE u.id = $1), a.date) AS rate
+  FROM accounts a
+  WHERE
+    (
+      ($2::int IS NULL AND a.household_id IS NULL AND a.user_id = $1)
+      OR (
+        a.household_id = $2::int
+        AND a.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $1)
+      )
+    )
+  AND
+    a.type = $3
+  AND
+    LOWER(a.title) LIKE CONCAT('%', LOWER($4::text), '%')
+  AND
+    LOWER(a.description) LIKE CONCAT('%', LOWER($5::text), '%')
+  AND
+    ($6::int IS NULL OR a.category_id = $6::int)
+  AND
+    a.wallet_id = COALESCE($7, a.wallet_id)
+  AND
+    a.date = COALESCE($8, a.date)
+  AND
+    ($9::date IS NULL OR a.date >= $9::date)
+  AND
+    ($10::date IS NULL OR a.date <= $10::date)
+  AND
+    ($11::bigint IS NULL OR a.value >= $11::bigint)
+  AND
+    ($12::bigint IS NULL OR a.value <= $12::bigint)
+  AND
+    ($13::bool OR a.transfer_id IS NULL)
+)
+SELECT
+  COUNT(*) AS total_count,
+  COALESCE(SUM(ROUND(filtered.value * filtered.rate)), 0)::money_minor AS total_value,
+  COUNT(*) FILTER (WHERE filtered.rate IS NULL) AS missing_rates
+FROM filtered
 `
 
-type GetAccountsReportsParams struct {
-	UserID int32  `json:"user_id"`
-	Type   string `json:"type"`
+type GetAccountsSummaryParams struct {
+	UserID           int32         `json:"user_id"`
+	HouseholdID      sql.NullInt32 `json:"household_id"`
+	Type             string        `json:"type"`
+	Title            string        `json:"title"`
+	Description      string        `json:"description"`
+	CategoryID       sql.NullInt32 `json:"category_id"`
+	WalletID         sql.NullInt32 `json:"wallet_id"`
+	Date             sql.NullTime  `json:"date"`
+	DateFrom         sql.NullTime  `json:"date_from"`
+	DateTo           sql.NullTime  `json:"date_to"`
+	MinValue         sql.NullInt64 `json:"min_value"`
+	MaxValue         sql.NullInt64 `json:"max_value"`
+	IncludeTransfers bool          `json:"include_transfers"`
 }
 
-func (q *Queries) GetAccountsReports(ctx context.Context, arg GetAccountsReportsParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getAccountsReports, arg.UserID, arg.Type)
-	var sum_value int64
-	err := row.Scan(&sum_value)
-	return sum_value, err
+type GetAccountsSummaryRow struct {
+	TotalCount   int64      `json:"total_count"`
+	TotalValue   util.Money `json:"total_value"`
+	MissingRates int64      `json:"missing_rates"`
+}
+
+// total das transações com os mesmos filtros de GetAccounts, sem a paginação; a soma é
+// convertida para a moeda base do usuário
+func (q *Queries) GetAccountsSummary(ctx context.Context, arg GetAccountsSummaryParams) (GetAccountsSummaryRow, error) {
+	row := q.db.QueryRowContext(ctx, getAccountsSummary,
+		arg.UserID,
+		arg.HouseholdID,
+		arg.Type,
+		arg.Title,
+		arg.Description,
+		arg.CategoryID,
+		arg.WalletID,
+		arg.Date,
+		arg.DateFrom,
+		arg.DateTo,
+		arg.MinValue,
+		arg.MaxValue,
+		arg.IncludeTransfers,
+	)
+	var i GetAccountsSummaryRow
+	err := row.Scan(&i.TotalCount, &i.TotalValue, &i.MissingRates)
+	return i, err
 }
 
 const updateAccount = `-- name: UpdateAccount :one
-UPDATE accounts SET title = $2, description = $3, value = $4 WHERE id = $1 RETURNING id, user_id, category_id, title, type, description, value, date, created_at
+UPDATE accounts SET title = $1, description = $2, value = $3
+WHERE id = $4
+AND transfer_id IS NULL
+AND (
+  (accounts.household_id IS NULL AND accounts.user_id = $5)
+  OR accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $5 AND m.role IN ('owner', 'editor'))
+)
+RETURNING id, user_id, category_id, title, type, description, value, date, created_at, household_id, currency, wallet_id, transfer_id, external_id
 `
 
 type UpdateAccountParams struct {
-	ID          int32  `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Value       int32  `json:"value"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Value       util.Money `json:"value"`
+	ID          int32      `json:"id"`
+	UserID      int32      `json:"user_id"`
 }
 
 func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error) {
 	row := q.db.QueryRowContext(ctx, updateAccount,
-		arg.ID,
 		arg.Title,
 		arg.Description,
 		arg.Value,
+		arg.ID,
+		arg.UserID,
 	)
 	var i Account
 	err := row.Scan(
@@ -241,6 +521,11 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Value,
 		&i.Date,
 		&i.CreatedAt,
+		&i.HouseholdID,
+		&i.Currency,
+		&i.WalletID,
+		&i.TransferID,
+		&i.ExternalID,
 	)
 	return i, err
 }
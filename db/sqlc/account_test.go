@@ -12,8 +12,10 @@ import (
 
 func createRandomAccount(t *testing.T) Account {
 	category := createRandomCategory(t)
+	wallet := createRandomWallet(t, category.UserID)
 	arg := CreateAccountParams{
 		UserID:      category.UserID,
+		WalletID:    wallet.ID,
 		CategoryID:  category.ID,
 		Title:       util.RandomString(12),
 		Type:        category.Type,
@@ -27,7 +29,9 @@ func createRandomAccount(t *testing.T) Account {
 	require.NotEmpty(t, account)
 
 	require.Equal(t, arg.UserID, account.UserID)
-	require.Equal(t, arg.CategoryID, account.CategoryID)
+	require.Equal(t, arg.CategoryID, account.CategoryID.Int32)
+	require.Equal(t, arg.WalletID, account.WalletID)
+	require.Equal(t, wallet.Currency, account.Currency)
 	require.Equal(t, arg.Value, account.Value)
 	require.Equal(t, arg.Title, account.Title)
 	require.Equal(t, arg.Type, account.Type)
@@ -45,7 +49,11 @@ func TestCreateAccount(t *testing.T) {
 
 func TestGetAccount(t *testing.T) {
 	account1 := createRandomAccount(t)
-	account2, err := testQueries.GetAccount(context.Background(), account1.ID)
+	arg := GetAccountParams{
+		ID:     account1.ID,
+		UserID: account1.UserID,
+	}
+	account2, err := testQueries.GetAccount(context.Background(), arg)
 	require.NoError(t, err)
 	require.NotEmpty(t, account2)
 
@@ -62,9 +70,14 @@ func TestGetAccount(t *testing.T) {
 
 func TestDeleteAccount(t *testing.T) {
 	account := createRandomAccount(t)
-	err := testQueries.DeleteAccount(context.Background(), account.ID)
+	arg := DeleteAccountParams{
+		ID:     account.ID,
+		UserID: account.UserID,
+	}
+	rowsDeleted, err := testQueries.DeleteAccount(context.Background(), arg)
 
 	require.NoError(t, err)
+	require.Equal(t, int64(1), rowsDeleted)
 }
 
 func TestUpdateAccount(t *testing.T) {
@@ -72,6 +85,7 @@ func TestUpdateAccount(t *testing.T) {
 
 	arg := UpdateAccountParams{
 		ID:          account1.ID,
+		UserID:      account1.UserID,
 		Title:       util.RandomString(12),
 		Description: util.RandomString(20),
 		Value:       15,
@@ -89,6 +103,33 @@ func TestUpdateAccount(t *testing.T) {
 
 }
 
+func TestAccountOwnership(t *testing.T) {
+	account := createRandomAccount(t)
+	otherUser := createRandomUser(t)
+
+	_, err := testQueries.GetAccount(context.Background(), GetAccountParams{
+		ID:     account.ID,
+		UserID: otherUser.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:          account.ID,
+		UserID:      otherUser.ID,
+		Title:       util.RandomString(12),
+		Description: util.RandomString(20),
+		Value:       15,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	rowsDeleted, err := testQueries.DeleteAccount(context.Background(), DeleteAccountParams{
+		ID:     account.ID,
+		UserID: otherUser.ID,
+	})
+	require.NoError(t, err)
+	require.Zero(t, rowsDeleted)
+}
+
 func TestListAccounts(t *testing.T) {
 	var lastAccount Account
 	for i := 0; i < 5; i++ {
@@ -96,18 +137,17 @@ func TestListAccounts(t *testing.T) {
 	}
 
 	arg := GetAccountsParams{
-		UserID: lastAccount.UserID,
-		Type:   lastAccount.Type,
-		CategoryID: sql.NullInt32{
-			Int32: lastAccount.CategoryID,
-			Valid: true,
-		},
+		UserID:      lastAccount.UserID,
+		Type:        lastAccount.Type,
+		CategoryID:  lastAccount.CategoryID,
 		Title:       lastAccount.Title,
 		Description: lastAccount.Description,
 		Date: sql.NullTime{
 			Time:  lastAccount.Date,
 			Valid: true,
 		},
+		SortBy:   "date",
+		PageSize: 10,
 	}
 
 	accounts, err := testQueries.GetAccounts(context.Background(), arg)
@@ -144,18 +184,161 @@ func TestListGetReports(t *testing.T) {
 	require.NotEmpty(t, accounts)
 }
 
-func TestListGetGraph(t *testing.T) {
-	var lastAccount Account
+func TestGetReportsLargeValues(t *testing.T) {
+	category := createRandomCategory(t)
+	wallet := createRandomWallet(t, category.UserID)
+	value := util.NewMoney(30_000_000_000, 1)
+	for i := 0; i < 3; i++ {
+		_, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+			UserID:      category.UserID,
+			WalletID:    wallet.ID,
+			CategoryID:  category.ID,
+			Title:       util.RandomString(12),
+			Type:        category.Type,
+			Description: util.RandomString(20),
+			Value:       value,
+			Date:        time.Now(),
+		})
+		require.NoError(t, err)
+	}
+
+	sumValue, err := testQueries.GetAccountsReports(context.Background(), GetAccountsReportsParams{
+		UserID: category.UserID,
+		Type:   category.Type,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.NewMoney(90_000_000_000, 3), sumValue)
+}
+
+func TestGetAccountsPagination(t *testing.T) {
+	user := createRandomUser(t)
+	wallet := createRandomWallet(t, user.ID)
+	var created []Account
 	for i := 0; i < 5; i++ {
-		lastAccount = createRandomAccount(t)
+		date := time.Date(2024, 1, 10+i%3, 0, 0, 0, 0, time.UTC)
+		created = append(created, createWalletTransaction(t, wallet, "debit", util.NewMoney(int64(10*(i+1)), 0), date))
 	}
 
-	arg := GetAccountsGraphParams{
-		UserID: lastAccount.UserID,
-		Type:   lastAccount.Type,
+	for _, sortBy := range []string{"date", "value", "title", "created_at"} {
+		for _, descending := range []bool{false, true} {
+			arg := GetAccountsParams{UserID: user.ID, Type: "debit", SortBy: sortBy, Descending: descending, PageSize: 2}
+			seen := make(map[int32]bool)
+			var pages int
+			for {
+				page, err := testQueries.GetAccounts(context.Background(), arg)
+				require.NoError(t, err)
+				pages++
+				for _, account := range page {
+					require.False(t, seen[account.ID])
+					seen[account.ID] = true
+				}
+				if len(page) < int(arg.PageSize) {
+					break
+				}
+				last := page[len(page)-1]
+				arg.AfterID = sql.NullInt32{Int32: last.ID, Valid: true}
+				arg.AfterValue = sql.NullInt64{Int64: int64(last.Value), Valid: true}
+				arg.AfterTitle = sql.NullString{String: last.Title, Valid: true}
+				arg.AfterCreatedAt = sql.NullTime{Time: last.CreatedAt, Valid: true}
+				arg.AfterDate = sql.NullTime{Time: last.Date, Valid: true}
+			}
+			require.Len(t, seen, len(created), sortBy)
+			require.Equal(t, 3, pages)
+		}
 	}
 
-	accounts, err := testQueries.GetAccountsGraph(context.Background(), arg)
+	page, err := testQueries.GetAccounts(context.Background(), GetAccountsParams{
+		UserID:     user.ID,
+		Type:       "debit",
+		SortBy:     "value",
+		Descending: true,
+		PageSize:   1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, created[4].ID, page[0].ID)
+
+	filter := GetAccountsSummaryParams{
+		UserID:   user.ID,
+		Type:     "debit",
+		DateFrom: sql.NullTime{Time: time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), Valid: true},
+		MinValue: sql.NullInt64{Int64: int64(util.NewMoney(20, 0)), Valid: true},
+		MaxValue: sql.NullInt64{Int64: int64(util.NewMoney(40, 0)), Valid: true},
+	}
+	summary, err := testQueries.GetAccountsSummary(context.Background(), filter)
+	require.NoError(t, err)
+	// 20 no dia 11, 30 no dia 12 e 40 no dia 10, que fica de fora
+	require.Equal(t, int64(2), summary.TotalCount)
+	require.Equal(t, util.NewMoney(50, 0), summary.TotalValue)
+	require.Zero(t, summary.MissingRates)
+}
+
+func TestGetAccountsTransfers(t *testing.T) {
+	user := createRandomUser(t)
+	wallet := createRandomWallet(t, user.ID)
+	savings := createRandomWallet(t, user.ID)
+	createWalletTransaction(t, wallet, "debit", util.NewMoney(30, 0), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))
+	createTestTransfer(t, wallet, savings, util.NewMoney(100, 0), util.NewMoney(100, 0))
+
+	// a lista e o total usam o mesmo filtro das transferências
+	for _, includeTransfers := range []bool{false, true} {
+		accounts, err := testQueries.GetAccounts(context.Background(), GetAccountsParams{
+			UserID:           user.ID,
+			Type:             "debit",
+			WalletID:         sql.NullInt32{Int32: wallet.ID, Valid: true},
+			IncludeTransfers: includeTransfers,
+			SortBy:           "date",
+			PageSize:         10,
+		})
+		require.NoError(t, err)
+		summary, err := testQueries.GetAccountsSummary(context.Background(), GetAccountsSummaryParams{
+			UserID:           user.ID,
+			Type:             "debit",
+			WalletID:         sql.NullInt32{Int32: wallet.ID, Valid: true},
+			IncludeTransfers: includeTransfers,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(len(accounts)), summary.TotalCount)
+
+		total := util.Money(0)
+		for _, account := range accounts {
+			total += account.Value
+		}
+		require.Equal(t, total, summary.TotalValue)
+	}
+}
+
+func TestAccountExternalID(t *testing.T) {
+	category := createRandomCategory(t)
+	wallet := createRandomWallet(t, category.UserID)
+	otherWallet := createRandomWallet(t, category.UserID)
+	arg := CreateAccountParams{
+		UserID:      category.UserID,
+		WalletID:    wallet.ID,
+		CategoryID:  category.ID,
+		Title:       util.RandomString(12),
+		Type:        category.Type,
+		Description: util.RandomString(20),
+		Value:       10,
+		Date:        time.Now(),
+		ExternalID:  sql.NullString{String: util.RandomString(16), Valid: true},
+	}
+
+	exists, err := testQueries.AccountExternalIDExists(context.Background(), AccountExternalIDExistsParams{WalletID: wallet.ID, ExternalID: arg.ExternalID.String})
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	account, err := testQueries.CreateAccount(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ExternalID, account.ExternalID)
+
+	exists, err = testQueries.AccountExternalIDExists(context.Background(), AccountExternalIDExistsParams{WalletID: wallet.ID, ExternalID: arg.ExternalID.String})
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	// o mesmo ID não entra de novo na carteira, mas pode existir em outra
+	_, err = testQueries.CreateAccount(context.Background(), arg)
+	require.Error(t, err)
+	arg.WalletID = otherWallet.ID
+	_, err = testQueries.CreateAccount(context.Background(), arg)
 	require.NoError(t, err)
-	require.NotEmpty(t, accounts)
 }
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomAPIKey(t *testing.T, user User, expiresAt sql.NullTime) ApiKey {
+	arg := CreateAPIKeyParams{
+		UserID:    user.ID,
+		Name:      util.RandomString(8),
+		Prefix:    util.RandomString(11),
+		KeyHash:   util.RandomString(64),
+		Scopes:    []string{"accounts:read", "reports:read"},
+		ExpiresAt: expiresAt,
+	}
+
+	apiKey, err := testQueries.CreateAPIKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, apiKey)
+
+	require.Equal(t, arg.UserID, apiKey.UserID)
+	require.Equal(t, arg.Name, apiKey.Name)
+	require.Equal(t, arg.KeyHash, apiKey.KeyHash)
+	require.Equal(t, arg.Scopes, apiKey.Scopes)
+	require.False(t, apiKey.RevokedAt.Valid)
+
+	return apiKey
+}
+
+func TestGetValidAPIKey(t *testing.T) {
+	user := createRandomUser(t)
+	apiKey := createRandomAPIKey(t, user, sql.NullTime{})
+
+	found, err := testQueries.GetValidAPIKey(context.Background(), apiKey.KeyHash)
+	require.NoError(t, err)
+	require.Equal(t, apiKey.ID, found.ID)
+	require.Equal(t, apiKey.Scopes, found.Scopes)
+
+	expired := createRandomAPIKey(t, user, sql.NullTime{Time: time.Now().UTC().Add(-time.Hour), Valid: true})
+	_, err = testQueries.GetValidAPIKey(context.Background(), expired.KeyHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	user := createRandomUser(t)
+	apiKey := createRandomAPIKey(t, user, sql.NullTime{})
+
+	other := createRandomUser(t)
+	rows, err := testQueries.RevokeAPIKey(context.Background(), RevokeAPIKeyParams{ID: apiKey.ID, UserID: other.ID})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	rows, err = testQueries.RevokeAPIKey(context.Background(), RevokeAPIKeyParams{ID: apiKey.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = testQueries.GetValidAPIKey(context.Background(), apiKey.KeyHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	apiKeys, err := testQueries.GetAPIKeys(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Empty(t, apiKeys)
+}
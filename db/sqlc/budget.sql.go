@@ -0,0 +1,372 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: budget.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+const createBudget = `-- name: CreateBudget :one
+INSERT INTO budgets (
+  user_id,
+  household_id,
+  category_id,
+  month,
+  amount,
+  recurring,
+  rollover
+)
+SELECT
+  $1::int,
+  $2::int,
+  $3::int,
+  $4::date,
+  $5::money_minor,
+  $6::boolean,
+  $7::boolean
+WHERE
+  $2::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = $2::int AND m.user_id = $1::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING id, user_id, household_id, category_id, month, amount, recurring, rollover, created_at
+`
+
+type CreateBudgetParams struct {
+	UserID      int32         `json:"user_id"`
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	CategoryID  int32         `json:"category_id"`
+	Month       time.Time     `json:"month"`
+	Amount      util.Money    `json:"amount"`
+	Recurring   bool          `json:"recurring"`
+	Rollover    bool          `json:"rollover"`
+}
+
+func (q *Queries) CreateBudget(ctx context.Context, arg CreateBudgetParams) (Budget, error) {
+	row := q.db.QueryRowContext(ctx, createBudget,
+		arg.UserID,
+		arg.HouseholdID,
+		arg.CategoryID,
+		arg.Month,
+		arg.Amount,
+		arg.Recurring,
+		arg.Rollover,
+	)
+	var i Budget
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.CategoryID,
+		&i.Month,
+		&i.Amount,
+		&i.Recurring,
+		&i.Rollover,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteBudget = `-- name: DeleteBudget :execrows
+DELETE FROM budgets
+WHERE id = $1
+AND (
+  (budgets.household_id IS NULL AND budgets.user_id = $2)
+  OR budgets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2 AND m.role IN ('owner', 'editor'))
+)
+`
+
+type DeleteBudgetParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) DeleteBudget(ctx context.Context, arg DeleteBudgetParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteBudget, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getBudget = `-- name: GetBudget :one
+SELECT id, user_id, household_id, category_id, month, amount, recurring, rollover, created_at FROM budgets
+WHERE id = $1
+AND (
+  (budgets.household_id IS NULL AND budgets.user_id = $2)
+  OR budgets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+)
+LIMIT 1
+`
+
+type GetBudgetParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) GetBudget(ctx context.Context, arg GetBudgetParams) (Budget, error) {
+	row := q.db.QueryRowContext(ctx, getBudget, arg.ID, arg.UserID)
+	var i Budget
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.CategoryID,
+		&i.Month,
+		&i.Amount,
+		&i.Recurring,
+		&i.Rollover,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getBudgetSpending = `-- name: GetBudgetSpending :many
+WITH converted AS (
+  SELECT
+    accounts.category_id,
+    date_trunc('month', accounts.date)::date AS month,
+    accounts.value,
+    exchange_rate(accounts.currency, (SELECT u.base_currency FROM users u WHERE u.id = $1), accounts.date) AS rate
+  FROM accounts
+  WHERE (
+    ($2::int IS NULL AND accounts.household_id IS NULL AND accounts.user_id = $1)
+    OR (
+      accounts.household_id = $2::int
+      AND accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $1)
+    )
+  )
+  AND accounts.type = 'debit'
+  AND accounts.transfer_id IS NULL
+  AND accounts.category_id IN (SELECT budgets.category_id FROM budgets)
+  AND accounts.date >= $3::date
+  AND accounts.date < $4::date
+)
+SELECT
+  converted.category_id::int AS category_id,
+  converted.month::date AS month,
+  COALESCE(SUM(ROUND(converted.value * converted.rate)), 0)::money_minor AS spent,
+  COUNT(*) FILTER (WHERE converted.rate IS NULL) AS missing_rates
+FROM converted
+GROUP BY converted.category_id, converted.month
+ORDER BY converted.category_id, converted.month
+`
+
+type GetBudgetSpendingParams struct {
+	UserID      int32         `json:"user_id"`
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	FromMonth   time.Time     `json:"from_month"`
+	ToMonth     time.Time     `json:"to_month"`
+}
+
+type GetBudgetSpendingRow struct {
+	CategoryID   int32      `json:"category_id"`
+	Month        time.Time  `json:"month"`
+	Spent        util.Money `json:"spent"`
+	MissingRates int64      `json:"missing_rates"`
+}
+
+// gastos por mês das categorias com orçamento, convertidos para a moeda base do usuário
+func (q *Queries) GetBudgetSpending(ctx context.Context, arg GetBudgetSpendingParams) ([]GetBudgetSpendingRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBudgetSpending,
+		arg.UserID,
+		arg.HouseholdID,
+		arg.FromMonth,
+		arg.ToMonth,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetBudgetSpendingRow{}
+	for rows.Next() {
+		var i GetBudgetSpendingRow
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.Month,
+			&i.Spent,
+			&i.MissingRates,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBudgets = `-- name: GetBudgets :many
+SELECT id, user_id, household_id, category_id, month, amount, recurring, rollover, created_at FROM budgets
+WHERE (
+  ($1::int IS NULL AND budgets.household_id IS NULL AND budgets.user_id = $2)
+  OR (
+    budgets.household_id = $1::int
+    AND budgets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+  )
+)
+ORDER BY budgets.month, budgets.category_id
+`
+
+type GetBudgetsParams struct {
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	UserID      int32         `json:"user_id"`
+}
+
+func (q *Queries) GetBudgets(ctx context.Context, arg GetBudgetsParams) ([]Budget, error) {
+	rows, err := q.db.QueryContext(ctx, getBudgets, arg.HouseholdID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Budget{}
+	for rows.Next() {
+		var i Budget
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.HouseholdID,
+			&i.CategoryID,
+			&i.Month,
+			&i.Amount,
+			&i.Recurring,
+			&i.Rollover,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBudgetsUntil = `-- name: GetBudgetsUntil :many
+SELECT budgets.id, budgets.user_id, budgets.household_id, budgets.category_id, budgets.month, budgets.amount, budgets.recurring, budgets.rollover, budgets.created_at, categories.title AS category_title FROM budgets
+JOIN categories ON categories.id = budgets.category_id
+WHERE (
+  ($1::int IS NULL AND budgets.household_id IS NULL AND budgets.user_id = $2)
+  OR (
+    budgets.household_id = $1::int
+    AND budgets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+  )
+)
+AND budgets.month <= $3::date
+ORDER BY budgets.category_id, budgets.month
+`
+
+type GetBudgetsUntilParams struct {
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	UserID      int32         `json:"user_id"`
+	Month       time.Time     `json:"month"`
+}
+
+type GetBudgetsUntilRow struct {
+	ID            int32         `json:"id"`
+	UserID        int32         `json:"user_id"`
+	HouseholdID   sql.NullInt32 `json:"household_id"`
+	CategoryID    int32         `json:"category_id"`
+	Month         time.Time     `json:"month"`
+	Amount        util.Money    `json:"amount"`
+	Recurring     bool          `json:"recurring"`
+	Rollover      bool          `json:"rollover"`
+	CreatedAt     time.Time     `json:"created_at"`
+	CategoryTitle string        `json:"category_title"`
+}
+
+// orçamentos do escopo até o mês, com o título da categoria
+func (q *Queries) GetBudgetsUntil(ctx context.Context, arg GetBudgetsUntilParams) ([]GetBudgetsUntilRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBudgetsUntil, arg.HouseholdID, arg.UserID, arg.Month)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetBudgetsUntilRow{}
+	for rows.Next() {
+		var i GetBudgetsUntilRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.HouseholdID,
+			&i.CategoryID,
+			&i.Month,
+			&i.Amount,
+			&i.Recurring,
+			&i.Rollover,
+			&i.CreatedAt,
+			&i.CategoryTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateBudget = `-- name: UpdateBudget :one
+UPDATE budgets SET
+  amount = $1,
+  recurring = $2,
+  rollover = $3
+WHERE id = $4
+AND (
+  (budgets.household_id IS NULL AND budgets.user_id = $5)
+  OR budgets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $5 AND m.role IN ('owner', 'editor'))
+)
+RETURNING id, user_id, household_id, category_id, month, amount, recurring, rollover, created_at
+`
+
+type UpdateBudgetParams struct {
+	Amount    util.Money `json:"amount"`
+	Recurring bool       `json:"recurring"`
+	Rollover  bool       `json:"rollover"`
+	ID        int32      `json:"id"`
+	UserID    int32      `json:"user_id"`
+}
+
+func (q *Queries) UpdateBudget(ctx context.Context, arg UpdateBudgetParams) (Budget, error) {
+	row := q.db.QueryRowContext(ctx, updateBudget,
+		arg.Amount,
+		arg.Recurring,
+		arg.Rollover,
+		arg.ID,
+		arg.UserID,
+	)
+	var i Budget
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.CategoryID,
+		&i.Month,
+		&i.Amount,
+		&i.Recurring,
+		&i.Rollover,
+		&i.CreatedAt,
+	)
+	return i, err
+}
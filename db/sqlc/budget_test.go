@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestBudget(t *testing.T, category Category, month time.Time, amount util.Money) Budget {
+	arg := CreateBudgetParams{
+		UserID:      category.UserID,
+		HouseholdID: category.HouseholdID,
+		CategoryID:  category.ID,
+		Month:       month,
+		Amount:      amount,
+		Recurring:   true,
+	}
+
+	budget, err := testQueries.CreateBudget(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, budget.ID)
+	require.Equal(t, arg.CategoryID, budget.CategoryID)
+	require.True(t, budget.Month.Equal(month))
+	require.Equal(t, arg.Amount, budget.Amount)
+	return budget
+}
+
+func TestCreateBudgetUnique(t *testing.T) {
+	category := createRandomCategory(t)
+	month := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	createTestBudget(t, category, month, util.NewMoney(500, 0))
+
+	_, err := testQueries.CreateBudget(context.Background(), CreateBudgetParams{
+		UserID:     category.UserID,
+		CategoryID: category.ID,
+		Month:      month,
+		Amount:     util.NewMoney(600, 0),
+	})
+	require.Error(t, err)
+
+	// o mês é sempre o primeiro dia
+	_, err = testQueries.CreateBudget(context.Background(), CreateBudgetParams{
+		UserID:     category.UserID,
+		CategoryID: category.ID,
+		Month:      month.AddDate(0, 1, 4),
+		Amount:     util.NewMoney(600, 0),
+	})
+	require.Error(t, err)
+}
+
+func TestBudgetPermissions(t *testing.T) {
+	owner := createRandomUser(t)
+	household := createRandomHousehold(t, owner)
+	householdID := sql.NullInt32{Int32: household.ID, Valid: true}
+	category, err := testQueries.CreateCategory(context.Background(), CreateCategoryParams{
+		UserID:      owner.ID,
+		HouseholdID: householdID,
+		Title:       util.RandomString(12),
+		Type:        "debit",
+		Description: util.RandomString(20),
+	})
+	require.NoError(t, err)
+	budget := createTestBudget(t, category, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), util.NewMoney(500, 0))
+	viewer := addRandomHouseholdMember(t, household, "viewer")
+
+	_, err = testQueries.CreateBudget(context.Background(), CreateBudgetParams{
+		UserID:      viewer.ID,
+		HouseholdID: householdID,
+		CategoryID:  category.ID,
+		Month:       time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Amount:      util.NewMoney(500, 0),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	budgets, err := testQueries.GetBudgets(context.Background(), GetBudgetsParams{HouseholdID: householdID, UserID: viewer.ID})
+	require.NoError(t, err)
+	require.Len(t, budgets, 1)
+
+	_, err = testQueries.UpdateBudget(context.Background(), UpdateBudgetParams{ID: budget.ID, UserID: viewer.ID, Amount: util.NewMoney(1, 0)})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	rowsDeleted, err := testQueries.DeleteBudget(context.Background(), DeleteBudgetParams{ID: budget.ID, UserID: viewer.ID})
+	require.NoError(t, err)
+	require.Zero(t, rowsDeleted)
+
+	_, err = testQueries.GetBudget(context.Background(), GetBudgetParams{ID: budget.ID, UserID: createRandomUser(t).ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestGetBudgetSpending(t *testing.T) {
+	user := createRandomUser(t)
+	wallet := createRandomWallet(t, user.ID)
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	groceries := createWalletTransaction(t, wallet, "debit", util.NewMoney(100, 0), march.AddDate(0, 0, 4))
+	category, err := testQueries.GetCategory(context.Background(), GetCategoryParams{ID: groceries.CategoryID.Int32, UserID: user.ID})
+	require.NoError(t, err)
+	budget := createTestBudget(t, category, march, util.NewMoney(500, 0))
+	_, err = testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		UserID:      user.ID,
+		WalletID:    wallet.ID,
+		CategoryID:  category.ID,
+		Title:       util.RandomString(12),
+		Type:        "debit",
+		Description: util.RandomString(20),
+		Value:       util.NewMoney(50, 25),
+		Date:        march.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+	// sem orçamento, a categoria não entra
+	createWalletTransaction(t, wallet, "debit", util.NewMoney(70, 0), march)
+
+	budgets, err := testQueries.GetBudgetsUntil(context.Background(), GetBudgetsUntilParams{UserID: user.ID, Month: march})
+	require.NoError(t, err)
+	require.Len(t, budgets, 1)
+	require.Equal(t, budget.ID, budgets[0].ID)
+	require.Equal(t, category.Title, budgets[0].CategoryTitle)
+
+	spending, err := testQueries.GetBudgetSpending(context.Background(), GetBudgetSpendingParams{
+		UserID:    user.ID,
+		FromMonth: march,
+		ToMonth:   march.AddDate(0, 2, 0),
+	})
+	require.NoError(t, err)
+	require.Len(t, spending, 2)
+	require.Equal(t, category.ID, spending[0].CategoryID)
+	require.True(t, spending[0].Month.Equal(march))
+	require.Equal(t, util.NewMoney(100, 0), spending[0].Spent)
+	require.True(t, spending[1].Month.Equal(march.AddDate(0, 1, 0)))
+	require.Equal(t, util.NewMoney(50, 25), spending[1].Spent)
+	require.Zero(t, spending[1].MissingRates)
+}
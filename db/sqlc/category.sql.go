@@ -7,29 +7,45 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createCategory = `-- name: CreateCategory :one
 INSERT INTO categories (
   user_id,
+  household_id,
   title,
   type,
   description
-) VALUES (
-  $1, $2, $3, $4
-) RETURNING id, user_id, title, type, description, created_at
+)
+SELECT
+  $1::int,
+  $2::int,
+  $3::varchar,
+  $4::varchar,
+  $5::varchar
+WHERE
+  $2::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = $2::int AND m.user_id = $1::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING id, user_id, title, type, description, created_at, household_id
 `
 
 type CreateCategoryParams struct {
-	UserID      int32  `json:"user_id"`
-	Title       string `json:"title"`
-	Type        string `json:"type"`
-	Description string `json:"description"`
+	UserID      int32         `json:"user_id"`
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	Title       string        `json:"title"`
+	Type        string        `json:"type"`
+	Description string        `json:"description"`
 }
 
 func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
 	row := q.db.QueryRowContext(ctx, createCategory,
 		arg.UserID,
+		arg.HouseholdID,
 		arg.Title,
 		arg.Type,
 		arg.Description,
@@ -42,32 +58,58 @@ func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams)
 		&i.Type,
 		&i.Description,
 		&i.CreatedAt,
+		&i.HouseholdID,
 	)
 	return i, err
 }
 
-const deleteCategories = `-- name: DeleteCategories :exec
-DELETE FROM categories WHERE id = $1
+const deleteCategories = `-- name: DeleteCategories :execrows
+DELETE FROM categories
+WHERE id = $1
+AND (
+  (categories.household_id IS NULL AND categories.user_id = $2)
+  OR categories.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2 AND m.role IN ('owner', 'editor'))
+)
 `
 
-func (q *Queries) DeleteCategories(ctx context.Context, id int32) error {
-	_, err := q.db.ExecContext(ctx, deleteCategories, id)
-	return err
+type DeleteCategoriesParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) DeleteCategories(ctx context.Context, arg DeleteCategoriesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCategories, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const getCategories = `-- name: GetCategories :many
-SELECT id, user_id, title, type, description, created_at FROM categories WHERE user_id = $1 AND type = $2 AND LOWER(title) LIKE CONCAT('%', LOWER($3::text), '%') AND LOWER(description) LIKE CONCAT('%', LOWER($4::text), '%')
+SELECT id, user_id, title, type, description, created_at, household_id FROM categories
+WHERE (
+  ($1::int IS NULL AND categories.household_id IS NULL AND categories.user_id = $2)
+  OR (
+    categories.household_id = $1::int
+    AND categories.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+  )
+)
+AND type = $3
+AND LOWER(title) LIKE CONCAT('%', LOWER($4::text), '%')
+AND LOWER(description) LIKE CONCAT('%', LOWER($5::text), '%')
 `
 
 type GetCategoriesParams struct {
-	UserID      int32  `json:"user_id"`
-	Type        string `json:"type"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	UserID      int32         `json:"user_id"`
+	Type        string        `json:"type"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
 }
 
 func (q *Queries) GetCategories(ctx context.Context, arg GetCategoriesParams) ([]Category, error) {
 	rows, err := q.db.QueryContext(ctx, getCategories,
+		arg.HouseholdID,
 		arg.UserID,
 		arg.Type,
 		arg.Title,
@@ -87,6 +129,7 @@ func (q *Queries) GetCategories(ctx context.Context, arg GetCategoriesParams) ([
 			&i.Type,
 			&i.Description,
 			&i.CreatedAt,
+			&i.HouseholdID,
 		); err != nil {
 			return nil, err
 		}
@@ -102,11 +145,22 @@ func (q *Queries) GetCategories(ctx context.Context, arg GetCategoriesParams) ([
 }
 
 const getCategory = `-- name: GetCategory :one
-SELECT id, user_id, title, type, description, created_at FROM categories WHERE id = $1 LIMIT 1
+SELECT id, user_id, title, type, description, created_at, household_id FROM categories
+WHERE id = $1
+AND (
+  (categories.household_id IS NULL AND categories.user_id = $2)
+  OR categories.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+)
+LIMIT 1
 `
 
-func (q *Queries) GetCategory(ctx context.Context, id int32) (Category, error) {
-	row := q.db.QueryRowContext(ctx, getCategory, id)
+type GetCategoryParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) GetCategory(ctx context.Context, arg GetCategoryParams) (Category, error) {
+	row := q.db.QueryRowContext(ctx, getCategory, arg.ID, arg.UserID)
 	var i Category
 	err := row.Scan(
 		&i.ID,
@@ -115,22 +169,35 @@ func (q *Queries) GetCategory(ctx context.Context, id int32) (Category, error) {
 		&i.Type,
 		&i.Description,
 		&i.CreatedAt,
+		&i.HouseholdID,
 	)
 	return i, err
 }
 
 const updateCategories = `-- name: UpdateCategories :one
-UPDATE categories SET title = $2, description = $3 WHERE id = $1 RETURNING id, user_id, title, type, description, created_at
+UPDATE categories SET title = $1, description = $2
+WHERE id = $3
+AND (
+  (categories.household_id IS NULL AND categories.user_id = $4)
+  OR categories.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $4 AND m.role IN ('owner', 'editor'))
+)
+RETURNING id, user_id, title, type, description, created_at, household_id
 `
 
 type UpdateCategoriesParams struct {
-	ID          int32  `json:"id"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
+	ID          int32  `json:"id"`
+	UserID      int32  `json:"user_id"`
 }
 
 func (q *Queries) UpdateCategories(ctx context.Context, arg UpdateCategoriesParams) (Category, error) {
-	row := q.db.QueryRowContext(ctx, updateCategories, arg.ID, arg.Title, arg.Description)
+	row := q.db.QueryRowContext(ctx, updateCategories,
+		arg.Title,
+		arg.Description,
+		arg.ID,
+		arg.UserID,
+	)
 	var i Category
 	err := row.Scan(
 		&i.ID,
@@ -139,6 +206,7 @@ func (q *Queries) UpdateCategories(ctx context.Context, arg UpdateCategoriesPara
 		&i.Type,
 		&i.Description,
 		&i.CreatedAt,
+		&i.HouseholdID,
 	)
 	return i, err
 }
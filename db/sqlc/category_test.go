@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 
 	"github.com/SraReaper/gofinance-backend/util"
@@ -36,7 +37,11 @@ func TestCreateCategory(t *testing.T) {
 
 func TestGetCategory(t *testing.T) {
 	category1 := createRandomCategory(t)
-	category2, err := testQueries.GetCategory(context.Background(), category1.ID)
+	arg := GetCategoryParams{
+		ID:     category1.ID,
+		UserID: category1.UserID,
+	}
+	category2, err := testQueries.GetCategory(context.Background(), arg)
 
 	require.NoError(t, err)
 	require.NotEmpty(t, category2)
@@ -51,9 +56,14 @@ func TestGetCategory(t *testing.T) {
 
 func TestDeleteCategory(t *testing.T) {
 	category := createRandomCategory(t)
-	err := testQueries.DeleteCategories(context.Background(), category.ID)
+	arg := DeleteCategoriesParams{
+		ID:     category.ID,
+		UserID: category.UserID,
+	}
+	rowsDeleted, err := testQueries.DeleteCategories(context.Background(), arg)
 
 	require.NoError(t, err)
+	require.Equal(t, int64(1), rowsDeleted)
 }
 
 func TestUpdateCategory(t *testing.T) {
@@ -61,6 +71,7 @@ func TestUpdateCategory(t *testing.T) {
 
 	arg := UpdateCategoriesParams{
 		ID:          category1.ID,
+		UserID:      category1.UserID,
 		Title:       util.RandomString(12),
 		Description: util.RandomString(20),
 	}
@@ -75,6 +86,32 @@ func TestUpdateCategory(t *testing.T) {
 	require.NotEmpty(t, category2.CreatedAt)
 }
 
+func TestCategoryOwnership(t *testing.T) {
+	category := createRandomCategory(t)
+	otherUser := createRandomUser(t)
+
+	_, err := testQueries.GetCategory(context.Background(), GetCategoryParams{
+		ID:     category.ID,
+		UserID: otherUser.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.UpdateCategories(context.Background(), UpdateCategoriesParams{
+		ID:          category.ID,
+		UserID:      otherUser.ID,
+		Title:       util.RandomString(12),
+		Description: util.RandomString(20),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	rowsDeleted, err := testQueries.DeleteCategories(context.Background(), DeleteCategoriesParams{
+		ID:     category.ID,
+		UserID: otherUser.ID,
+	})
+	require.NoError(t, err)
+	require.Zero(t, rowsDeleted)
+}
+
 func TestListCategory(t *testing.T) {
 	var lastCategory Category
 	for i := 0; i < 5; i++ {
@@ -0,0 +1,67 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: exchange_rate.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const getExchangeRate = `-- name: GetExchangeRate :one
+SELECT r.rate::numeric AS rate
+FROM (SELECT exchange_rate($1::varchar, $2::varchar, $3::date) AS rate) r
+WHERE r.rate IS NOT NULL
+`
+
+type GetExchangeRateParams struct {
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	OnDate       time.Time `json:"on_date"`
+}
+
+func (q *Queries) GetExchangeRate(ctx context.Context, arg GetExchangeRateParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getExchangeRate, arg.FromCurrency, arg.ToCurrency, arg.OnDate)
+	var rate string
+	err := row.Scan(&rate)
+	return rate, err
+}
+
+const upsertExchangeRates = `-- name: UpsertExchangeRates :execrows
+INSERT INTO exchange_rates (
+  base_currency,
+  quote_currency,
+  rate,
+  effective_date
+)
+SELECT
+  unnest($1::varchar[]),
+  unnest($2::varchar[]),
+  unnest($3::numeric[]),
+  unnest($4::date[])
+ON CONFLICT (base_currency, quote_currency, effective_date) DO UPDATE SET rate = EXCLUDED.rate
+`
+
+type UpsertExchangeRatesParams struct {
+	BaseCurrencies  []string    `json:"base_currencies"`
+	QuoteCurrencies []string    `json:"quote_currencies"`
+	Rates           []string    `json:"rates"`
+	EffectiveDates  []time.Time `json:"effective_dates"`
+}
+
+func (q *Queries) UpsertExchangeRates(ctx context.Context, arg UpsertExchangeRatesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, upsertExchangeRates,
+		pq.Array(arg.BaseCurrencies),
+		pq.Array(arg.QuoteCurrencies),
+		pq.Array(arg.Rates),
+		pq.Array(arg.EffectiveDates),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomCurrency() string {
+	return strings.ToUpper(util.RandomString(3))
+}
+
+func upsertTestRate(t *testing.T, base string, quote string, rate string, date time.Time) {
+	rows, err := testQueries.UpsertExchangeRates(context.Background(), UpsertExchangeRatesParams{
+		BaseCurrencies:  []string{base},
+		QuoteCurrencies: []string{quote},
+		Rates:           []string{rate},
+		EffectiveDates:  []time.Time{date},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
+
+func requireRate(t *testing.T, want string, from string, to string, date time.Time) {
+	rate, err := testQueries.GetExchangeRate(context.Background(), GetExchangeRateParams{
+		FromCurrency: from,
+		ToCurrency:   to,
+		OnDate:       date,
+	})
+	require.NoError(t, err)
+
+	got, ok := new(big.Rat).SetString(rate)
+	require.True(t, ok)
+	expected, _ := new(big.Rat).SetString(want)
+	require.Equal(t, 0, got.Cmp(expected), "got %s, want %s", rate, want)
+}
+
+func TestGetExchangeRate(t *testing.T) {
+	pivot, from, to, other := randomCurrency(), randomCurrency(), randomCurrency(), randomCurrency()
+	january := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	upsertTestRate(t, from, to, "5", january)
+	upsertTestRate(t, from, to, "6", february)
+	upsertTestRate(t, pivot, from, "4", january)
+	upsertTestRate(t, pivot, other, "2", january)
+
+	// direta, com a taxa em vigor na data
+	requireRate(t, "5", from, to, january.AddDate(0, 0, 10))
+	requireRate(t, "6", from, to, february)
+	// inversa
+	requireRate(t, "0.2", to, from, january)
+	// cruzada pela moeda em comum
+	requireRate(t, "0.5", from, other, january)
+	requireRate(t, "2", other, from, january)
+	requireRate(t, "1", to, to, january)
+
+	_, err := testQueries.GetExchangeRate(context.Background(), GetExchangeRateParams{
+		FromCurrency: from,
+		ToCurrency:   to,
+		OnDate:       january.AddDate(0, 0, -1),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// uma nova carga para o mesmo par e data substitui a taxa
+	upsertTestRate(t, from, to, "5.5", january)
+	requireRate(t, "5.5", from, to, january)
+}
+
+func TestGetAccountsReportsConverted(t *testing.T) {
+	base, foreign := randomCurrency(), randomCurrency()
+	january := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	upsertTestRate(t, foreign, base, "5.5", january)
+	upsertTestRate(t, foreign, base, "6", january.AddDate(0, 1, 0))
+
+	category := createRandomCategory(t)
+	_, err := testQueries.UpdateUserBaseCurrency(context.Background(), UpdateUserBaseCurrencyParams{
+		ID:           category.UserID,
+		BaseCurrency: base,
+	})
+	require.NoError(t, err)
+
+	createAccount := func(value util.Money, currency string, date time.Time) Account {
+		wallet := createTestWallet(t, category.UserID, sql.NullInt32{}, currency)
+		account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+			UserID:      category.UserID,
+			WalletID:    wallet.ID,
+			CategoryID:  category.ID,
+			Title:       util.RandomString(12),
+			Type:        category.Type,
+			Description: util.RandomString(20),
+			Value:       value,
+			Date:        date,
+		})
+		require.NoError(t, err)
+		return account
+	}
+
+	account := createAccount(1000, "", january)
+	require.Equal(t, base, account.Currency)
+	createAccount(1001, foreign, january.AddDate(0, 0, 15))
+
+	arg := GetAccountsReportsParams{
+		UserID: category.UserID,
+		Type:   category.Type,
+	}
+	report, err := testQueries.GetAccountsReports(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, report.MissingRates)
+	// 1001 * 5.5 = 5505.5, arredondado para longe do zero
+	require.Equal(t, util.Money(1000+5506), report.SumValue)
+
+	createAccount(1000, foreign, january.AddDate(0, 0, -1))
+	report, err = testQueries.GetAccountsReports(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), report.MissingRates)
+}
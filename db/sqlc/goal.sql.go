@@ -0,0 +1,430 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: goal.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+const createGoal = `-- name: CreateGoal :one
+INSERT INTO goals (
+  user_id,
+  household_id,
+  name,
+  target_amount,
+  currency,
+  deadline,
+  category_id,
+  wallet_id
+)
+SELECT
+  $1::int,
+  $2::int,
+  $3::varchar,
+  $4::money_minor,
+  COALESCE($5::varchar, (SELECT u.base_currency FROM users u WHERE u.id = $1::int)),
+  $6::date,
+  $7::int,
+  $8::int
+WHERE
+  $2::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = $2::int AND m.user_id = $1::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING id, user_id, household_id, name, target_amount, currency, deadline, category_id, wallet_id, created_at
+`
+
+type CreateGoalParams struct {
+	UserID       int32          `json:"user_id"`
+	HouseholdID  sql.NullInt32  `json:"household_id"`
+	Name         string         `json:"name"`
+	TargetAmount util.Money     `json:"target_amount"`
+	Currency     sql.NullString `json:"currency"`
+	Deadline     sql.NullTime   `json:"deadline"`
+	CategoryID   sql.NullInt32  `json:"category_id"`
+	WalletID     sql.NullInt32  `json:"wallet_id"`
+}
+
+func (q *Queries) CreateGoal(ctx context.Context, arg CreateGoalParams) (Goal, error) {
+	row := q.db.QueryRowContext(ctx, createGoal,
+		arg.UserID,
+		arg.HouseholdID,
+		arg.Name,
+		arg.TargetAmount,
+		arg.Currency,
+		arg.Deadline,
+		arg.CategoryID,
+		arg.WalletID,
+	)
+	var i Goal
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.Name,
+		&i.TargetAmount,
+		&i.Currency,
+		&i.Deadline,
+		&i.CategoryID,
+		&i.WalletID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createGoalContribution = `-- name: CreateGoalContribution :one
+INSERT INTO goal_contributions (
+  goal_id,
+  account_id,
+  amount,
+  date,
+  note
+)
+SELECT
+  goals.id,
+  $1::int,
+  $2::money_minor,
+  $3::date,
+  $4::varchar
+FROM goals
+WHERE goals.id = $5::int
+AND (
+  (goals.household_id IS NULL AND goals.user_id = $6::int)
+  OR goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $6::int AND m.role IN ('owner', 'editor'))
+)
+RETURNING id, goal_id, account_id, amount, date, note, created_at
+`
+
+type CreateGoalContributionParams struct {
+	AccountID sql.NullInt32 `json:"account_id"`
+	Amount    util.Money    `json:"amount"`
+	Date      time.Time     `json:"date"`
+	Note      string        `json:"note"`
+	GoalID    int32         `json:"goal_id"`
+	UserID    int32         `json:"user_id"`
+}
+
+func (q *Queries) CreateGoalContribution(ctx context.Context, arg CreateGoalContributionParams) (GoalContribution, error) {
+	row := q.db.QueryRowContext(ctx, createGoalContribution,
+		arg.AccountID,
+		arg.Amount,
+		arg.Date,
+		arg.Note,
+		arg.GoalID,
+		arg.UserID,
+	)
+	var i GoalContribution
+	err := row.Scan(
+		&i.ID,
+		&i.GoalID,
+		&i.AccountID,
+		&i.Amount,
+		&i.Date,
+		&i.Note,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteGoal = `-- name: DeleteGoal :execrows
+DELETE FROM goals
+WHERE id = $1
+AND (
+  (goals.household_id IS NULL AND goals.user_id = $2)
+  OR goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2 AND m.role IN ('owner', 'editor'))
+)
+`
+
+type DeleteGoalParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) DeleteGoal(ctx context.Context, arg DeleteGoalParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteGoal, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteGoalContribution = `-- name: DeleteGoalContribution :execrows
+DELETE FROM goal_contributions
+USING goals
+WHERE goal_contributions.id = $1
+AND goal_contributions.goal_id = $2
+AND goals.id = goal_contributions.goal_id
+AND (
+  (goals.household_id IS NULL AND goals.user_id = $3)
+  OR goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $3 AND m.role IN ('owner', 'editor'))
+)
+`
+
+type DeleteGoalContributionParams struct {
+	ID     int32 `json:"id"`
+	GoalID int32 `json:"goal_id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) DeleteGoalContribution(ctx context.Context, arg DeleteGoalContributionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteGoalContribution, arg.ID, arg.GoalID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getGoal = `-- name: GetGoal :one
+SELECT
+  goals.id, goals.user_id, goals.household_id, goals.name, goals.target_amount, goals.currency, goals.deadline, goals.category_id, goals.wallet_id, goals.created_at,
+  COALESCE(SUM(c.amount), 0)::money_minor AS saved,
+  COALESCE(MIN(c.date), CURRENT_DATE)::date AS first_contribution
+FROM goals
+LEFT JOIN goal_contributions c ON c.goal_id = goals.id
+WHERE goals.id = $1
+AND (
+  (goals.household_id IS NULL AND goals.user_id = $2)
+  OR goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+)
+GROUP BY goals.id
+LIMIT 1
+`
+
+type GetGoalParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+type GetGoalRow struct {
+	ID                int32         `json:"id"`
+	UserID            int32         `json:"user_id"`
+	HouseholdID       sql.NullInt32 `json:"household_id"`
+	Name              string        `json:"name"`
+	TargetAmount      util.Money    `json:"target_amount"`
+	Currency          string        `json:"currency"`
+	Deadline          sql.NullTime  `json:"deadline"`
+	CategoryID        sql.NullInt32 `json:"category_id"`
+	WalletID          sql.NullInt32 `json:"wallet_id"`
+	CreatedAt         time.Time     `json:"created_at"`
+	Saved             util.Money    `json:"saved"`
+	FirstContribution time.Time     `json:"first_contribution"`
+}
+
+// saved é o total das contribuições e first_contribution a data da primeira (hoje, se não houver)
+func (q *Queries) GetGoal(ctx context.Context, arg GetGoalParams) (GetGoalRow, error) {
+	row := q.db.QueryRowContext(ctx, getGoal, arg.ID, arg.UserID)
+	var i GetGoalRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.Name,
+		&i.TargetAmount,
+		&i.Currency,
+		&i.Deadline,
+		&i.CategoryID,
+		&i.WalletID,
+		&i.CreatedAt,
+		&i.Saved,
+		&i.FirstContribution,
+	)
+	return i, err
+}
+
+const getGoalContribution = `-- name: GetGoalContribution :one
+SELECT goal_contributions.id, goal_contributions.goal_id, goal_contributions.account_id, goal_contributions.amount, goal_contributions.date, goal_contributions.note, goal_contributions.created_at FROM goal_contributions
+JOIN goals ON goals.id = goal_contributions.goal_id
+WHERE goal_contributions.id = $1
+AND goal_contributions.goal_id = $2
+AND (
+  (goals.household_id IS NULL AND goals.user_id = $3)
+  OR goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $3)
+)
+LIMIT 1
+`
+
+type GetGoalContributionParams struct {
+	ID     int32 `json:"id"`
+	GoalID int32 `json:"goal_id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) GetGoalContribution(ctx context.Context, arg GetGoalContributionParams) (GoalContribution, error) {
+	row := q.db.QueryRowContext(ctx, getGoalContribution, arg.ID, arg.GoalID, arg.UserID)
+	var i GoalContribution
+	err := row.Scan(
+		&i.ID,
+		&i.GoalID,
+		&i.AccountID,
+		&i.Amount,
+		&i.Date,
+		&i.Note,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getGoalContributions = `-- name: GetGoalContributions :many
+SELECT id, goal_id, account_id, amount, date, note, created_at FROM goal_contributions
+WHERE goal_id = $1
+ORDER BY date, id
+`
+
+func (q *Queries) GetGoalContributions(ctx context.Context, goalID int32) ([]GoalContribution, error) {
+	rows, err := q.db.QueryContext(ctx, getGoalContributions, goalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GoalContribution{}
+	for rows.Next() {
+		var i GoalContribution
+		if err := rows.Scan(
+			&i.ID,
+			&i.GoalID,
+			&i.AccountID,
+			&i.Amount,
+			&i.Date,
+			&i.Note,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGoals = `-- name: GetGoals :many
+SELECT
+  goals.id, goals.user_id, goals.household_id, goals.name, goals.target_amount, goals.currency, goals.deadline, goals.category_id, goals.wallet_id, goals.created_at,
+  COALESCE(SUM(c.amount), 0)::money_minor AS saved,
+  COALESCE(MIN(c.date), CURRENT_DATE)::date AS first_contribution
+FROM goals
+LEFT JOIN goal_contributions c ON c.goal_id = goals.id
+WHERE (
+  ($1::int IS NULL AND goals.household_id IS NULL AND goals.user_id = $2)
+  OR (
+    goals.household_id = $1::int
+    AND goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+  )
+)
+GROUP BY goals.id
+ORDER BY goals.id
+`
+
+type GetGoalsParams struct {
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	UserID      int32         `json:"user_id"`
+}
+
+type GetGoalsRow struct {
+	ID                int32         `json:"id"`
+	UserID            int32         `json:"user_id"`
+	HouseholdID       sql.NullInt32 `json:"household_id"`
+	Name              string        `json:"name"`
+	TargetAmount      util.Money    `json:"target_amount"`
+	Currency          string        `json:"currency"`
+	Deadline          sql.NullTime  `json:"deadline"`
+	CategoryID        sql.NullInt32 `json:"category_id"`
+	WalletID          sql.NullInt32 `json:"wallet_id"`
+	CreatedAt         time.Time     `json:"created_at"`
+	Saved             util.Money    `json:"saved"`
+	FirstContribution time.Time     `json:"first_contribution"`
+}
+
+func (q *Queries) GetGoals(ctx context.Context, arg GetGoalsParams) ([]GetGoalsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getGoals, arg.HouseholdID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetGoalsRow{}
+	for rows.Next() {
+		var i GetGoalsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.HouseholdID,
+			&i.Name,
+			&i.TargetAmount,
+			&i.Currency,
+			&i.Deadline,
+			&i.CategoryID,
+			&i.WalletID,
+			&i.CreatedAt,
+			&i.Saved,
+			&i.FirstContribution,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateGoal = `-- name: UpdateGoal :one
+UPDATE goals SET
+  name = $1,
+  target_amount = $2,
+  deadline = $3::date
+WHERE id = $4
+AND (
+  (goals.household_id IS NULL AND goals.user_id = $5)
+  OR goals.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $5 AND m.role IN ('owner', 'editor'))
+)
+RETURNING id, user_id, household_id, name, target_amount, currency, deadline, category_id, wallet_id, created_at
+`
+
+type UpdateGoalParams struct {
+	Name         string       `json:"name"`
+	TargetAmount util.Money   `json:"target_amount"`
+	Deadline     sql.NullTime `json:"deadline"`
+	ID           int32        `json:"id"`
+	UserID       int32        `json:"user_id"`
+}
+
+func (q *Queries) UpdateGoal(ctx context.Context, arg UpdateGoalParams) (Goal, error) {
+	row := q.db.QueryRowContext(ctx, updateGoal,
+		arg.Name,
+		arg.TargetAmount,
+		arg.Deadline,
+		arg.ID,
+		arg.UserID,
+	)
+	var i Goal
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.Name,
+		&i.TargetAmount,
+		&i.Currency,
+		&i.Deadline,
+		&i.CategoryID,
+		&i.WalletID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestGoal(t *testing.T, userID int32, householdID sql.NullInt32) Goal {
+	arg := CreateGoalParams{
+		UserID:       userID,
+		HouseholdID:  householdID,
+		Name:         util.RandomString(12),
+		TargetAmount: util.NewMoney(1000, 0),
+		Deadline:     sql.NullTime{Time: time.Date(2030, 12, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	goal, err := testQueries.CreateGoal(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, goal.ID)
+	require.Equal(t, arg.Name, goal.Name)
+	require.Equal(t, arg.TargetAmount, goal.TargetAmount)
+	require.True(t, goal.Deadline.Time.Equal(arg.Deadline.Time))
+	return goal
+}
+
+func TestCreateGoal(t *testing.T) {
+	user := createRandomUser(t)
+	goal := createTestGoal(t, user.ID, sql.NullInt32{})
+	// sem moeda, a meta fica na moeda base do usuário
+	require.Equal(t, user.BaseCurrency, goal.Currency)
+
+	_, err := testQueries.CreateGoal(context.Background(), CreateGoalParams{
+		UserID:       user.ID,
+		Name:         util.RandomString(12),
+		TargetAmount: 0,
+	})
+	require.Error(t, err)
+}
+
+func TestGoalContributions(t *testing.T) {
+	account := createRandomAccount(t)
+	goal := createTestGoal(t, account.UserID, sql.NullInt32{})
+
+	row, err := testQueries.GetGoal(context.Background(), GetGoalParams{ID: goal.ID, UserID: goal.UserID})
+	require.NoError(t, err)
+	require.Zero(t, row.Saved)
+	require.NotZero(t, row.FirstContribution)
+
+	first := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	for i, date := range []time.Time{first.AddDate(0, 1, 0), first} {
+		contribution, err := testQueries.CreateGoalContribution(context.Background(), CreateGoalContributionParams{
+			GoalID: goal.ID,
+			UserID: goal.UserID,
+			Amount: util.NewMoney(int64(100*(i+1)), 0),
+			Date:   date,
+		})
+		require.NoError(t, err)
+		require.Equal(t, goal.ID, contribution.GoalID)
+	}
+
+	fromAccount := CreateGoalContributionParams{
+		GoalID:    goal.ID,
+		UserID:    goal.UserID,
+		AccountID: sql.NullInt32{Int32: account.ID, Valid: true},
+		Amount:    account.Value,
+		Date:      first.AddDate(0, 2, 0),
+	}
+	_, err = testQueries.CreateGoalContribution(context.Background(), fromAccount)
+	require.NoError(t, err)
+	// a mesma conta não contribui duas vezes para a meta
+	_, err = testQueries.CreateGoalContribution(context.Background(), fromAccount)
+	require.Error(t, err)
+
+	row, err = testQueries.GetGoal(context.Background(), GetGoalParams{ID: goal.ID, UserID: goal.UserID})
+	require.NoError(t, err)
+	require.Equal(t, util.NewMoney(300, 0)+account.Value, row.Saved)
+	require.True(t, row.FirstContribution.Equal(first))
+
+	contributions, err := testQueries.GetGoalContributions(context.Background(), goal.ID)
+	require.NoError(t, err)
+	require.Len(t, contributions, 3)
+	require.True(t, contributions[0].Date.Equal(first))
+
+	rows, err := testQueries.DeleteGoalContribution(context.Background(), DeleteGoalContributionParams{
+		ID:     contributions[0].ID,
+		GoalID: goal.ID,
+		UserID: goal.UserID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	// remover a meta remove as contribuições
+	rows, err = testQueries.DeleteGoal(context.Background(), DeleteGoalParams{ID: goal.ID, UserID: goal.UserID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+	contributions, err = testQueries.GetGoalContributions(context.Background(), goal.ID)
+	require.NoError(t, err)
+	require.Empty(t, contributions)
+}
+
+func TestGoalPermissions(t *testing.T) {
+	owner := createRandomUser(t)
+	household := createRandomHousehold(t, owner)
+	householdID := sql.NullInt32{Int32: household.ID, Valid: true}
+	goal := createTestGoal(t, owner.ID, householdID)
+	viewer := addRandomHouseholdMember(t, household, "viewer")
+	editor := addRandomHouseholdMember(t, household, "editor")
+	stranger := createRandomUser(t)
+
+	_, err := testQueries.CreateGoal(context.Background(), CreateGoalParams{
+		UserID:       viewer.ID,
+		HouseholdID:  householdID,
+		Name:         util.RandomString(12),
+		TargetAmount: util.NewMoney(100, 0),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	goals, err := testQueries.GetGoals(context.Background(), GetGoalsParams{HouseholdID: householdID, UserID: viewer.ID})
+	require.NoError(t, err)
+	require.Len(t, goals, 1)
+	require.Equal(t, goal.ID, goals[0].ID)
+
+	_, err = testQueries.GetGoal(context.Background(), GetGoalParams{ID: goal.ID, UserID: stranger.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.UpdateGoal(context.Background(), UpdateGoalParams{
+		ID:           goal.ID,
+		UserID:       viewer.ID,
+		Name:         goal.Name,
+		TargetAmount: util.NewMoney(2000, 0),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg := CreateGoalContributionParams{
+		GoalID: goal.ID,
+		UserID: viewer.ID,
+		Amount: util.NewMoney(50, 0),
+		Date:   time.Now(),
+	}
+	_, err = testQueries.CreateGoalContribution(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg.UserID = editor.ID
+	contribution, err := testQueries.CreateGoalContribution(context.Background(), arg)
+	require.NoError(t, err)
+
+	params := DeleteGoalContributionParams{ID: contribution.ID, GoalID: goal.ID, UserID: viewer.ID}
+	rows, err := testQueries.DeleteGoalContribution(context.Background(), params)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+	_, err = testQueries.GetGoalContribution(context.Background(), GetGoalContributionParams(params))
+	require.NoError(t, err)
+
+	rows, err = testQueries.DeleteGoal(context.Background(), DeleteGoalParams{ID: goal.ID, UserID: viewer.ID})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	updated, err := testQueries.UpdateGoal(context.Background(), UpdateGoalParams{
+		ID:           goal.ID,
+		UserID:       editor.ID,
+		Name:         goal.Name,
+		TargetAmount: util.NewMoney(2000, 0),
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.NewMoney(2000, 0), updated.TargetAmount)
+	require.False(t, updated.Deadline.Valid)
+}
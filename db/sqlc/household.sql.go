@@ -0,0 +1,404 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: household.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const acceptHouseholdInvitation = `-- name: AcceptHouseholdInvitation :one
+WITH accepted AS (
+  UPDATE household_invitations SET accepted_at = now()
+  WHERE household_invitations.id = $2
+  AND accepted_at IS NULL
+  AND declined_at IS NULL
+  AND expires_at > now()
+  RETURNING household_id, role
+)
+INSERT INTO household_members (household_id, user_id, role)
+SELECT accepted.household_id, $1::int, accepted.role FROM accepted
+ON CONFLICT (household_id, user_id) DO UPDATE SET role = household_members.role
+RETURNING household_id, user_id, role, created_at
+`
+
+type AcceptHouseholdInvitationParams struct {
+	UserID int32 `json:"user_id"`
+	ID     int32 `json:"id"`
+}
+
+func (q *Queries) AcceptHouseholdInvitation(ctx context.Context, arg AcceptHouseholdInvitationParams) (HouseholdMember, error) {
+	row := q.db.QueryRowContext(ctx, acceptHouseholdInvitation, arg.UserID, arg.ID)
+	var i HouseholdMember
+	err := row.Scan(
+		&i.HouseholdID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const countHouseholdOwners = `-- name: CountHouseholdOwners :one
+SELECT COUNT(*) FROM household_members WHERE household_id = $1 AND role = 'owner'
+`
+
+func (q *Queries) CountHouseholdOwners(ctx context.Context, householdID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countHouseholdOwners, householdID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createHousehold = `-- name: CreateHousehold :one
+WITH household AS (
+  INSERT INTO households (
+    name,
+    created_by
+  ) VALUES (
+    $1, $2
+  ) RETURNING id, name, created_by, created_at
+), owner AS (
+  INSERT INTO household_members (household_id, user_id, role)
+  SELECT household.id, household.created_by, 'owner' FROM household
+)
+SELECT id, name, created_by, created_at FROM household
+`
+
+type CreateHouseholdParams struct {
+	Name      string `json:"name"`
+	CreatedBy int32  `json:"created_by"`
+}
+
+type CreateHouseholdRow struct {
+	ID        int32     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedBy int32     `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) CreateHousehold(ctx context.Context, arg CreateHouseholdParams) (CreateHouseholdRow, error) {
+	row := q.db.QueryRowContext(ctx, createHousehold, arg.Name, arg.CreatedBy)
+	var i CreateHouseholdRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createHouseholdInvitation = `-- name: CreateHouseholdInvitation :one
+INSERT INTO household_invitations (
+  household_id,
+  email,
+  role,
+  token_hash,
+  invited_by,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING id, household_id, email, role, token_hash, invited_by, expires_at, accepted_at, declined_at, created_at
+`
+
+type CreateHouseholdInvitationParams struct {
+	HouseholdID int32     `json:"household_id"`
+	Email       string    `json:"email"`
+	Role        string    `json:"role"`
+	TokenHash   string    `json:"token_hash"`
+	InvitedBy   int32     `json:"invited_by"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CreateHouseholdInvitation(ctx context.Context, arg CreateHouseholdInvitationParams) (HouseholdInvitation, error) {
+	row := q.db.QueryRowContext(ctx, createHouseholdInvitation,
+		arg.HouseholdID,
+		arg.Email,
+		arg.Role,
+		arg.TokenHash,
+		arg.InvitedBy,
+		arg.ExpiresAt,
+	)
+	var i HouseholdInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.HouseholdID,
+		&i.Email,
+		&i.Role,
+		&i.TokenHash,
+		&i.InvitedBy,
+		&i.ExpiresAt,
+		&i.AcceptedAt,
+		&i.DeclinedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const declineHouseholdInvitation = `-- name: DeclineHouseholdInvitation :execrows
+UPDATE household_invitations SET declined_at = now()
+WHERE id = $1 AND accepted_at IS NULL AND declined_at IS NULL
+`
+
+func (q *Queries) DeclineHouseholdInvitation(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, declineHouseholdInvitation, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteHouseholdMember = `-- name: DeleteHouseholdMember :execrows
+DELETE FROM household_members WHERE household_id = $1 AND user_id = $2
+`
+
+type DeleteHouseholdMemberParams struct {
+	HouseholdID int32 `json:"household_id"`
+	UserID      int32 `json:"user_id"`
+}
+
+func (q *Queries) DeleteHouseholdMember(ctx context.Context, arg DeleteHouseholdMemberParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteHouseholdMember, arg.HouseholdID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getHousehold = `-- name: GetHousehold :one
+SELECT h.id, h.name, h.created_by, h.created_at FROM households h
+JOIN household_members m ON m.household_id = h.id
+WHERE h.id = $1 AND m.user_id = $2
+LIMIT 1
+`
+
+type GetHouseholdParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) GetHousehold(ctx context.Context, arg GetHouseholdParams) (Household, error) {
+	row := q.db.QueryRowContext(ctx, getHousehold, arg.ID, arg.UserID)
+	var i Household
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getHouseholdMember = `-- name: GetHouseholdMember :one
+SELECT household_id, user_id, role, created_at FROM household_members WHERE household_id = $1 AND user_id = $2 LIMIT 1
+`
+
+type GetHouseholdMemberParams struct {
+	HouseholdID int32 `json:"household_id"`
+	UserID      int32 `json:"user_id"`
+}
+
+func (q *Queries) GetHouseholdMember(ctx context.Context, arg GetHouseholdMemberParams) (HouseholdMember, error) {
+	row := q.db.QueryRowContext(ctx, getHouseholdMember, arg.HouseholdID, arg.UserID)
+	var i HouseholdMember
+	err := row.Scan(
+		&i.HouseholdID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getHouseholdMembers = `-- name: GetHouseholdMembers :many
+SELECT m.household_id, m.user_id, m.role, m.created_at, u.username, u.email
+FROM household_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.household_id = $1
+ORDER BY m.created_at
+`
+
+type GetHouseholdMembersRow struct {
+	HouseholdID int32     `json:"household_id"`
+	UserID      int32     `json:"user_id"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+	Username    string    `json:"username"`
+	Email       string    `json:"email"`
+}
+
+func (q *Queries) GetHouseholdMembers(ctx context.Context, householdID int32) ([]GetHouseholdMembersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getHouseholdMembers, householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetHouseholdMembersRow{}
+	for rows.Next() {
+		var i GetHouseholdMembersRow
+		if err := rows.Scan(
+			&i.HouseholdID,
+			&i.UserID,
+			&i.Role,
+			&i.CreatedAt,
+			&i.Username,
+			&i.Email,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getHouseholds = `-- name: GetHouseholds :many
+SELECT h.id, h.name, h.created_by, h.created_at, m.role
+FROM households h
+JOIN household_members m ON m.household_id = h.id
+WHERE m.user_id = $1
+ORDER BY h.name
+`
+
+type GetHouseholdsRow struct {
+	ID        int32     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedBy int32     `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	Role      string    `json:"role"`
+}
+
+func (q *Queries) GetHouseholds(ctx context.Context, userID int32) ([]GetHouseholdsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getHouseholds, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetHouseholdsRow{}
+	for rows.Next() {
+		var i GetHouseholdsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingHouseholdInvitation = `-- name: GetPendingHouseholdInvitation :one
+SELECT id, household_id, email, role, token_hash, invited_by, expires_at, accepted_at, declined_at, created_at FROM household_invitations
+WHERE token_hash = $1 AND accepted_at IS NULL AND declined_at IS NULL AND expires_at > now()
+LIMIT 1
+`
+
+func (q *Queries) GetPendingHouseholdInvitation(ctx context.Context, tokenHash string) (HouseholdInvitation, error) {
+	row := q.db.QueryRowContext(ctx, getPendingHouseholdInvitation, tokenHash)
+	var i HouseholdInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.HouseholdID,
+		&i.Email,
+		&i.Role,
+		&i.TokenHash,
+		&i.InvitedBy,
+		&i.ExpiresAt,
+		&i.AcceptedAt,
+		&i.DeclinedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPendingHouseholdInvitationsByEmail = `-- name: GetPendingHouseholdInvitationsByEmail :many
+SELECT i.id, i.household_id, i.email, i.role, i.expires_at, i.created_at, h.name AS household_name
+FROM household_invitations i
+JOIN households h ON h.id = i.household_id
+WHERE LOWER(i.email) = LOWER($1) AND i.accepted_at IS NULL AND i.declined_at IS NULL AND i.expires_at > now()
+ORDER BY i.created_at DESC
+`
+
+type GetPendingHouseholdInvitationsByEmailRow struct {
+	ID            int32     `json:"id"`
+	HouseholdID   int32     `json:"household_id"`
+	Email         string    `json:"email"`
+	Role          string    `json:"role"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	CreatedAt     time.Time `json:"created_at"`
+	HouseholdName string    `json:"household_name"`
+}
+
+func (q *Queries) GetPendingHouseholdInvitationsByEmail(ctx context.Context, lower string) ([]GetPendingHouseholdInvitationsByEmailRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingHouseholdInvitationsByEmail, lower)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPendingHouseholdInvitationsByEmailRow{}
+	for rows.Next() {
+		var i GetPendingHouseholdInvitationsByEmailRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.HouseholdID,
+			&i.Email,
+			&i.Role,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.HouseholdName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateHouseholdMemberRole = `-- name: UpdateHouseholdMemberRole :one
+UPDATE household_members SET role = $3
+WHERE household_id = $1 AND user_id = $2
+RETURNING household_id, user_id, role, created_at
+`
+
+type UpdateHouseholdMemberRoleParams struct {
+	HouseholdID int32  `json:"household_id"`
+	UserID      int32  `json:"user_id"`
+	Role        string `json:"role"`
+}
+
+func (q *Queries) UpdateHouseholdMemberRole(ctx context.Context, arg UpdateHouseholdMemberRoleParams) (HouseholdMember, error) {
+	row := q.db.QueryRowContext(ctx, updateHouseholdMemberRole, arg.HouseholdID, arg.UserID, arg.Role)
+	var i HouseholdMember
+	err := row.Scan(
+		&i.HouseholdID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
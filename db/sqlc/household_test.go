@@ -0,0 +1,212 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomHousehold(t *testing.T, owner User) Household {
+	arg := CreateHouseholdParams{
+		Name:      util.RandomString(10),
+		CreatedBy: owner.ID,
+	}
+
+	created, err := testQueries.CreateHousehold(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, created.Name)
+	require.Equal(t, arg.CreatedBy, created.CreatedBy)
+
+	household, err := testQueries.GetHousehold(context.Background(), GetHouseholdParams{ID: created.ID, UserID: owner.ID})
+	require.NoError(t, err)
+
+	member, err := testQueries.GetHouseholdMember(context.Background(), GetHouseholdMemberParams{
+		HouseholdID: household.ID,
+		UserID:      owner.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "owner", member.Role)
+
+	return household
+}
+
+func addRandomHouseholdMember(t *testing.T, household Household, role string) User {
+	user := createRandomUser(t)
+	invitation, err := testQueries.CreateHouseholdInvitation(context.Background(), CreateHouseholdInvitationParams{
+		HouseholdID: household.ID,
+		Email:       user.Email,
+		Role:        role,
+		TokenHash:   util.RandomString(64),
+		InvitedBy:   household.CreatedBy,
+		ExpiresAt:   time.Now().UTC().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	member, err := testQueries.AcceptHouseholdInvitation(context.Background(), AcceptHouseholdInvitationParams{
+		ID:     invitation.ID,
+		UserID: user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, role, member.Role)
+
+	return user
+}
+
+func TestCreateHousehold(t *testing.T) {
+	owner := createRandomUser(t)
+	household := createRandomHousehold(t, owner)
+
+	households, err := testQueries.GetHouseholds(context.Background(), owner.ID)
+	require.NoError(t, err)
+	require.Len(t, households, 1)
+	require.Equal(t, household.ID, households[0].ID)
+	require.Equal(t, "owner", households[0].Role)
+
+	other := createRandomUser(t)
+	_, err = testQueries.GetHousehold(context.Background(), GetHouseholdParams{ID: household.ID, UserID: other.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestHouseholdInvitation(t *testing.T) {
+	owner := createRandomUser(t)
+	household := createRandomHousehold(t, owner)
+	user := createRandomUser(t)
+
+	invitation, err := testQueries.CreateHouseholdInvitation(context.Background(), CreateHouseholdInvitationParams{
+		HouseholdID: household.ID,
+		Email:       user.Email,
+		Role:        "editor",
+		TokenHash:   util.RandomString(64),
+		InvitedBy:   owner.ID,
+		ExpiresAt:   time.Now().UTC().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	pending, err := testQueries.GetPendingHouseholdInvitationsByEmail(context.Background(), user.Email)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, household.Name, pending[0].HouseholdName)
+
+	rows, err := testQueries.DeclineHouseholdInvitation(context.Background(), invitation.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = testQueries.GetPendingHouseholdInvitation(context.Background(), invitation.TokenHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.AcceptHouseholdInvitation(context.Background(), AcceptHouseholdInvitationParams{
+		ID:     invitation.ID,
+		UserID: user.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestHouseholdMembers(t *testing.T) {
+	owner := createRandomUser(t)
+	household := createRandomHousehold(t, owner)
+	viewer := addRandomHouseholdMember(t, household, "viewer")
+
+	members, err := testQueries.GetHouseholdMembers(context.Background(), household.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+
+	member, err := testQueries.UpdateHouseholdMemberRole(context.Background(), UpdateHouseholdMemberRoleParams{
+		HouseholdID: household.ID,
+		UserID:      viewer.ID,
+		Role:        "owner",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "owner", member.Role)
+
+	owners, err := testQueries.CountHouseholdOwners(context.Background(), household.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), owners)
+
+	rows, err := testQueries.DeleteHouseholdMember(context.Background(), DeleteHouseholdMemberParams{
+		HouseholdID: household.ID,
+		UserID:      viewer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
+
+func TestHouseholdAccountPermissions(t *testing.T) {
+	owner := createRandomUser(t)
+	household := createRandomHousehold(t, owner)
+	editor := addRandomHouseholdMember(t, household, "editor")
+	viewer := addRandomHouseholdMember(t, household, "viewer")
+	outsider := createRandomUser(t)
+
+	_, err := testQueries.CreateCategory(context.Background(), CreateCategoryParams{
+		UserID:      viewer.ID,
+		HouseholdID: sql.NullInt32{Int32: household.ID, Valid: true},
+		Title:       util.RandomString(12),
+		Type:        "debit",
+		Description: util.RandomString(20),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	category, err := testQueries.CreateCategory(context.Background(), CreateCategoryParams{
+		UserID:      editor.ID,
+		HouseholdID: sql.NullInt32{Int32: household.ID, Valid: true},
+		Title:       util.RandomString(12),
+		Type:        "debit",
+		Description: util.RandomString(20),
+	})
+	require.NoError(t, err)
+
+	wallet := createTestWallet(t, editor.ID, category.HouseholdID, "")
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		UserID:      editor.ID,
+		HouseholdID: category.HouseholdID,
+		WalletID:    wallet.ID,
+		CategoryID:  category.ID,
+		Title:       util.RandomString(12),
+		Type:        "debit",
+		Description: util.RandomString(20),
+		Value:       10,
+		Date:        time.Now(),
+	})
+	require.NoError(t, err)
+
+	for _, user := range []User{owner, editor, viewer} {
+		_, err = testQueries.GetAccount(context.Background(), GetAccountParams{ID: account.ID, UserID: user.ID})
+		require.NoError(t, err)
+
+		accounts, err := testQueries.GetAccounts(context.Background(), GetAccountsParams{
+			HouseholdID: category.HouseholdID,
+			UserID:      user.ID,
+			Type:        "debit",
+			PageSize:    10,
+		})
+		require.NoError(t, err)
+		require.Len(t, accounts, 1)
+	}
+
+	_, err = testQueries.GetAccount(context.Background(), GetAccountParams{ID: account.ID, UserID: outsider.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// contas do household não aparecem na listagem pessoal
+	accounts, err := testQueries.GetAccounts(context.Background(), GetAccountsParams{UserID: editor.ID, Type: "debit", PageSize: 10})
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+
+	_, err = testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:     account.ID,
+		UserID: viewer.ID,
+		Title:  util.RandomString(12),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	rows, err := testQueries.DeleteAccount(context.Background(), DeleteAccountParams{ID: account.ID, UserID: viewer.ID})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	rows, err = testQueries.DeleteAccount(context.Background(), DeleteAccountParams{ID: account.ID, UserID: owner.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
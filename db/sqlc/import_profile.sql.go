@@ -0,0 +1,148 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: import_profile.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const createImportProfile = `-- name: CreateImportProfile :one
+INSERT INTO import_profiles (
+  user_id,
+  name,
+  mapping
+) VALUES (
+  $1, $2, $3
+) RETURNING id, user_id, name, mapping, created_at
+`
+
+type CreateImportProfileParams struct {
+	UserID  int32           `json:"user_id"`
+	Name    string          `json:"name"`
+	Mapping json.RawMessage `json:"mapping"`
+}
+
+func (q *Queries) CreateImportProfile(ctx context.Context, arg CreateImportProfileParams) (ImportProfile, error) {
+	row := q.db.QueryRowContext(ctx, createImportProfile, arg.UserID, arg.Name, arg.Mapping)
+	var i ImportProfile
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Mapping,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteImportProfile = `-- name: DeleteImportProfile :execrows
+DELETE FROM import_profiles
+WHERE id = $1 AND user_id = $2
+`
+
+type DeleteImportProfileParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) DeleteImportProfile(ctx context.Context, arg DeleteImportProfileParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteImportProfile, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getImportProfile = `-- name: GetImportProfile :one
+SELECT id, user_id, name, mapping, created_at FROM import_profiles
+WHERE id = $1 AND user_id = $2
+LIMIT 1
+`
+
+type GetImportProfileParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) GetImportProfile(ctx context.Context, arg GetImportProfileParams) (ImportProfile, error) {
+	row := q.db.QueryRowContext(ctx, getImportProfile, arg.ID, arg.UserID)
+	var i ImportProfile
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Mapping,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getImportProfiles = `-- name: GetImportProfiles :many
+SELECT id, user_id, name, mapping, created_at FROM import_profiles
+WHERE user_id = $1
+ORDER BY name
+`
+
+func (q *Queries) GetImportProfiles(ctx context.Context, userID int32) ([]ImportProfile, error) {
+	rows, err := q.db.QueryContext(ctx, getImportProfiles, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ImportProfile{}
+	for rows.Next() {
+		var i ImportProfile
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Mapping,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateImportProfile = `-- name: UpdateImportProfile :one
+UPDATE import_profiles SET name = $3, mapping = $4
+WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, name, mapping, created_at
+`
+
+type UpdateImportProfileParams struct {
+	ID      int32           `json:"id"`
+	UserID  int32           `json:"user_id"`
+	Name    string          `json:"name"`
+	Mapping json.RawMessage `json:"mapping"`
+}
+
+func (q *Queries) UpdateImportProfile(ctx context.Context, arg UpdateImportProfileParams) (ImportProfile, error) {
+	row := q.db.QueryRowContext(ctx, updateImportProfile,
+		arg.ID,
+		arg.UserID,
+		arg.Name,
+		arg.Mapping,
+	)
+	var i ImportProfile
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Mapping,
+		&i.CreatedAt,
+	)
+	return i, err
+}
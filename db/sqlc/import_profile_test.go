@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestImportProfile(t *testing.T, userID int32) ImportProfile {
+	arg := CreateImportProfileParams{
+		UserID:  userID,
+		Name:    util.RandomString(10),
+		Mapping: json.RawMessage(`{"date_column": 1, "description_column": 2, "amount_column": 3}`),
+	}
+
+	profile, err := testQueries.CreateImportProfile(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, profile.ID)
+	require.Equal(t, arg.Name, profile.Name)
+	require.JSONEq(t, string(arg.Mapping), string(profile.Mapping))
+	return profile
+}
+
+func TestImportProfiles(t *testing.T) {
+	user := createRandomUser(t)
+	other := createRandomUser(t)
+	profile := createTestImportProfile(t, user.ID)
+
+	// o nome é único por usuário
+	_, err := testQueries.CreateImportProfile(context.Background(), CreateImportProfileParams{
+		UserID:  user.ID,
+		Name:    profile.Name,
+		Mapping: profile.Mapping,
+	})
+	require.Error(t, err)
+	_, err = testQueries.CreateImportProfile(context.Background(), CreateImportProfileParams{
+		UserID:  other.ID,
+		Name:    profile.Name,
+		Mapping: profile.Mapping,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetImportProfile(context.Background(), GetImportProfileParams{ID: profile.ID, UserID: other.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	updated, err := testQueries.UpdateImportProfile(context.Background(), UpdateImportProfileParams{
+		ID:      profile.ID,
+		UserID:  user.ID,
+		Name:    "Banco",
+		Mapping: json.RawMessage(`{"sign": "inverted"}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Banco", updated.Name)
+
+	profiles, err := testQueries.GetImportProfiles(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+
+	rows, err := testQueries.DeleteImportProfile(context.Background(), DeleteImportProfileParams{ID: profile.ID, UserID: other.ID})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+	rows, err = testQueries.DeleteImportProfile(context.Background(), DeleteImportProfileParams{ID: profile.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
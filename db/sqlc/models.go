@@ -5,34 +5,253 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
 )
 
 type Account struct {
-	ID          int32     `json:"id"`
-	UserID      int32     `json:"user_id"`
-	CategoryID  int32     `json:"category_id"`
-	Title       string    `json:"title"`
-	Type        string    `json:"type"`
-	Description string    `json:"description"`
-	Value       int32     `json:"value"`
-	Date        time.Time `json:"date"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          int32          `json:"id"`
+	UserID      int32          `json:"user_id"`
+	CategoryID  sql.NullInt32  `json:"category_id"`
+	Title       string         `json:"title"`
+	Type        string         `json:"type"`
+	Description string         `json:"description"`
+	Value       util.Money     `json:"value"`
+	Date        time.Time      `json:"date"`
+	CreatedAt   time.Time      `json:"created_at"`
+	HouseholdID sql.NullInt32  `json:"household_id"`
+	Currency    string         `json:"currency"`
+	WalletID    int32          `json:"wallet_id"`
+	TransferID  sql.NullInt32  `json:"transfer_id"`
+	ExternalID  sql.NullString `json:"external_id"`
+}
+
+type AccountTag struct {
+	AccountID int32 `json:"account_id"`
+	TagID     int32 `json:"tag_id"`
+}
+
+type ApiKey struct {
+	ID         int32        `json:"id"`
+	UserID     int32        `json:"user_id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	KeyHash    string       `json:"key_hash"`
+	Scopes     []string     `json:"scopes"`
+	ExpiresAt  sql.NullTime `json:"expires_at"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	RevokedAt  sql.NullTime `json:"revoked_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type Budget struct {
+	ID          int32         `json:"id"`
+	UserID      int32         `json:"user_id"`
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	CategoryID  int32         `json:"category_id"`
+	Month       time.Time     `json:"month"`
+	Amount      util.Money    `json:"amount"`
+	Recurring   bool          `json:"recurring"`
+	Rollover    bool          `json:"rollover"`
+	CreatedAt   time.Time     `json:"created_at"`
 }
 
 type Category struct {
-	ID          int32     `json:"id"`
+	ID          int32         `json:"id"`
+	UserID      int32         `json:"user_id"`
+	Title       string        `json:"title"`
+	Type        string        `json:"type"`
+	Description string        `json:"description"`
+	CreatedAt   time.Time     `json:"created_at"`
+	HouseholdID sql.NullInt32 `json:"household_id"`
+}
+
+type ExchangeRate struct {
+	ID            int32     `json:"id"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Rate          string    `json:"rate"`
+	EffectiveDate time.Time `json:"effective_date"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type Goal struct {
+	ID           int32         `json:"id"`
+	UserID       int32         `json:"user_id"`
+	HouseholdID  sql.NullInt32 `json:"household_id"`
+	Name         string        `json:"name"`
+	TargetAmount util.Money    `json:"target_amount"`
+	Currency     string        `json:"currency"`
+	Deadline     sql.NullTime  `json:"deadline"`
+	CategoryID   sql.NullInt32 `json:"category_id"`
+	WalletID     sql.NullInt32 `json:"wallet_id"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type GoalContribution struct {
+	ID        int32         `json:"id"`
+	GoalID    int32         `json:"goal_id"`
+	AccountID sql.NullInt32 `json:"account_id"`
+	Amount    util.Money    `json:"amount"`
+	Date      time.Time     `json:"date"`
+	Note      string        `json:"note"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type Household struct {
+	ID        int32     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedBy int32     `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type HouseholdInvitation struct {
+	ID          int32        `json:"id"`
+	HouseholdID int32        `json:"household_id"`
+	Email       string       `json:"email"`
+	Role        string       `json:"role"`
+	TokenHash   string       `json:"token_hash"`
+	InvitedBy   int32        `json:"invited_by"`
+	ExpiresAt   time.Time    `json:"expires_at"`
+	AcceptedAt  sql.NullTime `json:"accepted_at"`
+	DeclinedAt  sql.NullTime `json:"declined_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+type HouseholdMember struct {
+	HouseholdID int32     `json:"household_id"`
 	UserID      int32     `json:"user_id"`
-	Title       string    `json:"title"`
-	Type        string    `json:"type"`
-	Description string    `json:"description"`
+	Role        string    `json:"role"`
 	CreatedAt   time.Time `json:"created_at"`
 }
 
+type ImportProfile struct {
+	ID        int32           `json:"id"`
+	UserID    int32           `json:"user_id"`
+	Name      string          `json:"name"`
+	Mapping   json.RawMessage `json:"mapping"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type RecurringOccurrence struct {
+	ID             int32         `json:"id"`
+	RuleID         int32         `json:"rule_id"`
+	OccurrenceDate time.Time     `json:"occurrence_date"`
+	Date           time.Time     `json:"date"`
+	Title          string        `json:"title"`
+	Description    string        `json:"description"`
+	Value          util.Money    `json:"value"`
+	Skipped        bool          `json:"skipped"`
+	AccountID      sql.NullInt32 `json:"account_id"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type RecurringRule struct {
+	ID                  int32         `json:"id"`
+	UserID              int32         `json:"user_id"`
+	HouseholdID         sql.NullInt32 `json:"household_id"`
+	WalletID            int32         `json:"wallet_id"`
+	CategoryID          int32         `json:"category_id"`
+	Title               string        `json:"title"`
+	Type                string        `json:"type"`
+	Description         string        `json:"description"`
+	Value               util.Money    `json:"value"`
+	Frequency           string        `json:"frequency"`
+	Interval            int32         `json:"interval"`
+	DayOfMonth          sql.NullInt32 `json:"day_of_month"`
+	StartDate           time.Time     `json:"start_date"`
+	EndDate             sql.NullTime  `json:"end_date"`
+	OccurrenceCount     sql.NullInt32 `json:"occurrence_count"`
+	MaterializedThrough sql.NullTime  `json:"materialized_through"`
+	CreatedAt           time.Time     `json:"created_at"`
+}
+
+type Session struct {
+	ID               int32        `json:"id"`
+	UserID           int32        `json:"user_id"`
+	RefreshTokenHash string       `json:"refresh_token_hash"`
+	UserAgent        string       `json:"user_agent"`
+	ClientIp         string       `json:"client_ip"`
+	ExpiresAt        time.Time    `json:"expires_at"`
+	RevokedAt        sql.NullTime `json:"revoked_at"`
+	LastUsedAt       time.Time    `json:"last_used_at"`
+	CreatedAt        time.Time    `json:"created_at"`
+}
+
+type SessionRotatedToken struct {
+	RefreshTokenHash string    `json:"refresh_token_hash"`
+	SessionID        int32     `json:"session_id"`
+	RotatedAt        time.Time `json:"rotated_at"`
+}
+
+type Tag struct {
+	ID          int32         `json:"id"`
+	UserID      int32         `json:"user_id"`
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	Name        string        `json:"name"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type Transfer struct {
+	ID           int32         `json:"id"`
+	UserID       int32         `json:"user_id"`
+	HouseholdID  sql.NullInt32 `json:"household_id"`
+	FromWalletID int32         `json:"from_wallet_id"`
+	ToWalletID   int32         `json:"to_wallet_id"`
+	Title        string        `json:"title"`
+	Description  string        `json:"description"`
+	Value        util.Money    `json:"value"`
+	ToValue      util.Money    `json:"to_value"`
+	Date         time.Time     `json:"date"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
 type User struct {
-	ID        int32     `json:"id"`
-	Username  string    `json:"username"`
-	Password  string    `json:"password"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
+	ID              int32        `json:"id"`
+	Username        string       `json:"username"`
+	Password        string       `json:"password"`
+	Email           string       `json:"email"`
+	CreatedAt       time.Time    `json:"created_at"`
+	EmailVerifiedAt sql.NullTime `json:"email_verified_at"`
+	BaseCurrency    string       `json:"base_currency"`
+}
+
+type UserRecoveryCode struct {
+	ID        int32        `json:"id"`
+	UserID    int32        `json:"user_id"`
+	CodeHash  string       `json:"code_hash"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type UserToken struct {
+	ID        int32        `json:"id"`
+	UserID    int32        `json:"user_id"`
+	Purpose   string       `json:"purpose"`
+	TokenHash string       `json:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type UserTotp struct {
+	UserID          int32        `json:"user_id"`
+	Secret          string       `json:"secret"`
+	ConfirmedAt     sql.NullTime `json:"confirmed_at"`
+	LastUsedCounter int64        `json:"last_used_counter"`
+	CreatedAt       time.Time    `json:"created_at"`
+}
+
+type Wallet struct {
+	ID             int32         `json:"id"`
+	UserID         int32         `json:"user_id"`
+	HouseholdID    sql.NullInt32 `json:"household_id"`
+	Name           string        `json:"name"`
+	Kind           string        `json:"kind"`
+	Currency       string        `json:"currency"`
+	OpeningBalance util.Money    `json:"opening_balance"`
+	CreatedAt      time.Time     `json:"created_at"`
 }
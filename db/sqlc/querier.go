@@ -6,24 +6,146 @@ package db
 
 import (
 	"context"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
 )
 
 type Querier interface {
+	AcceptHouseholdInvitation(ctx context.Context, arg AcceptHouseholdInvitationParams) (HouseholdMember, error)
+	AccountExternalIDExists(ctx context.Context, arg AccountExternalIDExistsParams) (bool, error)
+	AddAccountTag(ctx context.Context, arg AddAccountTagParams) error
+	ConfirmUserTOTP(ctx context.Context, arg ConfirmUserTOTPParams) (int64, error)
+	ConsumeUserToken(ctx context.Context, arg ConsumeUserTokenParams) (UserToken, error)
+	CountHouseholdOwners(ctx context.Context, householdID int32) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateBudget(ctx context.Context, arg CreateBudgetParams) (Budget, error)
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	CreateGoal(ctx context.Context, arg CreateGoalParams) (Goal, error)
+	CreateGoalContribution(ctx context.Context, arg CreateGoalContributionParams) (GoalContribution, error)
+	CreateHousehold(ctx context.Context, arg CreateHouseholdParams) (CreateHouseholdRow, error)
+	CreateHouseholdInvitation(ctx context.Context, arg CreateHouseholdInvitationParams) (HouseholdInvitation, error)
+	CreateImportProfile(ctx context.Context, arg CreateImportProfileParams) (ImportProfile, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error
+	CreateRecurringRule(ctx context.Context, arg CreateRecurringRuleParams) (RecurringRule, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateTag(ctx context.Context, arg CreateTagParams) (Tag, error)
+	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferAccount(ctx context.Context, arg CreateTransferAccountParams) (Account, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
-	DeleteAccount(ctx context.Context, id int32) error
-	DeleteCategories(ctx context.Context, id int32) error
-	GetAccount(ctx context.Context, id int32) (Account, error)
+	CreateUserToken(ctx context.Context, arg CreateUserTokenParams) (UserToken, error)
+	CreateWallet(ctx context.Context, arg CreateWalletParams) (Wallet, error)
+	DeclineHouseholdInvitation(ctx context.Context, id int32) (int64, error)
+	DeleteAccount(ctx context.Context, arg DeleteAccountParams) (int64, error)
+	DeleteAccountTags(ctx context.Context, accountID int32) error
+	DeleteBudget(ctx context.Context, arg DeleteBudgetParams) (int64, error)
+	DeleteCategories(ctx context.Context, arg DeleteCategoriesParams) (int64, error)
+	DeleteGoal(ctx context.Context, arg DeleteGoalParams) (int64, error)
+	DeleteGoalContribution(ctx context.Context, arg DeleteGoalContributionParams) (int64, error)
+	DeleteHouseholdMember(ctx context.Context, arg DeleteHouseholdMemberParams) (int64, error)
+	DeleteImportProfile(ctx context.Context, arg DeleteImportProfileParams) (int64, error)
+	DeleteRecoveryCodes(ctx context.Context, userID int32) error
+	DeleteRecurringRule(ctx context.Context, arg DeleteRecurringRuleParams) (int64, error)
+	DeleteTag(ctx context.Context, arg DeleteTagParams) (int64, error)
+	DeleteTransfer(ctx context.Context, arg DeleteTransferParams) (int64, error)
+	DeleteUserTOTP(ctx context.Context, userID int32) error
+	DeleteWallet(ctx context.Context, arg DeleteWalletParams) (int64, error)
+	GetAPIKeys(ctx context.Context, userID int32) ([]ApiKey, error)
+	GetAccount(ctx context.Context, arg GetAccountParams) (Account, error)
+	GetAccountTags(ctx context.Context, accountID int32) ([]Tag, error)
+	// paginação por keyset: after_* são a chave de ordenação e o id da última linha da página
+	// anterior; sort_by aceita date, value, title e created_at. As pernas das transferências só
+	// entram com include_transfers, no mesmo filtro de GetAccountsSummary
 	GetAccounts(ctx context.Context, arg GetAccountsParams) ([]GetAccountsRow, error)
-	GetAccountsGraph(ctx context.Context, arg GetAccountsGraphParams) (int64, error)
-	GetAccountsReports(ctx context.Context, arg GetAccountsReportsParams) (int64, error)
+	// soma convertida para a moeda base do usuário com a taxa em vigor na data de cada transação
+	GetAccountsReports(ctx context.Context, arg GetAccountsReportsParams) (GetAccountsReportsRow, error)
+	// total das transações com os mesmos filtros de GetAccounts, sem a paginação; a soma é
+	// convertida para a moeda base do usuário
+	GetAccountsSummary(ctx context.Context, arg GetAccountsSummaryParams) (GetAccountsSummaryRow, error)
+	GetBudget(ctx context.Context, arg GetBudgetParams) (Budget, error)
+	// gastos por mês das categorias com orçamento, convertidos para a moeda base do usuário
+	GetBudgetSpending(ctx context.Context, arg GetBudgetSpendingParams) ([]GetBudgetSpendingRow, error)
+	GetBudgets(ctx context.Context, arg GetBudgetsParams) ([]Budget, error)
+	// orçamentos do escopo até o mês, com o título da categoria
+	GetBudgetsUntil(ctx context.Context, arg GetBudgetsUntilParams) ([]GetBudgetsUntilRow, error)
 	GetCategories(ctx context.Context, arg GetCategoriesParams) ([]Category, error)
-	GetCategory(ctx context.Context, id int32) (Category, error)
+	GetCategory(ctx context.Context, arg GetCategoryParams) (Category, error)
+	// regras com dias ainda não processados até today; a regra com occurrence_count acaba quando
+	// as ocorrências já processadas, puladas ou não, chegam ao limite
+	GetDueRecurringRules(ctx context.Context, today time.Time) ([]int32, error)
+	GetExchangeRate(ctx context.Context, arg GetExchangeRateParams) (string, error)
+	// saved é o total das contribuições e first_contribution a data da primeira (hoje, se não houver)
+	GetGoal(ctx context.Context, arg GetGoalParams) (GetGoalRow, error)
+	GetGoalContribution(ctx context.Context, arg GetGoalContributionParams) (GoalContribution, error)
+	GetGoalContributions(ctx context.Context, goalID int32) ([]GoalContribution, error)
+	GetGoals(ctx context.Context, arg GetGoalsParams) ([]GetGoalsRow, error)
+	GetHousehold(ctx context.Context, arg GetHouseholdParams) (Household, error)
+	GetHouseholdMember(ctx context.Context, arg GetHouseholdMemberParams) (HouseholdMember, error)
+	GetHouseholdMembers(ctx context.Context, householdID int32) ([]GetHouseholdMembersRow, error)
+	GetHouseholds(ctx context.Context, userID int32) ([]GetHouseholdsRow, error)
+	GetImportProfile(ctx context.Context, arg GetImportProfileParams) (ImportProfile, error)
+	GetImportProfiles(ctx context.Context, userID int32) ([]ImportProfile, error)
+	GetPendingHouseholdInvitation(ctx context.Context, tokenHash string) (HouseholdInvitation, error)
+	GetPendingHouseholdInvitationsByEmail(ctx context.Context, lower string) ([]GetPendingHouseholdInvitationsByEmailRow, error)
+	GetRecurringOccurrences(ctx context.Context, arg GetRecurringOccurrencesParams) ([]RecurringOccurrence, error)
+	GetRecurringRule(ctx context.Context, arg GetRecurringRuleParams) (RecurringRule, error)
+	GetRecurringRules(ctx context.Context, arg GetRecurringRulesParams) ([]RecurringRule, error)
+	// receitas e despesas do período, convertidas para a moeda base do usuário, agrupadas por
+	// group_by: day, week, month e year agrupam por period; category, wallet e tag agrupam por
+	// group_id, com period igual a from_date. Por tag, uma transação entra uma vez em cada tag
+	// dela, e as sem tag ficam no grupo 0; qualquer outro valor dá uma linha só com o total
+	GetReport(ctx context.Context, arg GetReportParams) ([]GetReportRow, error)
+	// receitas e despesas anteriores a before_date, na moeda base do usuário; é o ponto de
+	// partida dos gráficos acumulados
+	GetReportTotalsBefore(ctx context.Context, arg GetReportTotalsBeforeParams) (GetReportTotalsBeforeRow, error)
+	GetRotatedRefreshTokenSession(ctx context.Context, refreshTokenHash string) (int32, error)
+	GetSession(ctx context.Context, id int32) (Session, error)
+	GetTag(ctx context.Context, arg GetTagParams) (Tag, error)
+	GetTags(ctx context.Context, arg GetTagsParams) ([]Tag, error)
+	GetTransfer(ctx context.Context, arg GetTransferParams) (Transfer, error)
+	GetTransferAccounts(ctx context.Context, transferID int32) ([]Account, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserById(ctx context.Context, id int32) (User, error)
+	GetUserTOTP(ctx context.Context, userID int32) (UserTotp, error)
+	GetValidAPIKey(ctx context.Context, keyHash string) (ApiKey, error)
+	GetValidUserToken(ctx context.Context, arg GetValidUserTokenParams) (UserToken, error)
+	GetWallet(ctx context.Context, arg GetWalletParams) (GetWalletRow, error)
+	// saldo ao fim do dia informado
+	GetWalletBalanceAt(ctx context.Context, arg GetWalletBalanceAtParams) (util.Money, error)
+	// saldo ao fim de cada dia com movimento no período
+	GetWalletBalanceHistory(ctx context.Context, arg GetWalletBalanceHistoryParams) ([]GetWalletBalanceHistoryRow, error)
+	GetWallets(ctx context.Context, arg GetWalletsParams) ([]GetWalletsRow, error)
+	InvalidateUserTokens(ctx context.Context, arg InvalidateUserTokensParams) error
+	// trava a regra até o fim da transação, para o agendador e as alterações pontuais não se cruzarem
+	LockRecurringRule(ctx context.Context, id int32) (RecurringRule, error)
+	MaterializeRecurringOccurrence(ctx context.Context, arg MaterializeRecurringOccurrenceParams) (RecurringOccurrence, error)
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error)
+	RevokeSession(ctx context.Context, id int32) error
+	RevokeUserSessions(ctx context.Context, userID int32) (int64, error)
+	RotateSessionRefreshToken(ctx context.Context, arg RotateSessionRefreshTokenParams) (RotateSessionRefreshTokenRow, error)
+	SetRecurringRuleMaterialized(ctx context.Context, arg SetRecurringRuleMaterializedParams) error
+	TouchAPIKey(ctx context.Context, id int32) error
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateBudget(ctx context.Context, arg UpdateBudgetParams) (Budget, error)
 	UpdateCategories(ctx context.Context, arg UpdateCategoriesParams) (Category, error)
+	UpdateGoal(ctx context.Context, arg UpdateGoalParams) (Goal, error)
+	UpdateHouseholdMemberRole(ctx context.Context, arg UpdateHouseholdMemberRoleParams) (HouseholdMember, error)
+	UpdateImportProfile(ctx context.Context, arg UpdateImportProfileParams) (ImportProfile, error)
+	UpdateRecurringRule(ctx context.Context, arg UpdateRecurringRuleParams) (RecurringRule, error)
+	UpdateTransfer(ctx context.Context, arg UpdateTransferParams) (Transfer, error)
+	UpdateTransferAccounts(ctx context.Context, arg UpdateTransferAccountsParams) (int64, error)
+	UpdateUserBaseCurrency(ctx context.Context, arg UpdateUserBaseCurrencyParams) (User, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpdateUserTOTPCounter(ctx context.Context, arg UpdateUserTOTPCounterParams) (int64, error)
+	UpdateWallet(ctx context.Context, arg UpdateWalletParams) (Wallet, error)
+	UpsertExchangeRates(ctx context.Context, arg UpsertExchangeRatesParams) (int64, error)
+	UpsertPendingUserTOTP(ctx context.Context, arg UpsertPendingUserTOTPParams) (UserTotp, error)
+	// grava a alteração pontual de uma ocorrência que ainda não virou transação
+	UpsertRecurringOccurrence(ctx context.Context, arg UpsertRecurringOccurrenceParams) (RecurringOccurrence, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
+	VerifyUserEmail(ctx context.Context, id int32) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,553 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: recurring.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+const createRecurringRule = `-- name: CreateRecurringRule :one
+INSERT INTO recurring_rules (
+  user_id,
+  household_id,
+  wallet_id,
+  category_id,
+  title,
+  type,
+  description,
+  value,
+  frequency,
+  interval,
+  day_of_month,
+  start_date,
+  end_date,
+  occurrence_count
+)
+SELECT
+  $1::int,
+  $2::int,
+  $3::int,
+  $4::int,
+  $5::varchar,
+  $6::varchar,
+  $7::varchar,
+  $8::money_minor,
+  $9::varchar,
+  $10::int,
+  $11::int,
+  $12::date,
+  $13::date,
+  $14::int
+WHERE
+  $2::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = $2::int AND m.user_id = $1::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING id, user_id, household_id, wallet_id, category_id, title, type, description, value, frequency, interval, day_of_month, start_date, end_date, occurrence_count, materialized_through, created_at
+`
+
+type CreateRecurringRuleParams struct {
+	UserID          int32         `json:"user_id"`
+	HouseholdID     sql.NullInt32 `json:"household_id"`
+	WalletID        int32         `json:"wallet_id"`
+	CategoryID      int32         `json:"category_id"`
+	Title           string        `json:"title"`
+	Type            string        `json:"type"`
+	Description     string        `json:"description"`
+	Value           util.Money    `json:"value"`
+	Frequency       string        `json:"frequency"`
+	Interval        int32         `json:"interval"`
+	DayOfMonth      sql.NullInt32 `json:"day_of_month"`
+	StartDate       time.Time     `json:"start_date"`
+	EndDate         sql.NullTime  `json:"end_date"`
+	OccurrenceCount sql.NullInt32 `json:"occurrence_count"`
+}
+
+func (q *Queries) CreateRecurringRule(ctx context.Context, arg CreateRecurringRuleParams) (RecurringRule, error) {
+	row := q.db.QueryRowContext(ctx, createRecurringRule,
+		arg.UserID,
+		arg.HouseholdID,
+		arg.WalletID,
+		arg.CategoryID,
+		arg.Title,
+		arg.Type,
+		arg.Description,
+		arg.Value,
+		arg.Frequency,
+		arg.Interval,
+		arg.DayOfMonth,
+		arg.StartDate,
+		arg.EndDate,
+		arg.OccurrenceCount,
+	)
+	var i RecurringRule
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.WalletID,
+		&i.CategoryID,
+		&i.Title,
+		&i.Type,
+		&i.Description,
+		&i.Value,
+		&i.Frequency,
+		&i.Interval,
+		&i.DayOfMonth,
+		&i.StartDate,
+		&i.EndDate,
+		&i.OccurrenceCount,
+		&i.MaterializedThrough,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteRecurringRule = `-- name: DeleteRecurringRule :execrows
+DELETE FROM recurring_rules
+WHERE id = $1
+AND (
+  (recurring_rules.household_id IS NULL AND recurring_rules.user_id = $2)
+  OR recurring_rules.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2 AND m.role IN ('owner', 'editor'))
+)
+`
+
+type DeleteRecurringRuleParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) DeleteRecurringRule(ctx context.Context, arg DeleteRecurringRuleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRecurringRule, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getDueRecurringRules = `-- name: GetDueRecurringRules :many
+SELECT id FROM recurring_rules
+WHERE start_date <= $1::date
+AND (materialized_through IS NULL OR materialized_through < $1::date)
+AND (end_date IS NULL OR materialized_through IS NULL OR materialized_through < end_date)
+AND (occurrence_count IS NULL OR materialized_through IS NULL OR (
+  SELECT count(*) FROM recurring_occurrences o
+  WHERE o.rule_id = recurring_rules.id AND o.occurrence_date <= recurring_rules.materialized_through
+) < occurrence_count)
+ORDER BY id
+`
+
+// regras com dias ainda não processados até today; a regra com occurrence_count acaba quando
+// as ocorrências já processadas, puladas ou não, chegam ao limite
+func (q *Queries) GetDueRecurringRules(ctx context.Context, today time.Time) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, getDueRecurringRules, today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRecurringOccurrences = `-- name: GetRecurringOccurrences :many
+SELECT id, rule_id, occurrence_date, date, title, description, value, skipped, account_id, created_at FROM recurring_occurrences
+WHERE rule_id = $1::int
+AND occurrence_date BETWEEN $2::date AND $3::date
+ORDER BY occurrence_date
+`
+
+type GetRecurringOccurrencesParams struct {
+	RuleID   int32     `json:"rule_id"`
+	FromDate time.Time `json:"from_date"`
+	ToDate   time.Time `json:"to_date"`
+}
+
+func (q *Queries) GetRecurringOccurrences(ctx context.Context, arg GetRecurringOccurrencesParams) ([]RecurringOccurrence, error) {
+	rows, err := q.db.QueryContext(ctx, getRecurringOccurrences, arg.RuleID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RecurringOccurrence{}
+	for rows.Next() {
+		var i RecurringOccurrence
+		if err := rows.Scan(
+			&i.ID,
+			&i.RuleID,
+			&i.OccurrenceDate,
+			&i.Date,
+			&i.Title,
+			&i.Description,
+			&i.Value,
+			&i.Skipped,
+			&i.AccountID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRecurringRule = `-- name: GetRecurringRule :one
+SELECT id, user_id, household_id, wallet_id, category_id, title, type, description, value, frequency, interval, day_of_month, start_date, end_date, occurrence_count, materialized_through, created_at FROM recurring_rules
+WHERE id = $1
+AND (
+  (recurring_rules.household_id IS NULL AND recurring_rules.user_id = $2)
+  OR recurring_rules.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+)
+LIMIT 1
+`
+
+type GetRecurringRuleParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) GetRecurringRule(ctx context.Context, arg GetRecurringRuleParams) (RecurringRule, error) {
+	row := q.db.QueryRowContext(ctx, getRecurringRule, arg.ID, arg.UserID)
+	var i RecurringRule
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.WalletID,
+		&i.CategoryID,
+		&i.Title,
+		&i.Type,
+		&i.Description,
+		&i.Value,
+		&i.Frequency,
+		&i.Interval,
+		&i.DayOfMonth,
+		&i.StartDate,
+		&i.EndDate,
+		&i.OccurrenceCount,
+		&i.MaterializedThrough,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getRecurringRules = `-- name: GetRecurringRules :many
+SELECT id, user_id, household_id, wallet_id, category_id, title, type, description, value, frequency, interval, day_of_month, start_date, end_date, occurrence_count, materialized_through, created_at FROM recurring_rules
+WHERE (
+  ($1::int IS NULL AND recurring_rules.household_id IS NULL AND recurring_rules.user_id = $2)
+  OR (
+    recurring_rules.household_id = $1::int
+    AND recurring_rules.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+  )
+)
+ORDER BY recurring_rules.start_date, recurring_rules.id
+`
+
+type GetRecurringRulesParams struct {
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	UserID      int32         `json:"user_id"`
+}
+
+func (q *Queries) GetRecurringRules(ctx context.Context, arg GetRecurringRulesParams) ([]RecurringRule, error) {
+	rows, err := q.db.QueryContext(ctx, getRecurringRules, arg.HouseholdID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RecurringRule{}
+	for rows.Next() {
+		var i RecurringRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.HouseholdID,
+			&i.WalletID,
+			&i.CategoryID,
+			&i.Title,
+			&i.Type,
+			&i.Description,
+			&i.Value,
+			&i.Frequency,
+			&i.Interval,
+			&i.DayOfMonth,
+			&i.StartDate,
+			&i.EndDate,
+			&i.OccurrenceCount,
+			&i.MaterializedThrough,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockRecurringRule = `-- name: LockRecurringRule :one
+SELECT id, user_id, household_id, wallet_id, category_id, title, type, description, value, frequency, interval, day_of_month, start_date, end_date, occurrence_count, materialized_through, created_at FROM recurring_rules
+WHERE id = $1
+FOR UPDATE
+`
+
+// trava a regra até o fim da transação, para o agendador e as alterações pontuais não se cruzarem
+func (q *Queries) LockRecurringRule(ctx context.Context, id int32) (RecurringRule, error) {
+	row := q.db.QueryRowContext(ctx, lockRecurringRule, id)
+	var i RecurringRule
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.WalletID,
+		&i.CategoryID,
+		&i.Title,
+		&i.Type,
+		&i.Description,
+		&i.Value,
+		&i.Frequency,
+		&i.Interval,
+		&i.DayOfMonth,
+		&i.StartDate,
+		&i.EndDate,
+		&i.OccurrenceCount,
+		&i.MaterializedThrough,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const materializeRecurringOccurrence = `-- name: MaterializeRecurringOccurrence :one
+INSERT INTO recurring_occurrences (
+  rule_id,
+  occurrence_date,
+  date,
+  title,
+  description,
+  value,
+  account_id
+) VALUES (
+  $1::int,
+  $2::date,
+  $3::date,
+  $4::varchar,
+  $5::varchar,
+  $6::money_minor,
+  $7::int
+)
+ON CONFLICT (rule_id, occurrence_date) DO UPDATE SET account_id = EXCLUDED.account_id
+RETURNING id, rule_id, occurrence_date, date, title, description, value, skipped, account_id, created_at
+`
+
+type MaterializeRecurringOccurrenceParams struct {
+	RuleID         int32      `json:"rule_id"`
+	OccurrenceDate time.Time  `json:"occurrence_date"`
+	Date           time.Time  `json:"date"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Value          util.Money `json:"value"`
+	AccountID      int32      `json:"account_id"`
+}
+
+func (q *Queries) MaterializeRecurringOccurrence(ctx context.Context, arg MaterializeRecurringOccurrenceParams) (RecurringOccurrence, error) {
+	row := q.db.QueryRowContext(ctx, materializeRecurringOccurrence,
+		arg.RuleID,
+		arg.OccurrenceDate,
+		arg.Date,
+		arg.Title,
+		arg.Description,
+		arg.Value,
+		arg.AccountID,
+	)
+	var i RecurringOccurrence
+	err := row.Scan(
+		&i.ID,
+		&i.RuleID,
+		&i.OccurrenceDate,
+		&i.Date,
+		&i.Title,
+		&i.Description,
+		&i.Value,
+		&i.Skipped,
+		&i.AccountID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const setRecurringRuleMaterialized = `-- name: SetRecurringRuleMaterialized :exec
+UPDATE recurring_rules SET materialized_through = $1::date
+WHERE id = $2
+`
+
+type SetRecurringRuleMaterializedParams struct {
+	MaterializedThrough time.Time `json:"materialized_through"`
+	ID                  int32     `json:"id"`
+}
+
+func (q *Queries) SetRecurringRuleMaterialized(ctx context.Context, arg SetRecurringRuleMaterializedParams) error {
+	_, err := q.db.ExecContext(ctx, setRecurringRuleMaterialized, arg.MaterializedThrough, arg.ID)
+	return err
+}
+
+const updateRecurringRule = `-- name: UpdateRecurringRule :one
+UPDATE recurring_rules SET
+  title = $1,
+  description = $2,
+  value = $3,
+  end_date = $4::date,
+  occurrence_count = $5::int
+WHERE id = $6
+AND (
+  (recurring_rules.household_id IS NULL AND recurring_rules.user_id = $7)
+  OR recurring_rules.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $7 AND m.role IN ('owner', 'editor'))
+)
+RETURNING id, user_id, household_id, wallet_id, category_id, title, type, description, value, frequency, interval, day_of_month, start_date, end_date, occurrence_count, materialized_through, created_at
+`
+
+type UpdateRecurringRuleParams struct {
+	Title           string        `json:"title"`
+	Description     string        `json:"description"`
+	Value           util.Money    `json:"value"`
+	EndDate         sql.NullTime  `json:"end_date"`
+	OccurrenceCount sql.NullInt32 `json:"occurrence_count"`
+	ID              int32         `json:"id"`
+	UserID          int32         `json:"user_id"`
+}
+
+func (q *Queries) UpdateRecurringRule(ctx context.Context, arg UpdateRecurringRuleParams) (RecurringRule, error) {
+	row := q.db.QueryRowContext(ctx, updateRecurringRule,
+		arg.Title,
+		arg.Description,
+		arg.Value,
+		arg.EndDate,
+		arg.OccurrenceCount,
+		arg.ID,
+		arg.UserID,
+	)
+	var i RecurringRule
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.WalletID,
+		&i.CategoryID,
+		&i.Title,
+		&i.Type,
+		&i.Description,
+		&i.Value,
+		&i.Frequency,
+		&i.Interval,
+		&i.DayOfMonth,
+		&i.StartDate,
+		&i.EndDate,
+		&i.OccurrenceCount,
+		&i.MaterializedThrough,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const upsertRecurringOccurrence = `-- name: UpsertRecurringOccurrence :one
+INSERT INTO recurring_occurrences (
+  rule_id,
+  occurrence_date,
+  date,
+  title,
+  description,
+  value,
+  skipped
+)
+SELECT
+  recurring_rules.id,
+  $1::date,
+  $2::date,
+  $3::varchar,
+  $4::varchar,
+  $5::money_minor,
+  $6::boolean
+FROM recurring_rules
+WHERE recurring_rules.id = $7::int
+AND (
+  (recurring_rules.household_id IS NULL AND recurring_rules.user_id = $8::int)
+  OR recurring_rules.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $8::int AND m.role IN ('owner', 'editor'))
+)
+ON CONFLICT (rule_id, occurrence_date) DO UPDATE SET
+  date = EXCLUDED.date,
+  title = EXCLUDED.title,
+  description = EXCLUDED.description,
+  value = EXCLUDED.value,
+  skipped = EXCLUDED.skipped
+WHERE recurring_occurrences.account_id IS NULL
+RETURNING id, rule_id, occurrence_date, date, title, description, value, skipped, account_id, created_at
+`
+
+type UpsertRecurringOccurrenceParams struct {
+	OccurrenceDate time.Time  `json:"occurrence_date"`
+	Date           time.Time  `json:"date"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Value          util.Money `json:"value"`
+	Skipped        bool       `json:"skipped"`
+	RuleID         int32      `json:"rule_id"`
+	UserID         int32      `json:"user_id"`
+}
+
+// grava a alteração pontual de uma ocorrência que ainda não virou transação
+func (q *Queries) UpsertRecurringOccurrence(ctx context.Context, arg UpsertRecurringOccurrenceParams) (RecurringOccurrence, error) {
+	row := q.db.QueryRowContext(ctx, upsertRecurringOccurrence,
+		arg.OccurrenceDate,
+		arg.Date,
+		arg.Title,
+		arg.Description,
+		arg.Value,
+		arg.Skipped,
+		arg.RuleID,
+		arg.UserID,
+	)
+	var i RecurringOccurrence
+	err := row.Scan(
+		&i.ID,
+		&i.RuleID,
+		&i.OccurrenceDate,
+		&i.Date,
+		&i.Title,
+		&i.Description,
+		&i.Value,
+		&i.Skipped,
+		&i.AccountID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
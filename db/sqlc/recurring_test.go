@@ -0,0 +1,240 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomRecurringRule(t *testing.T, householdID sql.NullInt32, owner int32) RecurringRule {
+	wallet := createTestWallet(t, owner, householdID, "")
+	category, err := testQueries.CreateCategory(context.Background(), CreateCategoryParams{
+		UserID:      owner,
+		HouseholdID: householdID,
+		Title:       util.RandomString(12),
+		Type:        "debit",
+		Description: util.RandomString(20),
+	})
+	require.NoError(t, err)
+
+	arg := CreateRecurringRuleParams{
+		UserID:      owner,
+		HouseholdID: householdID,
+		WalletID:    wallet.ID,
+		CategoryID:  category.ID,
+		Title:       util.RandomString(12),
+		Type:        "debit",
+		Description: util.RandomString(20),
+		Value:       util.NewMoney(100, 0),
+		Frequency:   "monthly",
+		Interval:    1,
+		DayOfMonth:  sql.NullInt32{Int32: 10, Valid: true},
+		StartDate:   time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+	}
+
+	rule, err := testQueries.CreateRecurringRule(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, rule.ID)
+	require.Equal(t, arg.WalletID, rule.WalletID)
+	require.Equal(t, arg.Value, rule.Value)
+	require.Equal(t, arg.Frequency, rule.Frequency)
+	require.False(t, rule.MaterializedThrough.Valid)
+	return rule
+}
+
+func TestCreateRecurringRuleInvalid(t *testing.T) {
+	rule := createRandomRecurringRule(t, sql.NullInt32{}, createRandomUser(t).ID)
+	arg := CreateRecurringRuleParams{
+		UserID:          rule.UserID,
+		WalletID:        rule.WalletID,
+		CategoryID:      rule.CategoryID,
+		Title:           rule.Title,
+		Type:            rule.Type,
+		Description:     rule.Description,
+		Value:           rule.Value,
+		Frequency:       rule.Frequency,
+		Interval:        1,
+		StartDate:       rule.StartDate,
+		EndDate:         sql.NullTime{Time: rule.StartDate.AddDate(1, 0, 0), Valid: true},
+		OccurrenceCount: sql.NullInt32{Int32: 12, Valid: true},
+	}
+
+	// fim por data e por quantidade ao mesmo tempo
+	_, err := testQueries.CreateRecurringRule(context.Background(), arg)
+	require.Error(t, err)
+
+	arg.EndDate = sql.NullTime{}
+	arg.Frequency = "hourly"
+	_, err = testQueries.CreateRecurringRule(context.Background(), arg)
+	require.Error(t, err)
+}
+
+func TestRecurringRulePermissions(t *testing.T) {
+	owner := createRandomUser(t)
+	household := createRandomHousehold(t, owner)
+	householdID := sql.NullInt32{Int32: household.ID, Valid: true}
+	rule := createRandomRecurringRule(t, householdID, owner.ID)
+	viewer := addRandomHouseholdMember(t, household, "viewer")
+	outsider := createRandomUser(t)
+
+	_, err := testQueries.GetRecurringRule(context.Background(), GetRecurringRuleParams{ID: rule.ID, UserID: viewer.ID})
+	require.NoError(t, err)
+	_, err = testQueries.GetRecurringRule(context.Background(), GetRecurringRuleParams{ID: rule.ID, UserID: outsider.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	rules, err := testQueries.GetRecurringRules(context.Background(), GetRecurringRulesParams{HouseholdID: householdID, UserID: viewer.ID})
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+
+	_, err = testQueries.UpdateRecurringRule(context.Background(), UpdateRecurringRuleParams{
+		ID:     rule.ID,
+		UserID: viewer.ID,
+		Title:  util.RandomString(12),
+		Value:  rule.Value,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.UpsertRecurringOccurrence(context.Background(), UpsertRecurringOccurrenceParams{
+		RuleID:         rule.ID,
+		UserID:         viewer.ID,
+		OccurrenceDate: rule.StartDate,
+		Date:           rule.StartDate,
+		Title:          rule.Title,
+		Value:          rule.Value,
+		Skipped:        true,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	rowsDeleted, err := testQueries.DeleteRecurringRule(context.Background(), DeleteRecurringRuleParams{ID: rule.ID, UserID: viewer.ID})
+	require.NoError(t, err)
+	require.Zero(t, rowsDeleted)
+
+	rowsDeleted, err = testQueries.DeleteRecurringRule(context.Background(), DeleteRecurringRuleParams{ID: rule.ID, UserID: owner.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rowsDeleted)
+}
+
+func TestRecurringOccurrences(t *testing.T) {
+	rule := createRandomRecurringRule(t, sql.NullInt32{}, createRandomUser(t).ID)
+	date := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	arg := UpsertRecurringOccurrenceParams{
+		RuleID:         rule.ID,
+		UserID:         rule.UserID,
+		OccurrenceDate: date,
+		Date:           date,
+		Title:          rule.Title,
+		Description:    rule.Description,
+		Value:          rule.Value,
+		Skipped:        true,
+	}
+	_, err := testQueries.UpsertRecurringOccurrence(context.Background(), arg)
+	require.NoError(t, err)
+
+	// a segunda alteração substitui a primeira
+	arg.Skipped = false
+	arg.Value = util.NewMoney(120, 0)
+	_, err = testQueries.UpsertRecurringOccurrence(context.Background(), arg)
+	require.NoError(t, err)
+
+	occurrences, err := testQueries.GetRecurringOccurrences(context.Background(), GetRecurringOccurrencesParams{RuleID: rule.ID, FromDate: date, ToDate: date})
+	require.NoError(t, err)
+	require.Len(t, occurrences, 1)
+	require.False(t, occurrences[0].Skipped)
+	require.Equal(t, arg.Value, occurrences[0].Value)
+
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		UserID:      rule.UserID,
+		WalletID:    rule.WalletID,
+		CategoryID:  rule.CategoryID,
+		Title:       arg.Title,
+		Type:        rule.Type,
+		Description: arg.Description,
+		Value:       arg.Value,
+		Date:        arg.Date,
+	})
+	require.NoError(t, err)
+	materialized, err := testQueries.MaterializeRecurringOccurrence(context.Background(), MaterializeRecurringOccurrenceParams{
+		RuleID:         rule.ID,
+		OccurrenceDate: date,
+		Date:           date,
+		Title:          arg.Title,
+		Description:    arg.Description,
+		Value:          arg.Value,
+		AccountID:      account.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.ID, materialized.AccountID.Int32)
+
+	// a ocorrência que já virou transação não muda mais
+	arg.Skipped = true
+	_, err = testQueries.UpsertRecurringOccurrence(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestGetDueRecurringRules(t *testing.T) {
+	rule := createRandomRecurringRule(t, sql.NullInt32{}, createRandomUser(t).ID)
+	today := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	due, err := testQueries.GetDueRecurringRules(context.Background(), rule.StartDate.AddDate(0, 0, -1))
+	require.NoError(t, err)
+	require.NotContains(t, due, rule.ID)
+
+	due, err = testQueries.GetDueRecurringRules(context.Background(), today)
+	require.NoError(t, err)
+	require.Contains(t, due, rule.ID)
+
+	err = testQueries.SetRecurringRuleMaterialized(context.Background(), SetRecurringRuleMaterializedParams{ID: rule.ID, MaterializedThrough: today})
+	require.NoError(t, err)
+
+	due, err = testQueries.GetDueRecurringRules(context.Background(), today)
+	require.NoError(t, err)
+	require.NotContains(t, due, rule.ID)
+
+	locked, err := testQueries.LockRecurringRule(context.Background(), rule.ID)
+	require.NoError(t, err)
+	require.True(t, locked.MaterializedThrough.Time.Equal(today))
+}
+
+func TestGetDueRecurringRulesOccurrenceCount(t *testing.T) {
+	rule := createRandomRecurringRule(t, sql.NullInt32{}, createRandomUser(t).ID)
+	rule, err := testQueries.UpdateRecurringRule(context.Background(), UpdateRecurringRuleParams{
+		ID:              rule.ID,
+		UserID:          rule.UserID,
+		Title:           rule.Title,
+		Description:     rule.Description,
+		Value:           rule.Value,
+		OccurrenceCount: sql.NullInt32{Int32: 2, Valid: true},
+	})
+	require.NoError(t, err)
+
+	// janeiro pulado e fevereiro processado: as duas ocorrências da regra acabaram
+	for _, date := range []time.Time{rule.StartDate, rule.StartDate.AddDate(0, 1, 0)} {
+		_, err = testQueries.UpsertRecurringOccurrence(context.Background(), UpsertRecurringOccurrenceParams{
+			RuleID:         rule.ID,
+			UserID:         rule.UserID,
+			OccurrenceDate: date,
+			Date:           date,
+			Title:          rule.Title,
+			Description:    rule.Description,
+			Value:          rule.Value,
+			Skipped:        true,
+		})
+		require.NoError(t, err)
+
+		err = testQueries.SetRecurringRuleMaterialized(context.Background(), SetRecurringRuleMaterializedParams{ID: rule.ID, MaterializedThrough: date})
+		require.NoError(t, err)
+
+		due, err := testQueries.GetDueRecurringRules(context.Background(), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		if date.Equal(rule.StartDate) {
+			require.Contains(t, due, rule.ID)
+		} else {
+			require.NotContains(t, due, rule.ID)
+		}
+	}
+}
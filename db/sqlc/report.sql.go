@@ -0,0 +1,163 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: report.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+const getReport = `-- name: GetReport :many
+WITH converted AS (
+  SELECT
+    CASE
+      WHEN $1::varchar IN ('day', 'week', 'month', 'year')
+      THEN date_trunc($1::varchar, accounts.date)::date
+      ELSE $2::date
+    END AS period,
+    CASE $1::varchar
+      WHEN 'category' THEN accounts.category_id
+      WHEN 'wallet' THEN accounts.wallet_id
+      WHEN 'tag' THEN account_tags.tag_id
+      ELSE 0
+    END AS group_id,
+    accounts.type,
+    accounts.value,
+    exchange_rate(accounts.currency, (SELECT u.base_currency FROM users u WHERE u.id = $3), accounts.date) AS rate
+  FROM accounts
+  LEFT JOIN account_tags ON $1::varchar = 'tag' AND account_tags.account_id = accounts.id
+  WHERE (
+    ($4::int IS NULL AND accounts.household_id IS NULL AND accounts.user_id = $3)
+    OR (
+      accounts.household_id = $4::int
+      AND accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $3)
+    )
+  )
+  AND accounts.type IN ('credit', 'debit')
+  AND accounts.transfer_id IS NULL
+  AND accounts.date >= $2::date
+  AND accounts.date <= $5::date
+)
+SELECT
+  converted.period::date AS period,
+  COALESCE(converted.group_id, 0)::int AS group_id,
+  COALESCE(categories.title, wallets.name, tags.name, '')::varchar AS label,
+  COALESCE(SUM(ROUND(converted.value * converted.rate)) FILTER (WHERE converted.type = 'credit'), 0)::money_minor AS income,
+  COALESCE(SUM(ROUND(converted.value * converted.rate)) FILTER (WHERE converted.type = 'debit'), 0)::money_minor AS expense,
+  COUNT(*) FILTER (WHERE converted.rate IS NULL) AS missing_rates
+FROM converted
+LEFT JOIN categories ON $1::varchar = 'category' AND categories.id = converted.group_id
+LEFT JOIN wallets ON $1::varchar = 'wallet' AND wallets.id = converted.group_id
+LEFT JOIN tags ON $1::varchar = 'tag' AND tags.id = converted.group_id
+GROUP BY converted.period, converted.group_id, categories.title, wallets.name, tags.name
+ORDER BY converted.period, converted.group_id
+`
+
+type GetReportParams struct {
+	GroupBy     string        `json:"group_by"`
+	FromDate    time.Time     `json:"from_date"`
+	UserID      int32         `json:"user_id"`
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	ToDate      time.Time     `json:"to_date"`
+}
+
+type GetReportRow struct {
+	Period       time.Time  `json:"period"`
+	GroupID      int32      `json:"group_id"`
+	Label        string     `json:"label"`
+	Income       util.Money `json:"income"`
+	Expense      util.Money `json:"expense"`
+	MissingRates int64      `json:"missing_rates"`
+}
+
+// receitas e despesas do período, convertidas para a moeda base do usuário, agrupadas por
+// group_by: day, week, month e year agrupam por period; category, wallet e tag agrupam por
+// group_id, com period igual a from_date. Por tag, uma transação entra uma vez em cada tag
+// dela, e as sem tag ficam no grupo 0; qualquer outro valor dá uma linha só com o total
+func (q *Queries) GetReport(ctx context.Context, arg GetReportParams) ([]GetReportRow, error) {
+	rows, err := q.db.QueryContext(ctx, getReport,
+		arg.GroupBy,
+		arg.FromDate,
+		arg.UserID,
+		arg.HouseholdID,
+		arg.ToDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetReportRow{}
+	for rows.Next() {
+		var i GetReportRow
+		if err := rows.Scan(
+			&i.Period,
+			&i.GroupID,
+			&i.Label,
+			&i.Income,
+			&i.Expense,
+			&i.MissingRates,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReportTotalsBefore = `-- name: GetReportTotalsBefore :one
+WITH converted AS (
+  SELECT
+    accounts.type,
+    accounts.value,
+    exchange_rate(accounts.currency, (SELECT u.base_currency FROM users u WHERE u.id = $1), accounts.date) AS rate
+  FROM accounts
+  WHERE (
+    ($2::int IS NULL AND accounts.household_id IS NULL AND accounts.user_id = $1)
+    OR (
+      accounts.household_id = $2::int
+      AND accounts.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $1)
+    )
+  )
+  AND accounts.type IN ('credit', 'debit')
+  AND accounts.transfer_id IS NULL
+  AND accounts.date < $3::date
+)
+SELECT
+  COALESCE(SUM(ROUND(converted.value * converted.rate)) FILTER (WHERE converted.type = 'credit'), 0)::money_minor AS income,
+  COALESCE(SUM(ROUND(converted.value * converted.rate)) FILTER (WHERE converted.type = 'debit'), 0)::money_minor AS expense,
+  COUNT(*) FILTER (WHERE converted.rate IS NULL) AS missing_rates
+FROM converted
+`
+
+type GetReportTotalsBeforeParams struct {
+	UserID      int32         `json:"user_id"`
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	BeforeDate  time.Time     `json:"before_date"`
+}
+
+type GetReportTotalsBeforeRow struct {
+	Income       util.Money `json:"income"`
+	Expense      util.Money `json:"expense"`
+	MissingRates int64      `json:"missing_rates"`
+}
+
+// receitas e despesas anteriores a before_date, na moeda base do usuário; é o ponto de
+// partida dos gráficos acumulados
+func (q *Queries) GetReportTotalsBefore(ctx context.Context, arg GetReportTotalsBeforeParams) (GetReportTotalsBeforeRow, error) {
+	row := q.db.QueryRowContext(ctx, getReportTotalsBefore, arg.UserID, arg.HouseholdID, arg.BeforeDate)
+	var i GetReportTotalsBeforeRow
+	err := row.Scan(&i.Income, &i.Expense, &i.MissingRates)
+	return i, err
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReport(t *testing.T) {
+	user := createRandomUser(t)
+	wallet := createRandomWallet(t, user.ID)
+	other := createRandomWallet(t, user.ID)
+	createWalletTransaction(t, wallet, "credit", util.NewMoney(1000, 0), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))
+	createWalletTransaction(t, wallet, "debit", util.NewMoney(300, 0), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC))
+	createWalletTransaction(t, other, "debit", util.NewMoney(50, 0), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC))
+	// fora do período
+	createWalletTransaction(t, wallet, "debit", util.NewMoney(70, 0), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	arg := GetReportParams{
+		GroupBy:  "month",
+		FromDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		ToDate:   time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		UserID:   user.ID,
+	}
+	rows, err := testQueries.GetReport(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.True(t, rows[0].Period.Equal(arg.FromDate))
+	require.Equal(t, util.NewMoney(1000, 0), rows[0].Income)
+	require.Equal(t, util.NewMoney(300, 0), rows[0].Expense)
+	require.True(t, rows[1].Period.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)))
+	require.Zero(t, rows[1].Income)
+	require.Equal(t, util.NewMoney(50, 0), rows[1].Expense)
+
+	arg.GroupBy = "wallet"
+	rows, err = testQueries.GetReport(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, wallet.ID, rows[0].GroupID)
+	require.Equal(t, wallet.Name, rows[0].Label)
+	require.True(t, rows[0].Period.Equal(arg.FromDate))
+	require.Equal(t, util.NewMoney(300, 0), rows[0].Expense)
+	require.Equal(t, other.ID, rows[1].GroupID)
+
+	arg.GroupBy = "category"
+	rows, err = testQueries.GetReport(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	for _, row := range rows {
+		require.NotZero(t, row.GroupID)
+		require.NotEmpty(t, row.Label)
+		require.Zero(t, row.MissingRates)
+	}
+}
+
+func TestGetReportByTag(t *testing.T) {
+	user := createRandomUser(t)
+	wallet := createRandomWallet(t, user.ID)
+	date := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	tagged := createWalletTransaction(t, wallet, "debit", util.NewMoney(100, 0), date)
+	createWalletTransaction(t, wallet, "debit", util.NewMoney(40, 0), date)
+	food := createTestTag(t, user.ID, sql.NullInt32{})
+	trip := createTestTag(t, user.ID, sql.NullInt32{})
+	for _, tag := range []Tag{food, trip} {
+		err := testQueries.AddAccountTag(context.Background(), AddAccountTagParams{AccountID: tagged.ID, TagID: tag.ID})
+		require.NoError(t, err)
+	}
+
+	arg := GetReportParams{
+		GroupBy:  "tag",
+		FromDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		ToDate:   time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		UserID:   user.ID,
+	}
+	rows, err := testQueries.GetReport(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	// sem tag fica no grupo 0; a transação com duas tags entra nas duas
+	require.Zero(t, rows[0].GroupID)
+	require.Equal(t, util.NewMoney(40, 0), rows[0].Expense)
+	for _, row := range rows[1:] {
+		require.Contains(t, []string{food.Name, trip.Name}, row.Label)
+		require.Equal(t, util.NewMoney(100, 0), row.Expense)
+	}
+
+	arg.GroupBy = "total"
+	rows, err = testQueries.GetReport(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, util.NewMoney(140, 0), rows[0].Expense)
+}
+
+func TestGetReportTotalsBefore(t *testing.T) {
+	user := createRandomUser(t)
+	wallet := createRandomWallet(t, user.ID)
+	createWalletTransaction(t, wallet, "credit", util.NewMoney(1000, 0), time.Date(2023, 12, 5, 0, 0, 0, 0, time.UTC))
+	createWalletTransaction(t, wallet, "debit", util.NewMoney(300, 0), time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))
+	// a partir de before_date já é o período do gráfico
+	createWalletTransaction(t, wallet, "debit", util.NewMoney(70, 0), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	totals, err := testQueries.GetReportTotalsBefore(context.Background(), GetReportTotalsBeforeParams{
+		UserID:     user.ID,
+		BeforeDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.NewMoney(1000, 0), totals.Income)
+	require.Equal(t, util.NewMoney(300, 0), totals.Expense)
+	require.Zero(t, totals.MissingRates)
+}
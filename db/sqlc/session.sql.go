@@ -0,0 +1,157 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: session.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createSession = `-- name: CreateSession :one
+INSERT INTO sessions (
+  user_id,
+  refresh_token_hash,
+  user_agent,
+  client_ip,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING id, user_id, refresh_token_hash, user_agent, client_ip, expires_at, revoked_at, last_used_at, created_at
+`
+
+type CreateSessionParams struct {
+	UserID           int32     `json:"user_id"`
+	RefreshTokenHash string    `json:"refresh_token_hash"`
+	UserAgent        string    `json:"user_agent"`
+	ClientIp         string    `json:"client_ip"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
+	row := q.db.QueryRowContext(ctx, createSession,
+		arg.UserID,
+		arg.RefreshTokenHash,
+		arg.UserAgent,
+		arg.ClientIp,
+		arg.ExpiresAt,
+	)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.RefreshTokenHash,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.LastUsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getRotatedRefreshTokenSession = `-- name: GetRotatedRefreshTokenSession :one
+SELECT session_id FROM session_rotated_tokens WHERE refresh_token_hash = $1 LIMIT 1
+`
+
+func (q *Queries) GetRotatedRefreshTokenSession(ctx context.Context, refreshTokenHash string) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getRotatedRefreshTokenSession, refreshTokenHash)
+	var session_id int32
+	err := row.Scan(&session_id)
+	return session_id, err
+}
+
+const getSession = `-- name: GetSession :one
+SELECT id, user_id, refresh_token_hash, user_agent, client_ip, expires_at, revoked_at, last_used_at, created_at FROM sessions WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetSession(ctx context.Context, id int32) (Session, error) {
+	row := q.db.QueryRowContext(ctx, getSession, id)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.RefreshTokenHash,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.LastUsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const revokeSession = `-- name: RevokeSession :exec
+UPDATE sessions SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeSession(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, revokeSession, id)
+	return err
+}
+
+const revokeUserSessions = `-- name: RevokeUserSessions :execrows
+UPDATE sessions SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeUserSessions(ctx context.Context, userID int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeUserSessions, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const rotateSessionRefreshToken = `-- name: RotateSessionRefreshToken :one
+WITH rotated AS (
+  UPDATE sessions
+  SET refresh_token_hash = $1::varchar, last_used_at = now()
+  WHERE sessions.refresh_token_hash = $2::varchar
+  AND revoked_at IS NULL
+  AND expires_at > now()
+  RETURNING id, user_id, refresh_token_hash, user_agent, client_ip, expires_at, revoked_at, last_used_at, created_at
+), history AS (
+  INSERT INTO session_rotated_tokens (refresh_token_hash, session_id)
+  SELECT $2::varchar, rotated.id FROM rotated
+)
+SELECT id, user_id, refresh_token_hash, user_agent, client_ip, expires_at, revoked_at, last_used_at, created_at FROM rotated
+`
+
+type RotateSessionRefreshTokenParams struct {
+	NewRefreshTokenHash string `json:"new_refresh_token_hash"`
+	OldRefreshTokenHash string `json:"old_refresh_token_hash"`
+}
+
+type RotateSessionRefreshTokenRow struct {
+	ID               int32        `json:"id"`
+	UserID           int32        `json:"user_id"`
+	RefreshTokenHash string       `json:"refresh_token_hash"`
+	UserAgent        string       `json:"user_agent"`
+	ClientIp         string       `json:"client_ip"`
+	ExpiresAt        time.Time    `json:"expires_at"`
+	RevokedAt        sql.NullTime `json:"revoked_at"`
+	LastUsedAt       time.Time    `json:"last_used_at"`
+	CreatedAt        time.Time    `json:"created_at"`
+}
+
+func (q *Queries) RotateSessionRefreshToken(ctx context.Context, arg RotateSessionRefreshTokenParams) (RotateSessionRefreshTokenRow, error) {
+	row := q.db.QueryRowContext(ctx, rotateSessionRefreshToken, arg.NewRefreshTokenHash, arg.OldRefreshTokenHash)
+	var i RotateSessionRefreshTokenRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.RefreshTokenHash,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.LastUsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
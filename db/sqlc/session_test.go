@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSession(t *testing.T) Session {
+	user := createRandomUser(t)
+	arg := CreateSessionParams{
+		UserID:           user.ID,
+		RefreshTokenHash: util.RandomString(64),
+		UserAgent:        util.RandomString(10),
+		ClientIp:         "127.0.0.1",
+		ExpiresAt:        time.Now().UTC().Add(time.Hour),
+	}
+
+	session, err := testQueries.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, session)
+
+	require.Equal(t, arg.UserID, session.UserID)
+	require.Equal(t, arg.RefreshTokenHash, session.RefreshTokenHash)
+	require.Equal(t, arg.UserAgent, session.UserAgent)
+	require.Equal(t, arg.ClientIp, session.ClientIp)
+	require.False(t, session.RevokedAt.Valid)
+	require.NotEmpty(t, session.CreatedAt)
+
+	return session
+}
+
+func TestCreateSession(t *testing.T) {
+	createRandomSession(t)
+}
+
+func TestRotateSessionRefreshToken(t *testing.T) {
+	session := createRandomSession(t)
+
+	arg := RotateSessionRefreshTokenParams{
+		NewRefreshTokenHash: util.RandomString(64),
+		OldRefreshTokenHash: session.RefreshTokenHash,
+	}
+	rotated, err := testQueries.RotateSessionRefreshToken(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, session.ID, rotated.ID)
+	require.Equal(t, arg.NewRefreshTokenHash, rotated.RefreshTokenHash)
+
+	_, err = testQueries.RotateSessionRefreshToken(context.Background(), RotateSessionRefreshTokenParams{
+		NewRefreshTokenHash: util.RandomString(64),
+		OldRefreshTokenHash: session.RefreshTokenHash,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	sessionID, err := testQueries.GetRotatedRefreshTokenSession(context.Background(), session.RefreshTokenHash)
+	require.NoError(t, err)
+	require.Equal(t, session.ID, sessionID)
+}
+
+func TestRevokeSession(t *testing.T) {
+	session := createRandomSession(t)
+
+	err := testQueries.RevokeSession(context.Background(), session.ID)
+	require.NoError(t, err)
+
+	revoked, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, revoked.RevokedAt.Valid)
+
+	_, err = testQueries.RotateSessionRefreshToken(context.Background(), RotateSessionRefreshTokenParams{
+		NewRefreshTokenHash: util.RandomString(64),
+		OldRefreshTokenHash: session.RefreshTokenHash,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	session := createRandomSession(t)
+
+	rowsRevoked, err := testQueries.RevokeUserSessions(context.Background(), session.UserID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rowsRevoked)
+
+	rowsRevoked, err = testQueries.RevokeUserSessions(context.Background(), session.UserID)
+	require.NoError(t, err)
+	require.Zero(t, rowsRevoked)
+}
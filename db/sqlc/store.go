@@ -1,9 +1,21 @@
 package db
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/lib/pq"
+)
 
 type Store interface {
 	Querier
+	ExecTx(ctx context.Context, fn func(Querier) error, options ...TxOption) error
+	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	UpdateTransferTx(ctx context.Context, arg UpdateTransferParams) (TransferTxResult, error)
 }
 
 type SQLStore struct {
@@ -17,3 +29,195 @@ func NewStore(db *sql.DB) *SQLStore {
 		Queries: New(db),
 	}
 }
+
+const (
+	defaultTxRetries = 3
+	txRetryBaseDelay = 10 * time.Millisecond
+)
+
+type txConfig struct {
+	options    sql.TxOptions
+	maxRetries int
+}
+
+// TxOption ajusta a transação aberta por ExecTx
+type TxOption func(*txConfig)
+
+// WithIsolation define o nível de isolamento; o padrão é o do banco (read committed no Postgres)
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(config *txConfig) {
+		config.options.Isolation = level
+	}
+}
+
+// WithMaxRetries define quantas vezes a transação é repetida depois de uma falha de
+// serialização ou deadlock; zero desliga as novas tentativas
+func WithMaxRetries(retries int) TxOption {
+	return func(config *txConfig) {
+		config.maxRetries = retries
+	}
+}
+
+// ExecTx roda fn dentro de uma transação do banco, com rollback se fn falhar. Em falhas de
+// serialização e deadlocks a transação inteira é repetida, então fn não deve ter efeitos
+// fora do banco
+func (store *SQLStore) ExecTx(ctx context.Context, fn func(Querier) error, options ...TxOption) error {
+	config := txConfig{maxRetries: defaultTxRetries}
+	for _, option := range options {
+		option(&config)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := store.execTx(ctx, config.options, fn)
+		if err == nil || !isRetryableTxError(err) || attempt >= config.maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(txRetryBaseDelay << attempt):
+		}
+	}
+}
+
+func (store *SQLStore) execTx(ctx context.Context, options sql.TxOptions, fn func(Querier) error) error {
+	tx, err := store.db.BeginTx(ctx, &options)
+	if err != nil {
+		return err
+	}
+
+	err = fn(New(tx))
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %w, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// isRetryableTxError indica falha de serialização (40001) ou deadlock (40P01)
+func isRetryableTxError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == "40001" || pqErr.Code == "40P01"
+}
+
+// TransferTxParams descreve uma transferência; ToValue é o valor creditado na moeda
+// da carteira de destino
+type TransferTxParams struct {
+	UserID       int32         `json:"user_id"`
+	HouseholdID  sql.NullInt32 `json:"household_id"`
+	FromWalletID int32         `json:"from_wallet_id"`
+	ToWalletID   int32         `json:"to_wallet_id"`
+	Title        string        `json:"title"`
+	Description  string        `json:"description"`
+	Value        util.Money    `json:"value"`
+	ToValue      util.Money    `json:"to_value"`
+	Date         time.Time     `json:"date"`
+}
+
+// TransferTxResult é a transferência com as duas transações ligadas a ela
+type TransferTxResult struct {
+	Transfer    Transfer `json:"transfer"`
+	FromAccount Account  `json:"from_account"`
+	ToAccount   Account  `json:"to_account"`
+}
+
+// TransferTx cria a transferência, o débito na origem e o crédito no destino de uma vez;
+// sem permissão de escrita no escopo retorna sql.ErrNoRows e nada é gravado
+func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.ExecTx(ctx, func(q Querier) error {
+		var err error
+
+		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+			UserID:       arg.UserID,
+			HouseholdID:  arg.HouseholdID,
+			FromWalletID: arg.FromWalletID,
+			ToWalletID:   arg.ToWalletID,
+			Title:        arg.Title,
+			Description:  arg.Description,
+			Value:        arg.Value,
+			ToValue:      arg.ToValue,
+			Date:         arg.Date,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.FromAccount, err = q.CreateTransferAccount(ctx, CreateTransferAccountParams{
+			UserID:      arg.UserID,
+			HouseholdID: arg.HouseholdID,
+			WalletID:    arg.FromWalletID,
+			TransferID:  result.Transfer.ID,
+			Title:       arg.Title,
+			Type:        "debit",
+			Description: arg.Description,
+			Value:       arg.Value,
+			Date:        arg.Date,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ToAccount, err = q.CreateTransferAccount(ctx, CreateTransferAccountParams{
+			UserID:      arg.UserID,
+			HouseholdID: arg.HouseholdID,
+			WalletID:    arg.ToWalletID,
+			TransferID:  result.Transfer.ID,
+			Title:       arg.Title,
+			Type:        "credit",
+			Description: arg.Description,
+			Value:       arg.ToValue,
+			Date:        arg.Date,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateTransferTx altera a transferência e as duas transações juntas, para que os
+// lados nunca fiquem diferentes
+func (store *SQLStore) UpdateTransferTx(ctx context.Context, arg UpdateTransferParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.ExecTx(ctx, func(q Querier) error {
+		var err error
+
+		result.Transfer, err = q.UpdateTransfer(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.UpdateTransferAccounts(ctx, UpdateTransferAccountsParams{
+			TransferID:  result.Transfer.ID,
+			Title:       result.Transfer.Title,
+			Description: result.Transfer.Description,
+			Value:       result.Transfer.Value,
+			ToValue:     result.Transfer.ToValue,
+			Date:        result.Transfer.Date,
+		})
+		if err != nil {
+			return err
+		}
+
+		accounts, err := q.GetTransferAccounts(ctx, result.Transfer.ID)
+		if err != nil {
+			return err
+		}
+		if len(accounts) != 2 {
+			return fmt.Errorf("transfer %d has %d accounts", result.Transfer.ID, len(accounts))
+		}
+		result.FromAccount, result.ToAccount = accounts[0], accounts[1]
+		return nil
+	})
+
+	return result, err
+}
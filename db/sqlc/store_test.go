@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecTxRollback(t *testing.T) {
+	errStop := errors.New("stop")
+	username := util.RandomString(10)
+
+	err := testStore.ExecTx(context.Background(), func(q Querier) error {
+		_, err := q.CreateUser(context.Background(), CreateUserParams{
+			Username: username,
+			Password: util.RandomString(12),
+			Email:    util.RamdomEmail(11),
+		})
+		require.NoError(t, err)
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+
+	_, err = testQueries.GetUser(context.Background(), username)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestExecTxRetries(t *testing.T) {
+	serializationFailure := &pq.Error{Code: "40001"}
+
+	attempts := 0
+	err := testStore.ExecTx(context.Background(), func(q Querier) error {
+		attempts++
+		if attempts < 3 {
+			return serializationFailure
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = testStore.ExecTx(context.Background(), func(q Querier) error {
+		attempts++
+		return &pq.Error{Code: "40P01"}
+	}, WithMaxRetries(1))
+	require.Error(t, err)
+	require.Equal(t, 2, attempts)
+
+	// outros erros não são repetidos
+	attempts = 0
+	err = testStore.ExecTx(context.Background(), func(q Querier) error {
+		attempts++
+		return sql.ErrNoRows
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.Equal(t, 1, attempts)
+}
+
+func TestExecTxSerializable(t *testing.T) {
+	owner := createRandomUser(t)
+	household := createRandomHousehold(t, owner)
+	other := addRandomHouseholdMember(t, household, "owner")
+
+	// os dois owners tentam sair ao mesmo tempo; só um pode conseguir
+	errLastOwner := errors.New("last owner")
+	results := make(chan error, 2)
+	var wg sync.WaitGroup
+	for _, user := range []User{owner, other} {
+		wg.Add(1)
+		go func(userID int32) {
+			defer wg.Done()
+			results <- testStore.ExecTx(context.Background(), func(q Querier) error {
+				owners, err := q.CountHouseholdOwners(context.Background(), household.ID)
+				if err != nil {
+					return err
+				}
+				if owners <= 1 {
+					return errLastOwner
+				}
+				_, err = q.UpdateHouseholdMemberRole(context.Background(), UpdateHouseholdMemberRoleParams{
+					HouseholdID: household.ID,
+					UserID:      userID,
+					Role:        "viewer",
+				})
+				return err
+			}, WithIsolation(sql.LevelSerializable), WithMaxRetries(5))
+		}(user.ID)
+	}
+	wg.Wait()
+	close(results)
+
+	var succeeded, refused int
+	for err := range results {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.Is(err, errLastOwner):
+			refused++
+		default:
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	require.Equal(t, 1, succeeded)
+	require.Equal(t, 1, refused)
+
+	owners, err := testQueries.CountHouseholdOwners(context.Background(), household.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), owners)
+}
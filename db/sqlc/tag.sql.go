@@ -0,0 +1,208 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: tag.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const addAccountTag = `-- name: AddAccountTag :exec
+INSERT INTO account_tags (account_id, tag_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type AddAccountTagParams struct {
+	AccountID int32 `json:"account_id"`
+	TagID     int32 `json:"tag_id"`
+}
+
+func (q *Queries) AddAccountTag(ctx context.Context, arg AddAccountTagParams) error {
+	_, err := q.db.ExecContext(ctx, addAccountTag, arg.AccountID, arg.TagID)
+	return err
+}
+
+const createTag = `-- name: CreateTag :one
+INSERT INTO tags (
+  user_id,
+  household_id,
+  name
+)
+SELECT
+  $1::int,
+  $2::int,
+  $3::varchar
+WHERE
+  $2::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = $2::int AND m.user_id = $1::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING id, user_id, household_id, name, created_at
+`
+
+type CreateTagParams struct {
+	UserID      int32         `json:"user_id"`
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	Name        string        `json:"name"`
+}
+
+func (q *Queries) CreateTag(ctx context.Context, arg CreateTagParams) (Tag, error) {
+	row := q.db.QueryRowContext(ctx, createTag, arg.UserID, arg.HouseholdID, arg.Name)
+	var i Tag
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.Name,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteAccountTags = `-- name: DeleteAccountTags :exec
+DELETE FROM account_tags
+WHERE account_id = $1
+`
+
+func (q *Queries) DeleteAccountTags(ctx context.Context, accountID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteAccountTags, accountID)
+	return err
+}
+
+const deleteTag = `-- name: DeleteTag :execrows
+DELETE FROM tags
+WHERE id = $1
+AND (
+  (tags.household_id IS NULL AND tags.user_id = $2)
+  OR tags.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2 AND m.role IN ('owner', 'editor'))
+)
+`
+
+type DeleteTagParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) DeleteTag(ctx context.Context, arg DeleteTagParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteTag, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getAccountTags = `-- name: GetAccountTags :many
+SELECT tags.id, tags.user_id, tags.household_id, tags.name, tags.created_at FROM tags
+JOIN account_tags ON account_tags.tag_id = tags.id
+WHERE account_tags.account_id = $1
+ORDER BY tags.name
+`
+
+func (q *Queries) GetAccountTags(ctx context.Context, accountID int32) ([]Tag, error) {
+	rows, err := q.db.QueryContext(ctx, getAccountTags, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tag{}
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.HouseholdID,
+			&i.Name,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTag = `-- name: GetTag :one
+SELECT id, user_id, household_id, name, created_at FROM tags
+WHERE id = $1
+AND (
+  (tags.household_id IS NULL AND tags.user_id = $2)
+  OR tags.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+)
+LIMIT 1
+`
+
+type GetTagParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) GetTag(ctx context.Context, arg GetTagParams) (Tag, error) {
+	row := q.db.QueryRowContext(ctx, getTag, arg.ID, arg.UserID)
+	var i Tag
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.Name,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTags = `-- name: GetTags :many
+SELECT id, user_id, household_id, name, created_at FROM tags
+WHERE (
+  ($1::int IS NULL AND tags.household_id IS NULL AND tags.user_id = $2)
+  OR (
+    tags.household_id = $1::int
+    AND tags.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+  )
+)
+ORDER BY tags.name
+`
+
+type GetTagsParams struct {
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	UserID      int32         `json:"user_id"`
+}
+
+func (q *Queries) GetTags(ctx context.Context, arg GetTagsParams) ([]Tag, error) {
+	rows, err := q.db.QueryContext(ctx, getTags, arg.HouseholdID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tag{}
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.HouseholdID,
+			&i.Name,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestTag(t *testing.T, userID int32, householdID sql.NullInt32) Tag {
+	arg := CreateTagParams{
+		UserID:      userID,
+		HouseholdID: householdID,
+		Name:        util.RandomString(8),
+	}
+
+	tag, err := testQueries.CreateTag(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, tag.ID)
+	require.Equal(t, arg.Name, tag.Name)
+	require.Equal(t, householdID, tag.HouseholdID)
+	return tag
+}
+
+func TestTags(t *testing.T) {
+	user := createRandomUser(t)
+	other := createRandomUser(t)
+	tag := createTestTag(t, user.ID, sql.NullInt32{})
+
+	// o nome é único por usuário
+	_, err := testQueries.CreateTag(context.Background(), CreateTagParams{UserID: user.ID, Name: tag.Name})
+	require.Error(t, err)
+	createTestTag(t, other.ID, sql.NullInt32{})
+
+	tags, err := testQueries.GetTags(context.Background(), GetTagsParams{UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, []Tag{tag}, tags)
+
+	_, err = testQueries.GetTag(context.Background(), GetTagParams{ID: tag.ID, UserID: other.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	deleted, err := testQueries.DeleteTag(context.Background(), DeleteTagParams{ID: tag.ID, UserID: other.ID})
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+
+	deleted, err = testQueries.DeleteTag(context.Background(), DeleteTagParams{ID: tag.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+}
+
+func TestAccountTags(t *testing.T) {
+	user := createRandomUser(t)
+	wallet := createRandomWallet(t, user.ID)
+	account := createWalletTransaction(t, wallet, "debit", util.NewMoney(100, 0), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC))
+	food := createTestTag(t, user.ID, sql.NullInt32{})
+	trip := createTestTag(t, user.ID, sql.NullInt32{})
+
+	for _, tag := range []Tag{food, trip, food} {
+		err := testQueries.AddAccountTag(context.Background(), AddAccountTagParams{AccountID: account.ID, TagID: tag.ID})
+		require.NoError(t, err)
+	}
+	tags, err := testQueries.GetAccountTags(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+
+	// apagar a tag tira ela das transações
+	_, err = testQueries.DeleteTag(context.Background(), DeleteTagParams{ID: trip.ID, UserID: user.ID})
+	require.NoError(t, err)
+	tags, err = testQueries.GetAccountTags(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, []Tag{food}, tags)
+
+	err = testQueries.DeleteAccountTags(context.Background(), account.ID)
+	require.NoError(t, err)
+	tags, err = testQueries.GetAccountTags(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+}
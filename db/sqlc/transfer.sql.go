@@ -0,0 +1,337 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: transfer.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+const createTransfer = `-- name: CreateTransfer :one
+INSERT INTO transfers (
+  user_id,
+  household_id,
+  from_wallet_id,
+  to_wallet_id,
+  title,
+  description,
+  value,
+  to_value,
+  date
+)
+SELECT
+  $1::int,
+  $2::int,
+  $3::int,
+  $4::int,
+  $5::varchar,
+  $6::varchar,
+  $7::money_minor,
+  $8::money_minor,
+  $9::date
+WHERE
+  $2::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = $2::int AND m.user_id = $1::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING id, user_id, household_id, from_wallet_id, to_wallet_id, title, description, value, to_value, date, created_at
+`
+
+type CreateTransferParams struct {
+	UserID       int32         `json:"user_id"`
+	HouseholdID  sql.NullInt32 `json:"household_id"`
+	FromWalletID int32         `json:"from_wallet_id"`
+	ToWalletID   int32         `json:"to_wallet_id"`
+	Title        string        `json:"title"`
+	Description  string        `json:"description"`
+	Value        util.Money    `json:"value"`
+	ToValue      util.Money    `json:"to_value"`
+	Date         time.Time     `json:"date"`
+}
+
+func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.UserID,
+		arg.HouseholdID,
+		arg.FromWalletID,
+		arg.ToWalletID,
+		arg.Title,
+		arg.Description,
+		arg.Value,
+		arg.ToValue,
+		arg.Date,
+	)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.FromWalletID,
+		&i.ToWalletID,
+		&i.Title,
+		&i.Description,
+		&i.Value,
+		&i.ToValue,
+		&i.Date,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createTransferAccount = `-- name: CreateTransferAccount :one
+INSERT INTO accounts (
+  user_id,
+  household_id,
+  wallet_id,
+  transfer_id,
+  title,
+  type,
+  description,
+  value,
+  currency,
+  date
+) VALUES (
+  $1::int,
+  $2::int,
+  $3::int,
+  $4::int,
+  $5::varchar,
+  $6::varchar,
+  $7::varchar,
+  $8::money_minor,
+  (SELECT w.currency FROM wallets w WHERE w.id = $3::int),
+  $9::date
+) RETURNING id, user_id, category_id, title, type, description, value, date, created_at, household_id, currency, wallet_id, transfer_id, external_id
+`
+
+type CreateTransferAccountParams struct {
+	UserID      int32         `json:"user_id"`
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	WalletID    int32         `json:"wallet_id"`
+	TransferID  int32         `json:"transfer_id"`
+	Title       string        `json:"title"`
+	Type        string        `json:"type"`
+	Description string        `json:"description"`
+	Value       util.Money    `json:"value"`
+	Date        time.Time     `json:"date"`
+}
+
+func (q *Queries) CreateTransferAccount(ctx context.Context, arg CreateTransferAccountParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, createTransferAccount,
+		arg.UserID,
+		arg.HouseholdID,
+		arg.WalletID,
+		arg.TransferID,
+		arg.Title,
+		arg.Type,
+		arg.Description,
+		arg.Value,
+		arg.Date,
+	)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CategoryID,
+		&i.Title,
+		&i.Type,
+		&i.Description,
+		&i.Value,
+		&i.Date,
+		&i.CreatedAt,
+		&i.HouseholdID,
+		&i.Currency,
+		&i.WalletID,
+		&i.TransferID,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const deleteTransfer = `-- name: DeleteTransfer :execrows
+DELETE FROM transfers
+WHERE id = $1
+AND (
+  (transfers.household_id IS NULL AND transfers.user_id = $2)
+  OR transfers.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2 AND m.role IN ('owner', 'editor'))
+)
+`
+
+type DeleteTransferParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) DeleteTransfer(ctx context.Context, arg DeleteTransferParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteTransfer, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getTransfer = `-- name: GetTransfer :one
+SELECT id, user_id, household_id, from_wallet_id, to_wallet_id, title, description, value, to_value, date, created_at FROM transfers
+WHERE id = $1
+AND (
+  (transfers.household_id IS NULL AND transfers.user_id = $2)
+  OR transfers.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+)
+LIMIT 1
+`
+
+type GetTransferParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) GetTransfer(ctx context.Context, arg GetTransferParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, getTransfer, arg.ID, arg.UserID)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.FromWalletID,
+		&i.ToWalletID,
+		&i.Title,
+		&i.Description,
+		&i.Value,
+		&i.ToValue,
+		&i.Date,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferAccounts = `-- name: GetTransferAccounts :many
+SELECT id, user_id, category_id, title, type, description, value, date, created_at, household_id, currency, wallet_id, transfer_id, external_id FROM accounts
+WHERE transfer_id = $1::int
+ORDER BY CASE type WHEN 'debit' THEN 0 ELSE 1 END
+`
+
+func (q *Queries) GetTransferAccounts(ctx context.Context, transferID int32) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, getTransferAccounts, transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.Title,
+			&i.Type,
+			&i.Description,
+			&i.Value,
+			&i.Date,
+			&i.CreatedAt,
+			&i.HouseholdID,
+			&i.Currency,
+			&i.WalletID,
+			&i.TransferID,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTransfer = `-- name: UpdateTransfer :one
+UPDATE transfers SET title = $1, description = $2, value = $3, to_value = $4, date = $5
+WHERE id = $6
+AND (
+  (transfers.household_id IS NULL AND transfers.user_id = $7)
+  OR transfers.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $7 AND m.role IN ('owner', 'editor'))
+)
+RETURNING id, user_id, household_id, from_wallet_id, to_wallet_id, title, description, value, to_value, date, created_at
+`
+
+type UpdateTransferParams struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Value       util.Money `json:"value"`
+	ToValue     util.Money `json:"to_value"`
+	Date        time.Time  `json:"date"`
+	ID          int32      `json:"id"`
+	UserID      int32      `json:"user_id"`
+}
+
+func (q *Queries) UpdateTransfer(ctx context.Context, arg UpdateTransferParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, updateTransfer,
+		arg.Title,
+		arg.Description,
+		arg.Value,
+		arg.ToValue,
+		arg.Date,
+		arg.ID,
+		arg.UserID,
+	)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.FromWalletID,
+		&i.ToWalletID,
+		&i.Title,
+		&i.Description,
+		&i.Value,
+		&i.ToValue,
+		&i.Date,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateTransferAccounts = `-- name: UpdateTransferAccounts :execrows
+UPDATE accounts SET
+  title = $1,
+  description = $2,
+  value = CASE type WHEN 'debit' THEN $3::money_minor ELSE $4::money_minor END,
+  date = $5
+WHERE transfer_id = $6::int
+`
+
+type UpdateTransferAccountsParams struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Value       util.Money `json:"value"`
+	ToValue     util.Money `json:"to_value"`
+	Date        time.Time  `json:"date"`
+	TransferID  int32      `json:"transfer_id"`
+}
+
+func (q *Queries) UpdateTransferAccounts(ctx context.Context, arg UpdateTransferAccountsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateTransferAccounts,
+		arg.Title,
+		arg.Description,
+		arg.Value,
+		arg.ToValue,
+		arg.Date,
+		arg.TransferID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
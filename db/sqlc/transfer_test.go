@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestTransfer(t *testing.T, from Wallet, to Wallet, value util.Money, toValue util.Money) TransferTxResult {
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		UserID:       from.UserID,
+		HouseholdID:  from.HouseholdID,
+		FromWalletID: from.ID,
+		ToWalletID:   to.ID,
+		Title:        util.RandomString(12),
+		Description:  util.RandomString(20),
+		Value:        value,
+		ToValue:      toValue,
+		Date:         time.Now(),
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func requireWalletBalance(t *testing.T, wallet Wallet, balance util.Money) {
+	stored, err := testQueries.GetWallet(context.Background(), GetWalletParams{ID: wallet.ID, UserID: wallet.UserID})
+	require.NoError(t, err)
+	require.Equal(t, balance, stored.Balance)
+}
+
+func TestTransferTx(t *testing.T) {
+	user := createRandomUser(t)
+	checking := createRandomWallet(t, user.ID)
+	savings := createRandomWallet(t, user.ID)
+
+	result := createTestTransfer(t, checking, savings, 300, 300)
+	require.NotZero(t, result.Transfer.ID)
+
+	require.Equal(t, checking.ID, result.FromAccount.WalletID)
+	require.Equal(t, "debit", result.FromAccount.Type)
+	require.Equal(t, savings.ID, result.ToAccount.WalletID)
+	require.Equal(t, "credit", result.ToAccount.Type)
+	for _, account := range []Account{result.FromAccount, result.ToAccount} {
+		require.Equal(t, result.Transfer.ID, account.TransferID.Int32)
+		require.False(t, account.CategoryID.Valid)
+	}
+
+	requireWalletBalance(t, checking, 700)
+	requireWalletBalance(t, savings, 1300)
+
+	// transferências não entram nos relatórios
+	for _, kind := range []string{"debit", "credit"} {
+		report, err := testQueries.GetAccountsReports(context.Background(), GetAccountsReportsParams{UserID: user.ID, Type: kind})
+		require.NoError(t, err)
+		require.Zero(t, report.SumValue)
+	}
+	totals, err := testQueries.GetReportTotalsBefore(context.Background(), GetReportTotalsBeforeParams{UserID: user.ID, BeforeDate: time.Now().AddDate(0, 0, 1)})
+	require.NoError(t, err)
+	require.Zero(t, totals.Income)
+	require.Zero(t, totals.Expense)
+}
+
+func TestTransferTxForbidden(t *testing.T) {
+	owner := createRandomUser(t)
+	outsider := createRandomUser(t)
+	household := createRandomHousehold(t, owner)
+	householdID := sql.NullInt32{Int32: household.ID, Valid: true}
+	from := createTestWallet(t, owner.ID, householdID, "")
+	to := createTestWallet(t, owner.ID, householdID, "")
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		UserID:       outsider.ID,
+		HouseholdID:  householdID,
+		FromWalletID: from.ID,
+		ToWalletID:   to.ID,
+		Title:        util.RandomString(12),
+		Value:        100,
+		ToValue:      100,
+		Date:         time.Now(),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	requireWalletBalance(t, from, 1000)
+	requireWalletBalance(t, to, 1000)
+}
+
+func TestUpdateTransferTx(t *testing.T) {
+	user := createRandomUser(t)
+	from := createRandomWallet(t, user.ID)
+	to := createRandomWallet(t, user.ID)
+	transfer := createTestTransfer(t, from, to, 300, 300)
+
+	// um lado sozinho não pode ser alterado pela query de contas
+	_, err := testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:     transfer.FromAccount.ID,
+		UserID: user.ID,
+		Title:  util.RandomString(12),
+		Value:  1,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	date := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	result, err := testStore.UpdateTransferTx(context.Background(), UpdateTransferParams{
+		ID:          transfer.Transfer.ID,
+		UserID:      user.ID,
+		Title:       "Reserva",
+		Description: util.RandomString(20),
+		Value:       450,
+		ToValue:     450,
+		Date:        date,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.Money(450), result.FromAccount.Value)
+	require.Equal(t, util.Money(450), result.ToAccount.Value)
+	require.Equal(t, "Reserva", result.ToAccount.Title)
+	require.True(t, result.FromAccount.Date.Equal(date))
+
+	requireWalletBalance(t, from, 550)
+	requireWalletBalance(t, to, 1450)
+}
+
+func TestDeleteTransfer(t *testing.T) {
+	user := createRandomUser(t)
+	from := createRandomWallet(t, user.ID)
+	to := createRandomWallet(t, user.ID)
+	transfer := createTestTransfer(t, from, to, 300, 300)
+
+	rows, err := testQueries.DeleteAccount(context.Background(), DeleteAccountParams{ID: transfer.ToAccount.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	rows, err = testQueries.DeleteTransfer(context.Background(), DeleteTransferParams{ID: transfer.Transfer.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	accounts, err := testQueries.GetTransferAccounts(context.Background(), transfer.Transfer.ID)
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+	requireWalletBalance(t, from, 1000)
+	requireWalletBalance(t, to, 1000)
+}
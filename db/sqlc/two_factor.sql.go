@@ -0,0 +1,149 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: two_factor.sql
+
+package db
+
+import (
+	"context"
+)
+
+const confirmUserTOTP = `-- name: ConfirmUserTOTP :execrows
+UPDATE user_totp SET confirmed_at = now(), last_used_counter = $2
+WHERE user_id = $1 AND confirmed_at IS NULL AND last_used_counter < $2
+`
+
+type ConfirmUserTOTPParams struct {
+	UserID          int32 `json:"user_id"`
+	LastUsedCounter int64 `json:"last_used_counter"`
+}
+
+func (q *Queries) ConfirmUserTOTP(ctx context.Context, arg ConfirmUserTOTPParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, confirmUserTOTP, arg.UserID, arg.LastUsedCounter)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createRecoveryCode = `-- name: CreateRecoveryCode :exec
+INSERT INTO user_recovery_codes (
+  user_id,
+  code_hash
+) VALUES (
+  $1, $2
+)
+`
+
+type CreateRecoveryCodeParams struct {
+	UserID   int32  `json:"user_id"`
+	CodeHash string `json:"code_hash"`
+}
+
+func (q *Queries) CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error {
+	_, err := q.db.ExecContext(ctx, createRecoveryCode, arg.UserID, arg.CodeHash)
+	return err
+}
+
+const deleteRecoveryCodes = `-- name: DeleteRecoveryCodes :exec
+DELETE FROM user_recovery_codes WHERE user_id = $1
+`
+
+func (q *Queries) DeleteRecoveryCodes(ctx context.Context, userID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteRecoveryCodes, userID)
+	return err
+}
+
+const deleteUserTOTP = `-- name: DeleteUserTOTP :exec
+DELETE FROM user_totp WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserTOTP(ctx context.Context, userID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteUserTOTP, userID)
+	return err
+}
+
+const getUserTOTP = `-- name: GetUserTOTP :one
+SELECT user_id, secret, confirmed_at, last_used_counter, created_at FROM user_totp WHERE user_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserTOTP(ctx context.Context, userID int32) (UserTotp, error) {
+	row := q.db.QueryRowContext(ctx, getUserTOTP, userID)
+	var i UserTotp
+	err := row.Scan(
+		&i.UserID,
+		&i.Secret,
+		&i.ConfirmedAt,
+		&i.LastUsedCounter,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateUserTOTPCounter = `-- name: UpdateUserTOTPCounter :execrows
+UPDATE user_totp SET last_used_counter = $2
+WHERE user_id = $1 AND confirmed_at IS NOT NULL AND last_used_counter < $2
+`
+
+type UpdateUserTOTPCounterParams struct {
+	UserID          int32 `json:"user_id"`
+	LastUsedCounter int64 `json:"last_used_counter"`
+}
+
+func (q *Queries) UpdateUserTOTPCounter(ctx context.Context, arg UpdateUserTOTPCounterParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserTOTPCounter, arg.UserID, arg.LastUsedCounter)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertPendingUserTOTP = `-- name: UpsertPendingUserTOTP :one
+INSERT INTO user_totp (
+  user_id,
+  secret
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, last_used_counter = 0, created_at = now()
+WHERE user_totp.confirmed_at IS NULL
+RETURNING user_id, secret, confirmed_at, last_used_counter, created_at
+`
+
+type UpsertPendingUserTOTPParams struct {
+	UserID int32  `json:"user_id"`
+	Secret string `json:"secret"`
+}
+
+func (q *Queries) UpsertPendingUserTOTP(ctx context.Context, arg UpsertPendingUserTOTPParams) (UserTotp, error) {
+	row := q.db.QueryRowContext(ctx, upsertPendingUserTOTP, arg.UserID, arg.Secret)
+	var i UserTotp
+	err := row.Scan(
+		&i.UserID,
+		&i.Secret,
+		&i.ConfirmedAt,
+		&i.LastUsedCounter,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const useRecoveryCode = `-- name: UseRecoveryCode :execrows
+UPDATE user_recovery_codes SET used_at = now()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+`
+
+type UseRecoveryCodeParams struct {
+	UserID   int32  `json:"user_id"`
+	CodeHash string `json:"code_hash"`
+}
+
+func (q *Queries) UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, useRecoveryCode, arg.UserID, arg.CodeHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
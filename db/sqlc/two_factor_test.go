@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createPendingUserTOTP(t *testing.T) UserTotp {
+	user := createRandomUser(t)
+	arg := UpsertPendingUserTOTPParams{
+		UserID: user.ID,
+		Secret: util.RandomString(32),
+	}
+
+	userTOTP, err := testQueries.UpsertPendingUserTOTP(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.UserID, userTOTP.UserID)
+	require.Equal(t, arg.Secret, userTOTP.Secret)
+	require.False(t, userTOTP.ConfirmedAt.Valid)
+	require.Zero(t, userTOTP.LastUsedCounter)
+
+	return userTOTP
+}
+
+func TestConfirmUserTOTP(t *testing.T) {
+	userTOTP := createPendingUserTOTP(t)
+
+	// antes da confirmação o segredo pode ser trocado
+	replaced, err := testQueries.UpsertPendingUserTOTP(context.Background(), UpsertPendingUserTOTPParams{
+		UserID: userTOTP.UserID,
+		Secret: util.RandomString(32),
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, userTOTP.Secret, replaced.Secret)
+
+	rows, err := testQueries.ConfirmUserTOTP(context.Background(), ConfirmUserTOTPParams{
+		UserID:          userTOTP.UserID,
+		LastUsedCounter: 100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	confirmed, err := testQueries.GetUserTOTP(context.Background(), userTOTP.UserID)
+	require.NoError(t, err)
+	require.True(t, confirmed.ConfirmedAt.Valid)
+	require.Equal(t, int64(100), confirmed.LastUsedCounter)
+
+	_, err = testQueries.UpsertPendingUserTOTP(context.Background(), UpsertPendingUserTOTPParams{
+		UserID: userTOTP.UserID,
+		Secret: util.RandomString(32),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUpdateUserTOTPCounter(t *testing.T) {
+	userTOTP := createPendingUserTOTP(t)
+
+	rows, err := testQueries.UpdateUserTOTPCounter(context.Background(), UpdateUserTOTPCounterParams{
+		UserID:          userTOTP.UserID,
+		LastUsedCounter: 10,
+	})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	_, err = testQueries.ConfirmUserTOTP(context.Background(), ConfirmUserTOTPParams{
+		UserID:          userTOTP.UserID,
+		LastUsedCounter: 10,
+	})
+	require.NoError(t, err)
+
+	rows, err = testQueries.UpdateUserTOTPCounter(context.Background(), UpdateUserTOTPCounterParams{
+		UserID:          userTOTP.UserID,
+		LastUsedCounter: 10,
+	})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	rows, err = testQueries.UpdateUserTOTPCounter(context.Background(), UpdateUserTOTPCounterParams{
+		UserID:          userTOTP.UserID,
+		LastUsedCounter: 11,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	user := createRandomUser(t)
+	codeHash := util.RandomString(64)
+
+	err := testQueries.CreateRecoveryCode(context.Background(), CreateRecoveryCodeParams{
+		UserID:   user.ID,
+		CodeHash: codeHash,
+	})
+	require.NoError(t, err)
+
+	other := createRandomUser(t)
+	rows, err := testQueries.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{
+		UserID:   other.ID,
+		CodeHash: codeHash,
+	})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	arg := UseRecoveryCodeParams{
+		UserID:   user.ID,
+		CodeHash: codeHash,
+	}
+	rows, err = testQueries.UseRecoveryCode(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	rows, err = testQueries.UseRecoveryCode(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
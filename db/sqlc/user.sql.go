@@ -16,7 +16,7 @@ INSERT INTO users (
   email
 ) VALUES (
   $1, $2, $3
-) RETURNING id, username, password, email, created_at
+) RETURNING id, username, password, email, created_at, email_verified_at, base_currency
 `
 
 type CreateUserParams struct {
@@ -34,12 +34,14 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Password,
 		&i.Email,
 		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.BaseCurrency,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, username, password, email, created_at FROM users WHERE username = $1 LIMIT 1
+SELECT id, username, password, email, created_at, email_verified_at, base_currency FROM users WHERE username = $1 LIMIT 1
 `
 
 func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
@@ -51,12 +53,33 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.Password,
 		&i.Email,
 		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.BaseCurrency,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, username, password, email, created_at, email_verified_at, base_currency FROM users WHERE email = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Password,
+		&i.Email,
+		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.BaseCurrency,
 	)
 	return i, err
 }
 
 const getUserById = `-- name: GetUserById :one
-SELECT id, username, password, email, created_at FROM users WHERE id = $1 LIMIT 1
+SELECT id, username, password, email, created_at, email_verified_at, base_currency FROM users WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetUserById(ctx context.Context, id int32) (User, error) {
@@ -68,6 +91,55 @@ func (q *Queries) GetUserById(ctx context.Context, id int32) (User, error) {
 		&i.Password,
 		&i.Email,
 		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.BaseCurrency,
 	)
 	return i, err
 }
+
+const updateUserBaseCurrency = `-- name: UpdateUserBaseCurrency :one
+UPDATE users SET base_currency = $2 WHERE id = $1 RETURNING id, username, password, email, created_at, email_verified_at, base_currency
+`
+
+type UpdateUserBaseCurrencyParams struct {
+	ID           int32  `json:"id"`
+	BaseCurrency string `json:"base_currency"`
+}
+
+func (q *Queries) UpdateUserBaseCurrency(ctx context.Context, arg UpdateUserBaseCurrencyParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserBaseCurrency, arg.ID, arg.BaseCurrency)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Password,
+		&i.Email,
+		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.BaseCurrency,
+	)
+	return i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users SET password = $2 WHERE id = $1
+`
+
+type UpdateUserPasswordParams struct {
+	ID       int32  `json:"id"`
+	Password string `json:"password"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserPassword, arg.ID, arg.Password)
+	return err
+}
+
+const verifyUserEmail = `-- name: VerifyUserEmail :exec
+UPDATE users SET email_verified_at = COALESCE(email_verified_at, now()) WHERE id = $1
+`
+
+func (q *Queries) VerifyUserEmail(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, verifyUserEmail, id)
+	return err
+}
@@ -57,3 +57,39 @@ func TestGetUserById(t *testing.T) {
 	require.Equal(t, user1.Email, user2.Email)
 	require.NotEmpty(t, user2.CreatedAt)
 }
+
+func TestGetUserByEmail(t *testing.T) {
+	user1 := createRandomUser(t)
+	user2, err := testQueries.GetUserByEmail(context.Background(), user1.Email)
+
+	require.NoError(t, err)
+	require.Equal(t, user1.ID, user2.ID)
+	require.Equal(t, user1.Username, user2.Username)
+}
+
+func TestVerifyUserEmail(t *testing.T) {
+	user1 := createRandomUser(t)
+	require.False(t, user1.EmailVerifiedAt.Valid)
+
+	err := testQueries.VerifyUserEmail(context.Background(), user1.ID)
+	require.NoError(t, err)
+
+	user2, err := testQueries.GetUserById(context.Background(), user1.ID)
+	require.NoError(t, err)
+	require.True(t, user2.EmailVerifiedAt.Valid)
+}
+
+func TestUpdateUserPassword(t *testing.T) {
+	user1 := createRandomUser(t)
+
+	arg := UpdateUserPasswordParams{
+		ID:       user1.ID,
+		Password: util.RandomString(12),
+	}
+	err := testQueries.UpdateUserPassword(context.Background(), arg)
+	require.NoError(t, err)
+
+	user2, err := testQueries.GetUserById(context.Background(), user1.ID)
+	require.NoError(t, err)
+	require.Equal(t, arg.Password, user2.Password)
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomUserToken(t *testing.T, purpose string, expiresAt time.Time) UserToken {
+	user := createRandomUser(t)
+	arg := CreateUserTokenParams{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: util.RandomString(64),
+		ExpiresAt: expiresAt,
+	}
+
+	userToken, err := testQueries.CreateUserToken(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, userToken)
+
+	require.Equal(t, arg.UserID, userToken.UserID)
+	require.Equal(t, arg.Purpose, userToken.Purpose)
+	require.Equal(t, arg.TokenHash, userToken.TokenHash)
+	require.False(t, userToken.UsedAt.Valid)
+
+	return userToken
+}
+
+func TestConsumeUserToken(t *testing.T) {
+	userToken := createRandomUserToken(t, "password_reset", time.Now().UTC().Add(time.Hour))
+
+	arg := ConsumeUserTokenParams{
+		TokenHash: userToken.TokenHash,
+		Purpose:   userToken.Purpose,
+	}
+	consumed, err := testQueries.ConsumeUserToken(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, userToken.ID, consumed.ID)
+	require.True(t, consumed.UsedAt.Valid)
+
+	_, err = testQueries.ConsumeUserToken(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestConsumeUserTokenWrongPurpose(t *testing.T) {
+	userToken := createRandomUserToken(t, "password_reset", time.Now().UTC().Add(time.Hour))
+
+	_, err := testQueries.ConsumeUserToken(context.Background(), ConsumeUserTokenParams{
+		TokenHash: userToken.TokenHash,
+		Purpose:   "email_verification",
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestConsumeUserTokenExpired(t *testing.T) {
+	userToken := createRandomUserToken(t, "password_reset", time.Now().UTC().Add(-time.Hour))
+
+	_, err := testQueries.ConsumeUserToken(context.Background(), ConsumeUserTokenParams{
+		TokenHash: userToken.TokenHash,
+		Purpose:   userToken.Purpose,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestInvalidateUserTokens(t *testing.T) {
+	userToken := createRandomUserToken(t, "email_verification", time.Now().UTC().Add(time.Hour))
+
+	err := testQueries.InvalidateUserTokens(context.Background(), InvalidateUserTokensParams{
+		UserID:  userToken.UserID,
+		Purpose: userToken.Purpose,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.ConsumeUserToken(context.Background(), ConsumeUserTokenParams{
+		TokenHash: userToken.TokenHash,
+		Purpose:   userToken.Purpose,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
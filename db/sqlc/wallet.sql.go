@@ -0,0 +1,322 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: wallet.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+const createWallet = `-- name: CreateWallet :one
+INSERT INTO wallets (
+  user_id,
+  household_id,
+  name,
+  kind,
+  currency,
+  opening_balance
+)
+SELECT
+  $1::int,
+  $2::int,
+  $3::varchar,
+  $4::varchar,
+  COALESCE($5::varchar, (SELECT u.base_currency FROM users u WHERE u.id = $1::int)),
+  $6::money_minor
+WHERE
+  $2::int IS NULL
+OR
+  EXISTS (
+    SELECT 1 FROM household_members m
+    WHERE m.household_id = $2::int AND m.user_id = $1::int AND m.role IN ('owner', 'editor')
+  )
+RETURNING id, user_id, household_id, name, kind, currency, opening_balance, created_at
+`
+
+type CreateWalletParams struct {
+	UserID         int32          `json:"user_id"`
+	HouseholdID    sql.NullInt32  `json:"household_id"`
+	Name           string         `json:"name"`
+	Kind           string         `json:"kind"`
+	Currency       sql.NullString `json:"currency"`
+	OpeningBalance util.Money     `json:"opening_balance"`
+}
+
+func (q *Queries) CreateWallet(ctx context.Context, arg CreateWalletParams) (Wallet, error) {
+	row := q.db.QueryRowContext(ctx, createWallet,
+		arg.UserID,
+		arg.HouseholdID,
+		arg.Name,
+		arg.Kind,
+		arg.Currency,
+		arg.OpeningBalance,
+	)
+	var i Wallet
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.Name,
+		&i.Kind,
+		&i.Currency,
+		&i.OpeningBalance,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteWallet = `-- name: DeleteWallet :execrows
+DELETE FROM wallets
+WHERE id = $1
+AND (
+  (wallets.household_id IS NULL AND wallets.user_id = $2)
+  OR wallets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2 AND m.role IN ('owner', 'editor'))
+)
+`
+
+type DeleteWalletParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) DeleteWallet(ctx context.Context, arg DeleteWalletParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteWallet, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getWallet = `-- name: GetWallet :one
+SELECT
+  wallets.id, wallets.user_id, wallets.household_id, wallets.name, wallets.kind, wallets.currency, wallets.opening_balance, wallets.created_at,
+  (wallets.opening_balance + COALESCE((
+    SELECT SUM(balance_change(a.type, a.value)) FROM accounts a WHERE a.wallet_id = wallets.id
+  ), 0))::money_minor AS balance
+FROM wallets
+WHERE wallets.id = $1
+AND (
+  (wallets.household_id IS NULL AND wallets.user_id = $2)
+  OR wallets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+)
+LIMIT 1
+`
+
+type GetWalletParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+type GetWalletRow struct {
+	ID             int32         `json:"id"`
+	UserID         int32         `json:"user_id"`
+	HouseholdID    sql.NullInt32 `json:"household_id"`
+	Name           string        `json:"name"`
+	Kind           string        `json:"kind"`
+	Currency       string        `json:"currency"`
+	OpeningBalance util.Money    `json:"opening_balance"`
+	CreatedAt      time.Time     `json:"created_at"`
+	Balance        util.Money    `json:"balance"`
+}
+
+func (q *Queries) GetWallet(ctx context.Context, arg GetWalletParams) (GetWalletRow, error) {
+	row := q.db.QueryRowContext(ctx, getWallet, arg.ID, arg.UserID)
+	var i GetWalletRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.Name,
+		&i.Kind,
+		&i.Currency,
+		&i.OpeningBalance,
+		&i.CreatedAt,
+		&i.Balance,
+	)
+	return i, err
+}
+
+const getWalletBalanceAt = `-- name: GetWalletBalanceAt :one
+SELECT (wallets.opening_balance + COALESCE((
+  SELECT SUM(balance_change(a.type, a.value)) FROM accounts a WHERE a.wallet_id = wallets.id AND a.date <= $1::date
+), 0))::money_minor AS balance
+FROM wallets
+WHERE wallets.id = $2
+`
+
+type GetWalletBalanceAtParams struct {
+	OnDate   time.Time `json:"on_date"`
+	WalletID int32     `json:"wallet_id"`
+}
+
+// saldo ao fim do dia informado
+func (q *Queries) GetWalletBalanceAt(ctx context.Context, arg GetWalletBalanceAtParams) (util.Money, error) {
+	row := q.db.QueryRowContext(ctx, getWalletBalanceAt, arg.OnDate, arg.WalletID)
+	var balance util.Money
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const getWalletBalanceHistory = `-- name: GetWalletBalanceHistory :many
+SELECT history.date, history.balance::money_minor AS balance
+FROM (
+  SELECT
+    daily.date,
+    wallets.opening_balance + SUM(daily.change) OVER (ORDER BY daily.date) AS balance
+  FROM (
+    SELECT a.date, SUM(balance_change(a.type, a.value)) AS change
+    FROM accounts a
+    WHERE a.wallet_id = $1 AND a.date <= $2::date
+    GROUP BY a.date
+  ) daily
+  JOIN wallets ON wallets.id = $1
+) history
+WHERE history.date >= $3::date
+ORDER BY history.date
+`
+
+type GetWalletBalanceHistoryParams struct {
+	WalletID int32     `json:"wallet_id"`
+	ToDate   time.Time `json:"to_date"`
+	FromDate time.Time `json:"from_date"`
+}
+
+type GetWalletBalanceHistoryRow struct {
+	Date    time.Time  `json:"date"`
+	Balance util.Money `json:"balance"`
+}
+
+// saldo ao fim de cada dia com movimento no período
+func (q *Queries) GetWalletBalanceHistory(ctx context.Context, arg GetWalletBalanceHistoryParams) ([]GetWalletBalanceHistoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWalletBalanceHistory, arg.WalletID, arg.ToDate, arg.FromDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetWalletBalanceHistoryRow{}
+	for rows.Next() {
+		var i GetWalletBalanceHistoryRow
+		if err := rows.Scan(&i.Date, &i.Balance); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWallets = `-- name: GetWallets :many
+SELECT
+  wallets.id, wallets.user_id, wallets.household_id, wallets.name, wallets.kind, wallets.currency, wallets.opening_balance, wallets.created_at,
+  (wallets.opening_balance + COALESCE((
+    SELECT SUM(balance_change(a.type, a.value)) FROM accounts a WHERE a.wallet_id = wallets.id
+  ), 0))::money_minor AS balance
+FROM wallets
+WHERE (
+  ($1::int IS NULL AND wallets.household_id IS NULL AND wallets.user_id = $2)
+  OR (
+    wallets.household_id = $1::int
+    AND wallets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $2)
+  )
+)
+ORDER BY wallets.name, wallets.id
+`
+
+type GetWalletsParams struct {
+	HouseholdID sql.NullInt32 `json:"household_id"`
+	UserID      int32         `json:"user_id"`
+}
+
+type GetWalletsRow struct {
+	ID             int32         `json:"id"`
+	UserID         int32         `json:"user_id"`
+	HouseholdID    sql.NullInt32 `json:"household_id"`
+	Name           string        `json:"name"`
+	Kind           string        `json:"kind"`
+	Currency       string        `json:"currency"`
+	OpeningBalance util.Money    `json:"opening_balance"`
+	CreatedAt      time.Time     `json:"created_at"`
+	Balance        util.Money    `json:"balance"`
+}
+
+func (q *Queries) GetWallets(ctx context.Context, arg GetWalletsParams) ([]GetWalletsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWallets, arg.HouseholdID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetWalletsRow{}
+	for rows.Next() {
+		var i GetWalletsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.HouseholdID,
+			&i.Name,
+			&i.Kind,
+			&i.Currency,
+			&i.OpeningBalance,
+			&i.CreatedAt,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateWallet = `-- name: UpdateWallet :one
+UPDATE wallets SET name = $1, kind = $2
+WHERE id = $3
+AND (
+  (wallets.household_id IS NULL AND wallets.user_id = $4)
+  OR wallets.household_id IN (SELECT m.household_id FROM household_members m WHERE m.user_id = $4 AND m.role IN ('owner', 'editor'))
+)
+RETURNING id, user_id, household_id, name, kind, currency, opening_balance, created_at
+`
+
+type UpdateWalletParams struct {
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	ID     int32  `json:"id"`
+	UserID int32  `json:"user_id"`
+}
+
+func (q *Queries) UpdateWallet(ctx context.Context, arg UpdateWalletParams) (Wallet, error) {
+	row := q.db.QueryRowContext(ctx, updateWallet,
+		arg.Name,
+		arg.Kind,
+		arg.ID,
+		arg.UserID,
+	)
+	var i Wallet
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.HouseholdID,
+		&i.Name,
+		&i.Kind,
+		&i.Currency,
+		&i.OpeningBalance,
+		&i.CreatedAt,
+	)
+	return i, err
+}
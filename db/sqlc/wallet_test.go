@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestWallet(t *testing.T, userID int32, householdID sql.NullInt32, currency string) Wallet {
+	arg := CreateWalletParams{
+		UserID:         userID,
+		HouseholdID:    householdID,
+		Name:           util.RandomString(8),
+		Kind:           "checking",
+		Currency:       sql.NullString{String: currency, Valid: currency != ""},
+		OpeningBalance: 1000,
+	}
+
+	wallet, err := testQueries.CreateWallet(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, wallet.ID)
+	require.Equal(t, arg.UserID, wallet.UserID)
+	require.Equal(t, arg.Name, wallet.Name)
+	require.Equal(t, arg.OpeningBalance, wallet.OpeningBalance)
+	return wallet
+}
+
+func createRandomWallet(t *testing.T, userID int32) Wallet {
+	return createTestWallet(t, userID, sql.NullInt32{}, "")
+}
+
+func createWalletTransaction(t *testing.T, wallet Wallet, kind string, value util.Money, date time.Time) Account {
+	category, err := testQueries.CreateCategory(context.Background(), CreateCategoryParams{
+		UserID:      wallet.UserID,
+		HouseholdID: wallet.HouseholdID,
+		Title:       util.RandomString(12),
+		Type:        kind,
+		Description: util.RandomString(20),
+	})
+	require.NoError(t, err)
+
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		UserID:      wallet.UserID,
+		HouseholdID: wallet.HouseholdID,
+		WalletID:    wallet.ID,
+		CategoryID:  category.ID,
+		Title:       util.RandomString(12),
+		Type:        kind,
+		Description: util.RandomString(20),
+		Value:       value,
+		Date:        date,
+	})
+	require.NoError(t, err)
+	return account
+}
+
+func TestCreateWalletDefaultCurrency(t *testing.T) {
+	user := createRandomUser(t)
+	wallet := createRandomWallet(t, user.ID)
+	require.Equal(t, user.BaseCurrency, wallet.Currency)
+
+	usd := createTestWallet(t, user.ID, sql.NullInt32{}, "USD")
+	require.Equal(t, "USD", usd.Currency)
+
+	// a transação fica na moeda da carteira
+	account := createWalletTransaction(t, usd, "debit", 10, time.Now())
+	require.Equal(t, "USD", account.Currency)
+}
+
+func TestGetWalletBalance(t *testing.T) {
+	user := createRandomUser(t)
+	wallet := createRandomWallet(t, user.ID)
+	createWalletTransaction(t, wallet, "credit", 500, time.Now())
+	createWalletTransaction(t, wallet, "debit", 200, time.Now())
+
+	stored, err := testQueries.GetWallet(context.Background(), GetWalletParams{ID: wallet.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, util.Money(1300), stored.Balance)
+
+	wallets, err := testQueries.GetWallets(context.Background(), GetWalletsParams{UserID: user.ID})
+	require.NoError(t, err)
+	require.Len(t, wallets, 1)
+	require.Equal(t, util.Money(1300), wallets[0].Balance)
+
+	other := createRandomUser(t)
+	_, err = testQueries.GetWallet(context.Background(), GetWalletParams{ID: wallet.ID, UserID: other.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestGetWalletBalanceHistory(t *testing.T) {
+	user := createRandomUser(t)
+	wallet := createRandomWallet(t, user.ID)
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	createWalletTransaction(t, wallet, "credit", 500, day)
+	createWalletTransaction(t, wallet, "debit", 100, day.AddDate(0, 0, 1))
+	createWalletTransaction(t, wallet, "debit", 50, day.AddDate(0, 0, 1))
+	createWalletTransaction(t, wallet, "credit", 25, day.AddDate(0, 0, 5))
+
+	balance, err := testQueries.GetWalletBalanceAt(context.Background(), GetWalletBalanceAtParams{
+		WalletID: wallet.ID,
+		OnDate:   day,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.Money(1500), balance)
+
+	history, err := testQueries.GetWalletBalanceHistory(context.Background(), GetWalletBalanceHistoryParams{
+		WalletID: wallet.ID,
+		FromDate: day.AddDate(0, 0, 1),
+		ToDate:   day.AddDate(0, 0, 4),
+	})
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.True(t, history[0].Date.Equal(day.AddDate(0, 0, 1)))
+	require.Equal(t, util.Money(1350), history[0].Balance)
+}
+
+func TestDeleteWallet(t *testing.T) {
+	user := createRandomUser(t)
+	wallet := createRandomWallet(t, user.ID)
+	createWalletTransaction(t, wallet, "debit", 10, time.Now())
+
+	_, err := testQueries.DeleteWallet(context.Background(), DeleteWalletParams{ID: wallet.ID, UserID: user.ID})
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, "foreign_key_violation", pqErr.Code.Name())
+
+	empty := createRandomWallet(t, user.ID)
+	other := createRandomUser(t)
+	rows, err := testQueries.DeleteWallet(context.Background(), DeleteWalletParams{ID: empty.ID, UserID: other.ID})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	rows, err = testQueries.DeleteWallet(context.Background(), DeleteWalletParams{ID: empty.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
@@ -0,0 +1,66 @@
+package exchangerate
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var csvColumns = map[string]string{
+	"date":           "date",
+	"effective_date": "date",
+	"base":           "base",
+	"base_currency":  "base",
+	"quote":          "quote",
+	"quote_currency": "quote",
+	"rate":           "rate",
+}
+
+// ParseCSV lê um CSV com cabeçalho e as colunas date, base, quote e rate em qualquer ordem,
+// por exemplo "2024-01-02,EUR,USD,1.0956"
+func ParseCSV(reader io.Reader) ([]Rate, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("exchange rate csv is empty")
+		}
+		return nil, err
+	}
+
+	positions := map[string]int{}
+	for i, name := range header {
+		column, ok := csvColumns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))]
+		if ok {
+			positions[column] = i
+		}
+	}
+	for _, column := range []string{"date", "base", "quote", "rate"} {
+		if _, ok := positions[column]; !ok {
+			return nil, fmt.Errorf("exchange rate csv has no %q column", column)
+		}
+	}
+
+	var rates []Rate
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := csvReader.FieldPos(0)
+		rate, err := newRate(record[positions["base"]], record[positions["quote"]], record[positions["rate"]], record[positions["date"]])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
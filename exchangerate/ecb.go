@@ -0,0 +1,45 @@
+package exchangerate
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// ecbBaseCurrency é a moeda base de todas as taxas de referência do BCE
+const ecbBaseCurrency = "EUR"
+
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// ParseECB lê o XML de taxas de referência do BCE (eurofxref-daily.xml ou eurofxref-hist.xml),
+// em que cada taxa é o valor de 1 EUR na moeda
+func ParseECB(reader io.Reader) ([]Rate, error) {
+	var envelope ecbEnvelope
+	err := xml.NewDecoder(reader).Decode(&envelope)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read ECB xml: %w", err)
+	}
+	if len(envelope.Days) == 0 {
+		return nil, fmt.Errorf("ECB xml has no rates")
+	}
+
+	var rates []Rate
+	for _, day := range envelope.Days {
+		for _, entry := range day.Rates {
+			rate, err := newRate(ecbBaseCurrency, entry.Currency, entry.Rate, day.Time)
+			if err != nil {
+				return nil, err
+			}
+			rates = append(rates, rate)
+		}
+	}
+	return rates, nil
+}
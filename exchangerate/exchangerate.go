@@ -0,0 +1,139 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+)
+
+// Formatos de arquivo aceitos por Parse
+const (
+	FormatCSV = "csv"
+	FormatECB = "ecb"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// o banco guarda numeric(20, 10): até 10 dígitos inteiros
+	maxRateIntegerDigits = 10
+	rateDecimals         = 10
+	loadBatchSize        = 1000
+)
+
+var (
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	ratePattern     = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+	ErrUnknownFormat = errors.New("unknown exchange rate format")
+)
+
+// Rate diz que 1 Base vale Rate Quote a partir de Date
+type Rate struct {
+	Base  string
+	Quote string
+	Rate  *big.Rat
+	Date  time.Time
+}
+
+// ValidCurrency confere se o código tem o formato ISO 4217 (três letras maiúsculas)
+func ValidCurrency(code string) bool {
+	return currencyPattern.MatchString(code)
+}
+
+// Parse lê as taxas no formato informado
+func Parse(reader io.Reader, format string) ([]Rate, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return ParseCSV(reader)
+	case FormatECB:
+		return ParseECB(reader)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+func newRate(base string, quote string, rate string, date string) (Rate, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	quote = strings.ToUpper(strings.TrimSpace(quote))
+	if !ValidCurrency(base) || !ValidCurrency(quote) {
+		return Rate{}, fmt.Errorf("invalid currency pair %q/%q", base, quote)
+	}
+	if base == quote {
+		return Rate{}, fmt.Errorf("currency pair %s/%s has the same currency twice", base, quote)
+	}
+
+	rate = strings.TrimSpace(rate)
+	integer, _, _ := strings.Cut(rate, ".")
+	if !ratePattern.MatchString(rate) || len(strings.TrimLeft(integer, "0")) > maxRateIntegerDigits {
+		return Rate{}, fmt.Errorf("invalid rate %q for %s/%s", rate, base, quote)
+	}
+	value, ok := new(big.Rat).SetString(rate)
+	if !ok || value.Sign() <= 0 {
+		return Rate{}, fmt.Errorf("invalid rate %q for %s/%s", rate, base, quote)
+	}
+
+	effectiveDate, err := time.Parse(dateLayout, strings.TrimSpace(date))
+	if err != nil {
+		return Rate{}, fmt.Errorf("invalid date %q: %w", date, err)
+	}
+
+	return Rate{Base: base, Quote: quote, Rate: value, Date: effectiveDate}, nil
+}
+
+// Load grava as taxas em lotes; uma taxa já existente para o par e a data é substituída
+// e, se o arquivo repetir o par na mesma data, vale a última ocorrência
+func Load(ctx context.Context, querier db.Querier, rates []Rate) (int64, error) {
+	type rateKey struct {
+		base, quote string
+		date        time.Time
+	}
+	unique := map[rateKey]Rate{}
+	for _, rate := range rates {
+		unique[rateKey{rate.Base, rate.Quote, rate.Date}] = rate
+	}
+
+	ordered := make([]Rate, 0, len(unique))
+	for _, rate := range unique {
+		ordered = append(ordered, rate)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if !ordered[i].Date.Equal(ordered[j].Date) {
+			return ordered[i].Date.Before(ordered[j].Date)
+		}
+		if ordered[i].Base != ordered[j].Base {
+			return ordered[i].Base < ordered[j].Base
+		}
+		return ordered[i].Quote < ordered[j].Quote
+	})
+
+	var loaded int64
+	for start := 0; start < len(ordered); start += loadBatchSize {
+		end := start + loadBatchSize
+		if end > len(ordered) {
+			end = len(ordered)
+		}
+
+		var arg db.UpsertExchangeRatesParams
+		for _, rate := range ordered[start:end] {
+			arg.BaseCurrencies = append(arg.BaseCurrencies, rate.Base)
+			arg.QuoteCurrencies = append(arg.QuoteCurrencies, rate.Quote)
+			arg.Rates = append(arg.Rates, rate.Rate.FloatString(rateDecimals))
+			arg.EffectiveDates = append(arg.EffectiveDates, rate.Date)
+		}
+
+		rows, err := querier.UpsertExchangeRates(ctx, arg)
+		if err != nil {
+			return loaded, err
+		}
+		loaded += rows
+	}
+	return loaded, nil
+}
@@ -0,0 +1,87 @@
+package exchangerate
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const ecbFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time='2024-01-03'>
+			<Cube currency='USD' rate='1.0919'/>
+			<Cube currency='BRL' rate='5.3623'/>
+		</Cube>
+		<Cube time='2024-01-02'>
+			<Cube currency='USD' rate='1.0956'/>
+			<Cube currency='BRL' rate='5.3516'/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestParseCSV(t *testing.T) {
+	input := "rate,date,base,quote\n5.0123,2024-01-02,usd,BRL\n0.9127, 2024-01-03 ,USD,EUR\n"
+
+	rates, err := Parse(strings.NewReader(input), FormatCSV)
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+
+	require.Equal(t, "USD", rates[0].Base)
+	require.Equal(t, "BRL", rates[0].Quote)
+	require.Equal(t, 0, rates[0].Rate.Cmp(big.NewRat(50123, 10000)))
+	require.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), rates[0].Date)
+	require.Equal(t, "EUR", rates[1].Quote)
+	require.Equal(t, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), rates[1].Date)
+}
+
+func TestParseCSVInvalid(t *testing.T) {
+	testCases := map[string]string{
+		"empty":          "",
+		"missing column": "date,base,rate\n2024-01-02,USD,5\n",
+		"bad currency":   "date,base,quote,rate\n2024-01-02,US,BRL,5\n",
+		"same currency":  "date,base,quote,rate\n2024-01-02,BRL,BRL,1\n",
+		"zero rate":      "date,base,quote,rate\n2024-01-02,USD,BRL,0\n",
+		"negative rate":  "date,base,quote,rate\n2024-01-02,USD,BRL,-5\n",
+		"exponent rate":  "date,base,quote,rate\n2024-01-02,USD,BRL,5e2\n",
+		"huge rate":      "date,base,quote,rate\n2024-01-02,USD,BRL,12345678901\n",
+		"bad date":       "date,base,quote,rate\n02/01/2024,USD,BRL,5\n",
+	}
+	for name, input := range testCases {
+		_, err := ParseCSV(strings.NewReader(input))
+		require.Error(t, err, name)
+	}
+}
+
+func TestParseECB(t *testing.T) {
+	rates, err := Parse(strings.NewReader(ecbFixture), FormatECB)
+	require.NoError(t, err)
+	require.Len(t, rates, 4)
+
+	for _, rate := range rates {
+		require.Equal(t, "EUR", rate.Base)
+	}
+	require.Equal(t, "USD", rates[0].Quote)
+	require.Equal(t, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), rates[0].Date)
+	require.Equal(t, "5.3516000000", rates[3].Rate.FloatString(rateDecimals))
+}
+
+func TestParseECBInvalid(t *testing.T) {
+	_, err := ParseECB(strings.NewReader("<Envelope></Envelope>"))
+	require.Error(t, err)
+
+	_, err = ParseECB(strings.NewReader("not xml"))
+	require.Error(t, err)
+}
+
+func TestParseUnknownFormat(t *testing.T) {
+	_, err := Parse(strings.NewReader(""), "ofx")
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
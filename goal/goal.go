@@ -0,0 +1,76 @@
+package goal
+
+import (
+	"math"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+// Progress é o andamento da meta. MonthlyRate é a média mensal das contribuições desde o
+// mês da primeira, e a data prevista supõe que essa média continua
+type Progress struct {
+	Saved           util.Money `json:"saved"`
+	Remaining       util.Money `json:"remaining"`
+	PercentComplete float64    `json:"percent_complete"`
+	Completed       bool       `json:"completed"`
+	MonthlyRate     util.Money `json:"monthly_rate"`
+	// RequiredMonthly é quanto falta contribuir por mês, contando o mês atual, para chegar
+	// ao alvo no prazo; nulo sem prazo
+	RequiredMonthly     *util.Money `json:"required_monthly"`
+	ProjectedCompletion *time.Time  `json:"projected_completion"`
+}
+
+// Compute calcula o andamento da meta no dia today
+func Compute(goal db.GetGoalRow, today time.Time) Progress {
+	today = date(today)
+	progress := Progress{
+		Saved:           goal.Saved,
+		Remaining:       goal.TargetAmount - goal.Saved,
+		PercentComplete: math.Round(float64(goal.Saved)/float64(goal.TargetAmount)*10000) / 100,
+	}
+	if progress.Remaining <= 0 {
+		progress.Remaining = 0
+		progress.Completed = true
+	}
+
+	if goal.Saved > 0 {
+		elapsed := monthsBetween(date(goal.FirstContribution), today)
+		progress.MonthlyRate = divideRound(goal.Saved, elapsed)
+	}
+
+	if goal.Deadline.Valid {
+		// com o prazo vencido, o que falta é para já
+		required := divideCeil(progress.Remaining, monthsBetween(today, date(goal.Deadline.Time)))
+		progress.RequiredMonthly = &required
+	}
+
+	if !progress.Completed && progress.MonthlyRate > 0 {
+		months := int(divideCeil(progress.Remaining, int64(progress.MonthlyRate)))
+		projected := today.AddDate(0, months, 0)
+		progress.ProjectedCompletion = &projected
+	}
+	return progress
+}
+
+// monthsBetween conta os meses do calendário de from a to, incluindo os dois; no mínimo 1
+func monthsBetween(from time.Time, to time.Time) int64 {
+	months := int64(to.Year()-from.Year())*12 + int64(to.Month()-from.Month()) + 1
+	if months < 1 {
+		return 1
+	}
+	return months
+}
+
+func divideRound(value util.Money, by int64) util.Money {
+	return util.Money((int64(value) + by/2) / by)
+}
+
+func divideCeil(value util.Money, by int64) util.Money {
+	return util.Money((int64(value) + by - 1) / by)
+}
+
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
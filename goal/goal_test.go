@@ -0,0 +1,101 @@
+package goal
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCompute(t *testing.T) {
+	goal := db.GetGoalRow{
+		TargetAmount:      util.NewMoney(10000, 0),
+		Deadline:          sql.NullTime{Time: day(2024, 12, 31), Valid: true},
+		Saved:             util.NewMoney(3000, 0),
+		FirstContribution: day(2024, 1, 20),
+	}
+
+	progress := Compute(goal, time.Date(2024, 3, 10, 18, 0, 0, 0, time.UTC))
+	require.Equal(t, util.NewMoney(3000, 0), progress.Saved)
+	require.Equal(t, util.NewMoney(7000, 0), progress.Remaining)
+	require.Equal(t, 30.0, progress.PercentComplete)
+	require.False(t, progress.Completed)
+	// 3000 em janeiro, fevereiro e março
+	require.Equal(t, util.NewMoney(1000, 0), progress.MonthlyRate)
+	// 7000 de março a dezembro
+	require.Equal(t, util.NewMoney(700, 0), *progress.RequiredMonthly)
+	require.Equal(t, day(2024, 10, 10), *progress.ProjectedCompletion)
+}
+
+func TestComputeRounding(t *testing.T) {
+	goal := db.GetGoalRow{
+		TargetAmount:      util.NewMoney(1000, 0),
+		Deadline:          sql.NullTime{Time: day(2024, 3, 1), Valid: true},
+		Saved:             util.NewMoney(100, 0),
+		FirstContribution: day(2024, 1, 1),
+	}
+
+	progress := Compute(goal, day(2024, 1, 31))
+	require.Equal(t, util.NewMoney(100, 0), progress.MonthlyRate)
+	// 900 em 3 meses
+	require.Equal(t, util.NewMoney(300, 0), *progress.RequiredMonthly)
+	require.Equal(t, day(2024, 10, 31), *progress.ProjectedCompletion)
+
+	goal.Saved = util.NewMoney(200, 0)
+	progress = Compute(goal, day(2024, 2, 1))
+	require.Equal(t, util.NewMoney(100, 0), progress.MonthlyRate)
+	require.Equal(t, util.NewMoney(400, 0), *progress.RequiredMonthly)
+
+	goal.Saved = util.NewMoney(0, 10)
+	progress = Compute(goal, day(2024, 3, 1))
+	require.Equal(t, util.NewMoney(0, 3), progress.MonthlyRate)
+	require.Equal(t, util.NewMoney(999, 90), *progress.RequiredMonthly)
+	require.Equal(t, 0.01, progress.PercentComplete)
+}
+
+func TestComputeWithoutContributions(t *testing.T) {
+	today := day(2024, 5, 1)
+	goal := db.GetGoalRow{TargetAmount: util.NewMoney(500, 0), FirstContribution: today}
+
+	progress := Compute(goal, today)
+	require.Zero(t, progress.Saved)
+	require.Equal(t, goal.TargetAmount, progress.Remaining)
+	require.Zero(t, progress.MonthlyRate)
+	require.Nil(t, progress.RequiredMonthly)
+	require.Nil(t, progress.ProjectedCompletion)
+}
+
+func TestComputeCompleted(t *testing.T) {
+	goal := db.GetGoalRow{
+		TargetAmount:      util.NewMoney(500, 0),
+		Deadline:          sql.NullTime{Time: day(2024, 12, 31), Valid: true},
+		Saved:             util.NewMoney(600, 0),
+		FirstContribution: day(2024, 1, 1),
+	}
+
+	progress := Compute(goal, day(2024, 6, 1))
+	require.True(t, progress.Completed)
+	require.Zero(t, progress.Remaining)
+	require.Equal(t, 120.0, progress.PercentComplete)
+	require.Zero(t, *progress.RequiredMonthly)
+	require.Nil(t, progress.ProjectedCompletion)
+}
+
+func TestComputeOverdue(t *testing.T) {
+	goal := db.GetGoalRow{
+		TargetAmount:      util.NewMoney(500, 0),
+		Deadline:          sql.NullTime{Time: day(2024, 1, 31), Valid: true},
+		Saved:             util.NewMoney(200, 0),
+		FirstContribution: day(2024, 1, 1),
+	}
+
+	progress := Compute(goal, day(2024, 6, 1))
+	require.Equal(t, util.NewMoney(300, 0), *progress.RequiredMonthly)
+}
@@ -0,0 +1,203 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCAMT = errors.New("invalid camt.053 file")
+	ErrNotBooked   = errors.New("entry is not booked")
+)
+
+// camtEntry é um Ntry do camt.053; os campos cobrem as versões 02 a 08, que mudaram o
+// lugar do status e do nome das partes
+type camtEntry struct {
+	Reference string `xml:"NtryRef"`
+	Amount    struct {
+		Value    string `xml:",chardata"`
+		Currency string `xml:"Ccy,attr"`
+	} `xml:"Amt"`
+	Indicator string `xml:"CdtDbtInd"`
+	Reversal  bool   `xml:"RvslInd"`
+	Status    struct {
+		Text string `xml:",chardata"`
+		Code string `xml:"Cd"`
+	} `xml:"Sts"`
+	BookingDate string `xml:"BookgDt>Dt"`
+	BookingTime string `xml:"BookgDt>DtTm"`
+	ValueDate   string `xml:"ValDt>Dt"`
+	ServicerRef string `xml:"AcctSvcrRef"`
+	BankCode    struct {
+		Domain    string `xml:"Domn>Cd"`
+		Family    string `xml:"Domn>Fmly>Cd"`
+		SubFamily string `xml:"Domn>Fmly>SubFmlyCd"`
+		Code      string `xml:"Prtry>Cd"`
+	} `xml:"BkTxCd"`
+	Details []struct {
+		Creditor   camtParty `xml:"RltdPties>Cdtr"`
+		Debtor     camtParty `xml:"RltdPties>Dbtr"`
+		Remittance []string  `xml:"RmtInf>Ustrd"`
+		References []string  `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+		Additional string    `xml:"AddtlTxInf"`
+	} `xml:"NtryDtls>TxDtls"`
+	AdditionalInfo string `xml:"AddtlNtryInf"`
+}
+
+type camtParty struct {
+	Name      string `xml:"Nm"`
+	PartyName string `xml:"Pty>Nm"`
+}
+
+func (party camtParty) name() string {
+	if party.Name != "" {
+		return party.Name
+	}
+	return party.PartyName
+}
+
+// ParseCAMT053 lê extratos ISO 20022 camt.053. Cada Ntry vira uma linha, e Line é a linha
+// onde ele começa. O ID é a referência do banco (AcctSvcrRef), que é única por conta, e a
+// moeda é o Ccy do valor
+func ParseCAMT053(data []byte) ([]Row, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		switch strings.ToLower(charset) {
+		case EncodingLatin1, "latin1", EncodingWindows1252:
+			data, err := io.ReadAll(input)
+			if err != nil {
+				return nil, err
+			}
+			return strings.NewReader(decode(data, EncodingWindows1252)), nil
+		}
+		return nil, fmt.Errorf("%w: unknown encoding %q", ErrInvalidCAMT, charset)
+	}
+
+	rows := []Row{}
+	statement := false
+	for {
+		line, _ := decoder.InputPos()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCAMT, err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "BkToCstmrStmt":
+			statement = true
+		case "Ntry":
+			if !statement {
+				continue
+			}
+			var entry camtEntry
+			err := decoder.DecodeElement(&entry, &start)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidCAMT, err)
+			}
+			rows = append(rows, parseCAMTEntry(line, entry))
+		}
+	}
+	if !statement {
+		return nil, ErrInvalidCAMT
+	}
+	return rows, nil
+}
+
+func parseCAMTEntry(line int, entry camtEntry) Row {
+	status := strings.TrimSpace(entry.Status.Code)
+	if status == "" {
+		status = strings.TrimSpace(entry.Status.Text)
+	}
+	if status != "" && status != "BOOK" {
+		return failedRow(line, ErrNotBooked)
+	}
+
+	var transaction Transaction
+	transaction.ID = entry.ServicerRef
+	if transaction.ID == "" {
+		transaction.ID = entry.Reference
+	}
+
+	value := entry.BookingDate
+	if value == "" {
+		value = entry.BookingTime
+	}
+	if value == "" {
+		value = entry.ValueDate
+	}
+	var err error
+	transaction.Date, err = camtDate(value)
+	if err != nil {
+		return failedRow(line, err)
+	}
+
+	transaction.Amount, err = ParseAmount(entry.Amount.Value, ".")
+	if err != nil || transaction.Amount < 0 {
+		return failedRow(line, fmt.Errorf("invalid amount %q", entry.Amount.Value))
+	}
+	transaction.Currency = strings.ToUpper(strings.TrimSpace(entry.Amount.Currency))
+	if entry.Indicator != "DBIT" && entry.Indicator != "CRDT" {
+		return failedRow(line, fmt.Errorf("invalid credit/debit indicator %q", entry.Indicator))
+	}
+	debit := entry.Indicator == "DBIT"
+	// um estorno tem o indicador do lançamento original, e o efeito contrário
+	if debit != entry.Reversal {
+		transaction.Amount = -transaction.Amount
+	}
+
+	memo := []string{}
+	if len(entry.Details) > 0 {
+		details := entry.Details[0]
+		// a outra parte é quem recebe nos débitos e quem paga nos créditos
+		if debit {
+			transaction.Payee = details.Creditor.name()
+		} else {
+			transaction.Payee = details.Debtor.name()
+		}
+		for _, text := range details.Remittance {
+			memo = append(memo, strings.TrimSpace(text))
+		}
+		for _, reference := range details.References {
+			memo = append(memo, strings.TrimSpace(reference))
+		}
+		if len(memo) == 0 && details.Additional != "" {
+			memo = append(memo, details.Additional)
+		}
+	}
+	transaction.Memo = strings.Join(memo, " ")
+	transaction.Description = strings.TrimSpace(entry.AdditionalInfo)
+	if transaction.Description == "" && transaction.Payee == "" {
+		transaction.Description = transaction.Memo
+	}
+
+	// o código da transação no banco é a única pista de categoria no camt.053
+	transaction.Category = entry.BankCode.Code
+	if transaction.Category == "" && entry.BankCode.Domain != "" {
+		transaction.Category = strings.Join([]string{entry.BankCode.Domain, entry.BankCode.Family, entry.BankCode.SubFamily}, "/")
+	}
+	return newRow(line, transaction)
+}
+
+// camtDate lê datas ISO como 2024-01-05 ou 2024-01-05T10:00:00+01:00; só o dia importa
+func camtDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 10 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	date, err := time.Parse("2006-01-02", value[:10])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
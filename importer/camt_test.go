@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCAMT053V02(t *testing.T) {
+	rows, err := Parse(FormatCAMT053, readFixture(t, "camt053_v02.xml"), Options{})
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	require.Equal(t, 17, rows[0].Line)
+	require.Equal(t, Transaction{
+		ID:       "2024010200001",
+		Date:     day(2024, 1, 2),
+		Amount:   util.NewMoney(2500, 0),
+		Currency: "EUR",
+		Payee:    "ACME GmbH",
+		Memo:     "Gehalt Januar 2024",
+		Category: "NTRF+153",
+	}, *rows[0].Transaction)
+
+	// débito: a outra parte é o credor; a referência estruturada vai para o memo
+	require.Equal(t, -util.NewMoney(64, 90), rows[1].Transaction.Amount)
+	require.Equal(t, "Stadtwerke München", rows[1].Transaction.Title())
+	require.Equal(t, "RF18539007547034", rows[1].Transaction.Details())
+	require.Equal(t, "PMNT/IDDT/ESDD", rows[1].Transaction.Category)
+
+	// estorno do débito volta como receita
+	require.Equal(t, util.NewMoney(64, 90), rows[2].Transaction.Amount)
+	require.Equal(t, "RUECKLASTSCHRIFT", rows[2].Transaction.Title())
+
+	require.Equal(t, ErrNotBooked.Error(), rows[3].Error)
+}
+
+func TestParseCAMT053V08(t *testing.T) {
+	rows, err := ParseCAMT053(readFixture(t, "camt053_v08.xml"))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	require.Equal(t, 8, rows[0].Line)
+	require.Equal(t, "E1", rows[0].Transaction.ID)
+	require.Equal(t, day(2024, 2, 10), rows[0].Transaction.Date)
+	require.Equal(t, -util.NewMoney(42, 50), rows[0].Transaction.Amount)
+	require.Equal(t, "Boulangerie Française", rows[0].Transaction.Payee)
+	require.Equal(t, "CB 10/02 PARIS", rows[0].Transaction.Memo)
+	require.Equal(t, "PMNT/CCRD/POSD", rows[0].Transaction.Category)
+
+	require.Equal(t, 20, rows[1].Line)
+	require.Contains(t, rows[1].Error, "invalid amount")
+}
+
+func TestParseCAMT053Currency(t *testing.T) {
+	rows, err := ParseCAMT053(readFixture(t, "camt053_currency.xml"))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, "CHF", rows[0].Transaction.Currency)
+	require.Equal(t, "EUR", rows[1].Transaction.Currency)
+
+	CheckCurrency(rows, "CHF")
+	require.NotNil(t, rows[0].Transaction)
+	require.Nil(t, rows[1].Transaction)
+	require.Equal(t, 16, rows[1].Line)
+	require.Equal(t, "currency EUR differs from the wallet currency CHF", rows[1].Error)
+}
+
+func TestParseCAMT053Invalid(t *testing.T) {
+	_, err := ParseCAMT053([]byte("<OFX></OFX>"))
+	require.ErrorIs(t, err, ErrInvalidCAMT)
+
+	_, err = ParseCAMT053([]byte("<Document><BkToCstmrStmt><Stmt><Ntry>"))
+	require.ErrorIs(t, err, ErrInvalidCAMT)
+}
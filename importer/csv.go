@@ -0,0 +1,306 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+// convenções de sinal do CSV
+const (
+	// SignSigned: valor negativo é despesa
+	SignSigned = "signed"
+	// SignInverted: valor positivo é despesa, como nas faturas de cartão
+	SignInverted = "inverted"
+	// SignColumns: créditos e débitos ficam em colunas separadas, sem sinal
+	SignColumns = "columns"
+)
+
+// codificações aceitas no CSV
+const (
+	EncodingUTF8        = "utf-8"
+	EncodingLatin1      = "iso-8859-1"
+	EncodingWindows1252 = "windows-1252"
+)
+
+var (
+	ErrMissingMapping = errors.New("csv imports need a column mapping")
+	ErrInvalidMapping = errors.New("invalid csv mapping")
+)
+
+// CSVMapping diz como ler o CSV de um banco. As colunas começam em 1 e zero é coluna
+// ausente. DateFormat usa DD, MM, YY e YYYY, como em "DD/MM/YYYY"
+type CSVMapping struct {
+	Delimiter         string `json:"delimiter"`
+	HeaderRows        int    `json:"header_rows"`
+	DateColumn        int    `json:"date_column"`
+	DateFormat        string `json:"date_format"`
+	DescriptionColumn int    `json:"description_column"`
+	CategoryColumn    int    `json:"category_column,omitempty"`
+	Sign              string `json:"sign"`
+	AmountColumn      int    `json:"amount_column,omitempty"`
+	CreditColumn      int    `json:"credit_column,omitempty"`
+	DebitColumn       int    `json:"debit_column,omitempty"`
+	DecimalSeparator  string `json:"decimal_separator"`
+	Encoding          string `json:"encoding"`
+}
+
+// Normalize preenche os padrões: vírgula como delimitador, sinal no valor, ponto decimal,
+// UTF-8 e datas como YYYY-MM-DD
+func (mapping CSVMapping) Normalize() CSVMapping {
+	if mapping.Delimiter == "" {
+		mapping.Delimiter = ","
+	}
+	if mapping.Sign == "" {
+		mapping.Sign = SignSigned
+	}
+	if mapping.DecimalSeparator == "" {
+		mapping.DecimalSeparator = "."
+	}
+	if mapping.Encoding == "" {
+		mapping.Encoding = EncodingUTF8
+	}
+	mapping.Encoding = strings.ToLower(mapping.Encoding)
+	if mapping.DateFormat == "" {
+		mapping.DateFormat = "YYYY-MM-DD"
+	}
+	return mapping
+}
+
+// Validate confere o mapeamento já normalizado
+func (mapping CSVMapping) Validate() error {
+	if utf8.RuneCountInString(mapping.Delimiter) != 1 {
+		return fmt.Errorf("%w: delimiter must be a single character", ErrInvalidMapping)
+	}
+	if mapping.HeaderRows < 0 {
+		return fmt.Errorf("%w: header_rows must not be negative", ErrInvalidMapping)
+	}
+	if mapping.DateColumn < 1 || mapping.DescriptionColumn < 1 || mapping.CategoryColumn < 0 {
+		return fmt.Errorf("%w: date_column and description_column are required", ErrInvalidMapping)
+	}
+	switch mapping.Sign {
+	case SignSigned, SignInverted:
+		if mapping.AmountColumn < 1 {
+			return fmt.Errorf("%w: amount_column is required", ErrInvalidMapping)
+		}
+	case SignColumns:
+		if mapping.CreditColumn < 1 || mapping.DebitColumn < 1 {
+			return fmt.Errorf("%w: credit_column and debit_column are required", ErrInvalidMapping)
+		}
+	default:
+		return fmt.Errorf("%w: unknown sign convention %q", ErrInvalidMapping, mapping.Sign)
+	}
+	if mapping.DecimalSeparator != "." && mapping.DecimalSeparator != "," {
+		return fmt.Errorf("%w: decimal_separator must be . or ,", ErrInvalidMapping)
+	}
+	switch mapping.Encoding {
+	case EncodingUTF8, EncodingLatin1, EncodingWindows1252:
+	default:
+		return fmt.Errorf("%w: unknown encoding %q", ErrInvalidMapping, mapping.Encoding)
+	}
+	if _, err := dateLayout(mapping.DateFormat); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ParseCSV lê o CSV com o mapeamento; Line é a linha do arquivo, contando o cabeçalho
+func ParseCSV(data []byte, mapping CSVMapping) ([]Row, error) {
+	mapping = mapping.Normalize()
+	err := mapping.Validate()
+	if err != nil {
+		return nil, err
+	}
+	layout, _ := dateLayout(mapping.DateFormat)
+
+	reader := csv.NewReader(strings.NewReader(decode(data, mapping.Encoding)))
+	reader.Comma, _ = utf8.DecodeRuneInString(mapping.Delimiter)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows := []Row{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rows = append(rows, failedRow(parseErr.StartLine, err))
+				continue
+			}
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if line <= mapping.HeaderRows || blank(record) {
+			continue
+		}
+		rows = append(rows, parseCSVRecord(line, record, mapping, layout))
+	}
+	return rows, nil
+}
+
+func parseCSVRecord(line int, record []string, mapping CSVMapping, layout string) Row {
+	column := func(index int) (string, error) {
+		if index > len(record) {
+			return "", fmt.Errorf("missing column %d", index)
+		}
+		return strings.TrimSpace(record[index-1]), nil
+	}
+
+	var transaction Transaction
+	value, err := column(mapping.DateColumn)
+	if err != nil {
+		return failedRow(line, err)
+	}
+	transaction.Date, err = time.Parse(layout, value)
+	if err != nil {
+		return failedRow(line, fmt.Errorf("invalid date %q", value))
+	}
+
+	transaction.Description, err = column(mapping.DescriptionColumn)
+	if err != nil {
+		return failedRow(line, err)
+	}
+	// a categoria é só sugestão; sem a coluna, a linha fica sem sugestão
+	if mapping.CategoryColumn > 0 && mapping.CategoryColumn <= len(record) {
+		transaction.Category, _ = column(mapping.CategoryColumn)
+	}
+
+	switch mapping.Sign {
+	case SignColumns:
+		credit, err := csvAmount(column, mapping.CreditColumn, mapping.DecimalSeparator)
+		if err != nil {
+			return failedRow(line, err)
+		}
+		debit, err := csvAmount(column, mapping.DebitColumn, mapping.DecimalSeparator)
+		if err != nil {
+			return failedRow(line, err)
+		}
+		// alguns bancos põem o débito com sinal negativo na própria coluna
+		if debit < 0 {
+			debit = -debit
+		}
+		transaction.Amount = credit - debit
+	default:
+		transaction.Amount, err = csvAmount(column, mapping.AmountColumn, mapping.DecimalSeparator)
+		if err != nil {
+			return failedRow(line, err)
+		}
+		if mapping.Sign == SignInverted {
+			transaction.Amount = -transaction.Amount
+		}
+	}
+	return newRow(line, transaction)
+}
+
+func csvAmount(column func(int) (string, error), index int, separator string) (util.Money, error) {
+	value, err := column(index)
+	if err != nil {
+		return 0, err
+	}
+	if value == "" {
+		return 0, nil
+	}
+	amount, err := ParseAmount(value, separator)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
+
+// ParseAmount lê valores como "1.234,56", "-12.30", "R$ 10,00" ou "(5.00)"; o separador
+// de milhar é o que não for o decimal
+func ParseAmount(value string, decimalSeparator string) (util.Money, error) {
+	value = strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = value[1 : len(value)-1]
+	}
+	if strings.HasPrefix(value, "-") {
+		negative = !negative
+		value = strings.TrimSpace(value[1:])
+	}
+
+	for _, symbol := range []string{"R$", "US$", "$", "€", "£"} {
+		value = strings.TrimSpace(strings.TrimPrefix(value, symbol))
+	}
+
+	thousands := ","
+	if decimalSeparator == "," {
+		thousands = "."
+	}
+	var cleaned strings.Builder
+	for _, char := range value {
+		switch {
+		case char >= '0' && char <= '9':
+			cleaned.WriteRune(char)
+		case string(char) == decimalSeparator:
+			cleaned.WriteByte('.')
+		case char == '-':
+			negative = !negative
+		case string(char) == thousands, char == '+', char == ' ', char == '\u00a0':
+		default:
+			return 0, util.ErrInvalidMoney
+		}
+	}
+
+	amount, err := util.ParseMoney(cleaned.String())
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// dateLayout traduz DD/MM/YYYY para o layout do pacote time; dia e mês aceitam um ou
+// dois dígitos
+func dateLayout(format string) (string, error) {
+	if !strings.Contains(format, "DD") || !strings.Contains(format, "MM") || !strings.Contains(format, "YY") {
+		return "", fmt.Errorf("%w: date_format needs DD, MM and YY or YYYY", ErrInvalidMapping)
+	}
+	return strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "1", "DD", "2").Replace(format), nil
+}
+
+// decode converte o arquivo para UTF-8 e tira o BOM
+func decode(data []byte, encoding string) string {
+	switch encoding {
+	case EncodingLatin1, EncodingWindows1252:
+		var decoded strings.Builder
+		for _, b := range data {
+			if encoding == EncodingWindows1252 && b >= 0x80 && b < 0xa0 && windows1252[b-0x80] != 0 {
+				decoded.WriteRune(windows1252[b-0x80])
+				continue
+			}
+			decoded.WriteRune(rune(b))
+		}
+		return decoded.String()
+	}
+	return string(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+}
+
+// windows1252 são os caracteres de 0x80 a 0x9f, onde o Windows-1252 difere do ISO-8859-1
+var windows1252 = [32]rune{
+	'€', 0, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0, 'Ž', 0,
+	0, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0, 'ž', 'Ÿ',
+}
+
+func blank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
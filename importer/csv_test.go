@@ -0,0 +1,132 @@
+package importer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseAmount(t *testing.T) {
+	testCases := []struct {
+		value     string
+		separator string
+		want      util.Money
+	}{
+		{"12.30", ".", util.NewMoney(12, 30)},
+		{"-1,234.5", ".", -util.NewMoney(1234, 50)},
+		{"1.234,56", ",", util.NewMoney(1234, 56)},
+		{"R$ 10,00", ",", util.NewMoney(10, 0)},
+		{"-R$ 10,00", ",", -util.NewMoney(10, 0)},
+		{"R$ -7,5", ",", -util.NewMoney(7, 50)},
+		{"(5.00)", ".", -util.NewMoney(5, 0)},
+		{"+3", ".", util.NewMoney(3, 0)},
+	}
+
+	for _, tc := range testCases {
+		amount, err := ParseAmount(tc.value, tc.separator)
+		require.NoError(t, err, tc.value)
+		require.Equal(t, tc.want, amount, tc.value)
+	}
+
+	for _, value := range []string{"abc", "1.2.3", "10.001", "-"} {
+		_, err := ParseAmount(value, ".")
+		require.Error(t, err, value)
+	}
+}
+
+func TestParseCSVSigned(t *testing.T) {
+	data := []byte("\xef\xbb\xbfData;Descrição;Valor;Categoria\n" +
+		"05/01/2024;Salário;\"5.000,00\";Renda\n" +
+		"7/1/2024;Mercado;-123,45;\n" +
+		"\n" +
+		"32/01/2024;Inválida;1,00;\n" +
+		"08/01/2024;Zero;0,00;\n" +
+		"09/01/2024;Sem valor\n")
+	mapping := CSVMapping{
+		Delimiter:         ";",
+		HeaderRows:        1,
+		DateColumn:        1,
+		DateFormat:        "DD/MM/YYYY",
+		DescriptionColumn: 2,
+		AmountColumn:      3,
+		CategoryColumn:    4,
+		DecimalSeparator:  ",",
+	}
+
+	rows, err := ParseCSV(data, mapping)
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+
+	require.Equal(t, 2, rows[0].Line)
+	require.Equal(t, Transaction{Date: day(2024, 1, 5), Amount: util.NewMoney(5000, 0), Description: "Salário", Category: "Renda"}, *rows[0].Transaction)
+	require.Equal(t, day(2024, 1, 7), rows[1].Transaction.Date)
+	require.Equal(t, -util.NewMoney(123, 45), rows[1].Transaction.Amount)
+
+	require.Equal(t, 5, rows[2].Line)
+	require.Nil(t, rows[2].Transaction)
+	require.Contains(t, rows[2].Error, "invalid date")
+	require.Equal(t, ErrZeroAmount.Error(), rows[3].Error)
+	require.Contains(t, rows[4].Error, "missing column 3")
+}
+
+func TestParseCSVColumns(t *testing.T) {
+	data := []byte("2024-03-01,Aluguel,,1500.00\n2024-03-02,Reembolso,80.10,\n2024-03-03,Tarifa,,-9.90\n")
+	mapping := CSVMapping{DateColumn: 1, DescriptionColumn: 2, Sign: SignColumns, CreditColumn: 3, DebitColumn: 4}
+
+	rows, err := ParseCSV(data, mapping)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, 1, rows[0].Line)
+	require.Equal(t, -util.NewMoney(1500, 0), rows[0].Transaction.Amount)
+	require.Equal(t, util.NewMoney(80, 10), rows[1].Transaction.Amount)
+	require.Equal(t, -util.NewMoney(9, 90), rows[2].Transaction.Amount)
+}
+
+func TestParseCSVInvertedLatin1(t *testing.T) {
+	// "Padaria São João" em ISO-8859-1 e um travessão do Windows-1252
+	data := []byte("2024-02-10,Padaria S\xe3o Jo\xe3o,12.50\n2024-02-11,Estorno \x96 loja,-3.00\n")
+	mapping := CSVMapping{DateColumn: 1, DescriptionColumn: 2, AmountColumn: 3, Sign: SignInverted, Encoding: "Windows-1252"}
+
+	rows, err := ParseCSV(data, mapping)
+	require.NoError(t, err)
+	require.Equal(t, "Padaria São João", rows[0].Transaction.Description)
+	require.Equal(t, -util.NewMoney(12, 50), rows[0].Transaction.Amount)
+	require.Equal(t, "Estorno – loja", rows[1].Transaction.Description)
+	require.Equal(t, util.NewMoney(3, 0), rows[1].Transaction.Amount)
+
+	mapping.Encoding = EncodingLatin1
+	rows, err = ParseCSV(data, mapping)
+	require.NoError(t, err)
+	require.Equal(t, "Padaria São João", rows[0].Transaction.Description)
+}
+
+func TestCSVMappingValidate(t *testing.T) {
+	valid := CSVMapping{DateColumn: 1, DescriptionColumn: 2, AmountColumn: 3}
+	require.NoError(t, valid.Normalize().Validate())
+
+	invalid := []CSVMapping{
+		{DescriptionColumn: 2, AmountColumn: 3},
+		{DateColumn: 1, DescriptionColumn: 2},
+		{DateColumn: 1, DescriptionColumn: 2, Sign: SignColumns, CreditColumn: 3},
+		{DateColumn: 1, DescriptionColumn: 2, AmountColumn: 3, Sign: "other"},
+		{DateColumn: 1, DescriptionColumn: 2, AmountColumn: 3, Delimiter: ";;"},
+		{DateColumn: 1, DescriptionColumn: 2, AmountColumn: 3, DecimalSeparator: "'"},
+		{DateColumn: 1, DescriptionColumn: 2, AmountColumn: 3, Encoding: "utf-16"},
+		{DateColumn: 1, DescriptionColumn: 2, AmountColumn: 3, DateFormat: "MM/YYYY"},
+	}
+	for _, mapping := range invalid {
+		err := mapping.Normalize().Validate()
+		require.ErrorIs(t, err, ErrInvalidMapping, "%+v", mapping)
+	}
+
+	_, err := Parse(FormatCSV, []byte("x"), Options{})
+	require.ErrorIs(t, err, ErrMissingMapping)
+	_, err = Parse("xls", []byte("x"), Options{})
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
@@ -0,0 +1,147 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+// formatos de extrato aceitos
+const (
+	FormatCSV = "csv"
+	FormatOFX = "ofx"
+	// FormatQFX é o OFX do Quicken, lido pelo mesmo parser
+	FormatQFX = "qfx"
+	FormatQIF = "qif"
+	// FormatCAMT053 é o extrato ISO 20022 (camt.053) dos bancos europeus
+	FormatCAMT053 = "camt053"
+)
+
+var (
+	ErrUnknownFormat      = errors.New("unknown import format")
+	ErrZeroAmount         = errors.New("amount is zero")
+	ErrMissingDescription = errors.New("description is empty")
+)
+
+// Transaction é um lançamento lido do extrato. Amount positivo é receita e negativo é
+// despesa; Category é só uma sugestão vinda do arquivo. ID é o identificador do banco,
+// quando o formato tem um, e serve para não importar o mesmo lançamento duas vezes.
+// Currency é a moeda de Amount, vazia nos formatos que não a informam
+type Transaction struct {
+	ID          string     `json:"id,omitempty"`
+	Date        time.Time  `json:"date"`
+	Amount      util.Money `json:"amount"`
+	Currency    string     `json:"currency,omitempty"`
+	Description string     `json:"description"`
+	Payee       string     `json:"payee,omitempty"`
+	Memo        string     `json:"memo,omitempty"`
+	Category    string     `json:"category,omitempty"`
+}
+
+// Row é uma linha do extrato, com a transação lida ou o erro que impediu a leitura
+type Row struct {
+	Line        int          `json:"line"`
+	Transaction *Transaction `json:"transaction,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// Options são as opções de leitura de cada formato. Só o CSV precisa de mapeamento;
+// DayFirst diz que as datas ambíguas do QIF estão como DD/MM, e não MM/DD
+type Options struct {
+	CSV      *CSVMapping
+	DayFirst bool
+}
+
+// Parse lê o extrato no formato pedido. O erro é para arquivos ilegíveis; problemas em
+// uma linha ficam no Row dela
+func Parse(format string, data []byte, options Options) ([]Row, error) {
+	switch format {
+	case FormatCSV:
+		if options.CSV == nil {
+			return nil, ErrMissingMapping
+		}
+		return ParseCSV(data, *options.CSV)
+	case FormatOFX, FormatQFX:
+		return ParseOFX(data)
+	case FormatQIF:
+		return ParseQIF(data, options.DayFirst)
+	case FormatCAMT053:
+		return ParseCAMT053(data)
+	}
+	return nil, ErrUnknownFormat
+}
+
+// Title é o título da transação na conta: o favorecido, se houver, ou a descrição
+func (transaction Transaction) Title() string {
+	if transaction.Payee != "" {
+		return transaction.Payee
+	}
+	return transaction.Description
+}
+
+// Details é a descrição da transação na conta: o que não foi para o título e o memo
+func (transaction Transaction) Details() string {
+	details := []string{}
+	if transaction.Payee != "" && transaction.Description != "" {
+		details = append(details, transaction.Description)
+	}
+	if transaction.Memo != "" && transaction.Memo != transaction.Title() {
+		details = append(details, transaction.Memo)
+	}
+	return strings.Join(details, " - ")
+}
+
+// CheckCurrency troca por erro as linhas em outra moeda que não currency, já que os valores
+// não são convertidos; as linhas sem moeda ficam como estão
+func CheckCurrency(rows []Row, currency string) {
+	for i, row := range rows {
+		if row.Transaction != nil && row.Transaction.Currency != "" && row.Transaction.Currency != currency {
+			rows[i] = failedRow(row.Line, fmt.Errorf("currency %s differs from the wallet currency %s", row.Transaction.Currency, currency))
+		}
+	}
+}
+
+func newRow(line int, transaction Transaction) Row {
+	if transaction.Amount == 0 {
+		return failedRow(line, ErrZeroAmount)
+	}
+	if transaction.Title() == "" {
+		return failedRow(line, ErrMissingDescription)
+	}
+	return Row{Line: line, Transaction: &transaction}
+}
+
+func failedRow(line int, err error) Row {
+	return Row{Line: line, Error: err.Error()}
+}
+
+// guessAmount lê valores de formatos sem separador decimal definido: o último ponto ou
+// vírgula é o decimal, a não ser que tenha três dígitos depois, como em "1,234"
+func guessAmount(value string) (util.Money, error) {
+	separator := "."
+	last := strings.LastIndexAny(value, ".,")
+	if last >= 0 {
+		digits := strings.TrimRight(value[last+1:], " )")
+		if (value[last] == ',') != (len(digits) == 3) {
+			separator = ","
+		}
+	}
+	amount, err := ParseAmount(value, separator)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
+
+// guessDecode trata arquivos que não são UTF-8 como Windows-1252, o padrão dos bancos e
+// programas antigos
+func guessDecode(data []byte) string {
+	if utf8.Valid(data) {
+		return decode(data, EncodingUTF8)
+	}
+	return decode(data, EncodingWindows1252)
+}
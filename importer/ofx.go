@@ -0,0 +1,150 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+var ErrInvalidOFX = errors.New("invalid ofx file")
+
+// ParseOFX lê extratos OFX 1.x (SGML, onde os campos não têm tag de fechamento) e 2.x
+// (XML). Cada STMTTRN vira uma linha, e Line é a linha do arquivo onde ele começa. A moeda
+// é o CURDEF do extrato, ou o CURRENCY do lançamento quando ele tem um
+func ParseOFX(data []byte) ([]Row, error) {
+	text := guessDecode(data)
+	start := strings.Index(strings.ToUpper(text), "<OFX>")
+	if start < 0 {
+		return nil, ErrInvalidOFX
+	}
+	line := 1 + strings.Count(text[:start], "\n")
+	body := text[start:]
+
+	rows := []Row{}
+	// fields guarda os campos do STMTTRN aberto; open é a tag que recebe o próximo texto
+	var fields map[string]string
+	var fieldsLine int
+	var open string
+	// currency é o CURDEF do extrato aberto; parent diz de quem é o próximo CURSYM
+	var currency, parent string
+	for {
+		lt := strings.IndexByte(body, '<')
+		if lt < 0 {
+			break
+		}
+		value := strings.TrimSpace(body[:lt])
+		if open == "CURDEF" && value != "" {
+			currency = strings.ToUpper(value)
+		}
+		if fields != nil && open != "" && value != "" {
+			// no SGML o campo vai até a próxima tag; vale o primeiro valor de cada campo
+			if _, ok := fields[open]; !ok {
+				fields[open] = html.UnescapeString(value)
+			}
+		}
+		line += strings.Count(body[:lt], "\n")
+
+		gt := strings.IndexByte(body[lt:], '>')
+		if gt < 0 {
+			return nil, ErrInvalidOFX
+		}
+		tag := ofxTagName(body[lt+1 : lt+gt])
+		line += strings.Count(body[lt:lt+gt], "\n")
+		body = body[lt+gt+1:]
+
+		switch {
+		case tag == "STMTTRN":
+			if fields != nil {
+				rows = append(rows, parseOFXTransaction(fieldsLine, fields, currency))
+			}
+			fields = map[string]string{}
+			fieldsLine = line
+			open = ""
+		case tag == "/STMTTRN":
+			if fields != nil {
+				rows = append(rows, parseOFXTransaction(fieldsLine, fields, currency))
+			}
+			fields = nil
+			open = ""
+		case tag == "CURRENCY", tag == "ORIGCURRENCY":
+			// no ORIGCURRENCY o valor já vem convertido para o CURDEF; só o CURRENCY muda a moeda
+			parent = tag
+			open = ""
+		case tag == "CURSYM":
+			open = parent + "." + tag
+		case strings.HasPrefix(tag, "/"), strings.HasPrefix(tag, "?"), strings.HasPrefix(tag, "!"):
+			if tag == "/"+parent {
+				parent = ""
+			}
+			open = ""
+		default:
+			open = tag
+		}
+	}
+	if fields != nil {
+		return nil, fmt.Errorf("%w: STMTTRN at line %d is not closed", ErrInvalidOFX, fieldsLine)
+	}
+	return rows, nil
+}
+
+// tipos de transação do OFX que alguns bancos mandam sem sinal no valor
+var (
+	ofxDebitTypes  = map[string]bool{"DEBIT": true, "PAYMENT": true, "FEE": true, "SRVCHG": true}
+	ofxCreditTypes = map[string]bool{"CREDIT": true, "DEP": true, "DIRECTDEP": true, "INT": true, "DIV": true}
+)
+
+func parseOFXTransaction(line int, fields map[string]string, currency string) Row {
+	var transaction Transaction
+	transaction.ID = fields["FITID"]
+	transaction.Currency = currency
+	if symbol := fields["CURRENCY.CURSYM"]; symbol != "" {
+		transaction.Currency = strings.ToUpper(symbol)
+	}
+
+	var err error
+	transaction.Date, err = ofxDate(fields["DTPOSTED"])
+	if err != nil {
+		return failedRow(line, err)
+	}
+
+	transaction.Amount, err = guessAmount(fields["TRNAMT"])
+	if err != nil {
+		return failedRow(line, err)
+	}
+	// o sinal do TRNAMT é o que vale, mas débitos positivos e créditos negativos são erros
+	// de exportação conhecidos
+	trnType := strings.ToUpper(fields["TRNTYPE"])
+	if (ofxDebitTypes[trnType] && transaction.Amount > 0) || (ofxCreditTypes[trnType] && transaction.Amount < 0) {
+		transaction.Amount = -transaction.Amount
+	}
+
+	transaction.Description = fields["NAME"]
+	transaction.Memo = fields["MEMO"]
+	if transaction.Description == "" {
+		transaction.Description = transaction.Memo
+	}
+	return newRow(line, transaction)
+}
+
+// ofxDate lê datas como 20240105, 20240105120000 ou 20240105120000.000[-3:BRT]; só o dia
+// importa
+func ofxDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+func ofxTagName(tag string) string {
+	fields := strings.Fields(tag)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(strings.TrimSuffix(fields[0], "/"))
+}
@@ -0,0 +1,94 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func TestParseOFXSGML(t *testing.T) {
+	rows, err := Parse(FormatOFX, readFixture(t, "sgml.ofx"), Options{})
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	require.Equal(t, 39, rows[0].Line)
+	require.Equal(t, Transaction{
+		ID:          "20240105001",
+		Date:        day(2024, 1, 5),
+		Amount:      util.NewMoney(5000, 0),
+		Currency:    "BRL",
+		Description: "SALÁRIO EMPRESA",
+		Memo:        "SALÁRIO EMPRESA",
+	}, *rows[0].Transaction)
+	require.Equal(t, "", rows[0].Transaction.Details())
+
+	// valor com vírgula, entidade e CHARSET:1252
+	require.Equal(t, -util.NewMoney(123, 45), rows[1].Transaction.Amount)
+	require.Equal(t, "SUPERMERCADO PÃO & CIA", rows[1].Transaction.Description)
+
+	// débito mandado sem sinal
+	require.Equal(t, -util.NewMoney(35, 90), rows[2].Transaction.Amount)
+	require.Equal(t, "TARIFA PACOTE", rows[2].Transaction.Title())
+	require.Equal(t, "Tarifa mensal", rows[2].Transaction.Details())
+
+	require.Nil(t, rows[3].Transaction)
+	require.Contains(t, rows[3].Error, "invalid date")
+}
+
+func TestParseOFXXML(t *testing.T) {
+	rows, err := Parse(FormatQFX, readFixture(t, "xml.qfx"), Options{})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	require.Equal(t, 22, rows[0].Line)
+	require.Equal(t, "2024020324692164034100013927553", rows[0].Transaction.ID)
+	require.Equal(t, day(2024, 2, 3), rows[0].Transaction.Date)
+	require.Equal(t, -util.NewMoney(42, 17), rows[0].Transaction.Amount)
+	require.Equal(t, "USD", rows[0].Transaction.Currency)
+	require.Equal(t, "COFFEE SHOP #12", rows[0].Transaction.Title())
+	require.Equal(t, "Card 1111", rows[0].Transaction.Details())
+
+	require.Equal(t, util.NewMoney(250, 0), rows[1].Transaction.Amount)
+	require.Equal(t, ErrZeroAmount.Error(), rows[2].Error)
+}
+
+func TestParseOFXCurrency(t *testing.T) {
+	rows, err := ParseOFX(readFixture(t, "currency.ofx"))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	require.Equal(t, "EUR", rows[0].Transaction.Currency)
+	// o CURRENCY do lançamento troca a moeda do valor
+	require.Equal(t, "USD", rows[1].Transaction.Currency)
+	require.Equal(t, -util.NewMoney(120, 0), rows[1].Transaction.Amount)
+	// no ORIGCURRENCY o valor já está na moeda do extrato
+	require.Equal(t, "EUR", rows[2].Transaction.Currency)
+
+	CheckCurrency(rows, "EUR")
+	require.NotNil(t, rows[0].Transaction)
+	require.Nil(t, rows[1].Transaction)
+	require.Equal(t, 32, rows[1].Line)
+	require.Equal(t, "currency USD differs from the wallet currency EUR", rows[1].Error)
+	require.NotNil(t, rows[2].Transaction)
+}
+
+func TestParseOFXInvalid(t *testing.T) {
+	_, err := ParseOFX([]byte("Data,Valor\n2024-01-01,10\n"))
+	require.ErrorIs(t, err, ErrInvalidOFX)
+
+	_, err = ParseOFX([]byte("<OFX><STMTTRN><TRNAMT>10.00<FITID>1"))
+	require.ErrorIs(t, err, ErrInvalidOFX)
+
+	rows, err := ParseOFX([]byte("<OFX><BANKTRANLIST></BANKTRANLIST></OFX>"))
+	require.NoError(t, err)
+	require.Empty(t, rows)
+}
@@ -0,0 +1,192 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidQIF = errors.New("invalid qif file")
+
+// tipos de conta do QIF cujos registros são transações; os demais (categorias, classes,
+// investimentos, lista de contas) são ignorados
+var qifTransactionTypes = map[string]bool{
+	"bank":  true,
+	"cash":  true,
+	"ccard": true,
+	"oth a": true,
+	"oth l": true,
+}
+
+// ParseQIF lê arquivos QIF do Quicken e do Money. Cada registro termina em "^", e Line é
+// a linha onde ele começa. As datas D aceitam 01/05/2024, 1/ 5'24, 2024-01-05 e variações;
+// dayFirst só é usado quando o arquivo não tem nenhuma data que tire a ambiguidade
+func ParseQIF(data []byte, dayFirst bool) ([]Row, error) {
+	lines := strings.Split(guessDecode(data), "\n")
+
+	type record struct {
+		line   int
+		fields map[byte]string
+		splits []string
+	}
+	records := []record{}
+	var current *record
+	transactions := false
+	headers := 0
+	for index, text := range lines {
+		text = strings.TrimRight(text, "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if text[0] == '!' {
+			header := strings.ToLower(strings.TrimSpace(text[1:]))
+			if strings.HasPrefix(header, "type:") {
+				headers++
+				transactions = qifTransactionTypes[strings.TrimSpace(header[len("type:"):])]
+			} else if header == "account" {
+				// a lista de contas vem antes de um !Type e tem registros próprios
+				transactions = false
+			}
+			current = nil
+			continue
+		}
+		if !transactions {
+			continue
+		}
+		if text[0] == '^' {
+			if current != nil {
+				records = append(records, *current)
+			}
+			current = nil
+			continue
+		}
+		if current == nil {
+			current = &record{line: index + 1, fields: map[byte]string{}}
+		}
+		code, value := text[0], strings.TrimSpace(text[1:])
+		if code == 'S' {
+			// o rateio entre categorias vira só a primeira, como sugestão
+			current.splits = append(current.splits, value)
+			continue
+		}
+		if _, ok := current.fields[code]; !ok {
+			current.fields[code] = value
+		}
+	}
+	if headers == 0 {
+		return nil, ErrInvalidQIF
+	}
+	if current != nil {
+		records = append(records, *current)
+	}
+
+	dates := make([]string, len(records))
+	for i, record := range records {
+		dates[i] = record.fields['D']
+	}
+	dayFirst = qifDayFirst(dates, dayFirst)
+
+	rows := []Row{}
+	for _, record := range records {
+		var transaction Transaction
+		var err error
+		transaction.Date, err = qifDate(record.fields['D'], dayFirst)
+		if err != nil {
+			rows = append(rows, failedRow(record.line, err))
+			continue
+		}
+
+		value, ok := record.fields['T']
+		if !ok {
+			value = record.fields['U']
+		}
+		transaction.Amount, err = guessAmount(value)
+		if err != nil {
+			rows = append(rows, failedRow(record.line, err))
+			continue
+		}
+
+		transaction.Payee = record.fields['P']
+		transaction.Memo = record.fields['M']
+		if transaction.Payee == "" {
+			transaction.Description = transaction.Memo
+		}
+		transaction.Category = record.fields['L']
+		if transaction.Category == "" && len(record.splits) > 0 {
+			transaction.Category = record.splits[0]
+		}
+		// a classe vem depois da barra, como em "Alimentação:Mercado/Viagem"
+		if slash := strings.Index(transaction.Category, "/"); slash >= 0 {
+			transaction.Category = transaction.Category[:slash]
+		}
+		rows = append(rows, newRow(record.line, transaction))
+	}
+	return rows, nil
+}
+
+// qifDateParts separa a data em três números e diz se o ano vem primeiro
+func qifDateParts(value string) ([3]int, bool, error) {
+	var parts [3]int
+	// o Quicken usa apóstrofo para anos depois de 1999, como em 1/ 5'24
+	fields := strings.FieldsFunc(strings.ReplaceAll(value, " ", ""), func(char rune) bool {
+		return char == '/' || char == '-' || char == '.' || char == '\''
+	})
+	if len(fields) != 3 {
+		return parts, false, fmt.Errorf("invalid date %q", value)
+	}
+	for i, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil {
+			return parts, false, fmt.Errorf("invalid date %q", value)
+		}
+		parts[i] = number
+	}
+	return parts, len(fields[0]) == 4, nil
+}
+
+// qifDayFirst decide a ordem de dia e mês pelo arquivo inteiro: um primeiro número maior
+// que 12 só pode ser dia, e um segundo número maior que 12 só pode ser dia também
+func qifDayFirst(dates []string, fallback bool) bool {
+	for _, value := range dates {
+		parts, yearFirst, err := qifDateParts(value)
+		if err != nil || yearFirst {
+			continue
+		}
+		if parts[0] > 12 {
+			return true
+		}
+		if parts[1] > 12 {
+			return false
+		}
+	}
+	return fallback
+}
+
+func qifDate(value string, dayFirst bool) (time.Time, error) {
+	parts, yearFirst, err := qifDateParts(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	year, month, day := parts[2], parts[0], parts[1]
+	switch {
+	case yearFirst:
+		year, month, day = parts[0], parts[1], parts[2]
+	case dayFirst:
+		month, day = parts[1], parts[0]
+	}
+	if year < 100 {
+		year += 1900
+		if year < 1970 {
+			year += 100
+		}
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	// time.Date normaliza 31/02 para março; aqui isso é data inválida
+	if date.Year() != year || int(date.Month()) != month || date.Day() != day {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
@@ -0,0 +1,79 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQIFQuicken(t *testing.T) {
+	rows, err := Parse(FormatQIF, readFixture(t, "quicken_us.qif"), Options{})
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+
+	require.Equal(t, 12, rows[0].Line)
+	require.Equal(t, Transaction{
+		Date:     day(2024, 1, 5),
+		Amount:   -util.NewMoney(1234, 56),
+		Payee:    "Landlord LLC",
+		Memo:     "January rent",
+		Category: "Housing:Rent",
+	}, *rows[0].Transaction)
+	require.Equal(t, "Landlord LLC", rows[0].Transaction.Title())
+	require.Equal(t, "January rent", rows[0].Transaction.Details())
+
+	require.Equal(t, day(2024, 1, 15), rows[1].Transaction.Date)
+	require.Equal(t, util.NewMoney(2500, 0), rows[1].Transaction.Amount)
+	require.Equal(t, "Salary", rows[1].Transaction.Category)
+
+	// rateio: a primeira categoria vira a sugestão
+	require.Equal(t, -util.NewMoney(86, 40), rows[2].Transaction.Amount)
+	require.Equal(t, "Groceries", rows[2].Transaction.Category)
+
+	require.Equal(t, "[Savings Account]", rows[3].Transaction.Category)
+	require.Contains(t, rows[4].Error, "invalid date")
+}
+
+func TestParseQIFMoneyEU(t *testing.T) {
+	rows, err := ParseQIF(readFixture(t, "money_eu.qif"), false)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	// 13.02.2024 tira a ambiguidade do arquivo inteiro
+	require.Equal(t, day(2024, 2, 5), rows[0].Transaction.Date)
+	require.Equal(t, -util.NewMoney(1234, 50), rows[0].Transaction.Amount)
+	require.Equal(t, "Müller Möbel", rows[0].Transaction.Payee)
+	require.Equal(t, "Wohnen:Möbel", rows[0].Transaction.Category)
+
+	require.Equal(t, -util.NewMoney(12, 99), rows[1].Transaction.Amount)
+	require.Equal(t, "Netflix", rows[1].Transaction.Title())
+	require.Equal(t, "", rows[1].Transaction.Details())
+
+	require.Equal(t, 13, rows[2].Line)
+	require.Contains(t, rows[2].Error, "invalid amount")
+}
+
+func TestParseQIFDayFirst(t *testing.T) {
+	data := readFixture(t, "ambiguous.qif")
+
+	rows, err := ParseQIF(data, false)
+	require.NoError(t, err)
+	require.Equal(t, day(2024, 3, 4), rows[0].Transaction.Date)
+	require.Equal(t, day(2024, 4, 10), rows[1].Transaction.Date)
+
+	rows, err = Parse(FormatQIF, data, Options{DayFirst: true})
+	require.NoError(t, err)
+	require.Equal(t, day(2024, 4, 3), rows[0].Transaction.Date)
+	require.Equal(t, day(2024, 4, 10), rows[1].Transaction.Date)
+}
+
+func TestParseQIFInvalid(t *testing.T) {
+	_, err := ParseQIF([]byte("Data,Valor\n2024-01-01,10\n"), false)
+	require.ErrorIs(t, err, ErrInvalidQIF)
+
+	// só contas de investimento: nada a importar
+	rows, err := ParseQIF([]byte("!Type:Invst\nD1/5'24\nNBuy\nYACME\nT100.00\n^\n"), false)
+	require.NoError(t, err)
+	require.Empty(t, rows)
+}
@@ -0,0 +1,51 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer grava cada e-mail como um arquivo .eml, útil para testar o fluxo localmente
+type FileMailer struct {
+	from string
+	dir  string
+}
+
+func NewFileMailer(from string, dir string) (*FileMailer, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("mail file dir is required")
+	}
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileMailer{from: from, dir: dir}, nil
+}
+
+func (mailer *FileMailer) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d.eml", time.Now().UnixNano())
+	return os.WriteFile(filepath.Join(mailer.dir, name), format(mailer.from, message), 0o600)
+}
+
+// LogMailer escreve os e-mails no log em vez de enviá-los
+type LogMailer struct {
+	from string
+}
+
+func NewLogMailer(from string) *LogMailer {
+	return &LogMailer{from: from}
+}
+
+func (mailer *LogMailer) Send(ctx context.Context, message Message) error {
+	log.Printf("mail:\n%s", format(mailer.from, message))
+	return nil
+}
@@ -0,0 +1,51 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+// Message é um e-mail de texto simples
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Mailer envia e-mails; a implementação é escolhida pela configuração
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// NewMailer cria o Mailer configurado em MAIL_DRIVER (smtp, file ou log)
+func NewMailer(config util.MailConfig) (Mailer, error) {
+	switch config.Driver {
+	case "smtp":
+		return NewSMTPMailer(config), nil
+	case "file":
+		return NewFileMailer(config.From, config.FileDir)
+	case "", "log":
+		return NewLogMailer(config.From), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail driver %q", config.Driver)
+	}
+}
+
+// format monta a mensagem no formato RFC 5322
+func format(from string, message Message) []byte {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "From: %s\r\n", from)
+	fmt.Fprintf(&buffer, "To: %s\r\n", strings.Join(message.To, ", "))
+	fmt.Fprintf(&buffer, "Subject: %s\r\n", message.Subject)
+	fmt.Fprintf(&buffer, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buffer.WriteString("MIME-Version: 1.0\r\n")
+	buffer.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buffer.WriteString("\r\n")
+	buffer.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	return buffer.Bytes()
+}
@@ -0,0 +1,73 @@
+package mail
+
+import (
+	"context"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+	mailer, err := NewMailer(util.MailConfig{
+		Driver:  "file",
+		From:    "gofinance <no-reply@gofinance.local>",
+		FileDir: dir,
+	})
+	require.NoError(t, err)
+
+	err = mailer.Send(context.Background(), Message{
+		To:      []string{"user@email.com"},
+		Subject: "Verify your email",
+		Body:    "line one\nline two",
+	})
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.Contains(t, string(content), "To: user@email.com\r\n")
+	require.Contains(t, string(content), "Subject: Verify your email\r\n")
+	require.Contains(t, string(content), "\r\n\r\nline one\r\nline two")
+}
+
+func TestSMTPMailer(t *testing.T) {
+	mailer := NewSMTPMailer(util.MailConfig{
+		From:         "gofinance <no-reply@gofinance.local>",
+		SMTPHost:     "smtp.gofinance.local",
+		SMTPPort:     2525,
+		SMTPUsername: "user",
+		SMTPPassword: "secret",
+	})
+
+	var sentAddr, sentFrom string
+	var sentTo []string
+	mailer.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		sentAddr, sentFrom, sentTo = addr, from, to
+		require.NotNil(t, a)
+		require.Contains(t, string(msg), "From: gofinance <no-reply@gofinance.local>\r\n")
+		return nil
+	}
+
+	err := mailer.Send(context.Background(), Message{
+		To:      []string{"user@email.com"},
+		Subject: "Reset your password",
+		Body:    "token",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "smtp.gofinance.local:2525", sentAddr)
+	require.Equal(t, "no-reply@gofinance.local", sentFrom)
+	require.Equal(t, []string{"user@email.com"}, sentTo)
+}
+
+func TestNewMailerUnsupportedDriver(t *testing.T) {
+	_, err := NewMailer(util.MailConfig{Driver: "carrier-pigeon"})
+	require.Error(t, err)
+}
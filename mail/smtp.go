@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	netmail "net/mail"
+	"net/smtp"
+
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+// SMTPMailer envia e-mails por um servidor SMTP
+type SMTPMailer struct {
+	from     string
+	address  string
+	auth     smtp.Auth
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSMTPMailer(config util.MailConfig) *SMTPMailer {
+	var auth smtp.Auth
+	if config.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", config.SMTPUsername, config.SMTPPassword, config.SMTPHost)
+	}
+
+	return &SMTPMailer{
+		from:     config.From,
+		address:  fmt.Sprintf("%s:%d", config.SMTPHost, config.SMTPPort),
+		auth:     auth,
+		sendMail: smtp.SendMail,
+	}
+}
+
+func (mailer *SMTPMailer) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	envelopeFrom, err := netmail.ParseAddress(mailer.from)
+	if err != nil {
+		return fmt.Errorf("invalid mail from address: %w", err)
+	}
+
+	return mailer.sendMail(mailer.address, mailer.auth, envelopeFrom.Address, message.To, format(mailer.from, message))
+}
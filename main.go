@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"os"
 
 	"github.com/SraReaper/gofinance-backend/api"
 	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/mail"
+	"github.com/SraReaper/gofinance-backend/ratelimit"
+	"github.com/SraReaper/gofinance-backend/recurring"
+	"github.com/SraReaper/gofinance-backend/util"
 	"github.com/joho/godotenv"
 	_ "github.com/joho/godotenv"
 	_ "github.com/lib/pq"
@@ -26,8 +31,51 @@ func main() {
 		log.Fatal("cannot connect to db: ", err)
 	}
 
+	tokenConfig, err := util.LoadTokenConfig()
+	if err != nil {
+		log.Fatal("cannot load token config: ", err)
+	}
+	tokenMaker, err := util.NewTokenMaker(tokenConfig)
+	if err != nil {
+		log.Fatal("cannot create token maker: ", err)
+	}
+
+	passwordParams, err := util.LoadPasswordParams()
+	if err != nil {
+		log.Fatal("cannot load password config: ", err)
+	}
+
+	mailConfig, err := util.LoadMailConfig()
+	if err != nil {
+		log.Fatal("cannot load mail config: ", err)
+	}
+	mailer, err := mail.NewMailer(mailConfig)
+	if err != nil {
+		log.Fatal("cannot create mailer: ", err)
+	}
+
+	rateLimitConfig, err := util.LoadRateLimitConfig()
+	if err != nil {
+		log.Fatal("cannot load rate limit config: ", err)
+	}
+	rateLimitStore, err := ratelimit.NewStore(rateLimitConfig)
+	if err != nil {
+		log.Fatal("cannot create rate limit store: ", err)
+	}
+
+	recurringInterval, err := util.LoadRecurringInterval()
+	if err != nil {
+		log.Fatal("cannot load recurring config: ", err)
+	}
+
 	store := db.NewStore(conn)
-	server := api.NewServer(store)
+	if recurringInterval > 0 {
+		go recurring.NewScheduler(store, recurringInterval).Run(context.Background())
+	}
+	server, err := api.NewServer(store, tokenMaker, util.NewPasswordHasher(passwordParams), mailer, mailConfig.AppURL, rateLimitStore, rateLimitConfig)
+	if err != nil {
+		log.Fatal("cannot create server: ", err)
+	}
 
 	err = server.Start(serverAddress)
 	if err != nil {
@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const memorySweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// expiresAt é quando o balde volta a ficar cheio e pode ser descartado
+	expiresAt time.Time
+}
+
+type failures struct {
+	count       int
+	lockedUntil time.Time
+	expiresAt   time.Time
+}
+
+// MemoryStore guarda os limites na memória do processo; serve para uma instância só
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	failures  map[string]*failures
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:  map[string]*bucket{},
+		failures: map[string]*failures{},
+		now:      time.Now,
+	}
+}
+
+func (store *MemoryStore) Take(ctx context.Context, key string, limit Limit) (time.Duration, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	now := store.now()
+	store.sweep(now)
+
+	current, ok := store.buckets[key]
+	if !ok {
+		current = &bucket{tokens: float64(limit.Burst), updated: now}
+		store.buckets[key] = current
+	}
+
+	elapsed := now.Sub(current.updated).Seconds()
+	current.tokens = math.Min(float64(limit.Burst), current.tokens+elapsed*limit.Rate)
+	current.updated = now
+
+	var wait time.Duration
+	if current.tokens >= 1 {
+		current.tokens--
+	} else {
+		wait = secondsToDuration((1 - current.tokens) / limit.Rate)
+	}
+	current.expiresAt = now.Add(secondsToDuration((float64(limit.Burst) - current.tokens) / limit.Rate))
+
+	return wait, nil
+}
+
+func (store *MemoryStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	current, ok := store.failures[key]
+	if !ok {
+		return 0, nil
+	}
+
+	now := store.now()
+	if !current.lockedUntil.After(now) {
+		return 0, nil
+	}
+	return current.lockedUntil.Sub(now), nil
+}
+
+func (store *MemoryStore) AddFailure(ctx context.Context, key string, policy LockoutPolicy) (time.Duration, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	now := store.now()
+	store.sweep(now)
+
+	current, ok := store.failures[key]
+	if !ok || !current.expiresAt.After(now) {
+		current = &failures{}
+		store.failures[key] = current
+	}
+
+	current.count++
+	delay := policy.delay(current.count)
+	if delay > 0 {
+		current.lockedUntil = now.Add(delay)
+	}
+	current.expiresAt = now.Add(policy.Window)
+	if current.lockedUntil.After(current.expiresAt) {
+		current.expiresAt = current.lockedUntil
+	}
+
+	return delay, nil
+}
+
+func (store *MemoryStore) Reset(ctx context.Context, key string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	delete(store.failures, key)
+	return nil
+}
+
+// sweep descarta as chaves expiradas para o mapa não crescer sem limite
+func (store *MemoryStore) sweep(now time.Time) {
+	if now.Sub(store.lastSweep) < memorySweepInterval {
+		return
+	}
+	store.lastSweep = now
+
+	for key, current := range store.buckets {
+		if !current.expiresAt.After(now) {
+			delete(store.buckets, key)
+		}
+	}
+	for key, current := range store.failures {
+		if !current.expiresAt.After(now) {
+			delete(store.failures, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMemoryStore() (*MemoryStore, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	store, now := newTestMemoryStore()
+	limit := Limit{Rate: 1, Burst: 3}
+
+	for i := 0; i < 3; i++ {
+		wait, err := store.Take(context.Background(), "ip", limit)
+		require.NoError(t, err)
+		require.Zero(t, wait)
+	}
+
+	wait, err := store.Take(context.Background(), "ip", limit)
+	require.NoError(t, err)
+	require.Equal(t, time.Second, wait)
+
+	// outras chaves têm o próprio balde
+	wait, err = store.Take(context.Background(), "other", limit)
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	*now = now.Add(500 * time.Millisecond)
+	wait, err = store.Take(context.Background(), "ip", limit)
+	require.NoError(t, err)
+	require.Equal(t, 500*time.Millisecond, wait)
+
+	*now = now.Add(500 * time.Millisecond)
+	wait, err = store.Take(context.Background(), "ip", limit)
+	require.NoError(t, err)
+	require.Zero(t, wait)
+}
+
+func TestMemoryStoreLockout(t *testing.T) {
+	store, now := newTestMemoryStore()
+	policy := LockoutPolicy{
+		Threshold: 3,
+		BaseDelay: time.Minute,
+		MaxDelay:  5 * time.Minute,
+		Window:    time.Hour,
+	}
+
+	for i := 0; i < 2; i++ {
+		delay, err := store.AddFailure(context.Background(), "user", policy)
+		require.NoError(t, err)
+		require.Zero(t, delay)
+	}
+
+	lockedFor, err := store.LockedFor(context.Background(), "user")
+	require.NoError(t, err)
+	require.Zero(t, lockedFor)
+
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	for _, want := range expected {
+		delay, err := store.AddFailure(context.Background(), "user", policy)
+		require.NoError(t, err)
+		require.Equal(t, want, delay)
+	}
+
+	lockedFor, err = store.LockedFor(context.Background(), "user")
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Minute, lockedFor)
+
+	*now = now.Add(5 * time.Minute)
+	lockedFor, err = store.LockedFor(context.Background(), "user")
+	require.NoError(t, err)
+	require.Zero(t, lockedFor)
+
+	err = store.Reset(context.Background(), "user")
+	require.NoError(t, err)
+	delay, err := store.AddFailure(context.Background(), "user", policy)
+	require.NoError(t, err)
+	require.Zero(t, delay)
+}
+
+func TestMemoryStoreLockoutWindow(t *testing.T) {
+	store, now := newTestMemoryStore()
+	policy := LockoutPolicy{Threshold: 2, BaseDelay: time.Minute, MaxDelay: time.Hour, Window: 10 * time.Minute}
+
+	_, err := store.AddFailure(context.Background(), "user", policy)
+	require.NoError(t, err)
+
+	*now = now.Add(11 * time.Minute)
+	delay, err := store.AddFailure(context.Background(), "user", policy)
+	require.NoError(t, err)
+	require.Zero(t, delay)
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	store, now := newTestMemoryStore()
+
+	_, err := store.Take(context.Background(), "ip", Limit{Rate: 1, Burst: 1})
+	require.NoError(t, err)
+	require.Len(t, store.buckets, 1)
+
+	*now = now.Add(2 * memorySweepInterval)
+	_, err = store.Take(context.Background(), "other", Limit{Rate: 1, Burst: 1})
+	require.NoError(t, err)
+	require.Len(t, store.buckets, 1)
+	require.Contains(t, store.buckets, "other")
+}
+
+func TestNewStore(t *testing.T) {
+	_, err := NewStore(util.RateLimitConfig{})
+	require.NoError(t, err)
+
+	_, err = NewStore(util.RateLimitConfig{Driver: "redis"})
+	require.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+// Limit é um token bucket: Burst requisições de uma vez e Rate tokens repostos por segundo
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute cria um limite de count requisições por minuto
+func PerMinute(count int) Limit {
+	return Limit{Rate: float64(count) / 60, Burst: count}
+}
+
+// Enabled indica se o limite está configurado; limites zerados não bloqueiam nada
+func (limit Limit) Enabled() bool {
+	return limit.Rate > 0 && limit.Burst > 0
+}
+
+// LockoutPolicy bloqueia a chave depois de Threshold falhas, dobrando o bloqueio
+// a cada nova falha até MaxDelay; as falhas são esquecidas depois de Window sem falhar
+type LockoutPolicy struct {
+	Threshold int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	Window    time.Duration
+}
+
+// Enabled indica se o bloqueio por falhas está configurado
+func (policy LockoutPolicy) Enabled() bool {
+	return policy.Threshold > 0 && policy.BaseDelay > 0 && policy.Window > 0
+}
+
+// delay retorna o bloqueio aplicado depois de failures falhas seguidas
+func (policy LockoutPolicy) delay(failures int) time.Duration {
+	if failures < policy.Threshold {
+		return 0
+	}
+
+	delay := policy.BaseDelay
+	for i := policy.Threshold; i < failures; i++ {
+		delay *= 2
+		if policy.MaxDelay > 0 && delay >= policy.MaxDelay {
+			return policy.MaxDelay
+		}
+	}
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		return policy.MaxDelay
+	}
+	return delay
+}
+
+// Store guarda o estado dos limites; a implementação é escolhida pela configuração e
+// precisa ser atômica por chave para funcionar com várias instâncias da API
+type Store interface {
+	// Take consome um token do balde da chave e retorna quanto esperar se ele estiver vazio
+	Take(ctx context.Context, key string, limit Limit) (time.Duration, error)
+	// LockedFor retorna quanto falta para a chave ser desbloqueada, zero se não está bloqueada
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	// AddFailure registra uma falha e retorna o bloqueio aplicado, zero se nenhum
+	AddFailure(ctx context.Context, key string, policy LockoutPolicy) (time.Duration, error)
+	// Reset apaga as falhas e o bloqueio da chave
+	Reset(ctx context.Context, key string) error
+}
+
+// NewStore cria o Store configurado em RATE_LIMIT_DRIVER; por enquanto só existe o memory
+func NewStore(config util.RateLimitConfig) (Store, error) {
+	switch config.Driver {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unsupported rate limit driver %q", config.Driver)
+	}
+}
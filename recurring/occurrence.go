@@ -0,0 +1,68 @@
+package recurring
+
+import (
+	"database/sql"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+// Occurrence é uma ocorrência da regra com a alteração pontual aplicada, se houver.
+// OccurrenceDate é a data gerada pela regra e identifica a ocorrência; Date é a data
+// da transação
+type Occurrence struct {
+	OccurrenceDate time.Time     `json:"occurrence_date"`
+	Date           time.Time     `json:"date"`
+	Title          string        `json:"title"`
+	Description    string        `json:"description"`
+	Value          util.Money    `json:"value"`
+	Skipped        bool          `json:"skipped"`
+	AccountID      sql.NullInt32 `json:"account_id"`
+}
+
+// ScheduleOf monta o agendamento guardado na regra
+func ScheduleOf(rule db.RecurringRule) Schedule {
+	schedule := Schedule{
+		Frequency:  rule.Frequency,
+		Interval:   int(rule.Interval),
+		DayOfMonth: int(rule.DayOfMonth.Int32),
+		Start:      rule.StartDate,
+		Count:      int(rule.OccurrenceCount.Int32),
+	}
+	if rule.EndDate.Valid {
+		schedule.Until = rule.EndDate.Time
+	}
+	return schedule
+}
+
+// Expand lista as ocorrências da regra entre from e to, aplicando as alterações pontuais
+// gravadas em changes
+func Expand(rule db.RecurringRule, changes []db.RecurringOccurrence, from time.Time, to time.Time) []Occurrence {
+	changed := make(map[time.Time]db.RecurringOccurrence, len(changes))
+	for _, change := range changes {
+		changed[Date(change.OccurrenceDate)] = change
+	}
+
+	dates := ScheduleOf(rule).Between(from, to)
+	occurrences := make([]Occurrence, 0, len(dates))
+	for _, date := range dates {
+		occurrence := Occurrence{
+			OccurrenceDate: date,
+			Date:           date,
+			Title:          rule.Title,
+			Description:    rule.Description,
+			Value:          rule.Value,
+		}
+		if change, ok := changed[date]; ok {
+			occurrence.Date = Date(change.Date)
+			occurrence.Title = change.Title
+			occurrence.Description = change.Description
+			occurrence.Value = change.Value
+			occurrence.Skipped = change.Skipped
+			occurrence.AccountID = change.AccountID
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences
+}
@@ -0,0 +1,129 @@
+package recurring
+
+import (
+	"errors"
+	"time"
+)
+
+// Frequências aceitas pelas regras, no estilo do RRULE
+const (
+	Daily   = "daily"
+	Weekly  = "weekly"
+	Monthly = "monthly"
+	// LastBusinessDay é o último dia útil (segunda a sexta) do mês; feriados não contam
+	LastBusinessDay = "last_business_day"
+	Yearly          = "yearly"
+)
+
+var (
+	ErrInvalidFrequency = errors.New("invalid recurrence frequency")
+	ErrInvalidInterval  = errors.New("recurrence interval must be positive")
+	ErrInvalidDay       = errors.New("day_of_month must be between 1 and 31 and is only used by monthly rules")
+	ErrInvalidEnd       = errors.New("use either end_date or occurrence_count, with end_date not before start_date")
+)
+
+// Schedule é o agendamento de uma regra. A primeira ocorrência é a primeira data gerada a
+// partir de Start; Until e Count limitam o fim, e as ocorrências puladas também contam em Count
+type Schedule struct {
+	Frequency string
+	Interval  int
+	// DayOfMonth só vale para Monthly; zero usa o dia de Start. Em meses mais curtos
+	// a ocorrência cai no último dia do mês
+	DayOfMonth int
+	Start      time.Time
+	Until      time.Time
+	Count      int
+}
+
+// Validate confere se o agendamento é válido
+func (schedule Schedule) Validate() error {
+	switch schedule.Frequency {
+	case Daily, Weekly, Monthly, LastBusinessDay, Yearly:
+	default:
+		return ErrInvalidFrequency
+	}
+	if schedule.Interval < 1 {
+		return ErrInvalidInterval
+	}
+	if schedule.DayOfMonth < 0 || schedule.DayOfMonth > 31 || (schedule.DayOfMonth > 0 && schedule.Frequency != Monthly) {
+		return ErrInvalidDay
+	}
+	if schedule.Count < 0 || (!schedule.Until.IsZero() && (schedule.Count > 0 || Date(schedule.Until).Before(Date(schedule.Start)))) {
+		return ErrInvalidEnd
+	}
+	return nil
+}
+
+// Between devolve as datas das ocorrências entre from e to, inclusive
+func (schedule Schedule) Between(from time.Time, to time.Time) []time.Time {
+	from, to = Date(from), Date(to)
+	start := Date(schedule.Start)
+	if !schedule.Until.IsZero() && Date(schedule.Until).Before(to) {
+		to = Date(schedule.Until)
+	}
+
+	dates := []time.Time{}
+	count := 0
+	for n := 0; ; n++ {
+		date := schedule.nth(start, n)
+		if date.After(to) {
+			break
+		}
+		if date.Before(start) {
+			continue
+		}
+		count++
+		if schedule.Count > 0 && count > schedule.Count {
+			break
+		}
+		if !date.Before(from) {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+// Includes diz se a data é uma ocorrência do agendamento
+func (schedule Schedule) Includes(date time.Time) bool {
+	return len(schedule.Between(date, date)) == 1
+}
+
+// nth é a n-ésima data gerada a partir do período de start, antes de aplicar os limites
+func (schedule Schedule) nth(start time.Time, n int) time.Time {
+	step := n * schedule.Interval
+	switch schedule.Frequency {
+	case Daily:
+		return start.AddDate(0, 0, step)
+	case Weekly:
+		return start.AddDate(0, 0, 7*step)
+	case Monthly:
+		day := schedule.DayOfMonth
+		if day == 0 {
+			day = start.Day()
+		}
+		return dayOfMonth(start.Year(), start.Month()+time.Month(step), day)
+	case LastBusinessDay:
+		date := dayOfMonth(start.Year(), start.Month()+time.Month(step), 31)
+		for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			date = date.AddDate(0, 0, -1)
+		}
+		return date
+	default:
+		return dayOfMonth(start.Year()+step, start.Month(), start.Day())
+	}
+}
+
+// dayOfMonth limita o dia ao último dia do mês; o mês pode passar de 12
+func dayOfMonth(year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	if day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// Date descarta o horário e o fuso, para comparar datas vindas do banco e dos requests
+func Date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
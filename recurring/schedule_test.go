@@ -0,0 +1,116 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestScheduleBetween(t *testing.T) {
+	testCases := map[string]struct {
+		schedule Schedule
+		from     time.Time
+		to       time.Time
+		dates    []time.Time
+	}{
+		"daily every 2 days": {
+			schedule: Schedule{Frequency: Daily, Interval: 2, Start: day(2024, 1, 30)},
+			from:     day(2024, 1, 30),
+			to:       day(2024, 2, 5),
+			dates:    []time.Time{day(2024, 1, 30), day(2024, 2, 1), day(2024, 2, 3), day(2024, 2, 5)},
+		},
+		"weekly": {
+			schedule: Schedule{Frequency: Weekly, Interval: 1, Start: day(2024, 1, 1)},
+			from:     day(2024, 1, 10),
+			to:       day(2024, 1, 31),
+			dates:    []time.Time{day(2024, 1, 15), day(2024, 1, 22), day(2024, 1, 29)},
+		},
+		"monthly on day 31 falls on the last day": {
+			schedule: Schedule{Frequency: Monthly, Interval: 1, DayOfMonth: 31, Start: day(2024, 1, 1)},
+			from:     day(2024, 1, 1),
+			to:       day(2024, 4, 30),
+			dates:    []time.Time{day(2024, 1, 31), day(2024, 2, 29), day(2024, 3, 31), day(2024, 4, 30)},
+		},
+		"monthly before the start day begins next month": {
+			schedule: Schedule{Frequency: Monthly, Interval: 1, DayOfMonth: 5, Start: day(2024, 1, 20)},
+			from:     day(2024, 1, 1),
+			to:       day(2024, 3, 31),
+			dates:    []time.Time{day(2024, 2, 5), day(2024, 3, 5)},
+		},
+		"quarterly uses the start day": {
+			schedule: Schedule{Frequency: Monthly, Interval: 3, Start: day(2024, 1, 15)},
+			from:     day(2024, 1, 1),
+			to:       day(2024, 12, 31),
+			dates:    []time.Time{day(2024, 1, 15), day(2024, 4, 15), day(2024, 7, 15), day(2024, 10, 15)},
+		},
+		"last business day skips weekends": {
+			schedule: Schedule{Frequency: LastBusinessDay, Interval: 1, Start: day(2024, 3, 1)},
+			from:     day(2024, 3, 1),
+			to:       day(2024, 6, 30),
+			dates:    []time.Time{day(2024, 3, 29), day(2024, 4, 30), day(2024, 5, 31), day(2024, 6, 28)},
+		},
+		"yearly on february 29": {
+			schedule: Schedule{Frequency: Yearly, Interval: 1, Start: day(2024, 2, 29)},
+			from:     day(2024, 1, 1),
+			to:       day(2025, 12, 31),
+			dates:    []time.Time{day(2024, 2, 29), day(2025, 2, 28)},
+		},
+		"count": {
+			schedule: Schedule{Frequency: Monthly, Interval: 1, Start: day(2024, 1, 10), Count: 3},
+			from:     day(2024, 2, 1),
+			to:       day(2024, 12, 31),
+			dates:    []time.Time{day(2024, 2, 10), day(2024, 3, 10)},
+		},
+		"until": {
+			schedule: Schedule{Frequency: Weekly, Interval: 1, Start: day(2024, 1, 1), Until: day(2024, 1, 15)},
+			from:     day(2024, 1, 1),
+			to:       day(2024, 12, 31),
+			dates:    []time.Time{day(2024, 1, 1), day(2024, 1, 8), day(2024, 1, 15)},
+		},
+		"before start": {
+			schedule: Schedule{Frequency: Daily, Interval: 1, Start: day(2024, 6, 1)},
+			from:     day(2024, 1, 1),
+			to:       day(2024, 5, 31),
+			dates:    []time.Time{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		require.NoError(t, testCase.schedule.Validate(), name)
+		require.Equal(t, testCase.dates, testCase.schedule.Between(testCase.from, testCase.to), name)
+	}
+}
+
+func TestScheduleIncludes(t *testing.T) {
+	schedule := Schedule{Frequency: Monthly, Interval: 1, DayOfMonth: 10, Start: day(2024, 1, 1)}
+	require.True(t, schedule.Includes(day(2024, 5, 10)))
+	require.True(t, schedule.Includes(time.Date(2024, 5, 10, 15, 30, 0, 0, time.UTC)))
+	require.False(t, schedule.Includes(day(2024, 5, 11)))
+	require.False(t, schedule.Includes(day(2023, 12, 10)))
+}
+
+func TestScheduleValidate(t *testing.T) {
+	start := day(2024, 1, 1)
+	testCases := map[string]struct {
+		schedule Schedule
+		err      error
+	}{
+		"frequency":            {Schedule{Frequency: "hourly", Interval: 1, Start: start}, ErrInvalidFrequency},
+		"interval":             {Schedule{Frequency: Daily, Start: start}, ErrInvalidInterval},
+		"day out of range":     {Schedule{Frequency: Monthly, Interval: 1, DayOfMonth: 32, Start: start}, ErrInvalidDay},
+		"day on weekly":        {Schedule{Frequency: Weekly, Interval: 1, DayOfMonth: 5, Start: start}, ErrInvalidDay},
+		"until and count":      {Schedule{Frequency: Daily, Interval: 1, Start: start, Until: day(2024, 2, 1), Count: 3}, ErrInvalidEnd},
+		"until before start":   {Schedule{Frequency: Daily, Interval: 1, Start: start, Until: day(2023, 12, 31)}, ErrInvalidEnd},
+		"negative count":       {Schedule{Frequency: Daily, Interval: 1, Start: start, Count: -1}, ErrInvalidEnd},
+		"until equal to start": {Schedule{Frequency: Daily, Interval: 1, Start: start, Until: start}, nil},
+	}
+
+	for name, testCase := range testCases {
+		require.Equal(t, testCase.err, testCase.schedule.Validate(), name)
+	}
+}
@@ -0,0 +1,134 @@
+package recurring
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+)
+
+// ErrRuleNotWritable indica que o dono da regra perdeu a permissão de escrita no household
+var ErrRuleNotWritable = errors.New("recurring rule owner cannot write to the household anymore")
+
+// Scheduler cria as transações das ocorrências vencidas. Cada regra é processada numa
+// transação com a regra travada e guarda até que dia já foi processada, então rodar de
+// novo, em paralelo ou em outra instância, não duplica transações
+type Scheduler struct {
+	store    db.Store
+	interval time.Duration
+	now      func() time.Time
+}
+
+func NewScheduler(store db.Store, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		store:    store,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Run processa as regras na hora e depois a cada intervalo, até o contexto ser cancelado
+func (scheduler *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(scheduler.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := scheduler.RunOnce(ctx); err != nil {
+			log.Printf("cannot list due recurring rules: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce materializa as ocorrências vencidas até hoje; uma regra com erro é registrada no
+// log e tentada de novo na próxima rodada, sem impedir as outras
+func (scheduler *Scheduler) RunOnce(ctx context.Context) error {
+	today := Date(scheduler.now().UTC())
+	ids, err := scheduler.store.GetDueRecurringRules(ctx, today)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := scheduler.materialize(ctx, id, today); err != nil {
+			log.Printf("cannot materialize recurring rule %d: %v", id, err)
+		}
+	}
+	return nil
+}
+
+func (scheduler *Scheduler) materialize(ctx context.Context, id int32, today time.Time) error {
+	return scheduler.store.ExecTx(ctx, func(q db.Querier) error {
+		rule, err := q.LockRecurringRule(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		from := Date(rule.StartDate)
+		if rule.MaterializedThrough.Valid {
+			from = Date(rule.MaterializedThrough.Time).AddDate(0, 0, 1)
+		}
+		if from.After(today) {
+			return nil
+		}
+
+		changes, err := q.GetRecurringOccurrences(ctx, db.GetRecurringOccurrencesParams{
+			RuleID:   rule.ID,
+			FromDate: from,
+			ToDate:   today,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, occurrence := range Expand(rule, changes, from, today) {
+			if occurrence.Skipped || occurrence.AccountID.Valid {
+				continue
+			}
+
+			account, err := q.CreateAccount(ctx, db.CreateAccountParams{
+				UserID:      rule.UserID,
+				HouseholdID: rule.HouseholdID,
+				WalletID:    rule.WalletID,
+				CategoryID:  rule.CategoryID,
+				Title:       occurrence.Title,
+				Type:        rule.Type,
+				Description: occurrence.Description,
+				Value:       occurrence.Value,
+				Date:        occurrence.Date,
+			})
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return ErrRuleNotWritable
+				}
+				return err
+			}
+
+			_, err = q.MaterializeRecurringOccurrence(ctx, db.MaterializeRecurringOccurrenceParams{
+				RuleID:         rule.ID,
+				OccurrenceDate: occurrence.OccurrenceDate,
+				Date:           occurrence.Date,
+				Title:          occurrence.Title,
+				Description:    occurrence.Description,
+				Value:          occurrence.Value,
+				AccountID:      account.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return q.SetRecurringRuleMaterialized(ctx, db.SetRecurringRuleMaterializedParams{
+			ID:                  rule.ID,
+			MaterializedThrough: today,
+		})
+	})
+}
@@ -0,0 +1,144 @@
+package recurring
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/SraReaper/gofinance-backend/db/mock"
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestScheduler cria o agendador com o store mockado e o relógio fixo em today
+func newTestScheduler(t *testing.T, today time.Time) (*Scheduler, *mockdb.MockStore) {
+	store := mockdb.NewMockStore(gomock.NewController(t))
+	scheduler := NewScheduler(store, time.Hour)
+	scheduler.now = func() time.Time { return today.Add(15 * time.Hour) }
+
+	store.EXPECT().
+		ExecTx(gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(db.Querier) error, options ...db.TxOption) error {
+			return fn(store)
+		})
+	return scheduler, store
+}
+
+func weeklyRule() db.RecurringRule {
+	return db.RecurringRule{
+		ID:          1,
+		UserID:      2,
+		WalletID:    3,
+		CategoryID:  4,
+		Title:       "Faxina",
+		Type:        "debit",
+		Description: "semanal",
+		Value:       util.NewMoney(150, 0),
+		Frequency:   Weekly,
+		Interval:    1,
+		StartDate:   day(2024, 1, 1),
+	}
+}
+
+func TestSchedulerMaterializes(t *testing.T) {
+	today := day(2024, 1, 17)
+	scheduler, store := newTestScheduler(t, today)
+	rule := weeklyRule()
+	rule.MaterializedThrough = sql.NullTime{Time: day(2024, 1, 1), Valid: true}
+	changes := []db.RecurringOccurrence{
+		{RuleID: rule.ID, OccurrenceDate: day(2024, 1, 8), Date: day(2024, 1, 8), Title: rule.Title, Value: rule.Value, Skipped: true},
+		{RuleID: rule.ID, OccurrenceDate: day(2024, 1, 15), Date: day(2024, 1, 16), Title: "Faxina extra", Description: rule.Description, Value: util.NewMoney(200, 0)},
+	}
+
+	store.EXPECT().GetDueRecurringRules(gomock.Any(), gomock.Eq(today)).Times(1).Return([]int32{rule.ID}, nil)
+	store.EXPECT().LockRecurringRule(gomock.Any(), gomock.Eq(rule.ID)).Times(1).Return(rule, nil)
+	store.EXPECT().GetRecurringOccurrences(gomock.Any(), gomock.Eq(db.GetRecurringOccurrencesParams{
+		RuleID:   rule.ID,
+		FromDate: day(2024, 1, 2),
+		ToDate:   today,
+	})).Times(1).Return(changes, nil)
+	// só a ocorrência alterada de 15/01 vira transação; a de 08/01 foi pulada
+	store.EXPECT().CreateAccount(gomock.Any(), gomock.Eq(db.CreateAccountParams{
+		UserID:      rule.UserID,
+		WalletID:    rule.WalletID,
+		CategoryID:  rule.CategoryID,
+		Title:       "Faxina extra",
+		Type:        rule.Type,
+		Description: rule.Description,
+		Value:       util.NewMoney(200, 0),
+		Date:        day(2024, 1, 16),
+	})).Times(1).Return(db.Account{ID: 10}, nil)
+	store.EXPECT().MaterializeRecurringOccurrence(gomock.Any(), gomock.Eq(db.MaterializeRecurringOccurrenceParams{
+		RuleID:         rule.ID,
+		OccurrenceDate: day(2024, 1, 15),
+		Date:           day(2024, 1, 16),
+		Title:          "Faxina extra",
+		Description:    rule.Description,
+		Value:          util.NewMoney(200, 0),
+		AccountID:      10,
+	})).Times(1).Return(db.RecurringOccurrence{}, nil)
+	store.EXPECT().SetRecurringRuleMaterialized(gomock.Any(), gomock.Eq(db.SetRecurringRuleMaterializedParams{
+		ID:                  rule.ID,
+		MaterializedThrough: today,
+	})).Times(1).Return(nil)
+
+	require.NoError(t, scheduler.RunOnce(context.Background()))
+}
+
+func TestSchedulerAlreadyMaterialized(t *testing.T) {
+	today := day(2024, 1, 17)
+	scheduler, store := newTestScheduler(t, today)
+	rule := weeklyRule()
+	// outra instância processou a regra entre a busca e a trava
+	rule.MaterializedThrough = sql.NullTime{Time: today, Valid: true}
+
+	store.EXPECT().GetDueRecurringRules(gomock.Any(), gomock.Any()).Times(1).Return([]int32{rule.ID}, nil)
+	store.EXPECT().LockRecurringRule(gomock.Any(), gomock.Eq(rule.ID)).Times(1).Return(rule, nil)
+	store.EXPECT().GetRecurringOccurrences(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().SetRecurringRuleMaterialized(gomock.Any(), gomock.Any()).Times(0)
+
+	require.NoError(t, scheduler.RunOnce(context.Background()))
+}
+
+func TestSchedulerContinuesAfterError(t *testing.T) {
+	today := day(2024, 1, 1)
+	scheduler, store := newTestScheduler(t, today)
+	rule := weeklyRule()
+	other := weeklyRule()
+	other.ID = 5
+
+	store.EXPECT().GetDueRecurringRules(gomock.Any(), gomock.Any()).Times(1).Return([]int32{rule.ID, other.ID}, nil)
+	store.EXPECT().LockRecurringRule(gomock.Any(), gomock.Eq(rule.ID)).Times(1).Return(rule, nil)
+	store.EXPECT().LockRecurringRule(gomock.Any(), gomock.Eq(other.ID)).Times(1).Return(other, nil)
+	store.EXPECT().GetRecurringOccurrences(gomock.Any(), gomock.Any()).Times(2).Return([]db.RecurringOccurrence{}, nil)
+	// a primeira regra falha sem impedir a segunda
+	gomock.InOrder(
+		store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrNoRows),
+		store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{ID: 11}, nil),
+	)
+	store.EXPECT().MaterializeRecurringOccurrence(gomock.Any(), gomock.Any()).Times(1).Return(db.RecurringOccurrence{}, nil)
+	store.EXPECT().SetRecurringRuleMaterialized(gomock.Any(), gomock.Eq(db.SetRecurringRuleMaterializedParams{
+		ID:                  other.ID,
+		MaterializedThrough: today,
+	})).Times(1).Return(nil)
+
+	require.NoError(t, scheduler.RunOnce(context.Background()))
+}
+
+func TestSchedulerMaterializeNotWritable(t *testing.T) {
+	today := day(2024, 1, 1)
+	scheduler, store := newTestScheduler(t, today)
+	rule := weeklyRule()
+
+	store.EXPECT().LockRecurringRule(gomock.Any(), gomock.Eq(rule.ID)).Times(1).Return(rule, nil)
+	store.EXPECT().GetRecurringOccurrences(gomock.Any(), gomock.Any()).Times(1).Return([]db.RecurringOccurrence{}, nil)
+	store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrNoRows)
+	store.EXPECT().SetRecurringRuleMaterialized(gomock.Any(), gomock.Any()).Times(0)
+
+	require.Equal(t, ErrRuleNotWritable, scheduler.materialize(context.Background(), rule.ID, today))
+}
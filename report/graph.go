@@ -0,0 +1,91 @@
+package report
+
+import (
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+// Graph é a série temporal do período, com um ponto por intervalo, inclusive os vazios.
+// As séries ficam lado a lado: Income[i] e Expense[i] são do intervalo que começa em
+// Periods[i]. Com Cumulative, cada ponto soma os anteriores
+type Graph struct {
+	Period
+	Interval   string       `json:"interval"`
+	Cumulative bool         `json:"cumulative"`
+	Periods    []time.Time  `json:"periods"`
+	Income     []util.Money `json:"income"`
+	Expense    []util.Money `json:"expense"`
+	Net        []util.Money `json:"net"`
+}
+
+// Intervals são os inícios dos intervalos que cobrem o período; o primeiro pode começar
+// antes de From, como o date_trunc do banco
+func Intervals(interval string, period Period) []time.Time {
+	start := truncate(interval, Date(period.From))
+	to := Date(period.To)
+	intervals := []time.Time{}
+	for at := start; !at.After(to); at = next(interval, at) {
+		intervals = append(intervals, at)
+	}
+	return intervals
+}
+
+// BuildGraph monta a série com as linhas de GetReport agrupadas pelo mesmo intervalo. Com
+// cumulative, a soma parte de opening, os totais anteriores ao período
+func BuildGraph(interval string, period Period, cumulative bool, opening db.GetReportTotalsBeforeRow, rows []db.GetReportRow) Graph {
+	graph := Graph{
+		Period:     Period{From: Date(period.From), To: Date(period.To)},
+		Interval:   interval,
+		Cumulative: cumulative,
+		Periods:    Intervals(interval, period),
+	}
+
+	totals := make(map[time.Time]Totals)
+	for _, row := range rows {
+		at := Date(row.Period)
+		totals[at] = add(totals[at], newTotals(row))
+	}
+
+	graph.Income = make([]util.Money, len(graph.Periods))
+	graph.Expense = make([]util.Money, len(graph.Periods))
+	graph.Net = make([]util.Money, len(graph.Periods))
+	running := Totals{Income: opening.Income, Expense: opening.Expense, Net: opening.Income - opening.Expense}
+	for i, at := range graph.Periods {
+		point := totals[at]
+		if cumulative {
+			running = add(running, point)
+			point = running
+		}
+		graph.Income[i] = point.Income
+		graph.Expense[i] = point.Expense
+		graph.Net[i] = point.Net
+	}
+	return graph
+}
+
+// truncate volta ao início do intervalo; a semana começa na segunda-feira
+func truncate(interval string, t time.Time) time.Time {
+	switch interval {
+	case GroupWeek:
+		return t.AddDate(0, 0, -(int(t.Weekday())+6)%7)
+	case GroupMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case GroupYear:
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return t
+}
+
+func next(interval string, t time.Time) time.Time {
+	switch interval {
+	case GroupWeek:
+		return t.AddDate(0, 0, 7)
+	case GroupMonth:
+		return t.AddDate(0, 1, 0)
+	case GroupYear:
+		return t.AddDate(1, 0, 0)
+	}
+	return t.AddDate(0, 0, 1)
+}
@@ -0,0 +1,62 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntervals(t *testing.T) {
+	period := Period{From: day(2024, 2, 28), To: day(2024, 3, 12)}
+
+	days := Intervals(GroupDay, period)
+	require.Len(t, days, 14)
+	require.Equal(t, day(2024, 2, 29), days[1])
+	require.Equal(t, day(2024, 3, 12), days[13])
+
+	// 28/02/2024 é uma quarta-feira
+	weeks := Intervals(GroupWeek, period)
+	require.Equal(t, []time.Time{day(2024, 2, 26), day(2024, 3, 4), day(2024, 3, 11)}, weeks)
+
+	months := Intervals(GroupMonth, Period{From: day(2024, 1, 31), To: day(2024, 4, 1)})
+	require.Equal(t, []time.Time{day(2024, 1, 1), day(2024, 2, 1), day(2024, 3, 1), day(2024, 4, 1)}, months)
+
+	require.Len(t, Intervals(GroupDay, Period{From: day(2024, 3, 1), To: day(2024, 3, 1)}), 1)
+}
+
+func TestBuildGraph(t *testing.T) {
+	period := Period{From: day(2024, 1, 1), To: day(2024, 4, 30)}
+	rows := []db.GetReportRow{
+		{Period: day(2024, 1, 1), Income: util.NewMoney(1000, 0), Expense: util.NewMoney(200, 0)},
+		{Period: day(2024, 3, 1), Expense: util.NewMoney(300, 0)},
+	}
+
+	graph := BuildGraph(GroupMonth, period, false, db.GetReportTotalsBeforeRow{Income: util.NewMoney(50, 0)}, rows)
+	require.Equal(t, period, graph.Period)
+	require.Len(t, graph.Periods, 4)
+	require.Equal(t, []util.Money{util.NewMoney(1000, 0), 0, 0, 0}, graph.Income)
+	require.Equal(t, []util.Money{util.NewMoney(200, 0), 0, util.NewMoney(300, 0), 0}, graph.Expense)
+	require.Equal(t, []util.Money{util.NewMoney(800, 0), 0, util.NewMoney(-300, 0), 0}, graph.Net)
+
+	graph = BuildGraph(GroupMonth, period, true, db.GetReportTotalsBeforeRow{}, rows)
+	require.True(t, graph.Cumulative)
+	require.Equal(t, []util.Money{util.NewMoney(1000, 0), util.NewMoney(1000, 0), util.NewMoney(1000, 0), util.NewMoney(1000, 0)}, graph.Income)
+	require.Equal(t, []util.Money{util.NewMoney(800, 0), util.NewMoney(800, 0), util.NewMoney(500, 0), util.NewMoney(500, 0)}, graph.Net)
+
+	// o saldo acumulado parte do que havia antes do período
+	opening := db.GetReportTotalsBeforeRow{Income: util.NewMoney(5000, 0), Expense: util.NewMoney(1500, 0)}
+	graph = BuildGraph(GroupMonth, period, true, opening, rows)
+	require.Equal(t, []util.Money{util.NewMoney(6000, 0), util.NewMoney(6000, 0), util.NewMoney(6000, 0), util.NewMoney(6000, 0)}, graph.Income)
+	require.Equal(t, []util.Money{util.NewMoney(1700, 0), util.NewMoney(1700, 0), util.NewMoney(2000, 0), util.NewMoney(2000, 0)}, graph.Expense)
+	require.Equal(t, []util.Money{util.NewMoney(4300, 0), util.NewMoney(4300, 0), util.NewMoney(4000, 0), util.NewMoney(4000, 0)}, graph.Net)
+}
+
+func TestBuildGraphEmpty(t *testing.T) {
+	graph := BuildGraph(GroupDay, Period{From: day(2024, 3, 1), To: day(2024, 3, 3)}, false, db.GetReportTotalsBeforeRow{}, nil)
+	require.Len(t, graph.Periods, 3)
+	require.Equal(t, []util.Money{0, 0, 0}, graph.Income)
+	require.Equal(t, []util.Money{0, 0, 0}, graph.Expense)
+}
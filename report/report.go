@@ -0,0 +1,172 @@
+package report
+
+import (
+	"math"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+)
+
+// agrupamentos aceitos por GetReport
+const (
+	GroupDay      = "day"
+	GroupWeek     = "week"
+	GroupMonth    = "month"
+	GroupYear     = "year"
+	GroupCategory = "category"
+	GroupWallet   = "wallet"
+	GroupTag      = "tag"
+	// GroupTotal não agrupa: GetReport devolve uma linha só, com o total do período
+	GroupTotal = "total"
+)
+
+// Totals são as receitas, as despesas e o saldo delas
+type Totals struct {
+	Income  util.Money `json:"income"`
+	Expense util.Money `json:"expense"`
+	Net     util.Money `json:"net"`
+}
+
+// Change é a variação percentual em relação ao período anterior; nula quando o valor
+// anterior é zero
+type Change struct {
+	Income  *float64 `json:"income"`
+	Expense *float64 `json:"expense"`
+	Net     *float64 `json:"net"`
+}
+
+// Bucket é um grupo do relatório. Nos agrupamentos por categoria, carteira e tag, Previous é
+// o mesmo grupo no período anterior; por tag, o grupo 0 são as transações sem tag
+type Bucket struct {
+	Period   *time.Time `json:"period,omitempty"`
+	GroupID  int32      `json:"group_id,omitempty"`
+	Label    string     `json:"label,omitempty"`
+	Previous *Totals    `json:"previous,omitempty"`
+	Totals
+}
+
+// Period é um intervalo de datas, com as duas pontas incluídas
+type Period struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Comparison é o total do período anterior
+type Comparison struct {
+	Period
+	Totals
+}
+
+// Report é o relatório do período comparado com o período anterior de mesmo tamanho
+type Report struct {
+	Period
+	GroupBy  string     `json:"group_by"`
+	Buckets  []Bucket   `json:"buckets"`
+	Totals   Totals     `json:"totals"`
+	Previous Comparison `json:"previous"`
+	Change   Change     `json:"change"`
+}
+
+// IsPeriod diz se o agrupamento é por tempo
+func IsPeriod(groupBy string) bool {
+	switch groupBy {
+	case GroupDay, GroupWeek, GroupMonth, GroupYear:
+		return true
+	}
+	return false
+}
+
+// Previous é o período imediatamente anterior com o mesmo tamanho. Um período de meses
+// inteiros volta o mesmo número de meses
+func Previous(period Period) Period {
+	from, to := Date(period.From), Date(period.To)
+	end := to.AddDate(0, 0, 1)
+	if from.Day() == 1 && end.Day() == 1 {
+		months := (end.Year()-from.Year())*12 + int(end.Month()-from.Month())
+		return Period{From: from.AddDate(0, -months, 0), To: from.AddDate(0, 0, -1)}
+	}
+	days := int(end.Sub(from).Hours() / 24)
+	return Period{From: from.AddDate(0, 0, -days), To: from.AddDate(0, 0, -1)}
+}
+
+// Build monta o relatório com as linhas de GetReport do período e do período anterior
+func Build(groupBy string, period Period, current []db.GetReportRow, previous []db.GetReportRow) Report {
+	report := Report{
+		Period:   Period{From: Date(period.From), To: Date(period.To)},
+		GroupBy:  groupBy,
+		Buckets:  []Bucket{},
+		Previous: Comparison{Period: Previous(period)},
+	}
+
+	before := make(map[int32]Totals)
+	for _, row := range previous {
+		totals := newTotals(row)
+		report.Previous.Totals = add(report.Previous.Totals, totals)
+		before[row.GroupID] = add(before[row.GroupID], totals)
+	}
+
+	for _, row := range current {
+		bucket := Bucket{Totals: newTotals(row)}
+		if IsPeriod(groupBy) {
+			bucketPeriod := Date(row.Period)
+			bucket.Period = &bucketPeriod
+		} else {
+			bucket.GroupID = row.GroupID
+			bucket.Label = row.Label
+			totals := before[row.GroupID]
+			bucket.Previous = &totals
+		}
+		report.Buckets = append(report.Buckets, bucket)
+		report.Totals = add(report.Totals, bucket.Totals)
+	}
+
+	report.compare()
+	return report
+}
+
+// SetTotals troca os totais pelos das linhas de GetReport agrupadas por GroupTotal. Por tag,
+// uma transação com várias tags está em vários grupos, e a soma dos grupos a contaria mais de
+// uma vez
+func (report *Report) SetTotals(current []db.GetReportRow, previous []db.GetReportRow) {
+	report.Totals = Totals{}
+	for _, row := range current {
+		report.Totals = add(report.Totals, newTotals(row))
+	}
+	report.Previous.Totals = Totals{}
+	for _, row := range previous {
+		report.Previous.Totals = add(report.Previous.Totals, newTotals(row))
+	}
+	report.compare()
+}
+
+func (report *Report) compare() {
+	report.Change = Change{
+		Income:  change(report.Totals.Income, report.Previous.Income),
+		Expense: change(report.Totals.Expense, report.Previous.Expense),
+		Net:     change(report.Totals.Net, report.Previous.Net),
+	}
+}
+
+func newTotals(row db.GetReportRow) Totals {
+	return Totals{Income: row.Income, Expense: row.Expense, Net: row.Income - row.Expense}
+}
+
+func add(a Totals, b Totals) Totals {
+	return Totals{Income: a.Income + b.Income, Expense: a.Expense + b.Expense, Net: a.Net + b.Net}
+}
+
+// change usa o módulo do valor anterior, para que um saldo negativo que melhora dê
+// variação positiva
+func change(current util.Money, previous util.Money) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	percent := math.Round(float64(current-previous)/math.Abs(float64(previous))*10000) / 100
+	return &percent
+}
+
+// Date descarta a hora e o fuso de t
+func Date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,131 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	db "github.com/SraReaper/gofinance-backend/db/sqlc"
+	"github.com/SraReaper/gofinance-backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPrevious(t *testing.T) {
+	testCases := []struct {
+		name   string
+		period Period
+		want   Period
+	}{
+		{
+			name:   "Month",
+			period: Period{From: day(2024, 3, 1), To: day(2024, 3, 31)},
+			want:   Period{From: day(2024, 2, 1), To: day(2024, 2, 29)},
+		},
+		{
+			name:   "Quarter",
+			period: Period{From: day(2024, 1, 1), To: day(2024, 3, 31)},
+			want:   Period{From: day(2023, 10, 1), To: day(2023, 12, 31)},
+		},
+		{
+			name:   "Days",
+			period: Period{From: day(2024, 3, 10), To: day(2024, 3, 16)},
+			want:   Period{From: day(2024, 3, 3), To: day(2024, 3, 9)},
+		},
+		{
+			name:   "SingleDay",
+			period: Period{From: day(2024, 3, 1), To: day(2024, 3, 1)},
+			want:   Period{From: day(2024, 2, 29), To: day(2024, 2, 29)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, Previous(tc.period))
+		})
+	}
+}
+
+func TestBuildByPeriod(t *testing.T) {
+	period := Period{From: day(2024, 1, 1), To: day(2024, 2, 29)}
+	current := []db.GetReportRow{
+		{Period: day(2024, 1, 1), Income: util.NewMoney(1000, 0), Expense: util.NewMoney(400, 0)},
+		{Period: day(2024, 2, 1), Income: util.NewMoney(1000, 0), Expense: util.NewMoney(1200, 0)},
+	}
+	previous := []db.GetReportRow{
+		{Period: day(2023, 11, 1), Income: util.NewMoney(800, 0), Expense: util.NewMoney(500, 0)},
+		{Period: day(2023, 12, 1), Income: util.NewMoney(800, 0), Expense: util.NewMoney(500, 0)},
+	}
+
+	report := Build(GroupMonth, period, current, previous)
+	require.Equal(t, period, report.Period)
+	require.Len(t, report.Buckets, 2)
+	require.Equal(t, day(2024, 2, 1), *report.Buckets[1].Period)
+	require.Equal(t, util.NewMoney(-200, 0), report.Buckets[1].Net)
+	require.Nil(t, report.Buckets[0].Previous)
+
+	require.Equal(t, Totals{Income: util.NewMoney(2000, 0), Expense: util.NewMoney(1600, 0), Net: util.NewMoney(400, 0)}, report.Totals)
+	require.Equal(t, Period{From: day(2023, 11, 1), To: day(2023, 12, 31)}, report.Previous.Period)
+	require.Equal(t, util.NewMoney(600, 0), report.Previous.Net)
+	require.Equal(t, 25.0, *report.Change.Income)
+	require.Equal(t, 60.0, *report.Change.Expense)
+	require.Equal(t, -33.33, *report.Change.Net)
+}
+
+func TestBuildByCategory(t *testing.T) {
+	period := Period{From: day(2024, 3, 1), To: day(2024, 3, 31)}
+	current := []db.GetReportRow{
+		{Period: period.From, GroupID: 1, Label: "Mercado", Expense: util.NewMoney(300, 0)},
+		{Period: period.From, GroupID: 2, Label: "Salário", Income: util.NewMoney(5000, 0)},
+	}
+	previous := []db.GetReportRow{
+		{Period: day(2024, 2, 1), GroupID: 1, Label: "Mercado", Expense: util.NewMoney(200, 0)},
+	}
+
+	report := Build(GroupCategory, period, current, previous)
+	require.Len(t, report.Buckets, 2)
+	require.Nil(t, report.Buckets[0].Period)
+	require.Equal(t, "Mercado", report.Buckets[0].Label)
+	require.Equal(t, util.NewMoney(200, 0), report.Buckets[0].Previous.Expense)
+	require.Zero(t, *report.Buckets[1].Previous)
+	// sem receita no período anterior, não há variação
+	require.Nil(t, report.Change.Income)
+	require.Equal(t, 50.0, *report.Change.Expense)
+	// o saldo anterior era negativo e melhorou
+	require.Equal(t, 2450.0, *report.Change.Net)
+}
+
+func TestBuildByTag(t *testing.T) {
+	period := Period{From: day(2024, 3, 1), To: day(2024, 3, 31)}
+	// a mesma despesa de 100 tem as duas tags
+	current := []db.GetReportRow{
+		{Period: period.From, Label: "", Expense: util.NewMoney(40, 0)},
+		{Period: period.From, GroupID: 1, Label: "Férias", Expense: util.NewMoney(100, 0)},
+		{Period: period.From, GroupID: 2, Label: "Viagem", Expense: util.NewMoney(100, 0)},
+	}
+	previous := []db.GetReportRow{
+		{Period: day(2024, 2, 1), GroupID: 2, Label: "Viagem", Expense: util.NewMoney(70, 0)},
+	}
+
+	report := Build(GroupTag, period, current, previous)
+	require.Len(t, report.Buckets, 3)
+	require.Equal(t, util.NewMoney(240, 0), report.Totals.Expense)
+
+	report.SetTotals([]db.GetReportRow{{Period: period.From, Expense: util.NewMoney(140, 0)}}, previous)
+	require.Len(t, report.Buckets, 3)
+	require.Equal(t, util.NewMoney(70, 0), report.Buckets[2].Previous.Expense)
+	require.Equal(t, util.NewMoney(140, 0), report.Totals.Expense)
+	require.Equal(t, util.NewMoney(-140, 0), report.Totals.Net)
+	require.Equal(t, util.NewMoney(70, 0), report.Previous.Expense)
+	require.Equal(t, 100.0, *report.Change.Expense)
+}
+
+func TestBuildEmpty(t *testing.T) {
+	report := Build(GroupDay, Period{From: day(2024, 3, 1), To: day(2024, 3, 7)}, nil, nil)
+	require.NotNil(t, report.Buckets)
+	require.Empty(t, report.Buckets)
+	require.Zero(t, report.Totals)
+	require.Nil(t, report.Change.Net)
+}
@@ -1,56 +1,263 @@
 package util
 
 import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"errors"
-	"net/http"
-	"strings"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+var ErrInvalidToken = errors.New("token is invalid")
+
 type Claims struct {
-	Username string `json:"username"`
+	UserID    int32  `json:"user_id"`
+	Username  string `json:"username"`
+	SessionID int32  `json:"session_id"`
 	jwt.RegisteredClaims
 }
 
-func ValidateToken(ctx *gin.Context, token string) error {
-	claims := &Claims{}
-	var jwtSignedKey = []byte("secret_key")
-	tokenParse, err := jwt.ParseWithClaims(token, claims,
-		func(t *jwt.Token) (interface{}, error) {
-			return jwtSignedKey, nil
-		})
+// signingKey é uma chave do keyset; private é nil para chaves só de verificação
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private interface{}
+	public  interface{}
+}
 
-	if err != nil {
-		if err == jwt.ErrSignatureInvalid {
-			ctx.JSON(http.StatusUnauthorized, err)
-			return err
+// TokenMaker cria e valida tokens com as chaves configuradas
+type TokenMaker struct {
+	issuer          string
+	audience        string
+	duration        time.Duration
+	refreshDuration time.Duration
+	active          *signingKey
+	keys            map[string]*signingKey
+}
+
+// NewTokenMaker monta o keyset a partir da configuração
+func NewTokenMaker(config TokenConfig) (*TokenMaker, error) {
+	if len(config.Keys) == 0 {
+		return nil, errors.New("no token signing key configured")
+	}
+
+	maker := &TokenMaker{
+		issuer:          config.Issuer,
+		audience:        config.Audience,
+		duration:        config.Duration,
+		refreshDuration: config.RefreshDuration,
+		keys:            map[string]*signingKey{},
+	}
+	if maker.duration == 0 {
+		maker.duration = defaultTokenDuration
+	}
+	if maker.refreshDuration == 0 {
+		maker.refreshDuration = defaultRefreshTokenDuration
+	}
+
+	for kid, content := range config.Keys {
+		key, err := parseSigningKey(kid, content)
+		if err != nil {
+			return nil, err
+		}
+		maker.keys[kid] = key
+	}
+
+	activeKID := config.ActiveKID
+	if activeKID == "" {
+		if len(maker.keys) > 1 {
+			return nil, errors.New("active kid is required when more than one key is configured")
+		}
+		for kid := range maker.keys {
+			activeKID = kid
 		}
-		ctx.JSON(http.StatusBadRequest, err)
-		return err
+	}
+
+	active, ok := maker.keys[activeKID]
+	if !ok {
+		return nil, fmt.Errorf("active kid %q not found in keyset", activeKID)
+	}
+	if active.private == nil {
+		return nil, fmt.Errorf("active kid %q has no private key", activeKID)
+	}
+	if config.Algorithm != "" && config.Algorithm != active.method.Alg() {
+		return nil, fmt.Errorf("active kid %q is a %s key, not %s", activeKID, active.method.Alg(), config.Algorithm)
+	}
+	maker.active = active
+
+	return maker, nil
+}
+
+// parseSigningKey identifica o algoritmo pelo conteúdo: PEM RSA, PEM Ed25519 ou segredo HS256
+func parseSigningKey(kid string, content []byte) (*signingKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return &signingKey{
+			kid:     kid,
+			method:  jwt.SigningMethodHS256,
+			private: content,
+			public:  content,
+		}, nil
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %q has unsupported PEM type %q", kid, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse key %q: %w", kid, err)
+	}
+
+	key := &signingKey{kid: kid}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.method, key.public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("key %q has unsupported type %T", kid, parsed)
+	}
+
+	return key, nil
+}
+
+// CreateToken gera um token assinado com a chave ativa para a sessão informada
+func (maker *TokenMaker) CreateToken(userID int32, username string, sessionID int32) (string, *Claims, error) {
+	now := time.Now()
+	claims := &Claims{
+		UserID:    userID,
+		Username:  username,
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(int(userID)),
+			Issuer:    maker.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(maker.duration)),
+		},
+	}
+	if maker.audience != "" {
+		claims.Audience = jwt.ClaimStrings{maker.audience}
+	}
+
+	token := jwt.NewWithClaims(maker.active.method, claims)
+	token.Header["kid"] = maker.active.kid
+	signed, err := token.SignedString(maker.active.private)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return signed, claims, nil
+}
+
+// RefreshDuration retorna a validade das sessões e dos refresh tokens
+func (maker *TokenMaker) RefreshDuration() time.Duration {
+	return maker.refreshDuration
+}
+
+// ValidateToken valida o token e retorna as claims do usuário
+func (maker *TokenMaker) ValidateToken(token string) (*Claims, error) {
+	options := []jwt.ParserOption{jwt.WithExpirationRequired()}
+	if maker.issuer != "" {
+		options = append(options, jwt.WithIssuer(maker.issuer))
+	}
+	if maker.audience != "" {
+		options = append(options, jwt.WithAudience(maker.audience))
+	}
+
+	claims := &Claims{}
+	tokenParse, err := jwt.ParseWithClaims(token, claims, maker.keyFunc, options...)
+	if err != nil {
+		return nil, err
 	}
 
 	if !tokenParse.Valid {
-		ctx.JSON(http.StatusUnauthorized, "Token is invalid")
-		return nil
+		return nil, ErrInvalidToken
 	}
 
-	ctx.Next()
-	return nil
+	return claims, nil
 }
 
-func GetTokenInHeaderAndVerify(ctx *gin.Context) error {
-	authorizationHeaderKey := ctx.GetHeader("authorization")
-	fields := strings.Fields(authorizationHeaderKey)
-	if len(fields) < 2 || fields[0] != "Bearer" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid authorization format"})
-		return errors.New("invalid authorization format")
+// keyFunc escolhe a chave de verificação pelo kid do header
+func (maker *TokenMaker) keyFunc(t *jwt.Token) (interface{}, error) {
+	key := maker.active
+	if kid, ok := t.Header["kid"].(string); ok {
+		key, ok = maker.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown kid %q", kid)
+		}
 	}
-	tokenToValidate := fields[1]
-	errOnValiteToken := ValidateToken(ctx, tokenToValidate)
-	if errOnValiteToken != nil {
-		return errOnValiteToken
+
+	if t.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %q", t.Method.Alg())
 	}
-	return nil
+
+	return key.public, nil
+}
+
+// JSONWebKey é a representação pública de uma chave no JWKS
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS publica as chaves públicas; segredos HS256 nunca são expostos
+func (maker *TokenMaker) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range maker.keys {
+		jwk := JSONWebKey{
+			Kid: key.kid,
+			Use: "sig",
+			Alg: key.method.Alg(),
+		}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].Kid < set.Keys[j].Kid
+	})
+	return set
 }
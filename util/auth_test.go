@@ -0,0 +1,171 @@
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func randomRSAKeyPEM(t *testing.T) ([]byte, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	private := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	publicBytes, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	public := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicBytes})
+	return private, public
+}
+
+func randomEd25519KeyPEM(t *testing.T) []byte {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	privateBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateBytes})
+}
+
+func TestTokenMakerAlgorithms(t *testing.T) {
+	rsaKey, _ := randomRSAKeyPEM(t)
+
+	testCases := []struct {
+		name      string
+		algorithm string
+		key       []byte
+	}{
+		{name: "HS256", algorithm: "HS256", key: []byte(RandomString(32))},
+		{name: "RS256", algorithm: "RS256", key: rsaKey},
+		{name: "EdDSA", algorithm: "EdDSA", key: randomEd25519KeyPEM(t)},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			maker, err := NewTokenMaker(TokenConfig{
+				Algorithm: tc.algorithm,
+				Issuer:    "gofinance",
+				Audience:  "gofinance-web",
+				Duration:  time.Minute,
+				Keys:      map[string][]byte{"k1": tc.key},
+			})
+			require.NoError(t, err)
+
+			token, created, err := maker.CreateToken(7, "finance", 3)
+			require.NoError(t, err)
+
+			claims, err := maker.ValidateToken(token)
+			require.NoError(t, err)
+			require.Equal(t, int32(7), claims.UserID)
+			require.Equal(t, "finance", claims.Username)
+			require.Equal(t, int32(3), claims.SessionID)
+			require.Equal(t, created.ExpiresAt, claims.ExpiresAt)
+		})
+	}
+}
+
+func TestTokenMakerAlgorithmMismatch(t *testing.T) {
+	_, err := NewTokenMaker(TokenConfig{
+		Algorithm: "RS256",
+		Keys:      map[string][]byte{"k1": []byte(RandomString(32))},
+	})
+	require.Error(t, err)
+}
+
+func TestTokenMakerRotation(t *testing.T) {
+	oldKey, oldPublic := randomRSAKeyPEM(t)
+	newKey := randomEd25519KeyPEM(t)
+
+	oldMaker, err := NewTokenMaker(TokenConfig{
+		Duration: time.Minute,
+		Keys:     map[string][]byte{"2024-01": oldKey},
+	})
+	require.NoError(t, err)
+	oldToken, _, err := oldMaker.CreateToken(1, "finance", 1)
+	require.NoError(t, err)
+
+	rotatedMaker, err := NewTokenMaker(TokenConfig{
+		Duration:  time.Minute,
+		ActiveKID: "2024-06",
+		Keys: map[string][]byte{
+			"2024-01": oldPublic,
+			"2024-06": newKey,
+		},
+	})
+	require.NoError(t, err)
+
+	claims, err := rotatedMaker.ValidateToken(oldToken)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), claims.UserID)
+
+	newToken, _, err := rotatedMaker.CreateToken(2, "finance", 2)
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+	require.NoError(t, err)
+	require.Equal(t, "2024-06", parsed.Header["kid"])
+	require.Equal(t, "EdDSA", parsed.Method.Alg())
+
+	_, err = oldMaker.ValidateToken(newToken)
+	require.Error(t, err)
+}
+
+func TestTokenMakerRejectsWrongIssuerAndAudience(t *testing.T) {
+	key := []byte(RandomString(32))
+
+	maker, err := NewTokenMaker(TokenConfig{
+		Issuer:   "other",
+		Audience: "other-web",
+		Duration: time.Minute,
+		Keys:     map[string][]byte{"k1": key},
+	})
+	require.NoError(t, err)
+	token, _, err := maker.CreateToken(1, "finance", 1)
+	require.NoError(t, err)
+
+	verifier, err := NewTokenMaker(TokenConfig{
+		Issuer:   "gofinance",
+		Audience: "gofinance-web",
+		Duration: time.Minute,
+		Keys:     map[string][]byte{"k1": key},
+	})
+	require.NoError(t, err)
+
+	_, err = verifier.ValidateToken(token)
+	require.Error(t, err)
+}
+
+func TestTokenMakerJWKS(t *testing.T) {
+	rsaKey, _ := randomRSAKeyPEM(t)
+
+	maker, err := NewTokenMaker(TokenConfig{
+		ActiveKID: "rsa",
+		Keys: map[string][]byte{
+			"rsa":    rsaKey,
+			"ed":     randomEd25519KeyPEM(t),
+			"secret": []byte(RandomString(32)),
+		},
+	})
+	require.NoError(t, err)
+
+	set := maker.JWKS()
+	require.Len(t, set.Keys, 2)
+
+	require.Equal(t, "ed", set.Keys[0].Kid)
+	require.Equal(t, "OKP", set.Keys[0].Kty)
+	require.Equal(t, "Ed25519", set.Keys[0].Crv)
+	require.NotEmpty(t, set.Keys[0].X)
+
+	require.Equal(t, "rsa", set.Keys[1].Kid)
+	require.Equal(t, "RSA", set.Keys[1].Kty)
+	require.Equal(t, "RS256", set.Keys[1].Alg)
+	require.Equal(t, "AQAB", set.Keys[1].E)
+	require.NotEmpty(t, set.Keys[1].N)
+}
@@ -0,0 +1,272 @@
+package util
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTokenDuration        = 5 * time.Minute
+	defaultRefreshTokenDuration = 30 * 24 * time.Hour
+)
+
+// TokenConfig guarda a configuração de assinatura dos tokens JWT
+type TokenConfig struct {
+	Algorithm string
+	Issuer    string
+	Audience  string
+	Duration  time.Duration
+	// RefreshDuration é a validade da sessão criada no login
+	RefreshDuration time.Duration
+	ActiveKID       string
+	// Keys mapeia o kid para o conteúdo da chave: PEM para RS256/EdDSA ou o segredo para HS256
+	Keys map[string][]byte
+}
+
+// LoadTokenConfig lê a configuração dos tokens das variáveis de ambiente
+//
+// TOKEN_KEYS recebe pares kid=arquivo separados por vírgula; TOKEN_SYMMETRIC_KEY
+// é usado como chave HS256 com kid "default" quando TOKEN_KEYS está vazio.
+func LoadTokenConfig() (TokenConfig, error) {
+	config := TokenConfig{
+		Algorithm:       os.Getenv("TOKEN_ALGORITHM"),
+		Issuer:          os.Getenv("TOKEN_ISSUER"),
+		Audience:        os.Getenv("TOKEN_AUDIENCE"),
+		Duration:        defaultTokenDuration,
+		RefreshDuration: defaultRefreshTokenDuration,
+		ActiveKID:       os.Getenv("TOKEN_ACTIVE_KID"),
+		Keys:            map[string][]byte{},
+	}
+
+	if duration := os.Getenv("TOKEN_DURATION"); duration != "" {
+		parsed, err := time.ParseDuration(duration)
+		if err != nil {
+			return config, fmt.Errorf("invalid TOKEN_DURATION: %w", err)
+		}
+		config.Duration = parsed
+	}
+
+	if duration := os.Getenv("TOKEN_REFRESH_DURATION"); duration != "" {
+		parsed, err := time.ParseDuration(duration)
+		if err != nil {
+			return config, fmt.Errorf("invalid TOKEN_REFRESH_DURATION: %w", err)
+		}
+		config.RefreshDuration = parsed
+	}
+
+	for _, entry := range strings.Split(os.Getenv("TOKEN_KEYS"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, path, found := strings.Cut(entry, "=")
+		if !found || kid == "" || path == "" {
+			return config, fmt.Errorf("invalid TOKEN_KEYS entry %q", entry)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return config, fmt.Errorf("cannot read key %q: %w", kid, err)
+		}
+		config.Keys[kid] = content
+	}
+
+	if len(config.Keys) == 0 {
+		if secret := os.Getenv("TOKEN_SYMMETRIC_KEY"); secret != "" {
+			config.Keys["default"] = []byte(secret)
+		}
+	}
+
+	return config, nil
+}
+
+// MailConfig guarda a configuração do envio de e-mails
+type MailConfig struct {
+	Driver       string
+	From         string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	FileDir      string
+	// AppURL é a URL do frontend usada nos links enviados por e-mail
+	AppURL string
+}
+
+// LoadMailConfig lê a configuração de e-mail das variáveis de ambiente
+func LoadMailConfig() (MailConfig, error) {
+	config := MailConfig{
+		Driver:       os.Getenv("MAIL_DRIVER"),
+		From:         os.Getenv("MAIL_FROM"),
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     587,
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		FileDir:      os.Getenv("MAIL_FILE_DIR"),
+		AppURL:       strings.TrimSuffix(os.Getenv("APP_URL"), "/"),
+	}
+	if config.From == "" {
+		config.From = "gofinance <no-reply@gofinance.local>"
+	}
+
+	if port := os.Getenv("SMTP_PORT"); port != "" {
+		parsed, err := strconv.Atoi(port)
+		if err != nil {
+			return config, fmt.Errorf("invalid SMTP_PORT: %w", err)
+		}
+		config.SMTPPort = parsed
+	}
+
+	return config, nil
+}
+
+// LoadPasswordParams lê os parâmetros do Argon2id; valores ausentes usam DefaultPasswordParams
+func LoadPasswordParams() (PasswordParams, error) {
+	params := DefaultPasswordParams
+
+	settings := []struct {
+		name  string
+		value *uint32
+	}{
+		{"PASSWORD_ARGON2_MEMORY", &params.Memory},
+		{"PASSWORD_ARGON2_ITERATIONS", &params.Iterations},
+		{"PASSWORD_ARGON2_KEY_LENGTH", &params.KeyLength},
+		{"PASSWORD_ARGON2_SALT_LENGTH", &params.SaltLength},
+	}
+	for _, setting := range settings {
+		value := os.Getenv(setting.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || parsed == 0 {
+			return params, fmt.Errorf("invalid %s: %q", setting.name, value)
+		}
+		*setting.value = uint32(parsed)
+	}
+
+	if value := os.Getenv("PASSWORD_ARGON2_PARALLELISM"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 8)
+		if err != nil || parsed == 0 {
+			return params, fmt.Errorf("invalid PASSWORD_ARGON2_PARALLELISM: %q", value)
+		}
+		params.Parallelism = uint8(parsed)
+	}
+
+	return params, nil
+}
+
+// RateLimitConfig guarda os limites de requisições; valores zerados desligam o limite
+type RateLimitConfig struct {
+	Driver string
+	// RequestsPerSecond e Burst limitam cada IP em toda a API
+	RequestsPerSecond float64
+	Burst             int
+	// LoginIPPerMinute e LoginUsernamePerMinute limitam as tentativas de login
+	LoginIPPerMinute       int
+	LoginUsernamePerMinute int
+	// LockoutThreshold é o número de falhas seguidas até o usuário ser bloqueado;
+	// cada falha seguinte dobra o bloqueio, de LockoutBaseDelay até LockoutMaxDelay
+	LockoutThreshold int
+	LockoutBaseDelay time.Duration
+	LockoutMaxDelay  time.Duration
+	LockoutWindow    time.Duration
+	// TrustedProxies são os IPs ou redes dos proxies cujo X-Forwarded-For vale como IP do
+	// cliente; vazio ignora o cabeçalho e usa o endereço da conexão
+	TrustedProxies []string
+}
+
+// LoadRateLimitConfig lê a configuração dos limites de requisições das variáveis de ambiente
+func LoadRateLimitConfig() (RateLimitConfig, error) {
+	config := RateLimitConfig{
+		Driver:                 os.Getenv("RATE_LIMIT_DRIVER"),
+		RequestsPerSecond:      10,
+		Burst:                  20,
+		LoginIPPerMinute:       20,
+		LoginUsernamePerMinute: 5,
+		LockoutThreshold:       5,
+		LockoutBaseDelay:       30 * time.Second,
+		LockoutMaxDelay:        time.Hour,
+		LockoutWindow:          time.Hour,
+	}
+
+	if value := os.Getenv("RATE_LIMIT_REQUESTS_PER_SECOND"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 {
+			return config, fmt.Errorf("invalid RATE_LIMIT_REQUESTS_PER_SECOND: %q", value)
+		}
+		config.RequestsPerSecond = parsed
+	}
+
+	counts := []struct {
+		name  string
+		value *int
+	}{
+		{"RATE_LIMIT_BURST", &config.Burst},
+		{"LOGIN_RATE_LIMIT_IP_PER_MINUTE", &config.LoginIPPerMinute},
+		{"LOGIN_RATE_LIMIT_USERNAME_PER_MINUTE", &config.LoginUsernamePerMinute},
+		{"LOGIN_LOCKOUT_THRESHOLD", &config.LockoutThreshold},
+	}
+	for _, setting := range counts {
+		value := os.Getenv(setting.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return config, fmt.Errorf("invalid %s: %q", setting.name, value)
+		}
+		*setting.value = parsed
+	}
+
+	durations := []struct {
+		name  string
+		value *time.Duration
+	}{
+		{"LOGIN_LOCKOUT_BASE_DELAY", &config.LockoutBaseDelay},
+		{"LOGIN_LOCKOUT_MAX_DELAY", &config.LockoutMaxDelay},
+		{"LOGIN_LOCKOUT_WINDOW", &config.LockoutWindow},
+	}
+	for _, setting := range durations {
+		value := os.Getenv(setting.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 {
+			return config, fmt.Errorf("invalid %s: %q", setting.name, value)
+		}
+		*setting.value = parsed
+	}
+
+	if value := os.Getenv("TRUSTED_PROXIES"); value != "" {
+		for _, proxy := range strings.Split(value, ",") {
+			proxy = strings.TrimSpace(proxy)
+			if net.ParseIP(proxy) == nil {
+				if _, _, err := net.ParseCIDR(proxy); err != nil {
+					return config, fmt.Errorf("invalid TRUSTED_PROXIES: %q", proxy)
+				}
+			}
+			config.TrustedProxies = append(config.TrustedProxies, proxy)
+		}
+	}
+
+	return config, nil
+}
+
+// LoadRecurringInterval lê de quanto em quanto tempo o agendador cria as transações
+// recorrentes vencidas; zero desliga o agendador nesta instância
+func LoadRecurringInterval() (time.Duration, error) {
+	value := os.Getenv("RECURRING_INTERVAL")
+	if value == "" {
+		return time.Hour, nil
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("invalid RECURRING_INTERVAL: %q", value)
+	}
+	return parsed, nil
+}
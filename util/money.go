@@ -0,0 +1,199 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MoneyScale é o número de casas decimais guardadas em Money
+const MoneyScale = 2
+
+const moneyFactor = 100
+
+var (
+	ErrInvalidMoney  = errors.New("invalid money amount")
+	ErrMoneyOverflow = errors.New("money amount overflows int64")
+)
+
+// Money é um valor exato em centavos (unidades menores); no JSON vira um número
+// decimal com duas casas, como 12.34, sem passar por float
+type Money int64
+
+// NewMoney cria um valor a partir da parte inteira e dos centavos
+func NewMoney(units int64, cents int64) Money {
+	return Money(units*moneyFactor + cents)
+}
+
+// ParseMoney lê um decimal como "12.34", "-0.5" ou "10"; mais de duas casas é erro
+func ParseMoney(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "-"), "+")
+
+	integer, fraction, _ := strings.Cut(value, ".")
+	if integer == "" && fraction == "" {
+		return 0, ErrInvalidMoney
+	}
+	if len(fraction) > MoneyScale {
+		return 0, fmt.Errorf("%w: more than %d decimal places in %q", ErrInvalidMoney, MoneyScale, value)
+	}
+	fraction += strings.Repeat("0", MoneyScale-len(fraction))
+	if integer == "" {
+		integer = "0"
+	}
+
+	digits := integer + fraction
+	for _, digit := range digits {
+		if digit < '0' || digit > '9' {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, value)
+		}
+	}
+
+	cents, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, ErrMoneyOverflow
+	}
+	if negative {
+		cents = -cents
+	}
+	return Money(cents), nil
+}
+
+// String formata o valor com duas casas decimais
+func (money Money) String() string {
+	cents := int64(money)
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+	}
+
+	absolute := new(big.Int).Abs(big.NewInt(cents)).String()
+	if len(absolute) <= MoneyScale {
+		absolute = strings.Repeat("0", MoneyScale-len(absolute)+1) + absolute
+	}
+	split := len(absolute) - MoneyScale
+	return sign + absolute[:split] + "." + absolute[split:]
+}
+
+func (money Money) MarshalJSON() ([]byte, error) {
+	return []byte(money.String()), nil
+}
+
+// UnmarshalJSON aceita número (12.34) ou string ("12.34")
+func (money *Money) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+	value = strings.Trim(value, `"`)
+	if strings.ContainsAny(value, "eE") {
+		return fmt.Errorf("%w: exponent notation is not accepted", ErrInvalidMoney)
+	}
+
+	parsed, err := ParseMoney(value)
+	if err != nil {
+		return err
+	}
+	*money = parsed
+	return nil
+}
+
+// UnmarshalParam lê o valor de query strings e formulários do gin
+func (money *Money) UnmarshalParam(param string) error {
+	parsed, err := ParseMoney(param)
+	if err != nil {
+		return err
+	}
+	*money = parsed
+	return nil
+}
+
+// Add soma dois valores, com erro em caso de overflow
+func (money Money) Add(other Money) (Money, error) {
+	sum := int64(money) + int64(other)
+	if (other > 0 && sum < int64(money)) || (other < 0 && sum > int64(money)) {
+		return 0, ErrMoneyOverflow
+	}
+	return Money(sum), nil
+}
+
+// Sub subtrai dois valores, com erro em caso de overflow
+func (money Money) Sub(other Money) (Money, error) {
+	if other == math.MinInt64 {
+		return 0, ErrMoneyOverflow
+	}
+	return money.Add(-other)
+}
+
+// Neg inverte o sinal
+func (money Money) Neg() Money {
+	return -money
+}
+
+// MulRat multiplica por uma fração exata (taxa de câmbio, porcentagem) e arredonda
+// para o centavo mais próximo, com metade arredondada para longe do zero
+func (money Money) MulRat(factor *big.Rat) (Money, error) {
+	product := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(money)), factor)
+	return roundRat(product)
+}
+
+// Allocate divide o valor em partes proporcionais aos pesos sem perder centavos;
+// a sobra vai para as primeiras partes
+func (money Money) Allocate(weights ...int64) ([]Money, error) {
+	var total int64
+	for _, weight := range weights {
+		if weight < 0 {
+			return nil, errors.New("allocation weights must not be negative")
+		}
+		total += weight
+	}
+	if total == 0 {
+		return nil, errors.New("allocation weights must not all be zero")
+	}
+
+	parts := make([]Money, len(weights))
+	remainder := int64(money)
+	for i, weight := range weights {
+		share := new(big.Int).Mul(big.NewInt(int64(money)), big.NewInt(weight))
+		share.Quo(share, big.NewInt(total))
+		parts[i] = Money(share.Int64())
+		remainder -= share.Int64()
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(parts) {
+		if weights[i] == 0 {
+			continue
+		}
+		parts[i] += Money(step)
+		remainder -= step
+	}
+	return parts, nil
+}
+
+func roundRat(value *big.Rat) (Money, error) {
+	numerator := new(big.Int).Set(value.Num())
+	denominator := value.Denom()
+
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	// |resto| * 2 >= denominador arredonda para longe do zero
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(denominator) >= 0 {
+		if numerator.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	if !quotient.IsInt64() {
+		return 0, ErrMoneyOverflow
+	}
+	return Money(quotient.Int64()), nil
+}
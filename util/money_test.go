@@ -0,0 +1,126 @@
+package util
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  Money
+	}{
+		{"12.34", 1234},
+		{"-12.34", -1234},
+		{"10", 1000},
+		{"0.5", 50},
+		{".05", 5},
+		{"+3.10", 310},
+		{"92233720368547758.07", math.MaxInt64},
+	}
+	for _, tc := range testCases {
+		got, err := ParseMoney(tc.input)
+		require.NoError(t, err, tc.input)
+		require.Equal(t, tc.want, got, tc.input)
+	}
+
+	for _, input := range []string{"", "-", "1.234", "1,50", "abc", "92233720368547758.08"} {
+		_, err := ParseMoney(input)
+		require.Error(t, err, input)
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	require.Equal(t, "0.00", Money(0).String())
+	require.Equal(t, "0.05", Money(5).String())
+	require.Equal(t, "-0.50", Money(-50).String())
+	require.Equal(t, "1234.56", NewMoney(1234, 56).String())
+	require.Equal(t, "-92233720368547758.08", Money(math.MinInt64).String())
+}
+
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Value Money `json:"value"`
+	}{Value: 123456})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"value": 1234.56}`, string(data))
+
+	var decoded struct {
+		Number Money `json:"number"`
+		String Money `json:"string"`
+	}
+	err = json.Unmarshal([]byte(`{"number": 10.1, "string": "-2.25"}`), &decoded)
+	require.NoError(t, err)
+	require.Equal(t, Money(1010), decoded.Number)
+	require.Equal(t, Money(-225), decoded.String)
+
+	err = json.Unmarshal([]byte(`{"number": 1e3}`), &decoded)
+	require.Error(t, err)
+	err = json.Unmarshal([]byte(`{"number": 0.001}`), &decoded)
+	require.Error(t, err)
+}
+
+func TestMoneyUnmarshalParam(t *testing.T) {
+	var money Money
+	require.NoError(t, money.UnmarshalParam("10.5"))
+	require.Equal(t, Money(1050), money)
+	require.Error(t, money.UnmarshalParam("abc"))
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	sum, err := Money(150).Add(275)
+	require.NoError(t, err)
+	require.Equal(t, Money(425), sum)
+
+	difference, err := Money(150).Sub(275)
+	require.NoError(t, err)
+	require.Equal(t, Money(-125), difference)
+
+	_, err = Money(math.MaxInt64).Add(1)
+	require.ErrorIs(t, err, ErrMoneyOverflow)
+	_, err = Money(math.MinInt64).Sub(1)
+	require.ErrorIs(t, err, ErrMoneyOverflow)
+}
+
+func TestMoneyMulRat(t *testing.T) {
+	testCases := []struct {
+		money  Money
+		factor *big.Rat
+		want   Money
+	}{
+		{1000, big.NewRat(1, 3), 333},
+		{1000, big.NewRat(2, 3), 667},
+		{5, big.NewRat(1, 2), 3},
+		{-5, big.NewRat(1, 2), -3},
+		{10000, big.NewRat(54321, 10000), 54321},
+	}
+	for _, tc := range testCases {
+		got, err := tc.money.MulRat(tc.factor)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, got)
+	}
+
+	_, err := Money(math.MaxInt64).MulRat(big.NewRat(2, 1))
+	require.ErrorIs(t, err, ErrMoneyOverflow)
+}
+
+func TestMoneyAllocate(t *testing.T) {
+	parts, err := Money(1000).Allocate(1, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []Money{334, 333, 333}, parts)
+
+	parts, err = Money(-1000).Allocate(1, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []Money{-334, -333, -333}, parts)
+
+	parts, err = Money(5).Allocate(0, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []Money{0, 3, 2}, parts)
+
+	_, err = Money(5).Allocate(0, 0)
+	require.Error(t, err)
+}
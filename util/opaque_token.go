@@ -0,0 +1,50 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+const opaqueTokenBytes = 32
+
+// NewOpaqueToken gera um token aleatório e o hash que deve ser guardado no banco
+func NewOpaqueToken() (string, string, error) {
+	buffer := make([]byte, opaqueTokenBytes)
+	_, err := rand.Read(buffer)
+	if err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buffer)
+	return token, HashOpaqueToken(token), nil
+}
+
+// HashOpaqueToken retorna o SHA-256 do token em hexadecimal
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyPrefix identifica as API keys no header Authorization, separando-as dos JWTs
+const APIKeyPrefix = "gf_"
+
+const apiKeyDisplayLength = 8
+
+// NewAPIKey gera uma API key, o trecho inicial mostrado na listagem e o hash guardado no banco
+func NewAPIKey() (string, string, string, error) {
+	token, _, err := NewOpaqueToken()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	key := APIKeyPrefix + token
+	return key, key[:len(APIKeyPrefix)+apiKeyDisplayLength], HashOpaqueToken(key), nil
+}
+
+// IsAPIKey indica se o token do header é uma API key
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
@@ -0,0 +1,23 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, prefix, hash, err := NewAPIKey()
+	require.NoError(t, err)
+	require.True(t, IsAPIKey(key))
+	require.True(t, strings.HasPrefix(key, prefix))
+	require.Len(t, prefix, len(APIKeyPrefix)+apiKeyDisplayLength)
+	require.Equal(t, HashOpaqueToken(key), hash)
+
+	otherKey, _, _, err := NewAPIKey()
+	require.NoError(t, err)
+	require.NotEqual(t, key, otherKey)
+
+	require.False(t, IsAPIKey("eyJhbGciOiJIUzI1NiJ9"))
+}
@@ -0,0 +1,141 @@
+package util
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrPasswordMismatch    = errors.New("password does not match")
+	ErrUnknownPasswordHash = errors.New("unknown password hash format")
+)
+
+// PasswordParams são os parâmetros do Argon2id gravados em cada hash
+type PasswordParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultPasswordParams segue a recomendação da RFC 9106 para memória limitada
+var DefaultPasswordParams = PasswordParams{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// PasswordHasher gera hashes Argon2id no formato PHC e ainda aceita os hashes bcrypt antigos
+type PasswordHasher struct {
+	params PasswordParams
+}
+
+func NewPasswordHasher(params PasswordParams) *PasswordHasher {
+	return &PasswordHasher{params: params}
+}
+
+// Hash gera o hash no formato $argon2id$v=19$m=...,t=...,p=...$salt$hash
+func (hasher *PasswordHasher) Hash(password string) (string, error) {
+	salt := make([]byte, hasher.params.SaltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, hasher.params.Iterations, hasher.params.Memory, hasher.params.Parallelism, hasher.params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		hasher.params.Memory,
+		hasher.params.Iterations,
+		hasher.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify retorna erro se a senha não confere com o hash guardado e indica se o hash
+// deve ser refeito com os parâmetros atuais
+func (hasher *PasswordHasher) Verify(password string, encodedHash string) (bool, error) {
+	switch {
+	case strings.HasPrefix(encodedHash, "$argon2id$"):
+		params, salt, key, err := decodeArgon2idHash(encodedHash)
+		if err != nil {
+			return false, err
+		}
+
+		otherKey := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+		if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+			return false, ErrPasswordMismatch
+		}
+
+		params.SaltLength = uint32(len(salt))
+		return params != hasher.params, nil
+	case strings.HasPrefix(encodedHash, "$2a$"), strings.HasPrefix(encodedHash, "$2b$"), strings.HasPrefix(encodedHash, "$2y$"):
+		err := bcrypt.CompareHashAndPassword([]byte(encodedHash), legacyPreparePassword(password))
+		if err != nil {
+			if err == bcrypt.ErrMismatchedHashAndPassword {
+				return false, ErrPasswordMismatch
+			}
+			return false, err
+		}
+		return true, nil
+	default:
+		return false, ErrUnknownPasswordHash
+	}
+}
+
+// legacyPreparePassword reproduz o SHA-512 com bytes.Trim usado antes do bcrypt nas senhas
+// antigas; só serve para verificá-las até o próximo login
+func legacyPreparePassword(password string) []byte {
+	hashedInput := sha512.Sum512([]byte(password))
+	return bytes.Trim(hashedInput[:], "\x00")
+}
+
+func decodeArgon2idHash(encodedHash string) (PasswordParams, []byte, []byte, error) {
+	var params PasswordParams
+
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	var version int
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
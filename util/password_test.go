@@ -0,0 +1,74 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testPasswordParams = PasswordParams{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestPasswordHasher(t *testing.T) {
+	hasher := NewPasswordHasher(testPasswordParams)
+	password := RandomString(12)
+
+	hashed, err := hasher.Hash(password)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hashed, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	needsRehash, err := hasher.Verify(password, hashed)
+	require.NoError(t, err)
+	require.False(t, needsRehash)
+
+	_, err = hasher.Verify(RandomString(12), hashed)
+	require.ErrorIs(t, err, ErrPasswordMismatch)
+
+	hashed2, err := hasher.Hash(password)
+	require.NoError(t, err)
+	require.NotEqual(t, hashed, hashed2)
+}
+
+func TestPasswordHasherParamsChanged(t *testing.T) {
+	password := RandomString(12)
+	hashed, err := NewPasswordHasher(testPasswordParams).Hash(password)
+	require.NoError(t, err)
+
+	stronger := testPasswordParams
+	stronger.Iterations = 2
+	needsRehash, err := NewPasswordHasher(stronger).Verify(password, hashed)
+	require.NoError(t, err)
+	require.True(t, needsRehash)
+}
+
+func TestPasswordHasherLegacyBcrypt(t *testing.T) {
+	hasher := NewPasswordHasher(testPasswordParams)
+	password := RandomString(12)
+
+	legacyHash, err := bcrypt.GenerateFromPassword(legacyPreparePassword(password), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	needsRehash, err := hasher.Verify(password, string(legacyHash))
+	require.NoError(t, err)
+	require.True(t, needsRehash)
+
+	_, err = hasher.Verify(RandomString(12), string(legacyHash))
+	require.ErrorIs(t, err, ErrPasswordMismatch)
+}
+
+func TestPasswordHasherUnknownFormat(t *testing.T) {
+	hasher := NewPasswordHasher(testPasswordParams)
+
+	_, err := hasher.Verify(RandomString(12), RandomString(40))
+	require.ErrorIs(t, err, ErrUnknownPasswordHash)
+
+	_, err = hasher.Verify(RandomString(12), "$argon2id$v=19$m=1024$broken")
+	require.ErrorIs(t, err, ErrUnknownPasswordHash)
+}
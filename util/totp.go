@@ -0,0 +1,94 @@
+package util
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base32"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp/hotp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	totpPeriod       = 30
+	totpSkew         = 1
+	totpQRCodeSize   = 256
+	recoveryCodeSize = 10
+)
+
+// TOTPKey é o segredo gerado na ativação do 2FA
+type TOTPKey struct {
+	Secret          string
+	ProvisioningURI string
+	QRCodePNG       []byte
+}
+
+// GenerateTOTPKey gera um segredo TOTP com a URI otpauth:// e o QR code em PNG
+func GenerateTOTPKey(issuer string, accountName string) (TOTPKey, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer,
+		AccountName: accountName,
+		Period:      totpPeriod,
+	})
+	if err != nil {
+		return TOTPKey{}, err
+	}
+
+	image, err := key.Image(totpQRCodeSize, totpQRCodeSize)
+	if err != nil {
+		return TOTPKey{}, err
+	}
+
+	var buffer bytes.Buffer
+	err = png.Encode(&buffer, image)
+	if err != nil {
+		return TOTPKey{}, err
+	}
+
+	return TOTPKey{
+		Secret:          key.Secret(),
+		ProvisioningURI: key.URL(),
+		QRCodePNG:       buffer.Bytes(),
+	}, nil
+}
+
+// ValidateTOTPCode confere o código na janela atual ± totpSkew e retorna o contador usado,
+// que deve ser maior que lastCounter para impedir a reutilização do mesmo código
+func ValidateTOTPCode(secret string, code string, now time.Time, lastCounter int64) (int64, bool) {
+	current := now.Unix() / totpPeriod
+	for offset := int64(-totpSkew); offset <= totpSkew; offset++ {
+		counter := current + offset
+		if counter <= lastCounter {
+			continue
+		}
+		if hotp.Validate(code, uint64(counter), secret) {
+			return counter, true
+		}
+	}
+	return 0, false
+}
+
+// NewRecoveryCodes gera códigos de recuperação no formato xxxx-xxxx-xxxx-xxxx
+func NewRecoveryCodes(count int) ([]string, error) {
+	codes := make([]string, count)
+	for i := range codes {
+		buffer := make([]byte, recoveryCodeSize)
+		_, err := rand.Read(buffer)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buffer))
+		codes[i] = strings.Join([]string{encoded[0:4], encoded[4:8], encoded[8:12], encoded[12:16]}, "-")
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode normaliza o código digitado antes de gerar o hash guardado no banco
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return HashOpaqueToken(normalized)
+}
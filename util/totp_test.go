@@ -0,0 +1,68 @@
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTOTPKey(t *testing.T) {
+	key, err := GenerateTOTPKey("gofinance", "finance@example.com")
+	require.NoError(t, err)
+	require.NotEmpty(t, key.Secret)
+	require.True(t, strings.HasPrefix(key.ProvisioningURI, "otpauth://totp/gofinance:finance@example.com?"))
+	require.Contains(t, key.ProvisioningURI, "secret="+key.Secret)
+	require.Equal(t, "\x89PNG", string(key.QRCodePNG[:4]))
+}
+
+func TestValidateTOTPCode(t *testing.T) {
+	key, err := GenerateTOTPKey("gofinance", "finance")
+	require.NoError(t, err)
+
+	now := time.Now()
+	code, err := totp.GenerateCode(key.Secret, now)
+	require.NoError(t, err)
+
+	counter, ok := ValidateTOTPCode(key.Secret, code, now, 0)
+	require.True(t, ok)
+	require.Equal(t, now.Unix()/totpPeriod, counter)
+
+	// o mesmo código não pode ser usado duas vezes
+	_, ok = ValidateTOTPCode(key.Secret, code, now, counter)
+	require.False(t, ok)
+
+	// o código anterior ainda vale dentro da janela de tolerância
+	previous, err := totp.GenerateCode(key.Secret, now.Add(-totpPeriod*time.Second))
+	require.NoError(t, err)
+	_, ok = ValidateTOTPCode(key.Secret, previous, now, 0)
+	require.True(t, ok)
+
+	old, err := totp.GenerateCode(key.Secret, now.Add(-5*totpPeriod*time.Second))
+	require.NoError(t, err)
+	_, ok = ValidateTOTPCode(key.Secret, old, now, 0)
+	require.False(t, ok)
+
+	_, ok = ValidateTOTPCode(key.Secret, "abc", now, 0)
+	require.False(t, ok)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := NewRecoveryCodes(10)
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		require.Len(t, code, 19)
+		require.Equal(t, 3, strings.Count(code, "-"))
+		require.False(t, seen[code])
+		seen[code] = true
+	}
+
+	hash := HashRecoveryCode(codes[0])
+	require.Equal(t, hash, HashRecoveryCode(strings.ToUpper(strings.ReplaceAll(codes[0], "-", " "))))
+	require.NotEqual(t, hash, HashRecoveryCode(codes[1]))
+}